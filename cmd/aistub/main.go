@@ -0,0 +1,32 @@
+// Command aistub runs the fake AI review service for local development.
+//
+//	AI_STUB_ADDR=:8000 AI_SERVICE_API_KEY=dev AI_CALLBACK_SECRET=secret go run ./cmd/aistub
+//
+// When AI_CALLBACK_SECRET is set, results are delivered asynchronously to the
+// callback URL sent by the API server.
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"QuanPhotos/internal/pkg/ai/aitest"
+)
+
+func main() {
+	addr := os.Getenv("AI_STUB_ADDR")
+	if addr == "" {
+		addr = ":8000"
+	}
+
+	stub := aitest.NewStub(os.Getenv("AI_SERVICE_API_KEY"))
+	if secret := os.Getenv("AI_CALLBACK_SECRET"); secret != "" {
+		stub.SetAsync(secret)
+	}
+
+	log.Printf("AI stub listening on %s", addr)
+	if err := http.ListenAndServe(addr, stub); err != nil {
+		log.Fatal(err)
+	}
+}
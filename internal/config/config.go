@@ -18,6 +18,12 @@ type Config struct {
 	Storage  StorageConfig
 	Image    ImageConfig
 	AI       AIConfig
+	Quality  QualityConfig
+	Review   ReviewConfig
+	Publish  PublishConfig
+	Trash    TrashConfig
+	Quota    QuotaConfig
+	Cache    CacheConfig
 	CORS     CORSConfig
 	Rate     RateConfig
 }
@@ -66,6 +72,9 @@ type StorageConfig struct {
 	BaseURL      string
 	MaxSize      int64
 	AllowedTypes []string
+
+	// MaxBatchFiles is the number of files one batch upload can carry
+	MaxBatchFiles int
 }
 
 // ImageConfig holds image processing configuration
@@ -85,10 +94,76 @@ type ImageConfig struct {
 
 // AIConfig holds AI service configuration
 type AIConfig struct {
-	ServiceURL string
-	Timeout    time.Duration
-	Retry      int
-	APIKey     string
+	Enabled          bool
+	ServiceURL       string
+	Timeout          time.Duration
+	Retry            int
+	APIKey           string
+	CallbackURL      string // Public URL of the callback endpoint, empty for synchronous reviews only
+	CallbackSecret   string // HMAC secret used to sign callback payloads
+	BreakerThreshold int    // Consecutive failures before the circuit opens
+	BreakerCooldown  time.Duration
+}
+
+// QualityConfig holds heuristic image quality screening thresholds.
+// A zero threshold disables the corresponding check.
+type QualityConfig struct {
+	Enabled          bool
+	MinWidth         int     // Rejected below
+	MinHeight        int     // Rejected below
+	RejectSharpness  float64 // Laplacian variance below which photos are rejected
+	FlagSharpness    float64
+	RejectClipping   float64 // Fraction of clipped pixels above which photos are rejected
+	FlagClipping     float64
+	FlagTilt         float64 // Horizon tilt in degrees
+	RejectNoiseRatio float64 // Measured noise relative to the noise expected at the photo's ISO
+	FlagNoiseRatio   float64
+}
+
+// ReviewConfig holds manual review queue configuration
+type ReviewConfig struct {
+	ClaimTTL  time.Duration // Lease duration of a claimed photo
+	MaxClaims int           // Maximum photos a reviewer may hold at once
+
+	// RequiredDecisions is the number of concurring decisions needed in
+	// categories without a review policy
+	RequiredDecisions int
+
+	// TrustAutoApprove lets trust tiers configured for it publish uploads
+	// without manual review
+	TrustAutoApprove bool
+	// TrustRecoveryApprovals is the number of photos a demoted user needs
+	// approved before the score can promote them again
+	TrustRecoveryApprovals int
+
+	// EditReReview sends approved photos back to review when the owner edits
+	// one of EditReviewFields
+	EditReReview     bool
+	EditReviewFields []string
+}
+
+// PublishConfig holds scheduled publication configuration
+type PublishConfig struct {
+	Interval time.Duration // How often due photos are published, zero disables the publisher
+}
+
+// TrashConfig holds configuration of the trash for deleted photos
+type TrashConfig struct {
+	Retention     time.Duration // How long deleted photos can be restored before they are purged
+	PurgeInterval time.Duration // How often expired photos are purged, zero disables the purger
+}
+
+// QuotaConfig holds per-user storage quota configuration
+type QuotaConfig struct {
+	Enabled           bool
+	DefaultMB         int64            // Limit of roles without their own limit, 0 is unlimited
+	RoleMB            map[string]int64 // Limits per role, 0 is unlimited
+	ReconcileInterval time.Duration    // How often recorded usage is corrected, zero disables the job
+}
+
+// CacheConfig holds in-memory cache configuration
+type CacheConfig struct {
+	PermissionTTL time.Duration // Upper bound for stale admin permissions across instances
 }
 
 // CORSConfig holds CORS configuration
@@ -149,6 +224,8 @@ func Load() (*Config, error) {
 			BaseURL:      getEnv("STORAGE_BASE_URL", ""),
 			MaxSize:      getEnvInt64("STORAGE_MAX_SIZE", 52428800),
 			AllowedTypes: getEnvSlice("STORAGE_ALLOWED_TYPES", []string{"jpg", "jpeg", "png"}),
+
+			MaxBatchFiles: getEnvInt("STORAGE_MAX_BATCH_FILES", 20),
 		},
 		Image: ImageConfig{
 			MaxDimension:   getEnvInt("IMAGE_MAX_DIMENSION", 4096),
@@ -164,10 +241,55 @@ func Load() (*Config, error) {
 			ThumbLgQuality: getEnvInt("THUMB_LG_QUALITY", 90),
 		},
 		AI: AIConfig{
-			ServiceURL: getEnv("AI_SERVICE_URL", "http://localhost:8000"),
-			Timeout:    time.Duration(getEnvInt("AI_SERVICE_TIMEOUT", 30)) * time.Second,
-			Retry:      getEnvInt("AI_SERVICE_RETRY", 3),
-			APIKey:     getEnv("AI_SERVICE_API_KEY", ""),
+			Enabled:          getEnvBool("AI_SERVICE_ENABLED", false),
+			ServiceURL:       getEnv("AI_SERVICE_URL", "http://localhost:8000"),
+			Timeout:          time.Duration(getEnvInt("AI_SERVICE_TIMEOUT", 30)) * time.Second,
+			Retry:            getEnvInt("AI_SERVICE_RETRY", 3),
+			APIKey:           getEnv("AI_SERVICE_API_KEY", ""),
+			CallbackURL:      getEnv("AI_CALLBACK_URL", ""),
+			CallbackSecret:   getEnv("AI_CALLBACK_SECRET", ""),
+			BreakerThreshold: getEnvInt("AI_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:  time.Duration(getEnvInt("AI_BREAKER_COOLDOWN", 60)) * time.Second,
+		},
+		Quality: QualityConfig{
+			Enabled:          getEnvBool("QUALITY_SCREENING_ENABLED", true),
+			MinWidth:         getEnvInt("QUALITY_MIN_WIDTH", 1024),
+			MinHeight:        getEnvInt("QUALITY_MIN_HEIGHT", 600),
+			RejectSharpness:  getEnvFloat("QUALITY_REJECT_SHARPNESS", 15),
+			FlagSharpness:    getEnvFloat("QUALITY_FLAG_SHARPNESS", 50),
+			RejectClipping:   getEnvFloat("QUALITY_REJECT_CLIPPING", 0.4),
+			FlagClipping:     getEnvFloat("QUALITY_FLAG_CLIPPING", 0.1),
+			FlagTilt:         getEnvFloat("QUALITY_FLAG_TILT", 1.5),
+			RejectNoiseRatio: getEnvFloat("QUALITY_REJECT_NOISE_RATIO", 0),
+			FlagNoiseRatio:   getEnvFloat("QUALITY_FLAG_NOISE_RATIO", 3),
+		},
+		Review: ReviewConfig{
+			ClaimTTL:  time.Duration(getEnvInt("REVIEW_CLAIM_TTL", 30)) * time.Minute,
+			MaxClaims: getEnvInt("REVIEW_MAX_CLAIMS", 20),
+
+			RequiredDecisions: getEnvInt("REVIEW_REQUIRED_DECISIONS", 1),
+
+			TrustAutoApprove:       getEnvBool("REVIEW_TRUST_AUTO_APPROVE", true),
+			TrustRecoveryApprovals: getEnvInt("REVIEW_TRUST_RECOVERY_APPROVALS", 10),
+
+			EditReReview:     getEnvBool("REVIEW_EDIT_REREVIEW", true),
+			EditReviewFields: getEnvSlice("REVIEW_EDIT_FIELDS", []string{"category_id", "aircraft_type", "airline", "registration"}),
+		},
+		Publish: PublishConfig{
+			Interval: time.Duration(getEnvInt("PUBLISH_INTERVAL", 60)) * time.Second,
+		},
+		Trash: TrashConfig{
+			Retention:     time.Duration(getEnvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
+			PurgeInterval: time.Duration(getEnvInt("TRASH_PURGE_INTERVAL", 60)) * time.Minute,
+		},
+		Quota: QuotaConfig{
+			Enabled:           getEnvBool("QUOTA_ENABLED", true),
+			DefaultMB:         getEnvInt64("QUOTA_DEFAULT_MB", 10240),
+			RoleMB:            getEnvInt64Map("QUOTA_ROLE_MB", map[string]int64{"reviewer": 51200, "admin": 0, "superadmin": 0}),
+			ReconcileInterval: time.Duration(getEnvInt("QUOTA_RECONCILE_INTERVAL", 1440)) * time.Minute,
+		},
+		Cache: CacheConfig{
+			PermissionTTL: time.Duration(getEnvInt("CACHE_PERMISSION_TTL", 60)) * time.Second,
 		},
 		CORS: CORSConfig{
 			Enabled:        getEnvBool("CORS_ENABLED", true),
@@ -224,6 +346,15 @@ func getEnvInt64(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolVal, err := strconv.ParseBool(value); err == nil {
@@ -239,3 +370,23 @@ func getEnvSlice(key string, defaultValue []string) []string {
 	}
 	return defaultValue
 }
+
+// getEnvInt64Map parses comma-separated key:value pairs, invalid pairs are skipped
+func getEnvInt64Map(key string, defaultValue map[string]int64) map[string]int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	result := make(map[string]int64)
+	for _, pair := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(pair, ":")
+		if !ok {
+			continue
+		}
+		if intVal, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+			result[strings.TrimSpace(k)] = intVal
+		}
+	}
+	return result
+}
@@ -199,12 +199,20 @@ func (h *AdminHandler) UpdateUserStatus(c *gin.Context) {
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(20)
-// @Param status query string false "Filter by status: ai_passed, ai_rejected, pending, all"
+// @Param status query string false "Filter by status: ai_passed, ai_rejected, pending, all (default: all three, ai_rejected last)"
+// @Param claimed query string false "Filter by claim: mine, unclaimed"
+// @Param stage query string false "Filter by review queue: standard, senior (reviewers only see standard)"
 // @Success 200 {object} response.Response
 // @Failure 401 {object} response.Response
 // @Failure 403 {object} response.Response
 // @Router /api/v1/admin/reviews [get]
 func (h *AdminHandler) ListReviews(c *gin.Context) {
+	reviewerID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
 	var req admin.ListReviewsRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		response.BadRequest(c, err.Error())
@@ -218,8 +226,15 @@ func (h *AdminHandler) ListReviews(c *gin.Context) {
 		req.PageSize = 20
 	}
 
-	result, err := h.adminService.ListReviews(c.Request.Context(), &req)
+	roleStr, _ := c.Get("role")
+	role := model.UserRole(roleStr.(string))
+
+	result, err := h.adminService.ListReviews(c.Request.Context(), reviewerID.(int64), role, &req)
 	if err != nil {
+		if errors.Is(err, admin.ErrInsufficientPerm) {
+			response.Forbidden(c, "Review permission required")
+			return
+		}
 		response.InternalError(c, "Failed to list reviews")
 		return
 	}
@@ -229,7 +244,7 @@ func (h *AdminHandler) ListReviews(c *gin.Context) {
 
 // ReviewPhoto performs a manual review on a photo
 // @Summary Review photo (Admin)
-// @Description Approve or reject a photo
+// @Description Approve, reject or escalate a photo. The photo's status follows from the category's review policy: it is decided once enough reviewers concur, disagreements and escalations go to the senior queue. Rejections need reason codes from the rejection reason catalogue or a note.
 // @Tags Admin
 // @Accept json
 // @Produce json
@@ -241,6 +256,7 @@ func (h *AdminHandler) ListReviews(c *gin.Context) {
 // @Failure 401 {object} response.Response
 // @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
 // @Router /api/v1/admin/reviews/{id} [post]
 func (h *AdminHandler) ReviewPhoto(c *gin.Context) {
 	reviewerID, exists := c.Get("userID")
@@ -262,26 +278,433 @@ func (h *AdminHandler) ReviewPhoto(c *gin.Context) {
 		return
 	}
 
-	err = h.adminService.ReviewPhoto(c.Request.Context(), photoID, reviewerID.(int64), &req)
+	roleStr, _ := c.Get("role")
+	role := model.UserRole(roleStr.(string))
+
+	result, err := h.adminService.ReviewPhoto(c.Request.Context(), photoID, reviewerID.(int64), role, &req)
 	if err != nil {
 		if errors.Is(err, admin.ErrPhotoNotFound) {
 			response.NotFound(c, "Photo not found")
 			return
 		}
+		if errors.Is(err, admin.ErrInsufficientPerm) {
+			response.Forbidden(c, "Review permission required")
+			return
+		}
+		if errors.Is(err, admin.ErrPhotoOutOfScope) {
+			response.Forbidden(c, "Photo is outside your assigned categories")
+			return
+		}
+		if errors.Is(err, admin.ErrSeniorReviewRequired) {
+			response.Forbidden(c, "Photo is in the senior review queue")
+			return
+		}
+		if errors.Is(err, admin.ErrClaimNotHeld) {
+			response.Conflict(c, "Photo is not claimed by you, claim it before reviewing")
+			return
+		}
+		if errors.Is(err, admin.ErrAlreadyDecided) {
+			response.Conflict(c, "You already reviewed this photo, a second opinion must come from another reviewer")
+			return
+		}
+		if errors.Is(err, admin.ErrNotReviewable) {
+			response.Conflict(c, "Photo is no longer awaiting review")
+			return
+		}
+		if errors.Is(err, admin.ErrAlreadyEscalated) {
+			response.Conflict(c, "Photo is already in the senior review queue")
+			return
+		}
+		if errors.Is(err, admin.ErrReasonRequired) || errors.Is(err, admin.ErrInvalidReasonCode) || errors.Is(err, admin.ErrEscalationDisabled) {
+			response.BadRequest(c, err.Error())
+			return
+		}
 		response.InternalError(c, "Failed to review photo")
 		return
 	}
 
-	response.Success(c, gin.H{"message": "Photo reviewed successfully"})
+	response.Success(c, result)
+}
+
+// ListSpotChecks lists auto-approved photos sampled for a spot check
+// @Summary List spot checks (Admin)
+// @Description Get published photos of trusted uploaders sampled for review after publication, oldest approval first. Reviewers only see photos in their assigned categories.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/reviews/spot-checks [get]
+func (h *AdminHandler) ListSpotChecks(c *gin.Context) {
+	reviewerID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req admin.ListSpotChecksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	roleStr, _ := c.Get("role")
+	role := model.UserRole(roleStr.(string))
+
+	result, err := h.adminService.ListSpotChecks(c.Request.Context(), reviewerID.(int64), role, &req)
+	if err != nil {
+		if errors.Is(err, admin.ErrInsufficientPerm) {
+			response.Forbidden(c, "Review permission required")
+			return
+		}
+		response.InternalError(c, "Failed to list spot checks")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// SpotCheck decides on an auto-approved photo sampled for a spot check
+// @Summary Spot check photo (Admin)
+// @Description Confirm or reject a published photo sampled for a spot check. Rejection unpublishes the photo and demotes the uploader's trust tier. Rejections need reason codes from the rejection reason catalogue or a note.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param request body admin.SpotCheckRequest true "Spot check decision"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/admin/reviews/{id}/spot-check [post]
+func (h *AdminHandler) SpotCheck(c *gin.Context) {
+	reviewerID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	var req admin.SpotCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	roleStr, _ := c.Get("role")
+	role := model.UserRole(roleStr.(string))
+
+	result, err := h.adminService.SpotCheck(c.Request.Context(), photoID, reviewerID.(int64), role, &req)
+	if err != nil {
+		if errors.Is(err, admin.ErrPhotoNotFound) {
+			response.NotFound(c, "Photo not found")
+			return
+		}
+		if errors.Is(err, admin.ErrInsufficientPerm) {
+			response.Forbidden(c, "Review permission required")
+			return
+		}
+		if errors.Is(err, admin.ErrPhotoOutOfScope) {
+			response.Forbidden(c, "Photo is outside your assigned categories")
+			return
+		}
+		if errors.Is(err, admin.ErrNoSpotCheck) {
+			response.Conflict(c, "Photo is not awaiting a spot check")
+			return
+		}
+		if errors.Is(err, admin.ErrReasonRequired) || errors.Is(err, admin.ErrInvalidReasonCode) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		response.InternalError(c, "Failed to spot check photo")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListReplacements lists replacement images of photos
+// @Summary List replacement images (Admin)
+// @Description Get replacement images uploaded for approved photos, by default those awaiting review, oldest first. Reviewers only see photos in their assigned categories.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param status query string false "Filter by status: pending, approved, rejected, superseded, all" default(pending)
+// @Param photo_id query int false "Filter by photo ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/reviews/replacements [get]
+func (h *AdminHandler) ListReplacements(c *gin.Context) {
+	reviewerID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req admin.ListReplacementsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	roleStr, _ := c.Get("role")
+	role := model.UserRole(roleStr.(string))
+
+	result, err := h.adminService.ListReplacements(c.Request.Context(), reviewerID.(int64), role, &req)
+	if err != nil {
+		if errors.Is(err, admin.ErrInsufficientPerm) {
+			response.Forbidden(c, "Review permission required")
+			return
+		}
+		response.InternalError(c, "Failed to list replacements")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ReviewReplacement approves or rejects a replacement image
+// @Summary Review replacement image (Admin)
+// @Description Approve or reject a replacement image. Approval makes the photo serve the new image and deletes the replaced files, keeping metadata, counters, comments, tags and featured status. Rejection needs a reason and deletes the new files. The photo's own review status is not affected.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Replacement ID"
+// @Param request body admin.ReviewReplacementRequest true "Review decision"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/admin/reviews/replacements/{id} [post]
+func (h *AdminHandler) ReviewReplacement(c *gin.Context) {
+	reviewerID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	replacementID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid replacement ID")
+		return
+	}
+
+	var req admin.ReviewReplacementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	roleStr, _ := c.Get("role")
+	role := model.UserRole(roleStr.(string))
+
+	result, err := h.adminService.ReviewReplacement(c.Request.Context(), replacementID, reviewerID.(int64), role, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, admin.ErrReplacementNotFound):
+			response.NotFound(c, "Replacement not found")
+		case errors.Is(err, admin.ErrInsufficientPerm):
+			response.Forbidden(c, "Review permission required")
+		case errors.Is(err, admin.ErrPhotoOutOfScope):
+			response.Forbidden(c, "Photo is outside your assigned categories")
+		case errors.Is(err, admin.ErrNotPendingReplacement):
+			response.Conflict(c, "Replacement is not awaiting review")
+		case errors.Is(err, admin.ErrReasonRequired):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalError(c, "Failed to review replacement")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetReviewHistory returns the full moderation history of a photo
+// @Summary Get photo review history (Admin)
+// @Description Get every review record of a photo, including AI results, screening reports and takedowns. Deleted photos are described by the snapshot taken at deletion.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/reviews/{id}/history [get]
+func (h *AdminHandler) GetReviewHistory(c *gin.Context) {
+	reviewerID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	idStr := c.Param("id")
+	photoID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	roleStr, _ := c.Get("role")
+	role := model.UserRole(roleStr.(string))
+
+	result, err := h.adminService.GetReviewHistory(c.Request.Context(), photoID, reviewerID.(int64), role)
+	if err != nil {
+		if errors.Is(err, admin.ErrPhotoNotFound) {
+			response.NotFound(c, "Photo not found")
+			return
+		}
+		if errors.Is(err, admin.ErrInsufficientPerm) {
+			response.Forbidden(c, "Review permission required")
+			return
+		}
+		if errors.Is(err, admin.ErrPhotoOutOfScope) {
+			response.Forbidden(c, "Photo is outside your assigned categories")
+			return
+		}
+		response.InternalError(c, "Failed to get review history")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ClaimReviews claims the next photos in the review queue
+// @Summary Claim photos to review (Admin)
+// @Description Claim the next photos in the review queue for a limited time and renew existing claims. Returns all photos held by the reviewer.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body admin.ClaimReviewsRequest false "Number of photos to claim"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/reviews/claim [post]
+func (h *AdminHandler) ClaimReviews(c *gin.Context) {
+	reviewerID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req admin.ClaimReviewsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+	}
+
+	roleStr, _ := c.Get("role")
+	role := model.UserRole(roleStr.(string))
+
+	result, err := h.adminService.ClaimReviews(c.Request.Context(), reviewerID.(int64), role, &req)
+	if err != nil {
+		if errors.Is(err, admin.ErrInsufficientPerm) {
+			response.Forbidden(c, "Review permission required")
+			return
+		}
+		response.InternalError(c, "Failed to claim reviews")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ReleaseClaim returns a claimed photo to the review queue
+// @Summary Release review claim (Admin)
+// @Description Give up the claim on a photo so other reviewers can pick it up
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/reviews/{id}/claim [delete]
+func (h *AdminHandler) ReleaseClaim(c *gin.Context) {
+	reviewerID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	idStr := c.Param("id")
+	photoID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	err = h.adminService.ReleaseClaim(c.Request.Context(), photoID, reviewerID.(int64))
+	if err != nil {
+		if errors.Is(err, admin.ErrClaimNotHeld) {
+			response.NotFound(c, "Claim not found")
+			return
+		}
+		response.InternalError(c, "Failed to release claim")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Claim released successfully"})
+}
+
+// ListClaims lists active review claims
+// @Summary List review claims (Admin)
+// @Description Get who is currently reviewing which photo
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param reviewer_id query int false "Filter by reviewer"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/reviews/claims [get]
+func (h *AdminHandler) ListClaims(c *gin.Context) {
+	var req admin.ListClaimsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	list, err := h.adminService.ListClaims(c.Request.Context(), &req)
+	if err != nil {
+		response.InternalError(c, "Failed to list claims")
+		return
+	}
+
+	response.Success(c, gin.H{"list": list})
 }
 
 // ============================================
 // Admin Delete Photo Handler
 // ============================================
 
-// AdminDeletePhoto deletes a photo with reason
+// AdminDeletePhoto moves a photo to the trash with reason
 // @Summary Delete photo (Admin)
-// @Description Delete a photo with reason
+// @Description Move a photo to the trash with reason, it is purged after the retention period
 // @Tags Admin
 // @Accept json
 // @Produce json
@@ -327,6 +750,160 @@ func (h *AdminHandler) AdminDeletePhoto(c *gin.Context) {
 	response.Success(c, gin.H{"message": "Photo deleted successfully"})
 }
 
+// ListTrash lists deleted photos
+// @Summary List deleted photos (Admin)
+// @Description Get photos in the trash that have not been purged yet, most recently deleted first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param user_id query int false "Filter by owner"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/photos/trash [get]
+func (h *AdminHandler) ListTrash(c *gin.Context) {
+	var req admin.ListTrashRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.adminService.ListTrash(c.Request.Context(), &req)
+	if err != nil {
+		response.InternalError(c, "Failed to list deleted photos")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RestorePhoto restores a deleted photo
+// @Summary Restore photo (Admin)
+// @Description Take a photo out of the trash before it is purged, with its comments, likes and favorites
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/photos/{id}/restore [post]
+func (h *AdminHandler) RestorePhoto(c *gin.Context) {
+	adminID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	idStr := c.Param("id")
+	photoID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	result, err := h.adminService.RestorePhoto(c.Request.Context(), photoID, adminID.(int64))
+	if err != nil {
+		if errors.Is(err, admin.ErrPhotoNotFound) {
+			response.NotFound(c, "Photo not found in trash")
+			return
+		}
+		response.InternalError(c, "Failed to restore photo")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListPhotoRevisions lists the metadata revisions of a photo
+// @Summary List photo revisions (Admin)
+// @Description Get every metadata revision of a photo with its changes and editor, newest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/photos/{id}/revisions [get]
+func (h *AdminHandler) ListPhotoRevisions(c *gin.Context) {
+	idStr := c.Param("id")
+	photoID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	result, err := h.adminService.ListPhotoRevisions(c.Request.Context(), photoID)
+	if err != nil {
+		if errors.Is(err, admin.ErrPhotoNotFound) {
+			response.NotFound(c, "Photo not found")
+			return
+		}
+		response.InternalError(c, "Failed to list photo revisions")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RollbackPhotoRevision rolls a photo back to before a revision
+// @Summary Roll back photo revision (Admin)
+// @Description Restore the metadata a photo had before a revision, undoing it and every later revision. The rollback is recorded as a new revision and does not send the photo back to review.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param revision_id path int true "Revision ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/admin/photos/{id}/revisions/{revision_id}/rollback [post]
+func (h *AdminHandler) RollbackPhotoRevision(c *gin.Context) {
+	adminID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	revisionID, err := strconv.ParseInt(c.Param("revision_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid revision ID")
+		return
+	}
+
+	result, err := h.adminService.RollbackPhotoRevision(c.Request.Context(), photoID, revisionID, adminID.(int64))
+	if err != nil {
+		switch {
+		case errors.Is(err, admin.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, admin.ErrRevisionNotFound):
+			response.NotFound(c, "Revision not found")
+		case errors.Is(err, admin.ErrNothingToRollback):
+			response.Conflict(c, "Photo already matches the revision")
+		default:
+			response.InternalError(c, "Failed to roll back photo revision")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
 // ============================================
 // Ticket Management Handlers
 // ============================================
@@ -371,7 +948,7 @@ func (h *AdminHandler) ListTickets(c *gin.Context) {
 
 // ProcessTicket processes a ticket
 // @Summary Process ticket (Admin)
-// @Description Update ticket status and optionally reply
+// @Description Update ticket status and optionally reply. Appeal tickets about a rejected photo can overturn the rejection, which requires the review_photos permission.
 // @Tags Admin
 // @Accept json
 // @Produce json
@@ -383,6 +960,7 @@ func (h *AdminHandler) ListTickets(c *gin.Context) {
 // @Failure 401 {object} response.Response
 // @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
 // @Router /api/v1/admin/tickets/{id} [put]
 func (h *AdminHandler) ProcessTicket(c *gin.Context) {
 	adminID, exists := c.Get("userID")
@@ -404,12 +982,27 @@ func (h *AdminHandler) ProcessTicket(c *gin.Context) {
 		return
 	}
 
-	result, err := h.adminService.ProcessTicket(c.Request.Context(), ticketID, adminID.(int64), &req)
+	roleStr, _ := c.Get("role")
+	role := model.UserRole(roleStr.(string))
+
+	result, err := h.adminService.ProcessTicket(c.Request.Context(), ticketID, adminID.(int64), role, &req)
 	if err != nil {
 		if errors.Is(err, admin.ErrTicketNotFound) {
 			response.NotFound(c, "Ticket not found")
 			return
 		}
+		if errors.Is(err, admin.ErrNotPhotoAppeal) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		if errors.Is(err, admin.ErrInsufficientPerm) {
+			response.Forbidden(c, "Review permission required to overturn a review")
+			return
+		}
+		if errors.Is(err, admin.ErrNotOverturnable) {
+			response.Conflict(c, "Photo is not rejected")
+			return
+		}
 		response.InternalError(c, "Failed to process ticket")
 		return
 	}
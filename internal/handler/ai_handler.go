@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/pkg/ai"
+	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/service/aireview"
+)
+
+// maxCallbackBody limits the size of AI callback payloads
+const maxCallbackBody = 1 << 20
+
+// AIHandler handles AI review service HTTP requests
+type AIHandler struct {
+	aiReviewService *aireview.Service
+}
+
+// NewAIHandler creates a new AI handler. aiReviewService is nil when AI review is disabled.
+func NewAIHandler(aiReviewService *aireview.Service) *AIHandler {
+	return &AIHandler{
+		aiReviewService: aiReviewService,
+	}
+}
+
+// Callback receives asynchronous AI review results
+// @Summary AI review callback
+// @Description Receive an AI review result. The body must be signed with HMAC-SHA256 in the X-AI-Signature header ("t=<unix>,v1=<hex>")
+// @Tags AI
+// @Accept json
+// @Produce json
+// @Param X-AI-Signature header string true "Payload signature"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/ai/callback [post]
+func (h *AIHandler) Callback(c *gin.Context) {
+	if h.aiReviewService == nil {
+		response.NotFound(c, "AI review is not enabled")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxCallbackBody))
+	if err != nil {
+		response.BadRequest(c, "Failed to read request body")
+		return
+	}
+
+	err = h.aiReviewService.HandleCallback(c.Request.Context(), c.GetHeader(ai.SignatureHeader), body)
+	if err != nil {
+		switch {
+		case errors.Is(err, aireview.ErrCallbackDisabled):
+			response.NotFound(c, "AI callback is not enabled")
+		case errors.Is(err, ai.ErrInvalidSignature), errors.Is(err, ai.ErrSignatureExpired):
+			response.Unauthorized(c, "Invalid signature")
+		case errors.Is(err, ai.ErrInvalidResult):
+			response.BadRequest(c, "Invalid review result")
+		default:
+			response.InternalError(c, "Failed to apply review result")
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
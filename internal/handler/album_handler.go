@@ -0,0 +1,404 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/middleware"
+	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/service/album"
+)
+
+// AlbumHandler handles album HTTP requests
+type AlbumHandler struct {
+	albumService *album.Service
+}
+
+// NewAlbumHandler creates a new album handler
+func NewAlbumHandler(albumService *album.Service) *AlbumHandler {
+	return &AlbumHandler{
+		albumService: albumService,
+	}
+}
+
+// Create creates an album
+// @Summary Create album
+// @Description Create an empty album for grouping own photos
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body album.CreateRequest true "Album info"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/albums [post]
+func (h *AlbumHandler) Create(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req album.CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.albumService.Create(c.Request.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, album.ErrTitleRequired):
+			response.BadRequest(c, "Album title is required")
+		case errors.Is(err, album.ErrInvalidVisibility):
+			response.BadRequest(c, "Invalid visibility, use public, unlisted or private")
+		default:
+			response.InternalError(c, "Failed to create album")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListMine lists current user's albums
+// @Summary List my albums
+// @Description Get all albums of current user in any visibility, most recently updated first
+// @Tags Albums
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/albums/mine [get]
+func (h *AlbumHandler) ListMine(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.albumService.ListMine(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		response.InternalError(c, "Failed to list albums")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListUserAlbums lists a user's public albums
+// @Summary List user's albums
+// @Description Get a user's public albums shown on their profile
+// @Tags Albums
+// @Produce json
+// @Param id path int true "User ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Router /api/v1/users/{id}/albums [get]
+func (h *AlbumHandler) ListUserAlbums(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.albumService.ListUserAlbums(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		response.InternalError(c, "Failed to list albums")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetDetail gets album detail
+// @Summary Get album detail
+// @Description Get album detail, private albums are only visible to their owner
+// @Tags Albums
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/albums/{id} [get]
+func (h *AlbumHandler) GetDetail(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid album ID")
+		return
+	}
+
+	var currentUserID *int64
+	if userID, exists := middleware.GetUserID(c); exists {
+		currentUserID = &userID
+	}
+
+	result, err := h.albumService.GetDetail(c.Request.Context(), id, currentUserID)
+	if err != nil {
+		if errors.Is(err, album.ErrAlbumNotFound) {
+			response.NotFound(c, "Album not found")
+			return
+		}
+		response.InternalError(c, "Failed to get album detail")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Update updates an album
+// @Summary Update album
+// @Description Update title, description, cover or visibility of own album
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Album ID"
+// @Param request body album.UpdateRequest true "Fields to update"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/albums/{id} [put]
+func (h *AlbumHandler) Update(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid album ID")
+		return
+	}
+
+	var req album.UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.albumService.Update(c.Request.Context(), id, userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update album")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete deletes an album
+// @Summary Delete album
+// @Description Delete own album, its photos are kept
+// @Tags Albums
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Album ID"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/albums/{id} [delete]
+func (h *AlbumHandler) Delete(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid album ID")
+		return
+	}
+
+	if err := h.albumService.Delete(c.Request.Context(), id, userID); err != nil {
+		h.handleError(c, err, "Failed to delete album")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// ListPhotos lists the photos of an album
+// @Summary List album photos
+// @Description Get the photos of an album in their manual order, other users only see published photos
+// @Tags Albums
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/albums/{id}/photos [get]
+func (h *AlbumHandler) ListPhotos(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid album ID")
+		return
+	}
+
+	var currentUserID *int64
+	if userID, exists := middleware.GetUserID(c); exists {
+		currentUserID = &userID
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.albumService.ListPhotos(c.Request.Context(), id, currentUserID, page, pageSize)
+	if err != nil {
+		if errors.Is(err, album.ErrAlbumNotFound) {
+			response.NotFound(c, "Album not found")
+			return
+		}
+		response.InternalError(c, "Failed to list album photos")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// AddPhotos adds photos to an album
+// @Summary Add photos to album
+// @Description Append own photos to the end of own album in the given order, photos already in it are skipped
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Album ID"
+// @Param request body album.PhotosRequest true "Photos to add (max 100)"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/albums/{id}/photos [post]
+func (h *AlbumHandler) AddPhotos(c *gin.Context) {
+	h.changePhotos(c, h.albumService.AddPhotos, "Failed to add photos to album")
+}
+
+// RemovePhotos removes photos from an album
+// @Summary Remove photos from album
+// @Description Remove photos from own album, the photos themselves are kept
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Album ID"
+// @Param request body album.PhotosRequest true "Photos to remove (max 100)"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/albums/{id}/photos [delete]
+func (h *AlbumHandler) RemovePhotos(c *gin.Context) {
+	h.changePhotos(c, h.albumService.RemovePhotos, "Failed to remove photos from album")
+}
+
+// MovePhoto moves a photo within an album
+// @Summary Reorder album photo
+// @Description Move a photo to a new position in own album, photos in between shift by one
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Album ID"
+// @Param photo_id path int true "Photo ID"
+// @Param request body album.MovePhotoRequest true "New position, 1 is the first photo"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/albums/{id}/photos/{photo_id}/position [put]
+func (h *AlbumHandler) MovePhoto(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid album ID")
+		return
+	}
+	photoID, err := strconv.ParseInt(c.Param("photo_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	var req album.MovePhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.albumService.MovePhoto(c.Request.Context(), id, photoID, userID, &req); err != nil {
+		h.handleError(c, err, "Failed to move photo")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// changePhotos binds a bulk photo request and applies it to an album
+func (h *AlbumHandler) changePhotos(c *gin.Context, change func(ctx context.Context, albumID, userID int64, req *album.PhotosRequest) (*album.PhotosResponse, error), failure string) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid album ID")
+		return
+	}
+
+	var req album.PhotosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := change(c.Request.Context(), id, userID, &req)
+	if err != nil {
+		h.handleError(c, err, failure)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// handleError maps album service errors of owner operations to responses
+func (h *AlbumHandler) handleError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, album.ErrAlbumNotFound):
+		response.NotFound(c, "Album not found")
+	case errors.Is(err, album.ErrNotOwner):
+		response.Forbidden(c, "You are not the owner of this album")
+	case errors.Is(err, album.ErrPhotoNotFound):
+		response.BadRequest(c, "Photos must be your own and not deleted")
+	case errors.Is(err, album.ErrPhotoNotInAlbum):
+		response.BadRequest(c, "Photo is not in this album")
+	case errors.Is(err, album.ErrTitleRequired):
+		response.BadRequest(c, "Album title is required")
+	case errors.Is(err, album.ErrInvalidVisibility):
+		response.BadRequest(c, "Invalid visibility, use public, unlisted or private")
+	default:
+		response.InternalError(c, failure)
+	}
+}
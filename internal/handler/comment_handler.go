@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"strconv"
 
+	"QuanPhotos/internal/middleware"
 	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/repository/postgresql/superadmin"
 	"QuanPhotos/internal/service/comment"
 
 	"github.com/gin-gonic/gin"
@@ -14,12 +16,14 @@ import (
 // CommentHandler handles comment-related requests
 type CommentHandler struct {
 	commentService *comment.Service
+	permissions    middleware.PermissionChecker
 }
 
 // NewCommentHandler creates a new comment handler
-func NewCommentHandler(commentService *comment.Service) *CommentHandler {
+func NewCommentHandler(commentService *comment.Service, permissions middleware.PermissionChecker) *CommentHandler {
 	return &CommentHandler{
 		commentService: commentService,
+		permissions:    permissions,
 	}
 }
 
@@ -44,12 +48,40 @@ func (h *CommentHandler) List(c *gin.Context) {
 		return
 	}
 
-	var req comment.ListRequest
+	h.list(c, comment.ListRequest{PhotoID: photoID})
+}
+
+// ListSeries godoc
+// @Summary Get comments for a series
+// @Description Get paginated comments for a photo series
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param parent_id query int false "Parent comment ID (for replies)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param sort_by query string false "Sort by field" Enums(created_at, like_count)
+// @Success 200 {object} response.Response{data=comment.ListResponse}
+// @Failure 400 {object} response.Response
+// @Router /api/v1/series/{id}/comments [get]
+func (h *CommentHandler) ListSeries(c *gin.Context) {
+	seriesID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid series id")
+		return
+	}
+
+	h.list(c, comment.ListRequest{SeriesID: seriesID})
+}
+
+// list binds the query of a comment list on a photo or series and responds
+// with the comments
+func (h *CommentHandler) list(c *gin.Context, req comment.ListRequest) {
 	if err := c.ShouldBindQuery(&req); err != nil {
 		response.BadRequest(c, err.Error())
 		return
 	}
-	req.PhotoID = photoID
 
 	// Get current user ID if authenticated
 	var currentUserID *int64
@@ -88,14 +120,41 @@ func (h *CommentHandler) Create(c *gin.Context) {
 		return
 	}
 
+	h.create(c, comment.CreateRequest{PhotoID: photoID})
+}
+
+// CreateSeries godoc
+// @Summary Comment on a series
+// @Description Create a new comment on a photo series
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param request body comment.CreateRequest true "Comment data"
+// @Success 201 {object} response.Response{data=comment.CommentItem}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/series/{id}/comments [post]
+func (h *CommentHandler) CreateSeries(c *gin.Context) {
+	seriesID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid series id")
+		return
+	}
+
+	h.create(c, comment.CreateRequest{SeriesID: seriesID})
+}
+
+// create binds a comment on a photo or series and creates it
+func (h *CommentHandler) create(c *gin.Context, req comment.CreateRequest) {
 	userID := c.GetInt64("userID")
 
-	var req comment.CreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		response.BadRequest(c, err.Error())
 		return
 	}
-	req.PhotoID = photoID
 
 	result, err := h.commentService.Create(c.Request.Context(), userID, &req)
 	if err != nil {
@@ -103,6 +162,10 @@ func (h *CommentHandler) Create(c *gin.Context) {
 			response.NotFound(c, "photo not found")
 			return
 		}
+		if errors.Is(err, comment.ErrSeriesNotFound) {
+			response.NotFound(c, "series not found")
+			return
+		}
 		if errors.Is(err, comment.ErrParentNotFound) {
 			response.NotFound(c, "parent comment not found")
 			return
@@ -120,7 +183,7 @@ func (h *CommentHandler) Create(c *gin.Context) {
 
 // Delete godoc
 // @Summary Delete a comment
-// @Description Delete a comment (owner or admin only)
+// @Description Delete a comment (owner or admin with delete_comments permission)
 // @Tags Comments
 // @Accept json
 // @Produce json
@@ -139,8 +202,11 @@ func (h *CommentHandler) Delete(c *gin.Context) {
 	}
 
 	userID := c.GetInt64("userID")
-	role := c.GetString("role")
-	isAdmin := role == "admin" || role == "superadmin"
+	isAdmin, err := middleware.HasPermission(c, h.permissions, superadmin.PermDeleteComments)
+	if err != nil {
+		response.InternalError(c, "failed to check permission")
+		return
+	}
 
 	err = h.commentService.Delete(c.Request.Context(), commentID, userID, isAdmin)
 	if err != nil {
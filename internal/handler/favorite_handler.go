@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/middleware"
+	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/service/photo"
+)
+
+// FavoriteHandler handles favorite folder HTTP requests
+type FavoriteHandler struct {
+	photoService *photo.Service
+}
+
+// NewFavoriteHandler creates a new favorite folder handler
+func NewFavoriteHandler(photoService *photo.Service) *FavoriteHandler {
+	return &FavoriteHandler{
+		photoService: photoService,
+	}
+}
+
+// ListFolders lists current user's favorite folders
+// @Summary List favorite folders
+// @Description Get all favorite folders of current user, default folder first. With photo_id each folder reports whether it holds that photo.
+// @Tags Favorites
+// @Produce json
+// @Security BearerAuth
+// @Param photo_id query int false "Photo ID to check folders for"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/favorite-folders [get]
+func (h *FavoriteHandler) ListFolders(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var photoID int64
+	if raw := c.Query("photo_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			response.BadRequest(c, "Invalid photo ID")
+			return
+		}
+		photoID = id
+	}
+
+	result, err := h.photoService.ListFavoriteFolders(c.Request.Context(), userID, photoID)
+	if err != nil {
+		response.InternalError(c, "Failed to list favorite folders")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// CreateFolder creates a favorite folder
+// @Summary Create favorite folder
+// @Description Create a named folder for favorite photos
+// @Tags Favorites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body photo.FolderRequest true "Folder name"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/favorite-folders [post]
+func (h *FavoriteHandler) CreateFolder(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req photo.FolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.photoService.CreateFavoriteFolder(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create favorite folder")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RenameFolder renames a favorite folder
+// @Summary Rename favorite folder
+// @Description Rename one of current user's favorite folders, the default folder included
+// @Tags Favorites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Folder ID"
+// @Param request body photo.FolderRequest true "Folder name"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/favorite-folders/{id} [put]
+func (h *FavoriteHandler) RenameFolder(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid folder ID")
+		return
+	}
+
+	var req photo.FolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.photoService.RenameFavoriteFolder(c.Request.Context(), id, userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to rename favorite folder")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DeleteFolder deletes a favorite folder
+// @Summary Delete favorite folder
+// @Description Delete one of current user's favorite folders. Photos only in this folder stay favorites and move to the default folder.
+// @Tags Favorites
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Folder ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/favorite-folders/{id} [delete]
+func (h *FavoriteHandler) DeleteFolder(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid folder ID")
+		return
+	}
+
+	if err := h.photoService.DeleteFavoriteFolder(c.Request.Context(), id, userID); err != nil {
+		h.handleError(c, err, "Failed to delete favorite folder")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// ListPhotos lists the photos of a favorite folder
+// @Summary List favorite folder photos
+// @Description Get the photos of one of current user's favorite folders, most recently added first
+// @Tags Favorites
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Folder ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/favorite-folders/{id}/photos [get]
+func (h *FavoriteHandler) ListPhotos(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid folder ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.photoService.ListFolderFavorites(c.Request.Context(), id, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to list favorite folder photos")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// AddPhotos adds photos to a favorite folder
+// @Summary Add photos to favorite folder
+// @Description File photos in one of current user's favorite folders, favoriting those not favorited yet
+// @Tags Favorites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Folder ID"
+// @Param request body photo.FolderPhotosRequest true "Photo IDs"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/favorite-folders/{id}/photos [post]
+func (h *FavoriteHandler) AddPhotos(c *gin.Context) {
+	h.changePhotos(c, h.photoService.AddToFavoriteFolder, "Failed to add photos to favorite folder")
+}
+
+// RemovePhotos removes photos from a favorite folder
+// @Summary Remove photos from favorite folder
+// @Description Take photos out of one of current user's favorite folders. Photos left in no folder are unfavorited.
+// @Tags Favorites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Folder ID"
+// @Param request body photo.FolderPhotosRequest true "Photo IDs"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/favorite-folders/{id}/photos [delete]
+func (h *FavoriteHandler) RemovePhotos(c *gin.Context) {
+	h.changePhotos(c, h.photoService.RemoveFromFavoriteFolder, "Failed to remove photos from favorite folder")
+}
+
+// MovePhotos moves photos to another favorite folder
+// @Summary Move favorites between folders
+// @Description Move photos from this favorite folder to another one of current user's folders
+// @Tags Favorites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Source folder ID"
+// @Param request body photo.MoveFavoritesRequest true "Photo IDs and target folder"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/favorite-folders/{id}/photos/move [post]
+func (h *FavoriteHandler) MovePhotos(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid folder ID")
+		return
+	}
+
+	var req photo.MoveFavoritesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.photoService.MoveFavorites(c.Request.Context(), id, userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to move favorites")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// changePhotos binds a list of photos and applies a change to a favorite folder
+func (h *FavoriteHandler) changePhotos(c *gin.Context, change func(ctx context.Context, folderID, userID int64, req *photo.FolderPhotosRequest) (*photo.FolderPhotosResponse, error), failure string) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid folder ID")
+		return
+	}
+
+	var req photo.FolderPhotosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := change(c.Request.Context(), id, userID, &req)
+	if err != nil {
+		h.handleError(c, err, failure)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// handleError maps favorite folder errors of the photo service to responses
+func (h *FavoriteHandler) handleError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, photo.ErrFolderNotFound):
+		response.NotFound(c, "Favorite folder not found")
+	case errors.Is(err, photo.ErrPhotoNotFound):
+		response.NotFound(c, "Photo not found")
+	case errors.Is(err, photo.ErrFolderNameEmpty):
+		response.BadRequest(c, "Folder name is required")
+	case errors.Is(err, photo.ErrFolderNameTaken):
+		response.BadRequest(c, "A folder with this name already exists")
+	case errors.Is(err, photo.ErrFolderLimit):
+		response.BadRequest(c, "Favorite folder limit reached")
+	case errors.Is(err, photo.ErrDefaultFolder):
+		response.BadRequest(c, "The default folder cannot be deleted")
+	case errors.Is(err, photo.ErrSameFolder):
+		response.BadRequest(c, "Target folder must differ from the source folder")
+	default:
+		response.InternalError(c, failure)
+	}
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/service/gear"
+)
+
+// GearHandler handles camera and lens page HTTP requests
+type GearHandler struct {
+	gearService *gear.Service
+}
+
+// NewGearHandler creates a new gear handler
+func NewGearHandler(gearService *gear.Service) *GearHandler {
+	return &GearHandler{
+		gearService: gearService,
+	}
+}
+
+// ListCameras lists camera bodies
+// @Summary List cameras
+// @Description Get camera bodies used in approved photos, most used first
+// @Tags Gear
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param keyword query string false "Search by camera name"
+// @Success 200 {object} response.Response
+// @Router /api/v1/gear/cameras [get]
+func (h *GearHandler) ListCameras(c *gin.Context) {
+	h.list(c, model.GearTypeCamera)
+}
+
+// ListLenses lists lenses
+// @Summary List lenses
+// @Description Get lenses used in approved photos, most used first
+// @Tags Gear
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param keyword query string false "Search by lens name"
+// @Success 200 {object} response.Response
+// @Router /api/v1/gear/lenses [get]
+func (h *GearHandler) ListLenses(c *gin.Context) {
+	h.list(c, model.GearTypeLens)
+}
+
+// CameraStats gets camera statistics
+// @Summary Get camera stats
+// @Description Get focal length distribution, median ISO and top photographers of a camera body
+// @Tags Gear
+// @Produce json
+// @Param key path string true "Camera key, e.g. nikon-z9"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/gear/cameras/{key}/stats [get]
+func (h *GearHandler) CameraStats(c *gin.Context) {
+	h.stats(c, model.GearTypeCamera)
+}
+
+// LensStats gets lens statistics
+// @Summary Get lens stats
+// @Description Get focal length distribution, median ISO and top photographers of a lens
+// @Tags Gear
+// @Produce json
+// @Param key path string true "Lens key"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/gear/lenses/{key}/stats [get]
+func (h *GearHandler) LensStats(c *gin.Context) {
+	h.stats(c, model.GearTypeLens)
+}
+
+// CameraPhotos lists photos taken with a camera
+// @Summary List camera photos
+// @Description Get approved photos taken with a camera body
+// @Tags Gear
+// @Produce json
+// @Param key path string true "Camera key, e.g. nikon-z9"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param sort_by query string false "Sort by field" Enums(created_at, view_count, like_count)
+// @Param sort_order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} response.Response
+// @Router /api/v1/gear/cameras/{key}/photos [get]
+func (h *GearHandler) CameraPhotos(c *gin.Context) {
+	h.photos(c, model.GearTypeCamera)
+}
+
+// LensPhotos lists photos taken with a lens
+// @Summary List lens photos
+// @Description Get approved photos taken with a lens
+// @Tags Gear
+// @Produce json
+// @Param key path string true "Lens key"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param sort_by query string false "Sort by field" Enums(created_at, view_count, like_count)
+// @Param sort_order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} response.Response
+// @Router /api/v1/gear/lenses/{key}/photos [get]
+func (h *GearHandler) LensPhotos(c *gin.Context) {
+	h.photos(c, model.GearTypeLens)
+}
+
+func (h *GearHandler) list(c *gin.Context, gearType model.GearType) {
+	var req gear.ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.gearService.List(c.Request.Context(), gearType, &req)
+	if err != nil {
+		response.InternalError(c, "Failed to list gear")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+func (h *GearHandler) stats(c *gin.Context, gearType model.GearType) {
+	key := c.Param("key")
+	if key == "" {
+		response.BadRequest(c, "Invalid gear key")
+		return
+	}
+
+	result, err := h.gearService.GetStats(c.Request.Context(), gearType, key)
+	if err != nil {
+		if errors.Is(err, gear.ErrGearNotFound) {
+			response.NotFound(c, "Gear not found")
+			return
+		}
+		response.InternalError(c, "Failed to get gear stats")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+func (h *GearHandler) photos(c *gin.Context, gearType model.GearType) {
+	key := c.Param("key")
+	if key == "" {
+		response.BadRequest(c, "Invalid gear key")
+		return
+	}
+
+	var req gear.ListPhotosRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.gearService.ListPhotos(c.Request.Context(), gearType, key, &req)
+	if err != nil {
+		response.InternalError(c, "Failed to list gear photos")
+		return
+	}
+
+	response.Success(c, result)
+}
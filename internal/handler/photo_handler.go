@@ -1,29 +1,36 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 
 	"QuanPhotos/internal/middleware"
 	"QuanPhotos/internal/model"
 	"QuanPhotos/internal/pkg/response"
 	"QuanPhotos/internal/pkg/storage"
+	"QuanPhotos/internal/repository/postgresql/superadmin"
 	"QuanPhotos/internal/service/photo"
 )
 
 // PhotoHandler handles photo HTTP requests
 type PhotoHandler struct {
 	photoService  *photo.Service
+	permissions   middleware.PermissionChecker
 	maxUploadSize int64
 }
 
 // NewPhotoHandler creates a new photo handler
-func NewPhotoHandler(photoService *photo.Service, maxUploadSize int64) *PhotoHandler {
+func NewPhotoHandler(photoService *photo.Service, permissions middleware.PermissionChecker, maxUploadSize int64) *PhotoHandler {
 	return &PhotoHandler{
 		photoService:  photoService,
+		permissions:   permissions,
 		maxUploadSize: maxUploadSize,
 	}
 }
@@ -44,10 +51,19 @@ func NewPhotoHandler(photoService *photo.Service, maxUploadSize int64) *PhotoHan
 // @Param registration formData string false "Aircraft registration"
 // @Param airport formData string false "Airport (ICAO/IATA)"
 // @Param category_id formData int false "Category ID"
+// @Param spot_id formData int false "Spotting spot ID"
+// @Param flight_number formData string false "Flight number, e.g. CA1234"
+// @Param origin formData string false "Origin airport (ICAO/IATA)"
+// @Param destination formData string false "Destination airport (ICAO/IATA)"
+// @Param flight_phase formData string false "Phase of flight: taxi, takeoff, landing, cruise, ground"
 // @Param tags formData string false "Tags (comma-separated)"
+// @Param visibility formData string false "Visibility: public, unlisted, private" default(public)
+// @Param publish_at formData string false "Scheduled publication time (RFC3339), published once approved when omitted"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response "Storage quota exceeded (40302)"
+// @Failure 503 {object} response.Response "Storage quota check unavailable"
 // @Router /api/v1/photos [post]
 func (h *PhotoHandler) Upload(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
@@ -91,6 +107,17 @@ func (h *PhotoHandler) Upload(c *gin.Context) {
 	}
 
 	categoryID, _ := strconv.ParseInt(c.PostForm("category_id"), 10, 32)
+	spotID, _ := strconv.ParseInt(c.PostForm("spot_id"), 10, 64)
+
+	var publishAt *time.Time
+	if value := c.PostForm("publish_at"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			response.BadRequest(c, "Invalid publish_at, expected RFC3339 time")
+			return
+		}
+		publishAt = &t
+	}
 
 	req := &photo.UploadRequest{
 		UserID:       userID,
@@ -103,24 +130,150 @@ func (h *PhotoHandler) Upload(c *gin.Context) {
 		Registration: c.PostForm("registration"),
 		Airport:      c.PostForm("airport"),
 		CategoryID:   int32(categoryID),
+		SpotID:       spotID,
+		FlightNumber: c.PostForm("flight_number"),
+		Origin:       c.PostForm("origin"),
+		Destination:  c.PostForm("destination"),
+		FlightPhase:  c.PostForm("flight_phase"),
 		Tags:         c.PostForm("tags"),
+		Visibility:   model.PhotoVisibility(c.PostForm("visibility")),
+		PublishAt:    publishAt,
 	}
 
 	result, err := h.photoService.Upload(c.Request.Context(), req)
 	if err != nil {
-		if errors.Is(err, storage.ErrFileTooLarge) {
-			response.Error(c, http.StatusRequestEntityTooLarge, response.CodeValidationError, "File too large")
+		status, code, message := uploadError(err)
+		response.Error(c, status, code, message)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// uploadError maps an upload failure to its HTTP status, error code and message
+func uploadError(err error) (int, int, string) {
+	switch {
+	case errors.Is(err, storage.ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge, response.CodeValidationError, "File too large"
+	case errors.Is(err, storage.ErrInvalidFileType):
+		return http.StatusBadRequest, response.CodeInvalidParams, "Invalid file type. Only JPG and PNG are allowed"
+	case errors.Is(err, photo.ErrSpotNotFound):
+		return http.StatusBadRequest, response.CodeInvalidParams, "Spotting spot not found"
+	case errors.Is(err, photo.ErrQuotaExceeded):
+		return http.StatusForbidden, response.CodeQuotaExceeded, "Storage quota exceeded"
+	case errors.Is(err, photo.ErrQuotaUnavailable):
+		return http.StatusServiceUnavailable, response.CodeInternalError, "Storage quota check unavailable, try again later"
+	case errors.Is(err, photo.ErrInvalidFlightNumber),
+		errors.Is(err, photo.ErrInvalidRouteAirport),
+		errors.Is(err, photo.ErrInvalidFlightPhase),
+		errors.Is(err, photo.ErrInvalidVisibility),
+		errors.Is(err, photo.ErrPublishAtPast),
+		errors.Is(err, photo.ErrEmptyTitle),
+		errors.Is(err, photo.ErrRawFileNotFound):
+		return http.StatusBadRequest, response.CodeInvalidParams, err.Error()
+	default:
+		return http.StatusInternalServerError, response.CodeInternalError, "Failed to upload photo"
+	}
+}
+
+// batchUploadItem is the outcome of one file of a batch upload
+type batchUploadItem struct {
+	Index    int    `json:"index"`
+	Filename string `json:"filename"`
+	Success  bool   `json:"success"`
+	ID       int64  `json:"id,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Code     int    `json:"code,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// BatchUpload uploads several photos in one request
+// @Summary Batch upload photos
+// @Description Upload several photos with a JSON manifest of shared defaults and per-file overrides. Every file is uploaded on its own, the response reports the result of each file. The batch counts as one upload for rate limiting.
+// @Tags Photos
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param files formData file true "Photo files (JPG/PNG), repeated"
+// @Param raw_files formData file false "RAW files referenced by name from the manifest, repeated"
+// @Param manifest formData string false "JSON manifest: {defaults: {...}, items: [{...}]}, items apply to files in order"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/photos/batch [post]
+func (h *PhotoHandler) BatchUpload(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		response.BadRequest(c, "Invalid multipart form")
+		return
+	}
+
+	req := &photo.BatchUploadRequest{
+		UserID:   userID,
+		Files:    form.File["files"],
+		RawFiles: form.File["raw_files"],
+	}
+	if len(req.Files) == 0 {
+		response.BadRequest(c, "No file provided")
+		return
+	}
+	if maxFiles := h.photoService.MaxBatchFiles(); len(req.Files) > maxFiles {
+		response.BadRequest(c, fmt.Sprintf("At most %d files can be uploaded in one batch", maxFiles))
+		return
+	}
+
+	if manifest := c.PostForm("manifest"); manifest != "" {
+		if err := json.Unmarshal([]byte(manifest), &req.Manifest); err != nil {
+			response.BadRequest(c, "Invalid manifest")
 			return
 		}
-		if errors.Is(err, storage.ErrInvalidFileType) {
-			response.BadRequest(c, "Invalid file type. Only JPG and PNG are allowed")
+		if err := binding.Validator.ValidateStruct(&req.Manifest); err != nil {
+			response.BadRequest(c, err.Error())
 			return
 		}
-		response.InternalError(c, "Failed to upload photo")
+	}
+
+	result, err := h.photoService.BatchUpload(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, photo.ErrBatchEmpty):
+			response.BadRequest(c, "No file provided")
+		case errors.Is(err, photo.ErrBatchTooLarge),
+			errors.Is(err, photo.ErrManifestMismatch):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalError(c, "Failed to upload photos")
+		}
 		return
 	}
 
-	response.Success(c, result)
+	items := make([]batchUploadItem, 0, len(result.Results))
+	for _, r := range result.Results {
+		item := batchUploadItem{Index: r.Index, Filename: r.Filename}
+		if r.Err != nil {
+			_, item.Code, item.Error = uploadError(r.Err)
+		} else {
+			item.Success = true
+			item.ID = r.Photo.ID
+			item.Status = r.Photo.Status
+			item.Title = r.Photo.Title
+		}
+		items = append(items, item)
+	}
+
+	response.Success(c, gin.H{
+		"total":     len(items),
+		"succeeded": result.Succeeded,
+		"failed":    result.Failed,
+		"items":     items,
+	})
 }
 
 // List lists photos with pagination and filters
@@ -135,12 +288,29 @@ func (h *PhotoHandler) Upload(c *gin.Context) {
 // @Param airline query string false "Filter by airline"
 // @Param airport query string false "Filter by airport"
 // @Param registration query string false "Filter by aircraft registration"
+// @Param flight_number query string false "Filter by flight number"
+// @Param origin query string false "Filter by origin airport code"
+// @Param destination query string false "Filter by destination airport code"
+// @Param flight_phase query string false "Filter by phase of flight: taxi, takeoff, landing, cruise, ground"
+// @Param camera query string false "Filter by camera key, e.g. nikon-z9"
+// @Param lens query string false "Filter by lens key"
+// @Param camera_model query string false "Filter by exact EXIF camera model (case-insensitive)"
+// @Param lens_model query string false "Filter by exact EXIF lens model (case-insensitive)"
+// @Param focal_min query number false "Minimum 35mm-equivalent focal length (mm)"
+// @Param focal_max query number false "Maximum 35mm-equivalent focal length (mm)"
+// @Param aperture_min query number false "Minimum f-number"
+// @Param aperture_max query number false "Maximum f-number"
+// @Param shutter_min query string false "Minimum exposure time, e.g. 1/2000 or 0.5"
+// @Param shutter_max query string false "Maximum exposure time, e.g. 1/60 or 2"
+// @Param iso_min query int false "Minimum ISO"
+// @Param iso_max query int false "Maximum ISO"
 // @Param keyword query string false "Search keyword (title, description, aircraft_type, registration)"
 // @Param taken_from query string false "Filter by photo taken date from (format: 2006-01-02)"
 // @Param taken_to query string false "Filter by photo taken date to (format: 2006-01-02)"
 // @Param sort_by query string false "Sort by: created_at, view_count, like_count, favorite_count"
 // @Param sort_order query string false "Sort order: asc, desc"
 // @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
 // @Router /api/v1/photos [get]
 func (h *PhotoHandler) List(c *gin.Context) {
 	var req photo.ListRequest
@@ -159,6 +329,10 @@ func (h *PhotoHandler) List(c *gin.Context) {
 
 	result, err := h.photoService.List(c.Request.Context(), &req)
 	if err != nil {
+		if errors.Is(err, photo.ErrInvalidExifFilter) {
+			response.BadRequest(c, "Invalid EXIF filter")
+			return
+		}
 		response.InternalError(c, "Failed to list photos")
 		return
 	}
@@ -166,6 +340,86 @@ func (h *PhotoHandler) List(c *gin.Context) {
 	response.Success(c, result)
 }
 
+// SearchByLocation searches photos by location
+// @Summary Search photos by location
+// @Description Search approved photos within a bounding box or within a radius around a point
+// @Tags Photos
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param min_lat query number false "Bounding box minimum latitude"
+// @Param max_lat query number false "Bounding box maximum latitude"
+// @Param min_lng query number false "Bounding box minimum longitude"
+// @Param max_lng query number false "Bounding box maximum longitude"
+// @Param lat query number false "Center latitude for radius search"
+// @Param lng query number false "Center longitude for radius search"
+// @Param radius_km query number false "Search radius in kilometers (max 500)"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/v1/photos/geo [get]
+func (h *PhotoHandler) SearchByLocation(c *gin.Context) {
+	var req photo.GeoSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.photoService.SearchByLocation(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, photo.ErrInvalidBoundingBox) {
+			response.BadRequest(c, "Invalid bounding box: min_lat, max_lat, min_lng and max_lng are required")
+			return
+		}
+		if errors.Is(err, photo.ErrInvalidRadius) {
+			response.BadRequest(c, "Invalid radius search: lat, lng and radius_km (0-500) are required")
+			return
+		}
+		response.InternalError(c, "Failed to search photos")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// MapClusters returns clustered photo markers for map display
+// @Summary Get map clusters
+// @Description Get photo markers clustered by geohash for the given zoom level
+// @Tags Photos
+// @Produce json
+// @Param zoom query int false "Map zoom level (0-20)" default(0)
+// @Param min_lat query number false "Viewport minimum latitude"
+// @Param max_lat query number false "Viewport maximum latitude"
+// @Param min_lng query number false "Viewport minimum longitude"
+// @Param max_lng query number false "Viewport maximum longitude"
+// @Param limit query int false "Maximum number of clusters" default(500)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/v1/photos/map [get]
+func (h *PhotoHandler) MapClusters(c *gin.Context) {
+	var req photo.MapClustersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	if req.Zoom < 0 || req.Zoom > 20 {
+		response.BadRequest(c, "Invalid zoom level")
+		return
+	}
+
+	result, err := h.photoService.ListMapClusters(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, photo.ErrInvalidBoundingBox) {
+			response.BadRequest(c, "Invalid bounding box")
+			return
+		}
+		response.InternalError(c, "Failed to get map clusters")
+		return
+	}
+
+	response.Success(c, result)
+}
+
 // GetDetail gets photo detail by ID
 // @Summary Get photo detail
 // @Description Get detailed information about a photo
@@ -188,8 +442,11 @@ func (h *PhotoHandler) GetDetail(c *gin.Context) {
 	if userID, exists := middleware.GetUserID(c); exists {
 		currentUserID = &userID
 	}
+	roleStr, _ := middleware.GetRole(c)
+	role := model.UserRole(roleStr)
+	isAdmin := role == model.RoleAdmin || role == model.RoleSuperAdmin
 
-	detail, err := h.photoService.GetDetail(c.Request.Context(), id, currentUserID)
+	detail, err := h.photoService.GetDetail(c.Request.Context(), id, currentUserID, isAdmin)
 	if err != nil {
 		if errors.Is(err, photo.ErrPhotoNotFound) {
 			response.NotFound(c, "Photo not found")
@@ -211,6 +468,8 @@ func (h *PhotoHandler) GetDetail(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(20)
 // @Param status query string false "Filter by status"
+// @Param visibility query string false "Filter by visibility: public, unlisted, private"
+// @Param scheduled query bool false "Only photos waiting for their scheduled publication"
 // @Success 200 {object} response.Response
 // @Failure 401 {object} response.Response
 // @Router /api/v1/photos/mine [get]
@@ -224,8 +483,10 @@ func (h *PhotoHandler) ListMine(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
 	status := c.Query("status")
+	visibility := c.Query("visibility")
+	scheduled := c.Query("scheduled") == "true"
 
-	result, err := h.photoService.ListMyPhotos(c.Request.Context(), userID, page, pageSize, status)
+	result, err := h.photoService.ListMyPhotos(c.Request.Context(), userID, page, pageSize, status, visibility, scheduled)
 	if err != nil {
 		response.InternalError(c, "Failed to list photos")
 		return
@@ -234,6 +495,36 @@ func (h *PhotoHandler) ListMine(c *gin.Context) {
 	response.Success(c, result)
 }
 
+// ListTrash lists the current user's deleted photos
+// @Summary List my deleted photos
+// @Description Get current user's photos in the trash that can still be restored by an admin, most recently deleted first
+// @Tags Photos
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/photos/trash [get]
+func (h *PhotoHandler) ListTrash(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.photoService.ListTrash(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		response.InternalError(c, "Failed to list deleted photos")
+		return
+	}
+
+	response.Success(c, result)
+}
+
 // ListUserPhotos lists a user's approved photos
 // @Summary List user's photos
 // @Description Get a user's approved photos
@@ -446,9 +737,9 @@ func (h *PhotoHandler) RemoveLike(c *gin.Context) {
 	response.Success(c, gin.H{"message": "Unliked"})
 }
 
-// Delete deletes a photo
+// Delete moves a photo to the trash
 // @Summary Delete photo
-// @Description Delete a photo (owner or admin only)
+// @Description Move a photo to the trash (owner or admin only), it is purged after the retention period
 // @Tags Photos
 // @Produce json
 // @Security BearerAuth
@@ -472,10 +763,12 @@ func (h *PhotoHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	// Check if user is admin
-	roleStr, _ := middleware.GetRole(c)
-	role := model.UserRole(roleStr)
-	isAdmin := role == model.RoleAdmin || role == model.RoleSuperAdmin
+	// Admins may delete any photo with the delete_photos permission
+	isAdmin, err := middleware.HasPermission(c, h.permissions, superadmin.PermDeletePhotos)
+	if err != nil {
+		response.InternalError(c, "Failed to check permission")
+		return
+	}
 
 	err = h.photoService.Delete(c.Request.Context(), photoID, userID, isAdmin)
 	if err != nil {
@@ -493,3 +786,604 @@ func (h *PhotoHandler) Delete(c *gin.Context) {
 
 	response.Success(c, gin.H{"message": "Photo deleted"})
 }
+
+// GetReviewHistory returns the review history of the current user's photo
+// @Summary Get photo review history
+// @Description Get the review history of one of your photos, also after it was deleted. Reviewer identities and internal routing are not included.
+// @Tags Photos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/photos/{id}/reviews [get]
+func (h *PhotoHandler) GetReviewHistory(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	idStr := c.Param("id")
+	photoID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	result, err := h.photoService.GetReviewHistory(c.Request.Context(), photoID, userID)
+	if err != nil {
+		if errors.Is(err, photo.ErrPhotoNotFound) {
+			response.NotFound(c, "Photo not found")
+			return
+		}
+		if errors.Is(err, photo.ErrNotOwner) {
+			response.Forbidden(c, "You are not the owner of this photo")
+			return
+		}
+		response.InternalError(c, "Failed to get review history")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Update edits the metadata of a photo
+// @Summary Edit photo
+// @Description Edit title, description, aviation info, category and tags of own photo. Omitted fields are left unchanged, empty values clear optional fields and tags replace all tags. Every edit is stored as a revision. Editing a review relevant field of an approved photo sends it back to review. Photos that are not published yet can be rescheduled with publish_at.
+// @Tags Photos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param request body photo.UpdateRequest true "Edited fields"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/photos/{id} [put]
+func (h *PhotoHandler) Update(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	var req photo.UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.photoService.Update(c.Request.Context(), photoID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, photo.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, photo.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		case errors.Is(err, photo.ErrEditRejected):
+			response.Conflict(c, "Rejected photos are corrected by resubmitting them")
+		case errors.Is(err, photo.ErrEmptyTitle):
+			response.BadRequest(c, "Title cannot be empty")
+		case errors.Is(err, photo.ErrNoChanges):
+			response.BadRequest(c, "Nothing to update")
+		case errors.Is(err, photo.ErrAlreadyPublished):
+			response.Conflict(c, "Photo is already published")
+		case errors.Is(err, photo.ErrPublishAtPast),
+			errors.Is(err, photo.ErrInvalidFlightNumber),
+			errors.Is(err, photo.ErrInvalidRouteAirport),
+			errors.Is(err, photo.ErrInvalidFlightPhase):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalError(c, "Failed to update photo")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListRevisions returns the metadata revisions of the current user's photo
+// @Summary List photo revisions
+// @Description Get the metadata revisions of one of your photos with the old and new value of every changed field, newest first
+// @Tags Photos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/photos/{id}/revisions [get]
+func (h *PhotoHandler) ListRevisions(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	result, err := h.photoService.ListRevisions(c.Request.Context(), photoID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, photo.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, photo.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		default:
+			response.InternalError(c, "Failed to list photo revisions")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// UpdateVisibility changes who can see a photo
+// @Summary Update photo visibility
+// @Description Make own photo public, unlisted (reachable by direct link or share token only) or private (owner and admins only). Unlisted photos get a share token.
+// @Tags Photos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param request body photo.VisibilityRequest true "Visibility"
+// @Success 200 {object} response.Response{data=photo.VisibilityResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/photos/{id}/visibility [put]
+func (h *PhotoHandler) UpdateVisibility(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	var req photo.VisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.photoService.UpdateVisibility(c.Request.Context(), photoID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, photo.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, photo.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		case errors.Is(err, photo.ErrInvalidVisibility):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalError(c, "Failed to update visibility")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Schedule reschedules the publication of a photo
+// @Summary Reschedule photo publication
+// @Description Set when own photo that is not published yet goes live. Approved photos stay hidden from everyone but the owner and admins until then. A null publish_at publishes the photo once approved, right away if it already is.
+// @Tags Photos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param request body photo.ScheduleRequest true "Publication time"
+// @Success 200 {object} response.Response{data=photo.ScheduleResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/photos/{id}/schedule [put]
+func (h *PhotoHandler) Schedule(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	var req photo.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.photoService.Schedule(c.Request.Context(), photoID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, photo.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, photo.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		case errors.Is(err, photo.ErrAlreadyPublished):
+			response.Conflict(c, "Photo is already published")
+		case errors.Is(err, photo.ErrPublishAtPast):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalError(c, "Failed to schedule photo")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetShared gets the photo a share link points to
+// @Summary Get shared photo
+// @Description Get the detail of an unlisted photo by its share token
+// @Tags Photos
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} response.Response{data=model.PhotoDetail}
+// @Failure 404 {object} response.Response
+// @Router /api/v1/photos/shared/{token} [get]
+func (h *PhotoHandler) GetShared(c *gin.Context) {
+	var currentUserID *int64
+	if userID, exists := middleware.GetUserID(c); exists {
+		currentUserID = &userID
+	}
+
+	detail, err := h.photoService.GetShared(c.Request.Context(), c.Param("token"), currentUserID)
+	if err != nil {
+		if errors.Is(err, photo.ErrPhotoNotFound) {
+			response.NotFound(c, "Photo not found")
+			return
+		}
+		response.InternalError(c, "Failed to get photo detail")
+		return
+	}
+
+	response.Success(c, detail)
+}
+
+// GuardFile stops stored files of private photos from being served to anyone
+// but their owner and staff. Requests authenticate with the Authorization
+// header; other files are served to everyone.
+func (h *PhotoHandler) GuardFile(c *gin.Context) {
+	var currentUserID *int64
+	if userID, exists := middleware.GetUserID(c); exists {
+		currentUserID = &userID
+	}
+	roleStr, _ := middleware.GetRole(c)
+	isStaff := model.RoleLevel(model.UserRole(roleStr)) >= model.RoleLevel(model.RoleReviewer)
+
+	allowed, err := h.photoService.CanAccessFile(c.Request.Context(), c.Param("filepath"), currentUserID, isStaff)
+	if err != nil {
+		response.InternalError(c, "Failed to check file access")
+		c.Abort()
+		return
+	}
+	if !allowed {
+		response.NotFound(c, "File not found")
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// ReplaceFile uploads a new image for an approved photo
+// @Summary Replace photo file
+// @Description Upload a new image for own approved photo, e.g. a corrected crop. The image is validated, processed and screened like a new upload. The photo keeps its current image until the replacement is approved; likes, comments, tags and featured status are kept.
+// @Tags Photos
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param file formData file true "Replacement photo file (JPG/PNG)"
+// @Param raw_file formData file false "Replacement RAW file"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Router /api/v1/photos/{id}/replace [post]
+func (h *PhotoHandler) ReplaceFile(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "No file provided")
+		return
+	}
+	if file.Size > h.maxUploadSize {
+		response.Error(c, http.StatusRequestEntityTooLarge, response.CodeValidationError, "File too large")
+		return
+	}
+	rawFile, _ := c.FormFile("raw_file")
+
+	result, err := h.photoService.ReplaceFile(c.Request.Context(), photoID, userID, file, rawFile)
+	if err != nil {
+		switch {
+		case errors.Is(err, photo.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, photo.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		case errors.Is(err, photo.ErrNotApproved):
+			response.Conflict(c, "Only approved photos can have their file replaced")
+		case errors.Is(err, storage.ErrFileTooLarge):
+			response.Error(c, http.StatusRequestEntityTooLarge, response.CodeValidationError, "File too large")
+		case errors.Is(err, storage.ErrInvalidFileType):
+			response.BadRequest(c, "Invalid file type. Only JPG and PNG are allowed")
+		case errors.Is(err, photo.ErrQuotaExceeded):
+			response.Error(c, http.StatusForbidden, response.CodeQuotaExceeded, "Storage quota exceeded")
+		case errors.Is(err, photo.ErrQuotaUnavailable):
+			response.Error(c, http.StatusServiceUnavailable, response.CodeInternalError, "Storage quota check unavailable, try again later")
+		default:
+			response.InternalError(c, "Failed to replace photo file")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListReplacements returns the replacement history of the current user's photo
+// @Summary List photo file replacements
+// @Description Get the replacement images uploaded for one of your photos and their review results, newest first
+// @Tags Photos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/photos/{id}/replacements [get]
+func (h *PhotoHandler) ListReplacements(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.photoService.ListReplacements(c.Request.Context(), photoID, userID, page, pageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, photo.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, photo.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		default:
+			response.InternalError(c, "Failed to list replacements")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// UpdateFlightInfo updates flight info of a photo
+// @Summary Update photo flight info
+// @Description Update flight number, route and phase of flight of own photo. Empty fields are cleared.
+// @Tags Photos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param request body photo.FlightInfoRequest true "Flight info"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/photos/{id}/flight [put]
+func (h *PhotoHandler) UpdateFlightInfo(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	var req photo.FlightInfoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	err = h.photoService.UpdateFlightInfo(c.Request.Context(), photoID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, photo.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, photo.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		case errors.Is(err, photo.ErrInvalidFlightNumber),
+			errors.Is(err, photo.ErrInvalidRouteAirport),
+			errors.Is(err, photo.ErrInvalidFlightPhase):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalError(c, "Failed to update flight info")
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// Resubmit sends a rejected photo back to review
+// @Summary Resubmit rejected photo
+// @Description Correct a rejected photo and send it back to review as a new attempt. Only submitted fields are changed, empty values clear optional fields. A new file replaces the image and is screened again.
+// @Tags Photos
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param file formData file false "Replacement photo file (JPG/PNG)"
+// @Param raw_file formData file false "Replacement RAW file, only used with file"
+// @Param title formData string false "Photo title" maxLength(100)
+// @Param description formData string false "Photo description" maxLength(500)
+// @Param aircraft_type formData string false "Aircraft type"
+// @Param airline formData string false "Airline"
+// @Param registration formData string false "Aircraft registration"
+// @Param airport formData string false "Airport (ICAO/IATA)"
+// @Param category_id formData int false "Category ID, 0 clears the category"
+// @Param flight_number formData string false "Flight number, e.g. CA1234"
+// @Param origin formData string false "Origin airport (ICAO/IATA)"
+// @Param destination formData string false "Destination airport (ICAO/IATA)"
+// @Param flight_phase formData string false "Phase of flight: taxi, takeoff, landing, cruise, ground"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/photos/{id}/resubmit [post]
+func (h *PhotoHandler) Resubmit(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	req := &photo.ResubmitRequest{UserID: userID}
+
+	// Get optional replacement file
+	if file, err := c.FormFile("file"); err == nil {
+		if file.Size > h.maxUploadSize {
+			response.Error(c, http.StatusRequestEntityTooLarge, response.CodeValidationError, "File too large")
+			return
+		}
+		req.File = file
+		req.RawFile, _ = c.FormFile("raw_file")
+	}
+
+	// Only submitted fields are corrected
+	formField := func(key string) *string {
+		if value, ok := c.GetPostForm(key); ok {
+			return &value
+		}
+		return nil
+	}
+
+	req.Title = formField("title")
+	if req.Title != nil {
+		if *req.Title == "" {
+			response.BadRequest(c, "Title cannot be empty")
+			return
+		}
+		if len(*req.Title) > 100 {
+			response.BadRequest(c, "Title must be less than 100 characters")
+			return
+		}
+	}
+	req.Description = formField("description")
+	if req.Description != nil && len(*req.Description) > 500 {
+		response.BadRequest(c, "Description must be less than 500 characters")
+		return
+	}
+	if value := formField("category_id"); value != nil {
+		categoryID, err := strconv.ParseInt(*value, 10, 32)
+		if err != nil || categoryID < 0 {
+			response.BadRequest(c, "Invalid category ID")
+			return
+		}
+		id := int32(categoryID)
+		req.CategoryID = &id
+	}
+	req.AircraftType = formField("aircraft_type")
+	req.Airline = formField("airline")
+	req.Registration = formField("registration")
+	req.Airport = formField("airport")
+	req.FlightNumber = formField("flight_number")
+	req.Origin = formField("origin")
+	req.Destination = formField("destination")
+	req.FlightPhase = formField("flight_phase")
+
+	result, err := h.photoService.Resubmit(c.Request.Context(), photoID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, photo.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, photo.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		case errors.Is(err, photo.ErrNotRejected):
+			response.Conflict(c, "Only rejected photos can be resubmitted")
+		case errors.Is(err, storage.ErrFileTooLarge):
+			response.Error(c, http.StatusRequestEntityTooLarge, response.CodeValidationError, "File too large")
+		case errors.Is(err, storage.ErrInvalidFileType):
+			response.BadRequest(c, "Invalid file type. Only JPG and PNG are allowed")
+		case errors.Is(err, photo.ErrQuotaExceeded):
+			response.Error(c, http.StatusForbidden, response.CodeQuotaExceeded, "Storage quota exceeded")
+		case errors.Is(err, photo.ErrQuotaUnavailable):
+			response.Error(c, http.StatusServiceUnavailable, response.CodeInternalError, "Storage quota check unavailable, try again later")
+		case errors.Is(err, photo.ErrInvalidFlightNumber),
+			errors.Is(err, photo.ErrInvalidRouteAirport),
+			errors.Is(err, photo.ErrInvalidFlightPhase):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalError(c, "Failed to resubmit photo")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/middleware"
+	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/service/quota"
+)
+
+// QuotaHandler handles storage usage and quota HTTP requests
+type QuotaHandler struct {
+	quotaService *quota.Service
+}
+
+// NewQuotaHandler creates a new quota handler
+func NewQuotaHandler(quotaService *quota.Service) *QuotaHandler {
+	return &QuotaHandler{
+		quotaService: quotaService,
+	}
+}
+
+// GetMyUsage returns the storage usage of the current user
+// @Summary Get my storage usage
+// @Description Get the bytes stored for the current user's photos, including RAW files, thumbnails and photos in the trash, with the storage quota
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/users/me/storage [get]
+func (h *QuotaHandler) GetMyUsage(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	result, err := h.quotaService.GetUsage(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, quota.ErrUserNotFound) {
+			response.NotFound(c, "User not found")
+			return
+		}
+		response.InternalError(c, "Failed to get storage usage")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetUserUsage returns the storage usage of a user
+// @Summary Get user storage usage (Admin)
+// @Description Get a user's storage usage and quota with the admin override
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id}/storage [get]
+func (h *QuotaHandler) GetUserUsage(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	result, err := h.quotaService.GetUserUsage(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, quota.ErrUserNotFound) {
+			response.NotFound(c, "User not found")
+			return
+		}
+		response.InternalError(c, "Failed to get storage usage")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// SetUserQuota overrides the storage quota of a user
+// @Summary Override user storage quota (Admin)
+// @Description Set the storage quota of a user in megabytes regardless of role and trust tier, 0 is unlimited, or clear the override with a null quota
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body quota.SetOverrideRequest true "Quota override"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id}/storage [put]
+func (h *QuotaHandler) SetUserQuota(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req quota.SetOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.quotaService.SetOverride(c.Request.Context(), userID, operatorID, &req)
+	if err != nil {
+		if errors.Is(err, quota.ErrUserNotFound) {
+			response.NotFound(c, "User not found")
+			return
+		}
+		response.InternalError(c, "Failed to override storage quota")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Reconcile handles POST /api/v1/superadmin/storage/reconcile
+func (h *QuotaHandler) Reconcile(c *gin.Context) {
+	result, err := h.quotaService.Reconcile(c.Request.Context())
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/service/rejection"
+)
+
+// RejectionHandler handles rejection reason HTTP requests
+type RejectionHandler struct {
+	rejectionService *rejection.Service
+}
+
+// NewRejectionHandler creates a new rejection reason handler
+func NewRejectionHandler(rejectionService *rejection.Service) *RejectionHandler {
+	return &RejectionHandler{
+		rejectionService: rejectionService,
+	}
+}
+
+// List lists active rejection reasons
+// @Summary List rejection reasons
+// @Description Get the active rejection reasons. Clients translate reasons by code, name and name_en are default labels.
+// @Tags RejectionReasons
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/v1/rejection-reasons [get]
+func (h *RejectionHandler) List(c *gin.Context) {
+	result, err := h.rejectionService.List(c.Request.Context(), false)
+	if err != nil {
+		response.InternalError(c, "Failed to list rejection reasons")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// AdminList lists all rejection reasons including inactive ones
+// @Summary List rejection reasons (Admin)
+// @Description Get all rejection reasons including inactive ones
+// @Tags RejectionReasons
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/rejection-reasons [get]
+func (h *RejectionHandler) AdminList(c *gin.Context) {
+	result, err := h.rejectionService.List(c.Request.Context(), true)
+	if err != nil {
+		response.InternalError(c, "Failed to list rejection reasons")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Create creates a new rejection reason (Admin only)
+// @Summary Create rejection reason (Admin)
+// @Description Add a reason to the rejection reason catalogue
+// @Tags RejectionReasons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body rejection.ReasonRequest true "Rejection reason data"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/admin/rejection-reasons [post]
+func (h *RejectionHandler) Create(c *gin.Context) {
+	var req rejection.ReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.rejectionService.Create(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, rejection.ErrInvalidCode) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		if errors.Is(err, rejection.ErrDuplicateCode) {
+			response.Conflict(c, "Rejection reason code already exists")
+			return
+		}
+		response.InternalError(c, "Failed to create rejection reason")
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// Update updates a rejection reason (Admin only)
+// @Summary Update rejection reason (Admin)
+// @Description Update a rejection reason, set is_active to false to retire it
+// @Tags RejectionReasons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Rejection reason ID"
+// @Param request body rejection.ReasonRequest true "Rejection reason data"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/admin/rejection-reasons/{id} [put]
+func (h *RejectionHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid rejection reason ID")
+		return
+	}
+
+	var req rejection.ReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.rejectionService.Update(c.Request.Context(), int32(id), &req)
+	if err != nil {
+		if errors.Is(err, rejection.ErrInvalidCode) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		if errors.Is(err, rejection.ErrReasonNotFound) {
+			response.NotFound(c, "Rejection reason not found")
+			return
+		}
+		if errors.Is(err, rejection.ErrDuplicateCode) {
+			response.Conflict(c, "Rejection reason code already exists")
+			return
+		}
+		response.InternalError(c, "Failed to update rejection reason")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete deletes an unused rejection reason (Admin only)
+// @Summary Delete rejection reason (Admin)
+// @Description Delete a rejection reason that was never used in a review
+// @Tags RejectionReasons
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Rejection reason ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/admin/rejection-reasons/{id} [delete]
+func (h *RejectionHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid rejection reason ID")
+		return
+	}
+
+	err = h.rejectionService.Delete(c.Request.Context(), int32(id))
+	if err != nil {
+		if errors.Is(err, rejection.ErrReasonNotFound) {
+			response.NotFound(c, "Rejection reason not found")
+			return
+		}
+		if errors.Is(err, rejection.ErrReasonInUse) {
+			response.Conflict(c, "Rejection reason has been used, deactivate it instead")
+			return
+		}
+		response.InternalError(c, "Failed to delete rejection reason")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Rejection reason deleted successfully"})
+}
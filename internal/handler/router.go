@@ -6,33 +6,50 @@ import (
 	"QuanPhotos/internal/config"
 	"QuanPhotos/internal/middleware"
 	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/ai"
 	"QuanPhotos/internal/pkg/jwt"
+	"QuanPhotos/internal/pkg/permission"
 	"QuanPhotos/internal/pkg/storage"
+	"QuanPhotos/internal/repository/postgresql/album"
 	"QuanPhotos/internal/repository/postgresql/category"
 	"QuanPhotos/internal/repository/postgresql/comment"
 	"QuanPhotos/internal/repository/postgresql/conversation"
+	"QuanPhotos/internal/repository/postgresql/gear"
 	"QuanPhotos/internal/repository/postgresql/notification"
 	"QuanPhotos/internal/repository/postgresql/photo"
+	"QuanPhotos/internal/repository/postgresql/quota"
 	"QuanPhotos/internal/repository/postgresql/ranking"
+	"QuanPhotos/internal/repository/postgresql/rejection"
+	"QuanPhotos/internal/repository/postgresql/series"
 	"QuanPhotos/internal/repository/postgresql/share"
+	"QuanPhotos/internal/repository/postgresql/spot"
 	"QuanPhotos/internal/repository/postgresql/superadmin"
 	"QuanPhotos/internal/repository/postgresql/tag"
 	"QuanPhotos/internal/repository/postgresql/ticket"
 	"QuanPhotos/internal/repository/postgresql/token"
+	"QuanPhotos/internal/repository/postgresql/trust"
 	"QuanPhotos/internal/repository/postgresql/user"
 	adminService "QuanPhotos/internal/service/admin"
+	aiReviewService "QuanPhotos/internal/service/aireview"
+	albumService "QuanPhotos/internal/service/album"
 	"QuanPhotos/internal/service/auth"
 	categoryService "QuanPhotos/internal/service/category"
 	commentService "QuanPhotos/internal/service/comment"
 	conversationService "QuanPhotos/internal/service/conversation"
+	gearService "QuanPhotos/internal/service/gear"
 	notificationService "QuanPhotos/internal/service/notification"
 	photoService "QuanPhotos/internal/service/photo"
+	quotaService "QuanPhotos/internal/service/quota"
 	rankingService "QuanPhotos/internal/service/ranking"
+	rejectionService "QuanPhotos/internal/service/rejection"
+	seriesService "QuanPhotos/internal/service/series"
 	shareService "QuanPhotos/internal/service/share"
+	spotService "QuanPhotos/internal/service/spot"
 	superadminService "QuanPhotos/internal/service/superadmin"
 	"QuanPhotos/internal/service/system"
 	tagService "QuanPhotos/internal/service/tag"
 	ticketService "QuanPhotos/internal/service/ticket"
+	trustService "QuanPhotos/internal/service/trust"
 	userService "QuanPhotos/internal/service/user"
 
 	"github.com/gin-gonic/gin"
@@ -47,6 +64,9 @@ type Router struct {
 	// JWT manager
 	jwtManager *jwt.Manager
 
+	// Admin permission lookup
+	permissions *permission.Cache
+
 	// Handlers
 	systemHandler       *SystemHandler
 	authHandler         *AuthHandler
@@ -62,6 +82,15 @@ type Router struct {
 	conversationHandler *ConversationHandler
 	notificationHandler *NotificationHandler
 	superadminHandler   *SuperadminHandler
+	spotHandler         *SpotHandler
+	gearHandler         *GearHandler
+	aiHandler           *AIHandler
+	rejectionHandler    *RejectionHandler
+	trustHandler        *TrustHandler
+	albumHandler        *AlbumHandler
+	favoriteHandler     *FavoriteHandler
+	seriesHandler       *SeriesHandler
+	quotaHandler        *QuotaHandler
 }
 
 // NewRouter creates a new router instance
@@ -109,6 +138,16 @@ func NewRouter(cfg *config.Config, db *sqlx.DB) *Router {
 	conversationRepo := conversation.NewConversationRepository(db)
 	notificationRepo := notification.NewNotificationRepository(db)
 	superadminRepo := superadmin.NewSuperadminRepository(db)
+	spotRepo := spot.NewSpotRepository(db)
+	gearRepo := gear.NewGearRepository(db)
+	rejectionRepo := rejection.NewRejectionRepository(db)
+	trustRepo := trust.NewTrustRepository(db)
+	albumRepo := album.NewAlbumRepository(db)
+	seriesRepo := series.NewSeriesRepository(db)
+	quotaRepo := quota.NewQuotaRepository(db)
+
+	// Initialize admin permission cache
+	permissions := permission.NewCache(superadminRepo.GetAdminPermissions, cfg.Cache.PermissionTTL)
 
 	// Initialize local storage
 	localStorage, err := storage.NewLocalStorage(cfg.Storage.Path, cfg.Storage.BaseURL)
@@ -120,7 +159,7 @@ func NewRouter(cfg *config.Config, db *sqlx.DB) *Router {
 	systemService := system.NewService(cfg)
 	authService := auth.New(db, userRepo, tokenRepo, jwtManager)
 	userSvc := userService.New(userRepo)
-	adminSvc := adminService.NewFull(userRepo, photoRepo, ticketRepo, cfg.Storage.BaseURL)
+	adminSvc := adminService.NewFull(userRepo, photoRepo, ticketRepo, superadminRepo, rejectionRepo, permissions, cfg)
 
 	// Initialize photo service with uploader if storage is available
 	var photoSvc *photoService.Service
@@ -130,6 +169,44 @@ func NewRouter(cfg *config.Config, db *sqlx.DB) *Router {
 		photoSvc = photoService.New(photoRepo, cfg.Storage.BaseURL)
 	}
 
+	// Initialize AI review, photos stay in the manual queue when disabled
+	var aiReviewSvc *aiReviewService.Service
+	if cfg.AI.Enabled {
+		aiClient := ai.NewClient(ai.Config{
+			ServiceURL:       cfg.AI.ServiceURL,
+			APIKey:           cfg.AI.APIKey,
+			Timeout:          cfg.AI.Timeout,
+			Retry:            cfg.AI.Retry,
+			BreakerThreshold: cfg.AI.BreakerThreshold,
+			BreakerCooldown:  cfg.AI.BreakerCooldown,
+		})
+		aiReviewSvc = aiReviewService.New(aiClient, photoRepo, cfg)
+		photoSvc.SetAIReviewer(aiReviewSvc)
+	}
+
+	// Initialize trust tiers, trusted uploads skip the manual queue and
+	// rejections or takedowns demote the uploader
+	trustSvc := trustService.New(trustRepo, cfg)
+	photoSvc.SetTrustPolicy(trustSvc)
+	adminSvc.SetDemoter(trustSvc)
+
+	// Uploads are checked against per-user storage quotas, the reconciler
+	// measures legacy files and corrects drifted usage
+	quotaSvc := quotaService.New(quotaRepo, cfg)
+	quotaSvc.SetFileMeasurer(photoSvc)
+	photoSvc.SetQuotaPolicy(quotaSvc)
+	if cfg.Quota.ReconcileInterval > 0 {
+		quotaSvc.StartReconciler(cfg.Quota.ReconcileInterval)
+	}
+
+	// Replacement reviews remove files no longer served
+	adminSvc.SetFileRemover(photoSvc)
+
+	// Edits of review relevant fields send approved photos back to review
+	if cfg.Review.EditReReview {
+		photoSvc.SetReReviewFields(cfg.Review.EditReviewFields)
+	}
+
 	// Initialize ticket service
 	ticketSvc := ticketService.New(ticketRepo, cfg.Storage.BaseURL)
 
@@ -145,28 +222,67 @@ func NewRouter(cfg *config.Config, db *sqlx.DB) *Router {
 	// Initialize conversation and notification services
 	conversationSvc := conversationService.New(conversationRepo)
 	notificationSvc := notificationService.New(notificationRepo)
-	superadminSvc := superadminService.New(superadminRepo)
+	superadminSvc := superadminService.New(superadminRepo, permissions)
+
+	// Scheduled photos go live once due and their owners are notified
+	photoSvc.SetPublishNotifier(notificationSvc)
+	if cfg.Publish.Interval > 0 {
+		photoSvc.StartPublisher(cfg.Publish.Interval)
+	}
+
+	// Deleted photos stay in the trash until the retention period expires
+	photoSvc.SetTrashRetention(cfg.Trash.Retention)
+	if cfg.Trash.PurgeInterval > 0 && cfg.Trash.Retention > 0 {
+		photoSvc.StartPurger(cfg.Trash.PurgeInterval)
+	}
+
+	// Initialize spotting spot service
+	spotSvc := spotService.New(spotRepo, photoRepo, cfg.Storage.BaseURL)
+
+	// Initialize gear service, photos stored before uploads wrote their gear
+	// are normalized once in the background
+	gearSvc := gearService.New(gearRepo, photoRepo, cfg.Storage.BaseURL)
+	gearSvc.StartNormalizer()
+
+	// Initialize rejection reason service
+	rejectionSvc := rejectionService.New(rejectionRepo)
+
+	// Initialize album service
+	albumSvc := albumService.New(albumRepo, photoRepo, cfg.Storage.BaseURL)
+
+	// Initialize series service
+	seriesSvc := seriesService.New(seriesRepo, photoRepo, cfg.Storage.BaseURL)
 
 	// Initialize handlers
 	systemHandler := NewSystemHandler(systemService)
 	authHandler := NewAuthHandler(authService)
 	userHandler := NewUserHandler(userSvc)
 	adminHandler := NewAdminHandler(adminSvc)
-	photoHandler := NewPhotoHandler(photoSvc, cfg.Storage.MaxSize)
+	photoHandler := NewPhotoHandler(photoSvc, permissions, cfg.Storage.MaxSize)
 	ticketHandler := NewTicketHandler(ticketSvc)
 	categoryHandler := NewCategoryHandler(categorySvc)
 	tagHandler := NewTagHandler(tagSvc)
-	commentHandler := NewCommentHandler(commentSvc)
+	commentHandler := NewCommentHandler(commentSvc, permissions)
 	shareHandler := NewShareHandler(shareSvc)
 	publicHandler := NewPublicHandler(photoRepo, rankingSvc, cfg.Storage.BaseURL)
 	conversationHandler := NewConversationHandler(conversationSvc)
 	notificationHandler := NewNotificationHandler(notificationSvc)
 	superadminHandler := NewSuperadminHandler(superadminSvc)
+	spotHandler := NewSpotHandler(spotSvc)
+	gearHandler := NewGearHandler(gearSvc)
+	aiHandler := NewAIHandler(aiReviewSvc)
+	rejectionHandler := NewRejectionHandler(rejectionSvc)
+	trustHandler := NewTrustHandler(trustSvc)
+	albumHandler := NewAlbumHandler(albumSvc)
+	favoriteHandler := NewFavoriteHandler(photoSvc)
+	seriesHandler := NewSeriesHandler(seriesSvc)
+	quotaHandler := NewQuotaHandler(quotaSvc)
 
 	return &Router{
 		engine:              engine,
 		config:              cfg,
 		jwtManager:          jwtManager,
+		permissions:         permissions,
 		systemHandler:       systemHandler,
 		authHandler:         authHandler,
 		userHandler:         userHandler,
@@ -181,6 +297,15 @@ func NewRouter(cfg *config.Config, db *sqlx.DB) *Router {
 		conversationHandler: conversationHandler,
 		notificationHandler: notificationHandler,
 		superadminHandler:   superadminHandler,
+		spotHandler:         spotHandler,
+		gearHandler:         gearHandler,
+		aiHandler:           aiHandler,
+		rejectionHandler:    rejectionHandler,
+		trustHandler:        trustHandler,
+		albumHandler:        albumHandler,
+		favoriteHandler:     favoriteHandler,
+		seriesHandler:       seriesHandler,
+		quotaHandler:        quotaHandler,
 	}
 }
 
@@ -189,8 +314,10 @@ func (r *Router) Setup() {
 	// Health check endpoint
 	r.engine.GET("/health", r.systemHandler.Health)
 
-	// Static file serving for data directory
-	r.engine.Static("/data", r.config.Storage.Path)
+	// Static file serving for data directory, files of private photos are
+	// only served to their owner and staff
+	files := r.engine.Group("/data", middleware.OptionalAuth(r.jwtManager), r.photoHandler.GuardFile)
+	files.Static("/", r.config.Storage.Path)
 
 	// API v1 routes
 	v1 := r.engine.Group("/api/v1")
@@ -213,11 +340,14 @@ func (r *Router) Setup() {
 			// Public routes
 			users.GET("/:id", r.userHandler.GetUser)
 			users.GET("/:id/photos", r.photoHandler.ListUserPhotos)
+			users.GET("/:id/albums", r.albumHandler.ListUserAlbums)
+			users.GET("/:id/series", r.seriesHandler.ListUserSeries)
 
 			// Protected routes (require authentication)
 			users.GET("/me", middleware.Auth(r.jwtManager), r.userHandler.GetCurrentUser)
 			users.PUT("/me", middleware.Auth(r.jwtManager), r.userHandler.UpdateCurrentUser)
 			users.PUT("/me/password", middleware.Auth(r.jwtManager), r.userHandler.ChangePassword)
+			users.GET("/me/storage", middleware.Auth(r.jwtManager), r.quotaHandler.GetMyUsage)
 		}
 
 		// Photos routes
@@ -225,20 +355,104 @@ func (r *Router) Setup() {
 		{
 			// Public routes
 			photos.GET("", r.photoHandler.List)
+			photos.GET("/geo", r.photoHandler.SearchByLocation)
+			photos.GET("/map", r.photoHandler.MapClusters)
+			photos.GET("/shared/:token", middleware.OptionalAuth(r.jwtManager), r.photoHandler.GetShared)
 			photos.GET("/:id", middleware.OptionalAuth(r.jwtManager), r.photoHandler.GetDetail)
 			photos.GET("/:id/comments", middleware.OptionalAuth(r.jwtManager), r.commentHandler.List)
 
 			// Protected routes (require authentication)
-			photos.POST("", middleware.Auth(r.jwtManager), middleware.UploadRateLimiter(), r.photoHandler.Upload)
+			// Every route accepting a file shares one upload limit, a batch counts as one upload
+			uploadLimiter := middleware.UploadRateLimiter()
+			photos.POST("", middleware.Auth(r.jwtManager), uploadLimiter, r.photoHandler.Upload)
+			photos.POST("/batch", middleware.Auth(r.jwtManager), uploadLimiter, r.photoHandler.BatchUpload)
 			photos.GET("/mine", middleware.Auth(r.jwtManager), r.photoHandler.ListMine)
 			photos.GET("/favorites", middleware.Auth(r.jwtManager), r.photoHandler.ListFavorites)
+			photos.GET("/trash", middleware.Auth(r.jwtManager), r.photoHandler.ListTrash)
 			photos.POST("/:id/favorite", middleware.Auth(r.jwtManager), r.photoHandler.AddFavorite)
 			photos.DELETE("/:id/favorite", middleware.Auth(r.jwtManager), r.photoHandler.RemoveFavorite)
 			photos.POST("/:id/like", middleware.Auth(r.jwtManager), r.photoHandler.AddLike)
 			photos.DELETE("/:id/like", middleware.Auth(r.jwtManager), r.photoHandler.RemoveLike)
+			photos.PUT("/:id", middleware.Auth(r.jwtManager), r.photoHandler.Update)
 			photos.DELETE("/:id", middleware.Auth(r.jwtManager), r.photoHandler.Delete)
 			photos.POST("/:id/comments", middleware.Auth(r.jwtManager), r.commentHandler.Create)
 			photos.POST("/:id/share", middleware.Auth(r.jwtManager), r.shareHandler.Share)
+			photos.GET("/:id/spot-suggestions", middleware.Auth(r.jwtManager), r.spotHandler.SuggestForPhoto)
+			photos.PUT("/:id/spot", middleware.Auth(r.jwtManager), r.spotHandler.LinkPhoto)
+			photos.PUT("/:id/flight", middleware.Auth(r.jwtManager), r.photoHandler.UpdateFlightInfo)
+			photos.PUT("/:id/visibility", middleware.Auth(r.jwtManager), r.photoHandler.UpdateVisibility)
+			photos.PUT("/:id/schedule", middleware.Auth(r.jwtManager), r.photoHandler.Schedule)
+			photos.POST("/:id/resubmit", middleware.Auth(r.jwtManager), uploadLimiter, r.photoHandler.Resubmit)
+			photos.POST("/:id/replace", middleware.Auth(r.jwtManager), uploadLimiter, r.photoHandler.ReplaceFile)
+			photos.GET("/:id/replacements", middleware.Auth(r.jwtManager), r.photoHandler.ListReplacements)
+			photos.GET("/:id/reviews", middleware.Auth(r.jwtManager), r.photoHandler.GetReviewHistory)
+			photos.GET("/:id/revisions", middleware.Auth(r.jwtManager), r.photoHandler.ListRevisions)
+		}
+
+		// Spotting spots routes
+		spots := v1.Group("/spots")
+		{
+			// Public routes
+			spots.GET("", r.spotHandler.List)
+			spots.GET("/nearby", r.spotHandler.Nearby)
+			spots.GET("/:id", middleware.OptionalAuth(r.jwtManager), r.spotHandler.GetDetail)
+			spots.GET("/:id/photos", r.spotHandler.ListPhotos)
+
+			// Protected routes (require authentication)
+			spots.POST("", middleware.Auth(r.jwtManager), r.spotHandler.Create)
+			spots.GET("/mine", middleware.Auth(r.jwtManager), r.spotHandler.ListMine)
+		}
+
+		// Album routes
+		albums := v1.Group("/albums")
+		{
+			// Public routes, private albums and unpublished photos are owner only
+			albums.GET("/:id", middleware.OptionalAuth(r.jwtManager), r.albumHandler.GetDetail)
+			albums.GET("/:id/photos", middleware.OptionalAuth(r.jwtManager), r.albumHandler.ListPhotos)
+
+			// Protected routes (require authentication)
+			albums.POST("", middleware.Auth(r.jwtManager), r.albumHandler.Create)
+			albums.GET("/mine", middleware.Auth(r.jwtManager), r.albumHandler.ListMine)
+			albums.PUT("/:id", middleware.Auth(r.jwtManager), r.albumHandler.Update)
+			albums.DELETE("/:id", middleware.Auth(r.jwtManager), r.albumHandler.Delete)
+			albums.POST("/:id/photos", middleware.Auth(r.jwtManager), r.albumHandler.AddPhotos)
+			albums.DELETE("/:id/photos", middleware.Auth(r.jwtManager), r.albumHandler.RemovePhotos)
+			albums.PUT("/:id/photos/:photo_id/position", middleware.Auth(r.jwtManager), r.albumHandler.MovePhoto)
+		}
+
+		// Series routes
+		seriesRoutes := v1.Group("/series")
+		{
+			// Public routes, series without published frames are owner only
+			seriesRoutes.GET("", r.seriesHandler.List)
+			seriesRoutes.GET("/:id", middleware.OptionalAuth(r.jwtManager), r.seriesHandler.GetDetail)
+			seriesRoutes.GET("/:id/comments", middleware.OptionalAuth(r.jwtManager), r.commentHandler.ListSeries)
+
+			// Protected routes (require authentication)
+			seriesRoutes.POST("", middleware.Auth(r.jwtManager), r.seriesHandler.Create)
+			seriesRoutes.GET("/mine", middleware.Auth(r.jwtManager), r.seriesHandler.ListMine)
+			seriesRoutes.PUT("/:id", middleware.Auth(r.jwtManager), r.seriesHandler.Update)
+			seriesRoutes.DELETE("/:id", middleware.Auth(r.jwtManager), r.seriesHandler.Delete)
+			seriesRoutes.POST("/:id/frames", middleware.Auth(r.jwtManager), r.seriesHandler.AddFrames)
+			seriesRoutes.DELETE("/:id/frames", middleware.Auth(r.jwtManager), r.seriesHandler.RemoveFrames)
+			seriesRoutes.PUT("/:id/frames/:photo_id/position", middleware.Auth(r.jwtManager), r.seriesHandler.MoveFrame)
+			seriesRoutes.POST("/:id/like", middleware.Auth(r.jwtManager), r.seriesHandler.AddLike)
+			seriesRoutes.DELETE("/:id/like", middleware.Auth(r.jwtManager), r.seriesHandler.RemoveLike)
+			seriesRoutes.POST("/:id/comments", middleware.Auth(r.jwtManager), r.commentHandler.CreateSeries)
+		}
+
+		// AI review service callback (authenticated by payload signature)
+		v1.POST("/ai/callback", r.aiHandler.Callback)
+
+		// Gear routes (public)
+		gearGroup := v1.Group("/gear")
+		{
+			gearGroup.GET("/cameras", r.gearHandler.ListCameras)
+			gearGroup.GET("/cameras/:key/stats", r.gearHandler.CameraStats)
+			gearGroup.GET("/cameras/:key/photos", r.gearHandler.CameraPhotos)
+			gearGroup.GET("/lenses", r.gearHandler.ListLenses)
+			gearGroup.GET("/lenses/:key/stats", r.gearHandler.LensStats)
+			gearGroup.GET("/lenses/:key/photos", r.gearHandler.LensPhotos)
 		}
 
 		// Comments routes (for individual comment operations)
@@ -258,9 +472,10 @@ func (r *Router) Setup() {
 			categories.GET("/:id", r.categoryHandler.GetByID)
 
 			// Admin routes
-			categories.POST("", middleware.Auth(r.jwtManager), middleware.RequireMinRole(model.RoleAdmin), r.categoryHandler.Create)
-			categories.PUT("/:id", middleware.Auth(r.jwtManager), middleware.RequireMinRole(model.RoleAdmin), r.categoryHandler.Update)
-			categories.DELETE("/:id", middleware.Auth(r.jwtManager), middleware.RequireMinRole(model.RoleAdmin), r.categoryHandler.Delete)
+			manageCategories := r.requirePermission(superadmin.PermManageCategories)
+			categories.POST("", middleware.Auth(r.jwtManager), middleware.RequireMinRole(model.RoleAdmin), manageCategories, r.categoryHandler.Create)
+			categories.PUT("/:id", middleware.Auth(r.jwtManager), middleware.RequireMinRole(model.RoleAdmin), manageCategories, r.categoryHandler.Update)
+			categories.DELETE("/:id", middleware.Auth(r.jwtManager), middleware.RequireMinRole(model.RoleAdmin), manageCategories, r.categoryHandler.Delete)
 		}
 
 		// Tags routes
@@ -272,6 +487,9 @@ func (r *Router) Setup() {
 			tags.GET("/:id/photos", r.tagHandler.ListPhotos)
 		}
 
+		// Rejection reasons routes (public)
+		v1.GET("/rejection-reasons", r.rejectionHandler.List)
+
 		// Featured photos routes (public)
 		v1.GET("/featured", r.publicHandler.ListFeatured)
 
@@ -300,6 +518,20 @@ func (r *Router) Setup() {
 			conversations.DELETE("/:id", r.conversationHandler.Delete)
 		}
 
+		// Favorite folders routes (require authentication)
+		favoriteFolders := v1.Group("/favorite-folders")
+		favoriteFolders.Use(middleware.Auth(r.jwtManager))
+		{
+			favoriteFolders.GET("", r.favoriteHandler.ListFolders)
+			favoriteFolders.POST("", r.favoriteHandler.CreateFolder)
+			favoriteFolders.PUT("/:id", r.favoriteHandler.RenameFolder)
+			favoriteFolders.DELETE("/:id", r.favoriteHandler.DeleteFolder)
+			favoriteFolders.GET("/:id/photos", r.favoriteHandler.ListPhotos)
+			favoriteFolders.POST("/:id/photos", r.favoriteHandler.AddPhotos)
+			favoriteFolders.DELETE("/:id/photos", r.favoriteHandler.RemovePhotos)
+			favoriteFolders.POST("/:id/photos/move", r.favoriteHandler.MovePhotos)
+		}
+
 		// Notifications routes (require authentication)
 		notifications := v1.Group("/notifications")
 		notifications.Use(middleware.Auth(r.jwtManager))
@@ -320,37 +552,74 @@ func (r *Router) Setup() {
 			tickets.POST("/:id/replies", r.ticketHandler.Reply)
 		}
 
-		// Admin routes (require admin or superadmin role)
+		// Photo review routes (reviewers are limited to their assigned categories)
+		reviews := v1.Group("/admin/reviews")
+		reviews.Use(middleware.Auth(r.jwtManager))
+		reviews.Use(middleware.RequireReviewer())
+		{
+			reviews.GET("", r.adminHandler.ListReviews)
+			reviews.GET("/claims", middleware.RequireAdmin(), r.requirePermission(superadmin.PermReviewPhotos), r.adminHandler.ListClaims)
+			reviews.POST("/claim", r.adminHandler.ClaimReviews)
+			reviews.GET("/spot-checks", r.adminHandler.ListSpotChecks)
+			reviews.GET("/replacements", r.adminHandler.ListReplacements)
+			reviews.POST("/replacements/:id", r.adminHandler.ReviewReplacement)
+			reviews.POST("/:id", r.adminHandler.ReviewPhoto)
+			reviews.GET("/:id/history", r.adminHandler.GetReviewHistory)
+			reviews.POST("/:id/spot-check", r.adminHandler.SpotCheck)
+			reviews.DELETE("/:id/claim", r.adminHandler.ReleaseClaim)
+		}
+
+		// Admin routes (require admin or superadmin role, plus a permission per route)
 		admin := v1.Group("/admin")
 		admin.Use(middleware.Auth(r.jwtManager))
 		admin.Use(middleware.RequireMinRole(model.RoleAdmin))
 		{
 			// User management
-			admin.GET("/users", r.adminHandler.ListUsers)
-			admin.PUT("/users/:id/role", r.adminHandler.UpdateUserRole)
-			admin.PUT("/users/:id/status", r.adminHandler.UpdateUserStatus)
-
-			// Photo reviews
-			admin.GET("/reviews", r.adminHandler.ListReviews)
-			admin.POST("/reviews/:id", r.adminHandler.ReviewPhoto)
+			admin.GET("/users", r.requirePermission(superadmin.PermViewUserDetails), r.adminHandler.ListUsers)
+			admin.PUT("/users/:id/role", r.requirePermission(superadmin.PermManageRoles), r.adminHandler.UpdateUserRole)
+			admin.PUT("/users/:id/status", r.requirePermission(superadmin.PermBanUsers), r.adminHandler.UpdateUserStatus)
+			admin.GET("/users/:id/trust", r.requirePermission(superadmin.PermViewUserDetails), r.trustHandler.GetUserTrust)
+			admin.PUT("/users/:id/trust", r.requirePermission(superadmin.PermManageUserTrust), r.trustHandler.SetUserTrust)
+			admin.GET("/users/:id/storage", r.requirePermission(superadmin.PermViewUserDetails), r.quotaHandler.GetUserUsage)
+			admin.PUT("/users/:id/storage", r.requirePermission(superadmin.PermManageUserStorage), r.quotaHandler.SetUserQuota)
 
 			// Photo management
-			admin.DELETE("/photos/:id", r.adminHandler.AdminDeletePhoto)
+			admin.DELETE("/photos/:id", r.requirePermission(superadmin.PermDeletePhotos), r.adminHandler.AdminDeletePhoto)
+			admin.GET("/photos/trash", r.requirePermission(superadmin.PermDeletePhotos), r.adminHandler.ListTrash)
+			admin.POST("/photos/:id/restore", r.requirePermission(superadmin.PermDeletePhotos), r.adminHandler.RestorePhoto)
+			admin.GET("/photos/:id/revisions", r.requirePermission(superadmin.PermReviewPhotos), r.adminHandler.ListPhotoRevisions)
+			admin.POST("/photos/:id/revisions/:revision_id/rollback", r.requirePermission(superadmin.PermReviewPhotos), r.adminHandler.RollbackPhotoRevision)
+
+			// Rejection reason catalogue
+			manageReasons := r.requirePermission(superadmin.PermReviewPhotos)
+			admin.GET("/rejection-reasons", manageReasons, r.rejectionHandler.AdminList)
+			admin.POST("/rejection-reasons", manageReasons, r.rejectionHandler.Create)
+			admin.PUT("/rejection-reasons/:id", manageReasons, r.rejectionHandler.Update)
+			admin.DELETE("/rejection-reasons/:id", manageReasons, r.rejectionHandler.Delete)
+
+			// Spotting spot moderation
+			manageSpots := r.requirePermission(superadmin.PermManageSpots)
+			admin.GET("/spots", manageSpots, r.spotHandler.AdminList)
+			admin.POST("/spots/:id/review", manageSpots, r.spotHandler.Review)
+			admin.DELETE("/spots/:id", manageSpots, r.spotHandler.AdminDelete)
 
 			// Ticket management
-			admin.GET("/tickets", r.adminHandler.ListTickets)
-			admin.PUT("/tickets/:id", r.adminHandler.ProcessTicket)
+			manageTickets := r.requirePermission(superadmin.PermManageTickets)
+			admin.GET("/tickets", manageTickets, r.adminHandler.ListTickets)
+			admin.PUT("/tickets/:id", manageTickets, r.adminHandler.ProcessTicket)
 
 			// Featured photos
-			admin.POST("/featured", r.adminHandler.AddFeatured)
-			admin.DELETE("/featured/:id", r.adminHandler.RemoveFeatured)
+			manageFeatured := r.requirePermission(superadmin.PermManageFeatured)
+			admin.POST("/featured", manageFeatured, r.adminHandler.AddFeatured)
+			admin.DELETE("/featured/:id", manageFeatured, r.adminHandler.RemoveFeatured)
 
 			// Announcements
-			admin.GET("/announcements", r.adminHandler.ListAnnouncements)
-			admin.GET("/announcements/:id", r.adminHandler.GetAnnouncement)
-			admin.POST("/announcements", r.adminHandler.CreateAnnouncement)
-			admin.PUT("/announcements/:id", r.adminHandler.UpdateAnnouncement)
-			admin.DELETE("/announcements/:id", r.adminHandler.DeleteAnnouncement)
+			manageAnnouncements := r.requirePermission(superadmin.PermManageAnnouncements)
+			admin.GET("/announcements", manageAnnouncements, r.adminHandler.ListAnnouncements)
+			admin.GET("/announcements/:id", manageAnnouncements, r.adminHandler.GetAnnouncement)
+			admin.POST("/announcements", manageAnnouncements, r.adminHandler.CreateAnnouncement)
+			admin.PUT("/announcements/:id", manageAnnouncements, r.adminHandler.UpdateAnnouncement)
+			admin.DELETE("/announcements/:id", manageAnnouncements, r.adminHandler.DeleteAnnouncement)
 		}
 
 		// Superadmin routes (require superadmin role)
@@ -373,13 +642,35 @@ func (r *Router) Setup() {
 			superadminRoutes.POST("/reviewers/:id/categories", r.superadminHandler.AssignCategories)
 			superadminRoutes.DELETE("/reviewers/:id/categories", r.superadminHandler.RevokeCategories)
 
+			// Review policies per category
+			superadminRoutes.GET("/review-policies", r.superadminHandler.ListReviewPolicies)
+			superadminRoutes.PUT("/review-policies/:category_id", r.superadminHandler.SetReviewPolicy)
+			superadminRoutes.DELETE("/review-policies/:category_id", r.superadminHandler.DeleteReviewPolicy)
+
+			// Trust tiers
+			superadminRoutes.GET("/trust-tiers", r.trustHandler.ListTiers)
+			superadminRoutes.PUT("/trust-tiers/:tier", r.trustHandler.UpdateTier)
+
+			// Review statistics
+			superadminRoutes.GET("/review-stats/reviewers", r.superadminHandler.GetReviewerStats)
+			superadminRoutes.GET("/review-stats/categories", r.superadminHandler.GetCategoryStats)
+			superadminRoutes.GET("/review-stats/queue", r.superadminHandler.GetQueueStats)
+
 			// User restrictions
 			superadminRoutes.GET("/users/:id/restrictions", r.superadminHandler.GetUserRestrictions)
 			superadminRoutes.PUT("/users/:id/restrictions", r.superadminHandler.UpdateUserRestrictions)
+
+			// Storage usage
+			superadminRoutes.POST("/storage/reconcile", r.quotaHandler.Reconcile)
 		}
 	}
 }
 
+// requirePermission creates middleware that requires an admin permission
+func (r *Router) requirePermission(permission string) gin.HandlerFunc {
+	return middleware.RequirePermission(r.permissions, permission)
+}
+
 // GetEngine returns the gin engine
 func (r *Router) GetEngine() *gin.Engine {
 	return r.engine
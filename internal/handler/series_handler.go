@@ -0,0 +1,456 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/middleware"
+	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/service/series"
+)
+
+// SeriesHandler handles photo series HTTP requests
+type SeriesHandler struct {
+	seriesService *series.Service
+}
+
+// NewSeriesHandler creates a new series handler
+func NewSeriesHandler(seriesService *series.Service) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+// Create creates a series
+// @Summary Create series
+// @Description Group own photos into one post with ordered frames, each frame keeps its own review status
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body series.CreateRequest true "Series info and frames"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/series [post]
+func (h *SeriesHandler) Create(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req series.CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.seriesService.Create(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create series")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// List lists series
+// @Summary List series
+// @Description Get series with at least one published frame as one card each, newest first
+// @Tags Series
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Router /api/v1/series [get]
+func (h *SeriesHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.seriesService.List(c.Request.Context(), page, pageSize)
+	if err != nil {
+		response.InternalError(c, "Failed to list series")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListMine lists current user's series
+// @Summary List my series
+// @Description Get all series of current user, including those without published frames yet
+// @Tags Series
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/series/mine [get]
+func (h *SeriesHandler) ListMine(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.seriesService.ListMine(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		response.InternalError(c, "Failed to list series")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListUserSeries lists a user's series
+// @Summary List user's series
+// @Description Get a user's series with at least one published frame
+// @Tags Series
+// @Produce json
+// @Param id path int true "User ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Router /api/v1/users/{id}/series [get]
+func (h *SeriesHandler) ListUserSeries(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.seriesService.ListUserSeries(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		response.InternalError(c, "Failed to list series")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetDetail gets series detail
+// @Summary Get series detail
+// @Description Get a series with its frames in order, other users only see published frames
+// @Tags Series
+// @Produce json
+// @Param id path int true "Series ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/series/{id} [get]
+func (h *SeriesHandler) GetDetail(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	var currentUserID *int64
+	if userID, exists := middleware.GetUserID(c); exists {
+		currentUserID = &userID
+	}
+
+	result, err := h.seriesService.GetDetail(c.Request.Context(), id, currentUserID)
+	if err != nil {
+		if errors.Is(err, series.ErrSeriesNotFound) {
+			response.NotFound(c, "Series not found")
+			return
+		}
+		response.InternalError(c, "Failed to get series detail")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Update updates a series
+// @Summary Update series
+// @Description Update title or description of own series
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param request body series.UpdateRequest true "Fields to update"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/series/{id} [put]
+func (h *SeriesHandler) Update(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	var req series.UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.seriesService.Update(c.Request.Context(), id, userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update series")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete deletes a series
+// @Summary Delete series
+// @Description Delete own series with its likes and comments, the frames are kept as single photos
+// @Tags Series
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/series/{id} [delete]
+func (h *SeriesHandler) Delete(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	if err := h.seriesService.Delete(c.Request.Context(), id, userID); err != nil {
+		h.handleError(c, err, "Failed to delete series")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// AddFrames adds frames to a series
+// @Summary Add frames to series
+// @Description Append own photos to the end of own series in the given order, photos already in it are skipped
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param request body series.FramesRequest true "Photos to add (max 50)"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/series/{id}/frames [post]
+func (h *SeriesHandler) AddFrames(c *gin.Context) {
+	h.changeFrames(c, h.seriesService.AddFrames, "Failed to add frames to series")
+}
+
+// RemoveFrames removes frames from a series
+// @Summary Remove frames from series
+// @Description Remove frames from own series, the photos themselves are kept
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param request body series.FramesRequest true "Photos to remove (max 50)"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/series/{id}/frames [delete]
+func (h *SeriesHandler) RemoveFrames(c *gin.Context) {
+	h.changeFrames(c, h.seriesService.RemoveFrames, "Failed to remove frames from series")
+}
+
+// MoveFrame moves a frame within a series
+// @Summary Reorder series frame
+// @Description Move a frame to a new position in own series, frames in between shift by one
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param photo_id path int true "Photo ID"
+// @Param request body series.MoveFrameRequest true "New position, 1 is the first frame"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/series/{id}/frames/{photo_id}/position [put]
+func (h *SeriesHandler) MoveFrame(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID")
+		return
+	}
+	photoID, err := strconv.ParseInt(c.Param("photo_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	var req series.MoveFrameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.seriesService.MoveFrame(c.Request.Context(), id, photoID, userID, &req); err != nil {
+		h.handleError(c, err, "Failed to move frame")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// AddLike likes a series
+// @Summary Like series
+// @Description Like a series as a whole, separate from likes of its frames
+// @Tags Series
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/series/{id}/like [post]
+func (h *SeriesHandler) AddLike(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	if err := h.seriesService.AddLike(c.Request.Context(), userID, id); err != nil {
+		if errors.Is(err, series.ErrSeriesNotFound) {
+			response.NotFound(c, "Series not found")
+			return
+		}
+		response.InternalError(c, "Failed to like series")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Liked"})
+}
+
+// RemoveLike removes a like from a series
+// @Summary Unlike series
+// @Description Remove a like from a series
+// @Tags Series
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/series/{id}/like [delete]
+func (h *SeriesHandler) RemoveLike(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	if err := h.seriesService.RemoveLike(c.Request.Context(), userID, id); err != nil {
+		if errors.Is(err, series.ErrNotLiked) {
+			response.NotFound(c, "Series is not liked")
+			return
+		}
+		response.InternalError(c, "Failed to unlike series")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Unliked"})
+}
+
+// changeFrames binds a bulk frame request and applies it to a series
+func (h *SeriesHandler) changeFrames(c *gin.Context, change func(ctx context.Context, seriesID, userID int64, req *series.FramesRequest) (*series.FramesResponse, error), failure string) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	var req series.FramesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := change(c.Request.Context(), id, userID, &req)
+	if err != nil {
+		h.handleError(c, err, failure)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// handleError maps series service errors of owner operations to responses
+func (h *SeriesHandler) handleError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, series.ErrSeriesNotFound):
+		response.NotFound(c, "Series not found")
+	case errors.Is(err, series.ErrNotOwner):
+		response.Forbidden(c, "You are not the owner of this series")
+	case errors.Is(err, series.ErrPhotoNotFound):
+		response.BadRequest(c, "Photos must be your own and not deleted")
+	case errors.Is(err, series.ErrPhotoInOtherSeries):
+		response.BadRequest(c, "Photo is already a frame of another series")
+	case errors.Is(err, series.ErrPhotoNotInSeries):
+		response.BadRequest(c, "Photo is not a frame of this series")
+	case errors.Is(err, series.ErrTitleRequired):
+		response.BadRequest(c, "Series title is required")
+	case errors.Is(err, series.ErrTooManyFrames):
+		response.BadRequest(c, "A series can have at most 50 frames")
+	case errors.Is(err, series.ErrLastFrame):
+		response.BadRequest(c, "A series must keep at least one frame, delete the series instead")
+	default:
+		response.InternalError(c, failure)
+	}
+}
@@ -0,0 +1,430 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/middleware"
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/service/spot"
+)
+
+// SpotHandler handles spotting spot HTTP requests
+type SpotHandler struct {
+	spotService *spot.Service
+}
+
+// NewSpotHandler creates a new spot handler
+func NewSpotHandler(spotService *spot.Service) *SpotHandler {
+	return &SpotHandler{
+		spotService: spotService,
+	}
+}
+
+// List lists approved spotting spots
+// @Summary List spotting spots
+// @Description Get a paginated list of approved spotting spots
+// @Tags Spots
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param airport query string false "Filter by airport code (ICAO/IATA)"
+// @Success 200 {object} response.Response
+// @Router /api/v1/spots [get]
+func (h *SpotHandler) List(c *gin.Context) {
+	var req spot.ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.spotService.List(c.Request.Context(), &req)
+	if err != nil {
+		response.InternalError(c, "Failed to list spots")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Nearby finds spotting spots near a point
+// @Summary Find nearby spots
+// @Description Find approved spotting spots near a location, nearest first
+// @Tags Spots
+// @Produce json
+// @Param lat query number true "Latitude"
+// @Param lng query number true "Longitude"
+// @Param radius_km query number false "Search radius in kilometers (max 50)" default(3)
+// @Param limit query int false "Maximum number of spots" default(10)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/v1/spots/nearby [get]
+func (h *SpotHandler) Nearby(c *gin.Context) {
+	var req spot.NearbyRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.spotService.Nearby(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, spot.ErrInvalidCoordinates) {
+			response.BadRequest(c, "Invalid coordinates")
+			return
+		}
+		response.InternalError(c, "Failed to find nearby spots")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetDetail gets spotting spot detail
+// @Summary Get spot detail
+// @Description Get spot detail including typical focal lengths used
+// @Tags Spots
+// @Produce json
+// @Param id path int true "Spot ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/spots/{id} [get]
+func (h *SpotHandler) GetDetail(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid spot ID")
+		return
+	}
+
+	var currentUserID *int64
+	if userID, exists := middleware.GetUserID(c); exists {
+		currentUserID = &userID
+	}
+	roleStr, _ := middleware.GetRole(c)
+	role := model.UserRole(roleStr)
+	isAdmin := role == model.RoleAdmin || role == model.RoleSuperAdmin
+
+	result, err := h.spotService.GetDetail(c.Request.Context(), id, currentUserID, isAdmin)
+	if err != nil {
+		if errors.Is(err, spot.ErrSpotNotFound) {
+			response.NotFound(c, "Spot not found")
+			return
+		}
+		response.InternalError(c, "Failed to get spot detail")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListPhotos lists photos taken from a spot
+// @Summary List spot photos
+// @Description Get approved photos taken from a spotting spot
+// @Tags Spots
+// @Produce json
+// @Param id path int true "Spot ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/spots/{id}/photos [get]
+func (h *SpotHandler) ListPhotos(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid spot ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.spotService.ListPhotos(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		if errors.Is(err, spot.ErrSpotNotFound) {
+			response.NotFound(c, "Spot not found")
+			return
+		}
+		response.InternalError(c, "Failed to list spot photos")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Create submits a new spotting spot
+// @Summary Submit spot
+// @Description Submit a new spotting spot, it becomes public after admin approval
+// @Tags Spots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body spot.CreateRequest true "Spot info"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/spots [post]
+func (h *SpotHandler) Create(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req spot.CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.spotService.Create(c.Request.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, spot.ErrInvalidAirport) {
+			response.BadRequest(c, "Invalid airport code, use the IATA or ICAO code of a known airport")
+			return
+		}
+		if errors.Is(err, spot.ErrInvalidCoordinates) {
+			response.BadRequest(c, "Invalid coordinates")
+			return
+		}
+		response.InternalError(c, "Failed to submit spot")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListMine lists spots submitted by current user
+// @Summary List my spots
+// @Description Get spotting spots submitted by current user in any status
+// @Tags Spots
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/spots/mine [get]
+func (h *SpotHandler) ListMine(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.spotService.ListMine(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		response.InternalError(c, "Failed to list spots")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// SuggestForPhoto suggests spots for a photo based on its GPS data
+// @Summary Suggest spots for photo
+// @Description Suggest approved spots near where the photo was taken (owner only)
+// @Tags Spots
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/photos/{id}/spot-suggestions [get]
+func (h *SpotHandler) SuggestForPhoto(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	result, err := h.spotService.SuggestForPhoto(c.Request.Context(), userID, photoID)
+	if err != nil {
+		switch {
+		case errors.Is(err, spot.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, spot.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		case errors.Is(err, spot.ErrNoPhotoLocation):
+			response.BadRequest(c, "Photo has no GPS location")
+		default:
+			response.InternalError(c, "Failed to suggest spots")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// LinkPhoto links a photo to a spot
+// @Summary Link photo to spot
+// @Description Link own photo to an approved spotting spot, null spot_id removes the link
+// @Tags Spots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Photo ID"
+// @Param request body spot.LinkPhotoRequest true "Spot to link"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/photos/{id}/spot [put]
+func (h *SpotHandler) LinkPhoto(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid photo ID")
+		return
+	}
+
+	var req spot.LinkPhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	err = h.spotService.LinkPhoto(c.Request.Context(), userID, photoID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, spot.ErrPhotoNotFound):
+			response.NotFound(c, "Photo not found")
+		case errors.Is(err, spot.ErrNotOwner):
+			response.Forbidden(c, "You are not the owner of this photo")
+		case errors.Is(err, spot.ErrSpotNotFound):
+			response.NotFound(c, "Spot not found")
+		default:
+			response.InternalError(c, "Failed to link photo to spot")
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// AdminList lists spots for moderation
+// @Summary List spots for review
+// @Description Get spotting spots for moderation (admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param airport query string false "Filter by airport code"
+// @Param status query string false "Filter by status: pending, approved, rejected, all" default(pending)
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/spots [get]
+func (h *SpotHandler) AdminList(c *gin.Context) {
+	var req spot.AdminListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.spotService.AdminList(c.Request.Context(), &req)
+	if err != nil {
+		response.InternalError(c, "Failed to list spots")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Review approves or rejects a spot
+// @Summary Review spot
+// @Description Approve or reject a pending spotting spot (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Spot ID"
+// @Param request body spot.ReviewRequest true "Review action"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/admin/spots/{id}/review [post]
+func (h *SpotHandler) Review(c *gin.Context) {
+	reviewerID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid spot ID")
+		return
+	}
+
+	var req spot.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	err = h.spotService.Review(c.Request.Context(), id, reviewerID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, spot.ErrSpotNotFound):
+			response.NotFound(c, "Spot not found")
+		case errors.Is(err, spot.ErrAlreadyReviewed):
+			response.Conflict(c, "Spot has already been reviewed")
+		case errors.Is(err, spot.ErrInvalidAction):
+			response.BadRequest(c, "Invalid action")
+		default:
+			response.InternalError(c, "Failed to review spot")
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// AdminDelete deletes a spot
+// @Summary Delete spot
+// @Description Delete a spotting spot, linked photos are unlinked (admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Spot ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/spots/{id} [delete]
+func (h *SpotHandler) AdminDelete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid spot ID")
+		return
+	}
+
+	err = h.spotService.Delete(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, spot.ErrSpotNotFound) {
+			response.NotFound(c, "Spot not found")
+			return
+		}
+		response.InternalError(c, "Failed to delete spot")
+		return
+	}
+
+	response.Success(c, nil)
+}
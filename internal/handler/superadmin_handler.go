@@ -308,3 +308,130 @@ func (h *SuperadminHandler) UpdateUserRestrictions(c *gin.Context) {
 
 	response.Success(c, restrictions)
 }
+
+// ListReviewPolicies handles GET /api/v1/superadmin/review-policies
+func (h *SuperadminHandler) ListReviewPolicies(c *gin.Context) {
+	result, err := h.service.ListReviewPolicies(c.Request.Context())
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// SetReviewPolicyRequest represents the request to set a category review policy
+type SetReviewPolicyRequest struct {
+	RequiredDecisions int   `json:"required_decisions" binding:"required,min=1,max=5"`
+	AllowEscalation   *bool `json:"allow_escalation"` // Defaults to true
+}
+
+// SetReviewPolicy handles PUT /api/v1/superadmin/review-policies/:category_id
+func (h *SuperadminHandler) SetReviewPolicy(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("category_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid category id")
+		return
+	}
+
+	var req SetReviewPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "authentication required")
+		return
+	}
+
+	allowEscalation := true
+	if req.AllowEscalation != nil {
+		allowEscalation = *req.AllowEscalation
+	}
+
+	policy, err := h.service.SetReviewPolicy(c.Request.Context(), categoryID, req.RequiredDecisions, allowEscalation, operatorID)
+	if err != nil {
+		if errors.Is(err, superadminService.ErrCategoryNotFound) {
+			response.NotFound(c, "category not found")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.Success(c, policy)
+}
+
+// DeleteReviewPolicy handles DELETE /api/v1/superadmin/review-policies/:category_id
+func (h *SuperadminHandler) DeleteReviewPolicy(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("category_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid category id")
+		return
+	}
+
+	err = h.service.DeleteReviewPolicy(c.Request.Context(), categoryID)
+	if err != nil {
+		if errors.Is(err, superadminService.ErrPolicyNotFound) {
+			response.NotFound(c, "review policy not found")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{"message": "review policy removed, category uses the default policy"})
+}
+
+// GetReviewerStats handles GET /api/v1/superadmin/review-stats/reviewers
+func (h *SuperadminHandler) GetReviewerStats(c *gin.Context) {
+	var rng superadminService.StatsRange
+	if err := c.ShouldBindQuery(&rng); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.GetReviewerStats(c.Request.Context(), rng)
+	if err != nil {
+		if errors.Is(err, superadminService.ErrInvalidDateRange) {
+			response.BadRequest(c, "invalid date range, expected from <= to (YYYY-MM-DD) within 366 days")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetCategoryStats handles GET /api/v1/superadmin/review-stats/categories
+func (h *SuperadminHandler) GetCategoryStats(c *gin.Context) {
+	var rng superadminService.StatsRange
+	if err := c.ShouldBindQuery(&rng); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.service.GetCategoryStats(c.Request.Context(), rng)
+	if err != nil {
+		if errors.Is(err, superadminService.ErrInvalidDateRange) {
+			response.BadRequest(c, "invalid date range, expected from <= to (YYYY-MM-DD) within 366 days")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetQueueStats handles GET /api/v1/superadmin/review-stats/queue
+func (h *SuperadminHandler) GetQueueStats(c *gin.Context) {
+	result, err := h.service.GetQueueStats(c.Request.Context())
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
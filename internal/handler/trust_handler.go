@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/middleware"
+	"QuanPhotos/internal/pkg/response"
+	"QuanPhotos/internal/service/trust"
+)
+
+// TrustHandler handles user trust and trust tier HTTP requests
+type TrustHandler struct {
+	trustService *trust.Service
+}
+
+// NewTrustHandler creates a new trust handler
+func NewTrustHandler(trustService *trust.Service) *TrustHandler {
+	return &TrustHandler{
+		trustService: trustService,
+	}
+}
+
+// GetUserTrust returns the reputation of a user
+// @Summary Get user trust (Admin)
+// @Description Evaluate and get a user's reputation score and trust tier. The effective tier is the admin override if set, otherwise the evaluated tier.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id}/trust [get]
+func (h *TrustHandler) GetUserTrust(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	result, err := h.trustService.GetUserTrust(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, trust.ErrUserNotFound) {
+			response.NotFound(c, "User not found")
+			return
+		}
+		response.InternalError(c, "Failed to get user trust")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// SetUserTrust overrides the trust tier of a user
+// @Summary Override user trust tier (Admin)
+// @Description Set the tier a user is treated as regardless of the score, or clear the override with a null tier. The next rejection or takedown clears the override.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body trust.SetOverrideRequest true "Tier override"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id}/trust [put]
+func (h *TrustHandler) SetUserTrust(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req trust.SetOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.trustService.SetOverride(c.Request.Context(), userID, operatorID, &req)
+	if err != nil {
+		if errors.Is(err, trust.ErrUserNotFound) {
+			response.NotFound(c, "User not found")
+			return
+		}
+		if errors.Is(err, trust.ErrTierNotFound) {
+			response.BadRequest(c, "Unknown trust tier")
+			return
+		}
+		response.InternalError(c, "Failed to override user trust")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListTiers handles GET /api/v1/superadmin/trust-tiers
+func (h *TrustHandler) ListTiers(c *gin.Context) {
+	result, err := h.trustService.ListTiers(c.Request.Context())
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// UpdateTier handles PUT /api/v1/superadmin/trust-tiers/:tier
+func (h *TrustHandler) UpdateTier(c *gin.Context) {
+	var req trust.UpdateTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "authentication required")
+		return
+	}
+
+	tier, err := h.trustService.UpdateTier(c.Request.Context(), c.Param("tier"), operatorID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, trust.ErrTierNotFound):
+			response.NotFound(c, "trust tier not found")
+		case errors.Is(err, trust.ErrInvalidThreshold):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalError(c, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, tier)
+}
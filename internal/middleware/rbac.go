@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"slices"
 
 	"github.com/gin-gonic/gin"
@@ -102,17 +103,39 @@ func RequireActiveUser(checkStatus func(c *gin.Context, userID int64) (bool, err
 	}
 }
 
-// RequirePermission creates middleware that checks if user can perform action
-func RequirePermission(action string, checkPermission func(c *gin.Context, userID int64, action string) (bool, error)) gin.HandlerFunc {
-	return func(c *gin.Context) {
+// PermissionChecker reports whether an admin holds a permission
+type PermissionChecker interface {
+	Has(ctx context.Context, adminID int64, permission string) (bool, error)
+}
+
+// HasPermission reports whether the current user holds an admin permission.
+// Superadmins hold every permission; users below admin hold none.
+func HasPermission(c *gin.Context, checker PermissionChecker, permission string) (bool, error) {
+	roleStr, _ := GetRole(c)
+	switch model.UserRole(roleStr) {
+	case model.RoleSuperAdmin:
+		return true, nil
+	case model.RoleAdmin:
 		userID, exists := GetUserID(c)
 		if !exists {
+			return false, nil
+		}
+		return checker.Has(c.Request.Context(), userID, permission)
+	default:
+		return false, nil
+	}
+}
+
+// RequirePermission creates middleware that requires an admin permission
+func RequirePermission(checker PermissionChecker, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := GetUserID(c); !exists {
 			response.Unauthorized(c, "User not authenticated")
 			c.Abort()
 			return
 		}
 
-		allowed, err := checkPermission(c, userID, action)
+		allowed, err := HasPermission(c, checker, permission)
 		if err != nil {
 			response.InternalError(c, "Failed to check permission")
 			c.Abort()
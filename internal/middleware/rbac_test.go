@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"QuanPhotos/internal/model"
+)
+
+// fakeChecker grants a fixed set of permissions and records lookups
+type fakeChecker struct {
+	granted map[string]bool
+	err     error
+	calls   int
+}
+
+func (f *fakeChecker) Has(_ context.Context, _ int64, permission string) (bool, error) {
+	f.calls++
+	return f.granted[permission], f.err
+}
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		role         model.UserRole
+		anonymous    bool
+		checker      *fakeChecker
+		expectStatus int
+		expectCalls  int
+	}{
+		{
+			name:         "Superadmin bypasses the permission check",
+			role:         model.RoleSuperAdmin,
+			checker:      &fakeChecker{},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "Admin with the permission",
+			role:         model.RoleAdmin,
+			checker:      &fakeChecker{granted: map[string]bool{"review_photos": true}},
+			expectStatus: http.StatusOK,
+			expectCalls:  1,
+		},
+		{
+			name:         "Admin without the permission",
+			role:         model.RoleAdmin,
+			checker:      &fakeChecker{granted: map[string]bool{"manage_tags": true}},
+			expectStatus: http.StatusForbidden,
+			expectCalls:  1,
+		},
+		{
+			name:         "Reviewer never holds admin permissions",
+			role:         model.RoleReviewer,
+			checker:      &fakeChecker{granted: map[string]bool{"review_photos": true}},
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "Failed lookup is an internal error",
+			role:         model.RoleAdmin,
+			checker:      &fakeChecker{err: errors.New("connection refused")},
+			expectStatus: http.StatusInternalServerError,
+			expectCalls:  1,
+		},
+		{
+			name:         "Anonymous request",
+			anonymous:    true,
+			checker:      &fakeChecker{},
+			expectStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/admin", func(c *gin.Context) {
+				if !tt.anonymous {
+					c.Set("userID", int64(2))
+					c.Set("role", string(tt.role))
+				}
+				c.Next()
+			}, RequirePermission(tt.checker, "review_photos"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+			if w.Code != tt.expectStatus {
+				t.Errorf("status = %d, expected %d", w.Code, tt.expectStatus)
+			}
+			if tt.checker.calls != tt.expectCalls {
+				t.Errorf("checker calls = %d, expected %d", tt.checker.calls, tt.expectCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Album represents a user's ordered collection of photos. Albums share the
+// visibility levels of photos: public albums appear on the owner's profile.
+type Album struct {
+	ID           int64           `db:"id" json:"id"`
+	UserID       int64           `db:"user_id" json:"user_id"`
+	Title        string          `db:"title" json:"title"`
+	Description  sql.NullString  `db:"description" json:"-"`
+	CoverPhotoID sql.NullInt64   `db:"cover_photo_id" json:"-"`
+	Visibility   PhotoVisibility `db:"visibility" json:"visibility"`
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time       `db:"updated_at" json:"updated_at"`
+
+	// Computed fields, counting only the photos the viewer can see
+	PhotoCount int            `db:"photo_count" json:"photo_count"`
+	CoverPath  sql.NullString `db:"cover_path" json:"-"` // Thumbnail of the cover, or of the first photo
+}
+
+// AlbumListItem represents an album in list view
+type AlbumListItem struct {
+	ID         int64           `json:"id"`
+	Title      string          `json:"title"`
+	CoverURL   *string         `json:"cover_url"`
+	Visibility PhotoVisibility `json:"visibility"`
+	PhotoCount int             `json:"photo_count"`
+	CreatedAt  string          `json:"created_at"`
+	UpdatedAt  string          `json:"updated_at"`
+}
+
+// AlbumDetail represents detailed album information
+type AlbumDetail struct {
+	ID           int64           `json:"id"`
+	Title        string          `json:"title"`
+	Description  *string         `json:"description"`
+	CoverPhotoID *int64          `json:"cover_photo_id"` // Null when the first photo is the cover
+	CoverURL     *string         `json:"cover_url"`
+	Visibility   PhotoVisibility `json:"visibility"`
+	PhotoCount   int             `json:"photo_count"`
+	User         *UserBrief      `json:"user,omitempty"`
+	IsOwner      bool            `json:"is_owner"`
+	CreatedAt    string          `json:"created_at"`
+	UpdatedAt    string          `json:"updated_at"`
+}
+
+// coverURL returns the URL of the album cover thumbnail
+func (a *Album) coverURL(baseURL string) *string {
+	if !a.CoverPath.Valid {
+		return nil
+	}
+	url := baseURL + a.CoverPath.String
+	return &url
+}
+
+// ToListItem converts Album to AlbumListItem
+func (a *Album) ToListItem(baseURL string) *AlbumListItem {
+	return &AlbumListItem{
+		ID:         a.ID,
+		Title:      a.Title,
+		CoverURL:   a.coverURL(baseURL),
+		Visibility: a.Visibility,
+		PhotoCount: a.PhotoCount,
+		CreatedAt:  a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  a.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToDetail converts Album to AlbumDetail
+func (a *Album) ToDetail(user *UserBrief, baseURL string, isOwner bool) *AlbumDetail {
+	detail := &AlbumDetail{
+		ID:         a.ID,
+		Title:      a.Title,
+		CoverURL:   a.coverURL(baseURL),
+		Visibility: a.Visibility,
+		PhotoCount: a.PhotoCount,
+		User:       user,
+		IsOwner:    isOwner,
+		CreatedAt:  a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  a.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if a.Description.Valid {
+		detail.Description = &a.Description.String
+	}
+	if a.CoverPhotoID.Valid {
+		detail.CoverPhotoID = &a.CoverPhotoID.Int64
+	}
+
+	return detail
+}
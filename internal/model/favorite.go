@@ -0,0 +1,52 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// DefaultFavoriteFolderName is the name of the folder favorites are filed in
+// unless the user picks another one
+const DefaultFavoriteFolderName = "默认收藏夹"
+
+// FavoriteFolder represents a named folder of a user's favorite photos
+type FavoriteFolder struct {
+	ID        int64     `db:"id" json:"id"`
+	UserID    int64     `db:"user_id" json:"-"`
+	Name      string    `db:"name" json:"name"`
+	IsDefault bool      `db:"is_default" json:"is_default"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+
+	// Computed fields
+	PhotoCount int            `db:"photo_count" json:"photo_count"`
+	CoverPath  sql.NullString `db:"cover_path" json:"-"` // Thumbnail of the most recently added photo
+	Contains   bool           `db:"contains" json:"-"`   // Whether the folder holds a given photo
+}
+
+// FavoriteFolderItem represents a favorite folder in list view
+type FavoriteFolderItem struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	IsDefault  bool    `json:"is_default"`
+	PhotoCount int     `json:"photo_count"`
+	CoverURL   *string `json:"cover_url"`
+	Contains   *bool   `json:"contains,omitempty"` // Only when asked about a photo
+	CreatedAt  string  `json:"created_at"`
+}
+
+// ToItem converts FavoriteFolder to FavoriteFolderItem
+func (f *FavoriteFolder) ToItem(baseURL string) *FavoriteFolderItem {
+	item := &FavoriteFolderItem{
+		ID:         f.ID,
+		Name:       f.Name,
+		IsDefault:  f.IsDefault,
+		PhotoCount: f.PhotoCount,
+		CreatedAt:  f.CreatedAt.Format(time.RFC3339),
+	}
+	if f.CoverPath.Valid {
+		url := baseURL + f.CoverPath.String
+		item.CoverURL = &url
+	}
+	return item
+}
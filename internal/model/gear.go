@@ -0,0 +1,42 @@
+package model
+
+// GearType represents the kind of photographic gear
+type GearType string
+
+const (
+	GearTypeCamera GearType = "camera"
+	GearTypeLens   GearType = "lens"
+)
+
+// GearItem represents a camera body or lens with its photo count
+type GearItem struct {
+	Key        string `db:"key" json:"key"`
+	Name       string `db:"name" json:"name"`
+	PhotoCount int    `db:"photo_count" json:"photo_count"`
+}
+
+// GearStats represents usage statistics of a camera body or lens
+type GearStats struct {
+	Type              GearType             `json:"type"`
+	Key               string               `json:"key"`
+	Name              string               `json:"name"`
+	PhotoCount        int                  `json:"photo_count"`
+	PhotographerCount int                  `json:"photographer_count"`
+	MedianISO         *float64             `json:"median_iso,omitempty"`
+	FocalLengths      []*FocalLengthBucket `json:"focal_lengths"`
+	TopPhotographers  []*GearPhotographer  `json:"top_photographers"`
+}
+
+// FocalLengthBucket represents the number of photos within a 35mm focal length range
+type FocalLengthBucket struct {
+	Label string   `json:"label"`
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max,omitempty"` // Exclusive, nil for the open-ended last bucket
+	Count int      `json:"count"`
+}
+
+// GearPhotographer represents a user and the number of photos taken with a gear
+type GearPhotographer struct {
+	User       *UserBrief `json:"user"`
+	PhotoCount int        `json:"photo_count"`
+}
@@ -16,29 +16,91 @@ const (
 	PhotoStatusRejected   PhotoStatus = "rejected"
 )
 
+// AwaitsReview reports whether a photo in this status is in the manual review
+// queue. Photos rejected by screening are reviewed last, so a human can still
+// overturn a wrong automatic verdict.
+func (s PhotoStatus) AwaitsReview() bool {
+	switch s {
+	case PhotoStatusPending, PhotoStatusAIPassed, PhotoStatusAIRejected:
+		return true
+	}
+	return false
+}
+
+// PhotoVisibility represents who can see an approved photo
+type PhotoVisibility string
+
+const (
+	PhotoVisibilityPublic   PhotoVisibility = "public"   // Listed everywhere
+	PhotoVisibilityUnlisted PhotoVisibility = "unlisted" // Direct link or share token only
+	PhotoVisibilityPrivate  PhotoVisibility = "private"  // Owner and admins only
+)
+
+// IsValid checks if the visibility is a known value
+func (v PhotoVisibility) IsValid() bool {
+	switch v {
+	case PhotoVisibilityPublic, PhotoVisibilityUnlisted, PhotoVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// FlightPhase represents the phase of flight shown in a photo
+type FlightPhase string
+
+const (
+	FlightPhaseTaxi    FlightPhase = "taxi"
+	FlightPhaseTakeoff FlightPhase = "takeoff"
+	FlightPhaseLanding FlightPhase = "landing"
+	FlightPhaseCruise  FlightPhase = "cruise"
+	FlightPhaseGround  FlightPhase = "ground"
+)
+
+// IsValid checks if the flight phase is a known value
+func (f FlightPhase) IsValid() bool {
+	switch f {
+	case FlightPhaseTaxi, FlightPhaseTakeoff, FlightPhaseLanding, FlightPhaseCruise, FlightPhaseGround:
+		return true
+	}
+	return false
+}
+
 // Photo represents a photo in the system
 type Photo struct {
-	ID            int64          `db:"id" json:"id"`
-	UserID        int64          `db:"user_id" json:"user_id"`
-	CategoryID    sql.NullInt32  `db:"category_id" json:"-"`
-	Title         string         `db:"title" json:"title"`
-	Description   sql.NullString `db:"description" json:"-"`
-	FilePath      string         `db:"file_path" json:"-"`
-	ThumbnailPath sql.NullString `db:"thumbnail_path" json:"-"`
-	RawFilePath   sql.NullString `db:"raw_file_path" json:"-"`
-	FileSize      sql.NullInt64  `db:"file_size" json:"-"`
-	Status        PhotoStatus    `db:"status" json:"status"`
-	ViewCount     int            `db:"view_count" json:"view_count"`
-	LikeCount     int            `db:"like_count" json:"like_count"`
-	FavoriteCount int            `db:"favorite_count" json:"favorite_count"`
-	CommentCount  int            `db:"comment_count" json:"comment_count"`
-	ShareCount    int            `db:"share_count" json:"share_count"`
+	ID            int64           `db:"id" json:"id"`
+	UserID        int64           `db:"user_id" json:"user_id"`
+	CategoryID    sql.NullInt32   `db:"category_id" json:"-"`
+	Title         string          `db:"title" json:"title"`
+	Description   sql.NullString  `db:"description" json:"-"`
+	FilePath      string          `db:"file_path" json:"-"`
+	ThumbnailPath sql.NullString  `db:"thumbnail_path" json:"-"`
+	RawFilePath   sql.NullString  `db:"raw_file_path" json:"-"`
+	FileSize      sql.NullInt64   `db:"file_size" json:"-"`
+	Status        PhotoStatus     `db:"status" json:"status"`
+	ViewCount     int             `db:"view_count" json:"view_count"`
+	LikeCount     int             `db:"like_count" json:"like_count"`
+	FavoriteCount int             `db:"favorite_count" json:"favorite_count"`
+	CommentCount  int             `db:"comment_count" json:"comment_count"`
+	ShareCount    int             `db:"share_count" json:"share_count"`
+	Attempt       int             `db:"attempt" json:"attempt"`      // Review attempt, incremented on resubmission
+	ReviewStage   ReviewStage     `db:"review_stage" json:"-"`       // Manual review queue the photo is in
+	SpotCheck     bool            `db:"spot_check_pending" json:"-"` // Auto-approved and sampled for review after publication
+	Visibility    PhotoVisibility `db:"visibility" json:"visibility"`
+	ShareToken    sql.NullString  `db:"share_token" json:"-"` // Share link token of unlisted photos
+	PublishAt     sql.NullTime    `db:"publish_at" json:"-"`  // Scheduled publication, hidden from others until cleared
 
 	// Aviation info
 	AircraftType sql.NullString `db:"aircraft_type" json:"-"`
 	Airline      sql.NullString `db:"airline" json:"-"`
 	Registration sql.NullString `db:"registration" json:"-"`
 	Airport      sql.NullString `db:"airport" json:"-"`
+	SpotID       sql.NullInt64  `db:"spot_id" json:"-"`
+
+	// Flight info
+	FlightNumber sql.NullString `db:"flight_number" json:"-"`
+	Origin       sql.NullString `db:"origin" json:"-"`
+	Destination  sql.NullString `db:"destination" json:"-"`
+	FlightPhase  sql.NullString `db:"flight_phase" json:"-"`
 
 	// EXIF Camera info
 	ExifCameraMake   sql.NullString `db:"exif_camera_make" json:"-"`
@@ -67,6 +129,7 @@ type Photo struct {
 	ExifGPSLatitude  sql.NullFloat64 `db:"exif_gps_latitude" json:"-"`
 	ExifGPSLongitude sql.NullFloat64 `db:"exif_gps_longitude" json:"-"`
 	ExifGPSAltitude  sql.NullFloat64 `db:"exif_gps_altitude" json:"-"`
+	ExifGeohash      sql.NullString  `db:"exif_geohash" json:"-"`
 
 	// EXIF Image info
 	ExifImageWidth  sql.NullInt32  `db:"exif_image_width" json:"-"`
@@ -75,10 +138,29 @@ type Photo struct {
 	ExifColorSpace  sql.NullString `db:"exif_color_space" json:"-"`
 	ExifSoftware    sql.NullString `db:"exif_software" json:"-"`
 
+	// Numeric EXIF values for range filters
+	ExifFocalLength35mmNum sql.NullFloat64 `db:"exif_focal_length_35mm_num" json:"-"` // Millimetres
+	ExifApertureNum        sql.NullFloat64 `db:"exif_aperture_num" json:"-"`          // f-number
+	ExifShutterSpeedNum    sql.NullFloat64 `db:"exif_shutter_speed_num" json:"-"`     // Seconds
+
+	// Normalized gear, maintained by database trigger
+	GearCameraKey  sql.NullString `db:"gear_camera_key" json:"-"`
+	GearCameraName sql.NullString `db:"gear_camera_name" json:"-"`
+	GearLensKey    sql.NullString `db:"gear_lens_key" json:"-"`
+	GearLensName   sql.NullString `db:"gear_lens_name" json:"-"`
+
 	// Timestamps
 	ApprovedAt sql.NullTime `db:"approved_at" json:"-"`
 	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
 	UpdatedAt  time.Time    `db:"updated_at" json:"updated_at"`
+
+	// Trash, deleted photos are purged after the retention period
+	DeletedAt sql.NullTime  `db:"deleted_at" json:"-"`
+	DeletedBy sql.NullInt64 `db:"deleted_by" json:"-"`
+
+	// Stored sizes counted against the owner's storage quota, NULL until measured
+	RawFileSize    sql.NullInt64 `db:"raw_file_size" json:"-"`
+	DerivativeSize sql.NullInt64 `db:"derivative_size" json:"-"` // Thumbnails
 }
 
 // PhotoListItem represents a photo in list view
@@ -96,6 +178,15 @@ type PhotoListItem struct {
 	CommentCount  int        `json:"comment_count"`
 	CreatedAt     string     `json:"created_at"`
 	User          *UserBrief `json:"user"`
+
+	// Series the photo is a frame of, clients show its frames as one card
+	Series *SeriesBrief `json:"series,omitempty"`
+
+	// Owner-only review state
+	Status     PhotoStatus     `json:"status,omitempty"`
+	Rejection  *PhotoRejection `json:"rejection,omitempty"`
+	Visibility PhotoVisibility `json:"visibility,omitempty"`
+	PublishAt  *string         `json:"publish_at,omitempty"`
 }
 
 // UserBrief represents brief user info for photo list
@@ -107,29 +198,38 @@ type UserBrief struct {
 
 // PhotoDetail represents detailed photo information
 type PhotoDetail struct {
-	ID            int64          `json:"id"`
-	Title         string         `json:"title"`
-	Description   *string        `json:"description,omitempty"`
-	ImageURL      string         `json:"image_url"`
-	ThumbnailURL  string         `json:"thumbnail_url"`
-	HasRAW        bool           `json:"has_raw"`
-	Status        PhotoStatus    `json:"status"`
-	AircraftType  *string        `json:"aircraft_type,omitempty"`
-	Airline       *string        `json:"airline,omitempty"`
-	Registration  *string        `json:"registration,omitempty"`
-	Airport       *string        `json:"airport,omitempty"`
-	Category      *CategoryBrief `json:"category,omitempty"`
-	Tags          []string       `json:"tags"`
-	EXIF          *PhotoEXIF     `json:"exif,omitempty"`
-	ViewCount     int            `json:"view_count"`
-	LikeCount     int            `json:"like_count"`
-	FavoriteCount int            `json:"favorite_count"`
-	CommentCount  int            `json:"comment_count"`
-	IsFavorited   bool           `json:"is_favorited"`
-	IsLiked       bool           `json:"is_liked"`
-	CreatedAt     string         `json:"created_at"`
-	ApprovedAt    *string        `json:"approved_at,omitempty"`
-	User          *UserBrief     `json:"user"`
+	ID            int64           `json:"id"`
+	Title         string          `json:"title"`
+	Description   *string         `json:"description,omitempty"`
+	ImageURL      string          `json:"image_url"`
+	ThumbnailURL  string          `json:"thumbnail_url"`
+	HasRAW        bool            `json:"has_raw"`
+	Status        PhotoStatus     `json:"status"`
+	Visibility    PhotoVisibility `json:"visibility"`
+	ShareToken    *string         `json:"share_token,omitempty"` // Owner only
+	PublishAt     *string         `json:"publish_at,omitempty"`  // Owner only, scheduled photos
+	AircraftType  *string         `json:"aircraft_type,omitempty"`
+	Airline       *string         `json:"airline,omitempty"`
+	Registration  *string         `json:"registration,omitempty"`
+	Airport       *string         `json:"airport,omitempty"`
+	FlightNumber  *string         `json:"flight_number,omitempty"`
+	Origin        *string         `json:"origin,omitempty"`
+	Destination   *string         `json:"destination,omitempty"`
+	FlightPhase   *FlightPhase    `json:"flight_phase,omitempty"`
+	Category      *CategoryBrief  `json:"category,omitempty"`
+	Spot          *SpotBrief      `json:"spot,omitempty"`
+	Series        *SeriesBrief    `json:"series,omitempty"`
+	Tags          []string        `json:"tags"`
+	EXIF          *PhotoEXIF      `json:"exif,omitempty"`
+	ViewCount     int             `json:"view_count"`
+	LikeCount     int             `json:"like_count"`
+	FavoriteCount int             `json:"favorite_count"`
+	CommentCount  int             `json:"comment_count"`
+	IsFavorited   bool            `json:"is_favorited"`
+	IsLiked       bool            `json:"is_liked"`
+	CreatedAt     string          `json:"created_at"`
+	ApprovedAt    *string         `json:"approved_at,omitempty"`
+	User          *UserBrief      `json:"user"`
 }
 
 // CategoryBrief represents brief category info
@@ -142,7 +242,9 @@ type CategoryBrief struct {
 type PhotoEXIF struct {
 	CameraMake      *string  `json:"camera_make,omitempty"`
 	CameraModel     *string  `json:"camera_model,omitempty"`
+	CameraKey       *string  `json:"camera_key,omitempty"`
 	LensModel       *string  `json:"lens_model,omitempty"`
+	LensKey         *string  `json:"lens_key,omitempty"`
 	FocalLength     *string  `json:"focal_length,omitempty"`
 	FocalLength35mm *string  `json:"focal_length_35mm,omitempty"`
 	Aperture        *string  `json:"aperture,omitempty"`
@@ -159,6 +261,16 @@ type PhotoEXIF struct {
 	ImageHeight     *int32   `json:"image_height,omitempty"`
 }
 
+// IsScheduled reports whether the photo waits for its scheduled publication
+func (p *Photo) IsScheduled() bool {
+	return p.PublishAt.Valid
+}
+
+// StoredBytes returns the recorded size of the photo's stored files
+func (p *Photo) StoredBytes() int64 {
+	return p.FileSize.Int64 + p.RawFileSize.Int64 + p.DerivativeSize.Int64
+}
+
 // ToListItem converts Photo to PhotoListItem
 func (p *Photo) ToListItem(user *UserBrief, baseURL string) *PhotoListItem {
 	item := &PhotoListItem{
@@ -198,6 +310,7 @@ func (p *Photo) ToDetail(user *UserBrief, category *CategoryBrief, tags []string
 		Title:         p.Title,
 		HasRAW:        p.RawFilePath.Valid,
 		Status:        p.Status,
+		Visibility:    p.Visibility,
 		Tags:          tags,
 		ViewCount:     p.ViewCount,
 		LikeCount:     p.LikeCount,
@@ -229,6 +342,19 @@ func (p *Photo) ToDetail(user *UserBrief, category *CategoryBrief, tags []string
 	if p.Airport.Valid {
 		detail.Airport = &p.Airport.String
 	}
+	if p.FlightNumber.Valid {
+		detail.FlightNumber = &p.FlightNumber.String
+	}
+	if p.Origin.Valid {
+		detail.Origin = &p.Origin.String
+	}
+	if p.Destination.Valid {
+		detail.Destination = &p.Destination.String
+	}
+	if p.FlightPhase.Valid {
+		phase := FlightPhase(p.FlightPhase.String)
+		detail.FlightPhase = &phase
+	}
 	if p.ApprovedAt.Valid {
 		approvedAt := p.ApprovedAt.Time.Format(time.RFC3339)
 		detail.ApprovedAt = &approvedAt
@@ -256,6 +382,12 @@ func (p *Photo) buildEXIF() *PhotoEXIF {
 		exif.LensModel = &p.ExifLensModel.String
 		hasData = true
 	}
+	if p.GearCameraKey.Valid {
+		exif.CameraKey = &p.GearCameraKey.String
+	}
+	if p.GearLensKey.Valid {
+		exif.LensKey = &p.GearLensKey.String
+	}
 	if p.ExifFocalLength.Valid {
 		exif.FocalLength = &p.ExifFocalLength.String
 		hasData = true
@@ -353,3 +485,52 @@ type Tag struct {
 	PhotoCount int       `db:"photo_count" json:"photo_count"`
 	CreatedAt  time.Time `db:"created_at" json:"created_at"`
 }
+
+// ReplacementStatus represents the review status of a replacement image
+type ReplacementStatus string
+
+const (
+	ReplacementStatusPending    ReplacementStatus = "pending"
+	ReplacementStatusApproved   ReplacementStatus = "approved"
+	ReplacementStatusRejected   ReplacementStatus = "rejected"
+	ReplacementStatusSuperseded ReplacementStatus = "superseded" // A newer replacement was uploaded before review
+)
+
+// PhotoReplacement represents a new image file uploaded for an existing photo
+type PhotoReplacement struct {
+	ID                    int64             `db:"id" json:"id"`
+	PhotoID               int64             `db:"photo_id" json:"photo_id"`
+	UserID                sql.NullInt64     `db:"user_id" json:"-"`
+	Status                ReplacementStatus `db:"status" json:"status"`
+	FilePath              string            `db:"file_path" json:"-"`
+	ThumbnailPath         sql.NullString    `db:"thumbnail_path" json:"-"`
+	RawFilePath           sql.NullString    `db:"raw_file_path" json:"-"`
+	FileParams            []byte            `db:"file_params" json:"-"` // Stored file and EXIF columns written to the photo on approval
+	Screening             []byte            `db:"screening" json:"-"`   // Quality screening report, NULL when not screened
+	PreviousFilePath      sql.NullString    `db:"previous_file_path" json:"-"`
+	PreviousThumbnailPath sql.NullString    `db:"previous_thumbnail_path" json:"-"`
+	PreviousRawFilePath   sql.NullString    `db:"previous_raw_file_path" json:"-"`
+	ReviewType            sql.NullString    `db:"review_type" json:"-"` // manual, trust, screening
+	ReviewerID            sql.NullInt64     `db:"reviewer_id" json:"-"`
+	Reason                sql.NullString    `db:"reason" json:"-"`
+	CreatedAt             time.Time         `db:"created_at" json:"created_at"`
+	DecidedAt             sql.NullTime      `db:"decided_at" json:"-"`
+}
+
+// StoredFiles returns a photo carrying only the replacement's files
+func (r *PhotoReplacement) StoredFiles() *Photo {
+	return &Photo{
+		FilePath:      r.FilePath,
+		ThumbnailPath: r.ThumbnailPath,
+		RawFilePath:   r.RawFilePath,
+	}
+}
+
+// PreviousFiles returns a photo carrying only the files replaced on approval
+func (r *PhotoReplacement) PreviousFiles() *Photo {
+	return &Photo{
+		FilePath:      r.PreviousFilePath.String,
+		ThumbnailPath: r.PreviousThumbnailPath,
+		RawFilePath:   r.PreviousRawFilePath,
+	}
+}
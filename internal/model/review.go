@@ -0,0 +1,199 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ScreeningVerdict represents the outcome of heuristic quality screening
+type ScreeningVerdict string
+
+const (
+	ScreeningPass   ScreeningVerdict = "pass"
+	ScreeningFlag   ScreeningVerdict = "flag"
+	ScreeningReject ScreeningVerdict = "reject"
+)
+
+// ScreeningSource identifies screening results stored in photo_reviews.ai_result
+const ScreeningSource = "screening"
+
+// QualityScreening is the heuristic quality report stored in photo_reviews.ai_result
+type QualityScreening struct {
+	Source            string            `json:"source"`
+	Verdict           ScreeningVerdict  `json:"verdict"`
+	Width             int               `json:"width"`
+	Height            int               `json:"height"`
+	Sharpness         float64           `json:"sharpness"`
+	ShadowClipping    float64           `json:"shadow_clipping"`
+	HighlightClipping float64           `json:"highlight_clipping"`
+	TiltDegrees       float64           `json:"tilt_degrees"`
+	TiltConfidence    float64           `json:"tilt_confidence"`
+	NoiseSigma        float64           `json:"noise_sigma"`
+	NoiseRatio        float64           `json:"noise_ratio"`
+	ISO               int               `json:"iso,omitempty"`
+	Issues            []*ScreeningIssue `json:"issues,omitempty"`
+}
+
+// ScreeningIssue describes a failed quality check
+type ScreeningIssue struct {
+	Check     string           `json:"check"` // resolution, sharpness, clipping, tilt, noise
+	Severity  ScreeningVerdict `json:"severity"`
+	Value     float64          `json:"value"`
+	Threshold float64          `json:"threshold"`
+}
+
+// ReviewClaim represents a reviewer's lease on a photo in the review queue
+type ReviewClaim struct {
+	PhotoID    int64     `db:"photo_id" json:"photo_id"`
+	ReviewerID int64     `db:"reviewer_id" json:"reviewer_id"`
+	ClaimedAt  time.Time `db:"claimed_at" json:"claimed_at"`
+	ExpiresAt  time.Time `db:"expires_at" json:"expires_at"`
+}
+
+// RejectionReason represents an entry in the rejection reason catalogue
+type RejectionReason struct {
+	ID          int32          `db:"id" json:"id"`
+	Code        string         `db:"code" json:"code"`
+	Name        string         `db:"name" json:"name"`
+	NameEN      string         `db:"name_en" json:"name_en"`
+	Description sql.NullString `db:"description" json:"-"`
+	SortOrder   int            `db:"sort_order" json:"sort_order"`
+	IsActive    bool           `db:"is_active" json:"is_active"`
+	CreatedAt   time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// RejectionReasonBrief represents brief rejection reason info
+type RejectionReasonBrief struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	NameEN string `json:"name_en"`
+}
+
+// PhotoRejection describes why the current attempt of a photo was rejected
+type PhotoRejection struct {
+	Attempt    int                     `json:"attempt"`
+	ReviewType string                  `json:"review_type"` // ai, manual
+	Reasons    []*RejectionReasonBrief `json:"reasons"`
+	Note       *string                 `json:"note,omitempty"`
+	RejectedAt string                  `json:"rejected_at"`
+}
+
+// ReviewStage represents the manual review queue a photo is in
+type ReviewStage string
+
+const (
+	// ReviewStageStandard is the queue of all reviewers
+	ReviewStageStandard ReviewStage = "standard"
+	// ReviewStageSenior is the queue of escalated and disputed photos,
+	// decided by superadmins and admins granted review_photos
+	ReviewStageSenior ReviewStage = "senior"
+)
+
+// IsValid checks if the review stage is valid
+func (s ReviewStage) IsValid() bool {
+	return s == ReviewStageStandard || s == ReviewStageSenior
+}
+
+// ReviewPolicy represents the manual review policy of a category
+type ReviewPolicy struct {
+	CategoryID        int32         `db:"category_id" json:"category_id"`
+	RequiredDecisions int           `db:"required_decisions" json:"required_decisions"`
+	AllowEscalation   bool          `db:"allow_escalation" json:"allow_escalation"`
+	UpdatedBy         sql.NullInt64 `db:"updated_by" json:"-"`
+	CreatedAt         time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt         time.Time     `db:"updated_at" json:"updated_at"`
+}
+
+// ReviewDecision represents a manual decision on the current attempt of a photo
+type ReviewDecision struct {
+	PhotoID    int64          `db:"photo_id" json:"-"`
+	ReviewerID int64          `db:"reviewer_id" json:"reviewer_id"`
+	Action     string         `db:"action" json:"action"` // approve, reject, escalate
+	Stage      ReviewStage    `db:"stage" json:"stage"`
+	Reason     sql.NullString `db:"reason" json:"-"`
+	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
+}
+
+// PhotoReview represents a record in a photo's review history
+type PhotoReview struct {
+	ID         int64          `db:"id" json:"id"`
+	PhotoID    int64          `db:"photo_id" json:"photo_id"`
+	ReviewerID sql.NullInt64  `db:"reviewer_id" json:"-"`
+	ReviewType string         `db:"review_type" json:"review_type"` // ai, manual, trust
+	Action     string         `db:"action" json:"action"`           // approve, reject, flag, escalate, delete, restore
+	Reason     sql.NullString `db:"reason" json:"-"`
+	AIResult   []byte         `db:"ai_result" json:"-"`
+	Attempt    int            `db:"attempt" json:"attempt"`
+	Stage      ReviewStage    `db:"stage" json:"stage"`
+	TicketID   sql.NullInt64  `db:"ticket_id" json:"-"` // Appeal ticket of a decision made on appeal
+	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
+}
+
+// DeletedPhoto is the snapshot of a photo's metadata taken at deletion
+type DeletedPhoto struct {
+	PhotoID      int64          `db:"photo_id" json:"photo_id"`
+	UserID       sql.NullInt64  `db:"user_id" json:"-"`
+	CategoryID   sql.NullInt32  `db:"category_id" json:"-"`
+	Title        string         `db:"title" json:"title"`
+	Description  sql.NullString `db:"description" json:"-"`
+	AircraftType sql.NullString `db:"aircraft_type" json:"-"`
+	Airline      sql.NullString `db:"airline" json:"-"`
+	Registration sql.NullString `db:"registration" json:"-"`
+	Airport      sql.NullString `db:"airport" json:"-"`
+	Status       PhotoStatus    `db:"status" json:"status"` // Status when deleted
+	Attempt      int            `db:"attempt" json:"attempt"`
+	UploadedAt   time.Time      `db:"uploaded_at" json:"uploaded_at"`
+	DeletedBy    sql.NullInt64  `db:"deleted_by" json:"-"`
+	Reason       sql.NullString `db:"reason" json:"-"`
+	DeletedAt    time.Time      `db:"deleted_at" json:"deleted_at"`
+}
+
+// TrustTier represents a reputation tier and its review policy
+type TrustTier struct {
+	Tier           string        `db:"tier" json:"tier"`
+	Rank           int           `db:"rank" json:"rank"`
+	MinScore       int           `db:"min_score" json:"min_score"`
+	AutoApprove    bool          `db:"auto_approve" json:"auto_approve"`         // Uploads skip the manual queue
+	SpotCheckRate  int           `db:"spot_check_rate" json:"spot_check_rate"`   // Percentage of auto approvals reviewed after publication
+	StorageQuotaMB *int64        `db:"storage_quota_mb" json:"storage_quota_mb"` // Null leaves the role limit, 0 is unlimited
+	UpdatedBy      sql.NullInt64 `db:"updated_by" json:"-"`
+	CreatedAt      time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time     `db:"updated_at" json:"updated_at"`
+}
+
+// TrustTierNewcomer is the lowest tier, held by users without a trust record
+const TrustTierNewcomer = "newcomer"
+
+// UserTrust represents the last evaluated reputation of a user
+type UserTrust struct {
+	UserID         int64          `db:"user_id" json:"user_id"`
+	Score          int            `db:"score" json:"score"`
+	Tier           string         `db:"tier" json:"tier"`
+	OverrideTier   sql.NullString `db:"override_tier" json:"-"`
+	OverrideBy     sql.NullInt64  `db:"override_by" json:"-"`
+	OverrideNote   sql.NullString `db:"override_note" json:"-"`
+	DemotedAt      sql.NullTime   `db:"demoted_at" json:"-"`
+	DemotionReason sql.NullString `db:"demotion_reason" json:"-"`
+	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// EffectiveTier returns the override tier if set, the evaluated tier otherwise
+func (t *UserTrust) EffectiveTier() string {
+	if t.OverrideTier.Valid {
+		return t.OverrideTier.String
+	}
+	return t.Tier
+}
+
+// TrustStats are the inputs of a user's reputation score
+type TrustStats struct {
+	JoinedAt      time.Time `db:"joined_at"`
+	Approved      int64     `db:"approved"`       // Photos currently approved
+	Rejected      int64     `db:"rejected"`       // Photos currently rejected by reviewers
+	TakenDown     int64     `db:"taken_down"`     // Photos deleted by admins
+	AppealsUpheld int64     `db:"appeals_upheld"` // Appeals that overturned a rejection
+	AppealsDenied int64     `db:"appeals_denied"` // Appeals closed without an overturn
+	ApprovedSince int64     `db:"approved_since"` // Approvals after the last demotion
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// RevisionSource identifies how a photo revision was made
+type RevisionSource string
+
+const (
+	RevisionSourceEdit     RevisionSource = "edit"     // Edited by the owner
+	RevisionSourceResubmit RevisionSource = "resubmit" // Corrected when resubmitting a rejected photo
+	RevisionSourceRollback RevisionSource = "rollback" // Rolled back by an admin
+)
+
+// FieldChange is the JSON encoded old and new value of a changed photo field
+type FieldChange struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+// PhotoRevision represents a stored change of a photo's metadata
+type PhotoRevision struct {
+	ID         int64          `db:"id" json:"id"`
+	PhotoID    int64          `db:"photo_id" json:"photo_id"`
+	EditorID   sql.NullInt64  `db:"editor_id" json:"-"`
+	Source     RevisionSource `db:"source" json:"source"`
+	Changes    []byte         `db:"changes" json:"-"`         // JSON object of FieldChange by field
+	ReReview   bool           `db:"rereview" json:"rereview"` // The change sent the approved photo back to review
+	RollbackOf sql.NullInt64  `db:"rollback_of" json:"-"`     // Revision undone by a rollback
+	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
+}
+
+// FieldChanges decodes the changes of the revision
+func (r *PhotoRevision) FieldChanges() (map[string]FieldChange, error) {
+	changes := make(map[string]FieldChange)
+	if err := json.Unmarshal(r.Changes, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Series represents a multi-photo post, e.g. a takeoff sequence or a set from
+// one event. Its frames keep their own review status and the series is shown
+// to others once one of them is visible.
+type Series struct {
+	ID           int64          `db:"id" json:"id"`
+	UserID       int64          `db:"user_id" json:"user_id"`
+	Title        string         `db:"title" json:"title"`
+	Description  sql.NullString `db:"description" json:"-"`
+	LikeCount    int            `db:"like_count" json:"like_count"`
+	CommentCount int            `db:"comment_count" json:"comment_count"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at" json:"updated_at"`
+
+	// Computed fields, counting only the frames the viewer can see
+	FrameCount int            `db:"frame_count" json:"frame_count"`
+	CoverPath  sql.NullString `db:"cover_path" json:"-"` // Thumbnail of the first frame
+}
+
+// SeriesListItem represents a series as one card in list view
+type SeriesListItem struct {
+	ID           int64      `json:"id"`
+	Title        string     `json:"title"`
+	CoverURL     *string    `json:"cover_url"`
+	FrameCount   int        `json:"frame_count"`
+	LikeCount    int        `json:"like_count"`
+	CommentCount int        `json:"comment_count"`
+	CreatedAt    string     `json:"created_at"`
+	User         *UserBrief `json:"user,omitempty"`
+}
+
+// SeriesDetail represents detailed series information with its frames
+type SeriesDetail struct {
+	ID           int64            `json:"id"`
+	Title        string           `json:"title"`
+	Description  *string          `json:"description"`
+	FrameCount   int              `json:"frame_count"`
+	LikeCount    int              `json:"like_count"`
+	CommentCount int              `json:"comment_count"`
+	IsLiked      bool             `json:"is_liked"`
+	IsOwner      bool             `json:"is_owner"`
+	User         *UserBrief       `json:"user,omitempty"`
+	Frames       []*PhotoListItem `json:"frames"`
+	CreatedAt    string           `json:"created_at"`
+	UpdatedAt    string           `json:"updated_at"`
+}
+
+// SeriesBrief represents the series a photo is a frame of, so lists can
+// collapse its frames into one card
+type SeriesBrief struct {
+	ID         int64  `db:"id" json:"id"`
+	Title      string `db:"title" json:"title"`
+	FrameCount int    `db:"frame_count" json:"frame_count"`
+}
+
+// ToListItem converts Series to SeriesListItem
+func (s *Series) ToListItem(user *UserBrief, baseURL string) *SeriesListItem {
+	item := &SeriesListItem{
+		ID:           s.ID,
+		Title:        s.Title,
+		FrameCount:   s.FrameCount,
+		LikeCount:    s.LikeCount,
+		CommentCount: s.CommentCount,
+		CreatedAt:    s.CreatedAt.Format(time.RFC3339),
+		User:         user,
+	}
+	if s.CoverPath.Valid {
+		url := baseURL + s.CoverPath.String
+		item.CoverURL = &url
+	}
+	return item
+}
+
+// ToDetail converts Series to SeriesDetail
+func (s *Series) ToDetail(user *UserBrief, frames []*PhotoListItem, isLiked, isOwner bool) *SeriesDetail {
+	detail := &SeriesDetail{
+		ID:           s.ID,
+		Title:        s.Title,
+		FrameCount:   s.FrameCount,
+		LikeCount:    s.LikeCount,
+		CommentCount: s.CommentCount,
+		IsLiked:      isLiked,
+		IsOwner:      isOwner,
+		User:         user,
+		Frames:       frames,
+		CreatedAt:    s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    s.UpdatedAt.Format(time.RFC3339),
+	}
+	if s.Description.Valid {
+		detail.Description = &s.Description.String
+	}
+	return detail
+}
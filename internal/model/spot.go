@@ -0,0 +1,153 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SpotStatus represents spotting spot moderation status
+type SpotStatus string
+
+const (
+	SpotStatusPending  SpotStatus = "pending"  // 待审核
+	SpotStatusApproved SpotStatus = "approved" // 已通过
+	SpotStatusRejected SpotStatus = "rejected" // 已拒绝
+)
+
+// SpottingSpot represents a location at an airport where spotters shoot from
+type SpottingSpot struct {
+	ID             int64          `db:"id" json:"id"`
+	UserID         sql.NullInt64  `db:"user_id" json:"-"`
+	Airport        string         `db:"airport" json:"airport"`
+	Name           string         `db:"name" json:"name"`
+	Latitude       float64        `db:"latitude" json:"latitude"`
+	Longitude      float64        `db:"longitude" json:"longitude"`
+	Description    sql.NullString `db:"description" json:"-"`
+	AccessNotes    sql.NullString `db:"access_notes" json:"-"`
+	BestLightTimes sql.NullString `db:"best_light_times" json:"-"`
+	Status         SpotStatus     `db:"status" json:"status"`
+	ReviewerID     sql.NullInt64  `db:"reviewer_id" json:"-"`
+	RejectReason   sql.NullString `db:"reject_reason" json:"-"`
+	ReviewedAt     sql.NullTime   `db:"reviewed_at" json:"-"`
+	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at" json:"updated_at"`
+
+	// Computed fields
+	PhotoCount int      `db:"photo_count" json:"photo_count"`
+	DistanceKm *float64 `db:"distance_km" json:"distance_km,omitempty"`
+}
+
+// SpotBrief represents brief spot info for photo detail
+type SpotBrief struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Airport string `json:"airport"`
+}
+
+// SpotListItem represents a spot in list view
+type SpotListItem struct {
+	ID             int64      `json:"id"`
+	Airport        string     `json:"airport"`
+	Name           string     `json:"name"`
+	Latitude       float64    `json:"latitude"`
+	Longitude      float64    `json:"longitude"`
+	BestLightTimes *string    `json:"best_light_times,omitempty"`
+	Status         SpotStatus `json:"status"`
+	PhotoCount     int        `json:"photo_count"`
+	DistanceKm     *float64   `json:"distance_km,omitempty"`
+	CreatedAt      string     `json:"created_at"`
+}
+
+// SpotDetail represents detailed spot information
+type SpotDetail struct {
+	ID             int64             `json:"id"`
+	Airport        string            `json:"airport"`
+	Name           string            `json:"name"`
+	Latitude       float64           `json:"latitude"`
+	Longitude      float64           `json:"longitude"`
+	Description    *string           `json:"description,omitempty"`
+	AccessNotes    *string           `json:"access_notes,omitempty"`
+	BestLightTimes *string           `json:"best_light_times,omitempty"`
+	Status         SpotStatus        `json:"status"`
+	RejectReason   *string           `json:"reject_reason,omitempty"`
+	PhotoCount     int               `json:"photo_count"`
+	FocalLengths   *FocalLengthStats `json:"focal_lengths,omitempty"`
+	CreatedAt      string            `json:"created_at"`
+	UpdatedAt      string            `json:"updated_at"`
+}
+
+// FocalLengthStats summarizes the 35mm-equivalent focal lengths used at a spot
+type FocalLengthStats struct {
+	SampleCount int                 `json:"sample_count"`
+	Min         float64             `json:"min"`
+	Median      float64             `json:"median"`
+	Max         float64             `json:"max"`
+	Common      []*FocalLengthCount `json:"common"`
+}
+
+// FocalLengthCount represents how often a focal length was used
+type FocalLengthCount struct {
+	FocalLength float64 `db:"focal_length" json:"focal_length"`
+	Count       int     `db:"count" json:"count"`
+}
+
+// ToBrief converts SpottingSpot to SpotBrief
+func (s *SpottingSpot) ToBrief() *SpotBrief {
+	return &SpotBrief{
+		ID:      s.ID,
+		Name:    s.Name,
+		Airport: s.Airport,
+	}
+}
+
+// ToListItem converts SpottingSpot to SpotListItem
+func (s *SpottingSpot) ToListItem() *SpotListItem {
+	item := &SpotListItem{
+		ID:         s.ID,
+		Airport:    s.Airport,
+		Name:       s.Name,
+		Latitude:   s.Latitude,
+		Longitude:  s.Longitude,
+		Status:     s.Status,
+		PhotoCount: s.PhotoCount,
+		DistanceKm: s.DistanceKm,
+		CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+	}
+
+	if s.BestLightTimes.Valid {
+		item.BestLightTimes = &s.BestLightTimes.String
+	}
+
+	return item
+}
+
+// ToDetail converts SpottingSpot to SpotDetail
+func (s *SpottingSpot) ToDetail(focalLengths *FocalLengthStats) *SpotDetail {
+	detail := &SpotDetail{
+		ID:           s.ID,
+		Airport:      s.Airport,
+		Name:         s.Name,
+		Latitude:     s.Latitude,
+		Longitude:    s.Longitude,
+		Status:       s.Status,
+		PhotoCount:   s.PhotoCount,
+		FocalLengths: focalLengths,
+		CreatedAt:    s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    s.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if s.Description.Valid {
+		detail.Description = &s.Description.String
+	}
+	if s.AccessNotes.Valid {
+		detail.AccessNotes = &s.AccessNotes.String
+	}
+	if s.BestLightTimes.Valid {
+		detail.BestLightTimes = &s.BestLightTimes.String
+	}
+	if s.RejectReason.Valid {
+		detail.RejectReason = &s.RejectReason.String
+	}
+
+	return detail
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// UserStorage represents the stored bytes of a user with the settings that
+// decide the user's storage quota
+type UserStorage struct {
+	UserID          int64          `db:"user_id"`
+	Role            UserRole       `db:"role"`
+	Tier            string         `db:"tier"` // Effective trust tier
+	UsedBytes       int64          `db:"used_bytes"`
+	TierQuotaMB     sql.NullInt64  `db:"tier_quota_mb"`     // Null leaves the role limit, 0 is unlimited
+	QuotaOverrideMB sql.NullInt64  `db:"quota_override_mb"` // Set by an admin, 0 is unlimited
+	OverrideBy      sql.NullInt64  `db:"override_by"`
+	OverrideNote    sql.NullString `db:"override_note"`
+	ReconciledAt    sql.NullTime   `db:"reconciled_at"`
+}
+
+// StorageDrift is a recorded storage usage corrected from the stored photos
+type StorageDrift struct {
+	UserID   int64
+	Recorded int64
+	Actual   int64
+}
+
+// ReconciledAtString returns the last reconciliation time, nil if never reconciled
+func (u *UserStorage) ReconciledAtString() *string {
+	if !u.ReconciledAt.Valid {
+		return nil
+	}
+	s := u.ReconciledAt.Time.Format(time.RFC3339)
+	return &s
+}
@@ -26,21 +26,22 @@ const (
 
 // User represents a user in the system
 type User struct {
-	ID           int64          `db:"id" json:"id"`
-	Username     string         `db:"username" json:"username"`
-	Email        string         `db:"email" json:"email"`
-	PasswordHash string         `db:"password_hash" json:"-"`
-	Role         UserRole       `db:"role" json:"role"`
-	Status       UserStatus     `db:"status" json:"status"`
-	CanComment   bool           `db:"can_comment" json:"can_comment"`
-	CanMessage   bool           `db:"can_message" json:"can_message"`
-	CanUpload    bool           `db:"can_upload" json:"can_upload"`
-	Avatar       sql.NullString `db:"avatar" json:"-"`
-	Bio          sql.NullString `db:"bio" json:"-"`
-	Location     sql.NullString `db:"location" json:"-"`
-	LastLoginAt  sql.NullTime   `db:"last_login_at" json:"-"`
-	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
-	UpdatedAt    time.Time      `db:"updated_at" json:"updated_at"`
+	ID                int64          `db:"id" json:"id"`
+	Username          string         `db:"username" json:"username"`
+	Email             string         `db:"email" json:"email"`
+	PasswordHash      string         `db:"password_hash" json:"-"`
+	Role              UserRole       `db:"role" json:"role"`
+	Status            UserStatus     `db:"status" json:"status"`
+	CanComment        bool           `db:"can_comment" json:"can_comment"`
+	CanMessage        bool           `db:"can_message" json:"can_message"`
+	CanUpload         bool           `db:"can_upload" json:"can_upload"`
+	HidePhotoLocation bool           `db:"hide_photo_location" json:"hide_photo_location"`
+	Avatar            sql.NullString `db:"avatar" json:"-"`
+	Bio               sql.NullString `db:"bio" json:"-"`
+	Location          sql.NullString `db:"location" json:"-"`
+	LastLoginAt       sql.NullTime   `db:"last_login_at" json:"-"`
+	CreatedAt         time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at" json:"updated_at"`
 }
 
 // UserPublicInfo represents public user information
@@ -56,20 +57,21 @@ type UserPublicInfo struct {
 
 // UserProfile represents full user profile (for self)
 type UserProfile struct {
-	ID          int64      `json:"id"`
-	Username    string     `json:"username"`
-	Email       string     `json:"email"`
-	Role        UserRole   `json:"role"`
-	Status      UserStatus `json:"status"`
-	CanComment  bool       `json:"can_comment"`
-	CanMessage  bool       `json:"can_message"`
-	CanUpload   bool       `json:"can_upload"`
-	Avatar      *string    `json:"avatar"`
-	Bio         *string    `json:"bio"`
-	Location    *string    `json:"location"`
-	LastLoginAt *string    `json:"last_login_at"`
-	CreatedAt   string     `json:"created_at"`
-	UpdatedAt   string     `json:"updated_at"`
+	ID                int64      `json:"id"`
+	Username          string     `json:"username"`
+	Email             string     `json:"email"`
+	Role              UserRole   `json:"role"`
+	Status            UserStatus `json:"status"`
+	CanComment        bool       `json:"can_comment"`
+	CanMessage        bool       `json:"can_message"`
+	CanUpload         bool       `json:"can_upload"`
+	HidePhotoLocation bool       `json:"hide_photo_location"`
+	Avatar            *string    `json:"avatar"`
+	Bio               *string    `json:"bio"`
+	Location          *string    `json:"location"`
+	LastLoginAt       *string    `json:"last_login_at"`
+	CreatedAt         string     `json:"created_at"`
+	UpdatedAt         string     `json:"updated_at"`
 }
 
 // ToPublicInfo converts User to UserPublicInfo
@@ -97,16 +99,17 @@ func (u *User) ToPublicInfo() *UserPublicInfo {
 // ToProfile converts User to UserProfile
 func (u *User) ToProfile() *UserProfile {
 	profile := &UserProfile{
-		ID:         u.ID,
-		Username:   u.Username,
-		Email:      u.Email,
-		Role:       u.Role,
-		Status:     u.Status,
-		CanComment: u.CanComment,
-		CanMessage: u.CanMessage,
-		CanUpload:  u.CanUpload,
-		CreatedAt:  u.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:  u.UpdatedAt.Format(time.RFC3339),
+		ID:                u.ID,
+		Username:          u.Username,
+		Email:             u.Email,
+		Role:              u.Role,
+		Status:            u.Status,
+		CanComment:        u.CanComment,
+		CanMessage:        u.CanMessage,
+		CanUpload:         u.CanUpload,
+		HidePhotoLocation: u.HidePhotoLocation,
+		CreatedAt:         u.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         u.UpdatedAt.Format(time.RFC3339),
 	}
 
 	if u.Avatar.Valid {
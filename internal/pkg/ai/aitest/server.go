@@ -0,0 +1,189 @@
+// Package aitest provides a fake AI review service for tests and local development.
+package aitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"QuanPhotos/internal/pkg/ai"
+)
+
+// Stub is an http.Handler that imitates the AI review service.
+// Photos pass by default; outcomes, failures and async delivery can be scripted.
+type Stub struct {
+	mu             sync.Mutex
+	apiKey         string
+	callbackSecret string
+	async          bool
+	decisions      map[int64]ai.Decision
+	failures       []int
+	requests       int
+	callbacks      sync.WaitGroup
+	httpClient     *http.Client
+}
+
+// NewStub creates a stub that requires apiKey as bearer token when non-empty
+func NewStub(apiKey string) *Stub {
+	return &Stub{
+		apiKey:     apiKey,
+		decisions:  make(map[int64]ai.Decision),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SetDecision scripts the decision for a photo
+func (s *Stub) SetDecision(photoID int64, decision ai.Decision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decisions[photoID] = decision
+}
+
+// FailNext makes the next n requests respond with the given status code
+func (s *Stub) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// SetAsync makes the stub answer 202 and post the signed result to the callback URL
+func (s *Stub) SetAsync(callbackSecret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.async = true
+	s.callbackSecret = callbackSecret
+}
+
+// Requests returns the number of review requests received
+func (s *Stub) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// WaitCallbacks blocks until all pending callbacks have been delivered
+func (s *Stub) WaitCallbacks() {
+	s.callbacks.Wait()
+}
+
+// ServeHTTP handles review requests
+func (s *Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != ai.ReviewPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests++
+	var failStatus int
+	if len(s.failures) > 0 {
+		failStatus = s.failures[0]
+		s.failures = s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	if failStatus != 0 {
+		http.Error(w, http.StatusText(failStatus), failStatus)
+		return
+	}
+
+	if s.apiKey != "" && r.Header.Get("Authorization") != "Bearer "+s.apiKey {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req ai.ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PhotoID <= 0 {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	body, _ := json.Marshal(s.result(req.PhotoID))
+
+	s.mu.Lock()
+	async, secret := s.async, s.callbackSecret
+	s.mu.Unlock()
+
+	if async && req.CallbackURL != "" {
+		s.callbacks.Add(1)
+		go s.deliver(req.CallbackURL, secret, body)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// result builds the scripted result for a photo
+func (s *Stub) result(photoID int64) *ai.Result {
+	s.mu.Lock()
+	decision, ok := s.decisions[photoID]
+	s.mu.Unlock()
+
+	if !ok {
+		decision = ai.DecisionPass
+	}
+
+	result := &ai.Result{
+		PhotoID:  photoID,
+		Decision: decision,
+		Score:    0.95,
+		Labels:   map[string]float64{"aircraft": 0.98},
+		Model:    "aitest-stub",
+	}
+	if decision == ai.DecisionReject {
+		result.Score = 0.12
+		result.Reasons = []string{"no aircraft detected"}
+		result.Labels = map[string]float64{"aircraft": 0.03}
+	}
+	return result
+}
+
+// deliver posts a signed result to the callback URL
+func (s *Stub) deliver(callbackURL, secret string, body []byte) {
+	defer s.callbacks.Done()
+
+	req, err := http.NewRequest(http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ai.SignatureHeader, ai.Sign(secret, time.Now(), body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// Server is a Stub listening on a local test server
+type Server struct {
+	*Stub
+	URL string
+
+	server *httptest.Server
+}
+
+// NewServer starts a fake AI service on a random local port
+func NewServer(apiKey string) *Server {
+	stub := NewStub(apiKey)
+	server := httptest.NewServer(stub)
+	return &Server{
+		Stub:   stub,
+		URL:    server.URL,
+		server: server,
+	}
+}
+
+// Close waits for pending callbacks and shuts the server down
+func (s *Server) Close() {
+	s.WaitCallbacks()
+	s.server.Close()
+}
@@ -0,0 +1,162 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ReviewPath is the AI service endpoint that reviews a photo
+const ReviewPath = "/v1/reviews"
+
+// maxBackoff caps the delay between retries
+const maxBackoff = 8 * time.Second
+
+// Config holds AI client configuration
+type Config struct {
+	ServiceURL       string
+	APIKey           string
+	Timeout          time.Duration // Per attempt
+	Retry            int           // Retries after the first attempt
+	RetryBackoff     time.Duration // Initial delay, doubled after each retry
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// Client calls the AI review service with retries and a circuit breaker
+type Client struct {
+	serviceURL string
+	apiKey     string
+	retry      int
+	backoff    time.Duration
+	httpClient *http.Client
+	breaker    *CircuitBreaker
+}
+
+// NewClient creates a new AI client
+func NewClient(cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.Retry < 0 {
+		cfg.Retry = 0
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 500 * time.Millisecond
+	}
+
+	return &Client{
+		serviceURL: strings.TrimRight(cfg.ServiceURL, "/"),
+		apiKey:     cfg.APIKey,
+		retry:      cfg.Retry,
+		backoff:    cfg.RetryBackoff,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		breaker:    NewCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+// Breaker returns the client's circuit breaker
+func (c *Client) Breaker() *CircuitBreaker {
+	return c.breaker
+}
+
+// Review submits a photo for review.
+// Returns ErrQueued when the service accepted the request and will post the
+// result to req.CallbackURL, and ErrCircuitOpen while the service is considered down.
+func (c *Client) Review(ctx context.Context, req *ReviewRequest) (*Result, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := c.backoff
+	for attempt := 0; ; attempt++ {
+		result, err := c.do(ctx, body)
+		if err == nil || errors.Is(err, ErrQueued) {
+			c.breaker.Success()
+			return result, err
+		}
+
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && !isRetryable(err) {
+			// The service is up but rejected the request
+			c.breaker.Success()
+			return nil, err
+		}
+		if !isRetryable(err) {
+			c.breaker.Failure()
+			return nil, err
+		}
+
+		if attempt >= c.retry || ctx.Err() != nil {
+			c.breaker.Failure()
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			c.breaker.Failure()
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+	}
+}
+
+// do performs a single review request
+func (c *Client) do(ctx context.Context, body []byte) (*Result, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.serviceURL+ReviewPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return ParseResult(respBody)
+	case http.StatusAccepted:
+		return nil, ErrQueued
+	default:
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+}
+
+// isRetryable reports whether a failed attempt may succeed when repeated
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	if errors.Is(err, ErrInvalidResult) {
+		return false
+	}
+	// Network errors and timeouts
+	return true
+}
@@ -0,0 +1,198 @@
+package ai_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"QuanPhotos/internal/pkg/ai"
+	"QuanPhotos/internal/pkg/ai/aitest"
+)
+
+func newClient(url string, retry, threshold int) *ai.Client {
+	return ai.NewClient(ai.Config{
+		ServiceURL:       url,
+		APIKey:           "test-key",
+		Timeout:          time.Second,
+		Retry:            retry,
+		RetryBackoff:     time.Millisecond,
+		BreakerThreshold: threshold,
+		BreakerCooldown:  50 * time.Millisecond,
+	})
+}
+
+func TestReviewDecisions(t *testing.T) {
+	server := aitest.NewServer("test-key")
+	defer server.Close()
+	server.SetDecision(2, ai.DecisionReject)
+
+	client := newClient(server.URL, 0, 5)
+
+	tests := []struct {
+		photoID  int64
+		decision ai.Decision
+	}{
+		{1, ai.DecisionPass},
+		{2, ai.DecisionReject},
+	}
+
+	for _, tt := range tests {
+		result, err := client.Review(context.Background(), &ai.ReviewRequest{PhotoID: tt.photoID, ImageURL: "http://example.com/a.jpg"})
+		if err != nil {
+			t.Fatalf("photo %d: unexpected error: %v", tt.photoID, err)
+		}
+		if result.PhotoID != tt.photoID || result.Decision != tt.decision {
+			t.Errorf("photo %d: got %+v, want decision %s", tt.photoID, result, tt.decision)
+		}
+		if len(result.Raw) == 0 {
+			t.Errorf("photo %d: raw payload not kept", tt.photoID)
+		}
+	}
+}
+
+func TestReviewRetriesTransientFailures(t *testing.T) {
+	server := aitest.NewServer("test-key")
+	defer server.Close()
+	server.FailNext(2, http.StatusServiceUnavailable)
+
+	client := newClient(server.URL, 3, 5)
+
+	result, err := client.Review(context.Background(), &ai.ReviewRequest{PhotoID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Decision != ai.DecisionPass {
+		t.Errorf("got decision %s, want pass", result.Decision)
+	}
+	if got := server.Requests(); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestReviewDoesNotRetryClientErrors(t *testing.T) {
+	server := aitest.NewServer("other-key")
+	defer server.Close()
+
+	client := newClient(server.URL, 3, 5)
+
+	_, err := client.Review(context.Background(), &ai.ReviewRequest{PhotoID: 1})
+	var statusErr *ai.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("got error %v, want 401 status error", err)
+	}
+	if got := server.Requests(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+	if state := client.Breaker().State(); state != ai.BreakerClosed {
+		t.Errorf("got breaker state %s, want closed", state)
+	}
+}
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	server := aitest.NewServer("test-key")
+	defer server.Close()
+	server.FailNext(2, http.StatusInternalServerError)
+
+	client := newClient(server.URL, 0, 2)
+	req := &ai.ReviewRequest{PhotoID: 1}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Review(context.Background(), req); err == nil {
+			t.Fatalf("call %d: expected error", i)
+		}
+	}
+
+	if _, err := client.Review(context.Background(), req); !errors.Is(err, ai.ErrCircuitOpen) {
+		t.Fatalf("got error %v, want ErrCircuitOpen", err)
+	}
+	if got := server.Requests(); got != 2 {
+		t.Errorf("got %d requests while open, want 2", got)
+	}
+
+	// After the cooldown a probe is let through and closes the circuit
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.Review(context.Background(), req); err != nil {
+		t.Fatalf("probe: unexpected error: %v", err)
+	}
+	if state := client.Breaker().State(); state != ai.BreakerClosed {
+		t.Errorf("got breaker state %s, want closed", state)
+	}
+}
+
+func TestReviewAsyncCallback(t *testing.T) {
+	const secret = "callback-secret"
+
+	received := make(chan *ai.Result, 1)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := ai.VerifySignature(secret, r.Header.Get(ai.SignatureHeader), body, time.Now()); err != nil {
+			t.Errorf("callback signature: %v", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		result, err := ai.ParseResult(body)
+		if err != nil {
+			t.Errorf("callback payload: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- result
+	}))
+	defer callback.Close()
+
+	server := aitest.NewServer("test-key")
+	defer server.Close()
+	server.SetAsync(secret)
+	server.SetDecision(7, ai.DecisionReject)
+
+	client := newClient(server.URL, 0, 5)
+
+	_, err := client.Review(context.Background(), &ai.ReviewRequest{PhotoID: 7, CallbackURL: callback.URL})
+	if !errors.Is(err, ai.ErrQueued) {
+		t.Fatalf("got error %v, want ErrQueued", err)
+	}
+
+	select {
+	case result := <-received:
+		if result.PhotoID != 7 || result.Decision != ai.DecisionReject {
+			t.Errorf("got %+v, want photo 7 rejected", result)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback not received")
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"photo_id":1,"decision":"pass"}`)
+	now := time.Now()
+	header := ai.Sign("secret", now, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{"valid", "secret", header, body, now, nil},
+		{"wrong secret", "other", header, body, now, ai.ErrInvalidSignature},
+		{"tampered body", "secret", header, []byte(`{"photo_id":1,"decision":"reject"}`), now, ai.ErrInvalidSignature},
+		{"expired", "secret", header, body, now.Add(ai.SignatureTolerance + time.Minute), ai.ErrSignatureExpired},
+		{"missing header", "secret", "", body, now, ai.ErrInvalidSignature},
+		{"malformed header", "secret", "v1=abc", body, now, ai.ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ai.VerifySignature(tt.secret, tt.header, tt.body, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrCircuitOpen      = errors.New("ai service circuit is open")
+	ErrQueued           = errors.New("ai review queued, result will be delivered by callback")
+	ErrInvalidResult    = errors.New("ai service returned an invalid result")
+	ErrInvalidSignature = errors.New("invalid ai callback signature")
+	ErrSignatureExpired = errors.New("ai callback signature has expired")
+)
+
+// Decision represents the AI verdict on a photo
+type Decision string
+
+const (
+	DecisionPass   Decision = "pass"
+	DecisionReject Decision = "reject"
+)
+
+// IsValid checks if the decision is a known value
+func (d Decision) IsValid() bool {
+	return d == DecisionPass || d == DecisionReject
+}
+
+// ReviewRequest is sent to the AI service for a single photo
+type ReviewRequest struct {
+	PhotoID     int64  `json:"photo_id"`
+	ImageURL    string `json:"image_url"`
+	CallbackURL string `json:"callback_url,omitempty"` // Set to receive the result asynchronously
+}
+
+// Result is the AI verdict, returned synchronously or posted to the callback
+type Result struct {
+	PhotoID  int64              `json:"photo_id"`
+	Decision Decision           `json:"decision"`
+	Score    float64            `json:"score"`
+	Reasons  []string           `json:"reasons,omitempty"`
+	Labels   map[string]float64 `json:"labels,omitempty"`
+	Model    string             `json:"model,omitempty"`
+
+	// Raw is the payload as received, stored for auditing
+	Raw json.RawMessage `json:"-"`
+}
+
+// ParseResult decodes and validates a result payload
+func ParseResult(body []byte) (*Result, error) {
+	var result Result
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResult, err)
+	}
+	if result.PhotoID <= 0 || !result.Decision.IsValid() {
+		return nil, ErrInvalidResult
+	}
+	result.Raw = append(json.RawMessage(nil), body...)
+	return &result, nil
+}
+
+// StatusError is returned when the AI service responds with an unexpected status
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("ai service responded with status %d: %s", e.StatusCode, e.Body)
+}
@@ -0,0 +1,74 @@
+package aviation
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// airportList holds the known airports, see airports.txt for the format
+//
+//go:embed airports.txt
+var airportList string
+
+var (
+	airportsOnce sync.Once
+	airportCodes map[string]bool // IATA and ICAO codes of known airports
+)
+
+// NormalizeAirportCode upper-cases an airport code and checks that it is the
+// IATA code (3 letters, e.g. PEK) or ICAO code (4 characters starting with a
+// letter, e.g. ZBAA) of a known airport.
+func NormalizeAirportCode(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !isAirportCode(code) {
+		return "", false
+	}
+
+	airportsOnce.Do(loadAirports)
+	if !airportCodes[code] {
+		return "", false
+	}
+	return code, true
+}
+
+// isAirportCode reports whether an upper-case code has the format of an IATA
+// or ICAO airport code
+func isAirportCode(code string) bool {
+	switch len(code) {
+	case 3:
+		for _, c := range code {
+			if c < 'A' || c > 'Z' {
+				return false
+			}
+		}
+		return true
+	case 4:
+		if code[0] < 'A' || code[0] > 'Z' {
+			return false
+		}
+		for _, c := range code[1:] {
+			if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// loadAirports indexes the codes of the embedded airport list
+func loadAirports() {
+	airportCodes = make(map[string]bool)
+	for _, line := range strings.Split(airportList, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		airportCodes[fields[0]] = true
+		if fields[1] != "-" {
+			airportCodes[fields[1]] = true
+		}
+	}
+}
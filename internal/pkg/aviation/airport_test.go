@@ -0,0 +1,84 @@
+package aviation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAirportCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		// IATA
+		{"PEK", "PEK", true},
+		{"pek", "PEK", true},
+		{" Pvg ", "PVG", true},
+		// ICAO
+		{"ZBAA", "ZBAA", true},
+		{"zsss", "ZSSS", true},
+		{"\tKJFK\n", "KJFK", true},
+		// Well-formed codes of no known airport
+		{"ZZZ", "", false},
+		{"ZZZZ", "", false},
+		{"K1G4", "", false},
+		// Invalid
+		{"", "", false},
+		{"PE", "", false},
+		{"PEKIN", "", false},
+		{"P3K", "", false},
+		{"1ZBA", "", false},
+		{"ZB-A", "", false},
+		{"Z BAA", "", false},
+		{"北京首都", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := NormalizeAirportCode(tt.input)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("NormalizeAirportCode(%q) = %q, %v, expected %q, %v", tt.input, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAirportList(t *testing.T) {
+	airportsOnce.Do(loadAirports)
+
+	icao := make(map[string]bool)
+	iata := make(map[string]bool)
+	for i, line := range strings.Split(airportList, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) < 3 {
+			t.Errorf("line %d: %q has no name", i+1, line)
+			continue
+		}
+		if len(fields[0]) != 4 || !isAirportCode(fields[0]) {
+			t.Errorf("line %d: %q is not an ICAO code", i+1, fields[0])
+		}
+		if icao[fields[0]] {
+			t.Errorf("line %d: duplicate ICAO code %s", i+1, fields[0])
+		}
+		icao[fields[0]] = true
+
+		if fields[1] == "-" {
+			continue
+		}
+		if len(fields[1]) != 3 || !isAirportCode(fields[1]) {
+			t.Errorf("line %d: %q is not an IATA code", i+1, fields[1])
+		}
+		if iata[fields[1]] {
+			t.Errorf("line %d: duplicate IATA code %s", i+1, fields[1])
+		}
+		iata[fields[1]] = true
+	}
+
+	if len(airportCodes) != len(icao)+len(iata) {
+		t.Errorf("indexed codes = %d, expected %d", len(airportCodes), len(icao)+len(iata))
+	}
+}
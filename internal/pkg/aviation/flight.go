@@ -0,0 +1,23 @@
+package aviation
+
+import (
+	"regexp"
+	"strings"
+)
+
+// flightNumberPattern matches an airline designator (2-character IATA or
+// 3-letter ICAO) followed by 1-4 digits and an optional suffix letter,
+// e.g. CA1234, CCA1234, 3U8633, MU5101A.
+var flightNumberPattern = regexp.MustCompile(`^([A-Z][A-Z0-9]|[0-9][A-Z]|[A-Z]{3})[0-9]{1,4}[A-Z]?$`)
+
+// NormalizeFlightNumber upper-cases a flight number, strips spaces and dashes,
+// and validates its format.
+func NormalizeFlightNumber(flightNumber string) (string, bool) {
+	flightNumber = strings.ToUpper(strings.TrimSpace(flightNumber))
+	flightNumber = strings.NewReplacer(" ", "", "-", "").Replace(flightNumber)
+
+	if !flightNumberPattern.MatchString(flightNumber) {
+		return "", false
+	}
+	return flightNumber, true
+}
@@ -0,0 +1,41 @@
+package aviation
+
+import "testing"
+
+func TestNormalizeFlightNumber(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		// IATA designators
+		{"CA1234", "CA1234", true},
+		{"ca1234", "CA1234", true},
+		{" MU 5101 ", "MU5101", true},
+		{"CZ-3101", "CZ3101", true},
+		{"3U8633", "3U8633", true},
+		{"B61", "B61", true},
+		{"MU5101A", "MU5101A", true},
+		// ICAO designators
+		{"CCA1234", "CCA1234", true},
+		{"cca 981", "CCA981", true},
+		// Invalid
+		{"", "", false},
+		{"CA", "", false},
+		{"CA12345", "", false},
+		{"33123", "", false},
+		{"C1", "", false},
+		{"CA12AB", "", false},
+		{"CCAA123", "", false},
+		{"CA_1234", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := NormalizeFlightNumber(tt.input)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("NormalizeFlightNumber(%q) = %q, %v, expected %q, %v", tt.input, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package exif
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Gear is the normalized camera body and lens of a photo, used for gear pages.
+// Empty names and keys mean the gear is unknown.
+type Gear struct {
+	CameraName string // e.g. "Nikon Z 9"
+	CameraKey  string // e.g. "nikon-z9"
+	LensName   string
+	LensKey    string
+}
+
+// brandAliases maps prefixes of upper-cased EXIF make strings to canonical
+// brand names, e.g. "NIKON CORPORATION" to Nikon
+var brandAliases = []struct {
+	prefix string
+	brand  string
+}{
+	{"NIKON", "Nikon"},
+	{"CANON", "Canon"},
+	{"SONY", "Sony"},
+	{"FUJI", "Fujifilm"},
+	{"OLYMPUS", "Olympus"},
+	{"OM DIGITAL", "OM System"},
+	{"PANASONIC", "Panasonic"},
+	{"LEICA", "Leica"},
+	{"PENTAX", "Pentax"},
+	{"RICOH", "Pentax"},
+	{"HASSELBLAD", "Hasselblad"},
+	{"SIGMA", "Sigma"},
+	{"DJI", "DJI"},
+	{"APPLE", "Apple"},
+	{"SAMSUNG", "Samsung"},
+	{"GOOGLE", "Google"},
+	{"HUAWEI", "Huawei"},
+	{"XIAOMI", "Xiaomi"},
+}
+
+var (
+	spacePattern = regexp.MustCompile(`\s+`)
+	keyPattern   = regexp.MustCompile(`[^a-z0-9.]+`)
+
+	// Placeholder lens strings written by some bodies, e.g. "----" or "0.0 mm f/0.0"
+	placeholderLensPattern = regexp.MustCompile(`(?i)^(?:[-\s]*|0(?:\.0)?\s*mm.*)$`)
+)
+
+// NormalizeGear derives the gear of a photo from its EXIF camera make,
+// camera model and lens model
+func NormalizeGear(cameraMake, cameraModel, lensModel string) Gear {
+	var g Gear
+
+	brand := GearBrand(cameraMake)
+	if model := stripBrand(brand, cameraModel); gearKey(model) != "" {
+		g.CameraName = strings.TrimSpace(brand + " " + model)
+		g.CameraKey = gearKey(model)
+		if brandKey := gearKey(brand); brandKey != "" {
+			g.CameraKey = brandKey + "-" + g.CameraKey
+		}
+	}
+
+	lens := collapseSpaces(lensModel)
+	if !placeholderLensPattern.MatchString(lens) && gearKey(lens) != "" {
+		g.LensName = lens
+		g.LensKey = gearKey(lens)
+	}
+
+	return g
+}
+
+// GearBrand maps a raw EXIF camera make to a canonical brand name,
+// e.g. "NIKON CORPORATION" to Nikon. Unknown makes keep their first word.
+func GearBrand(cameraMake string) string {
+	cameraMake = strings.TrimSpace(cameraMake)
+	if cameraMake == "" {
+		return ""
+	}
+
+	upper := strings.ToUpper(cameraMake)
+	for _, alias := range brandAliases {
+		if strings.HasPrefix(upper, alias.prefix) {
+			return alias.brand
+		}
+	}
+
+	first, _, _ := strings.Cut(cameraMake, " ")
+	return titleCase(first)
+}
+
+// stripBrand removes brand words repeated at the start of a model string,
+// e.g. "NIKON CORPORATION NIKON Z 9" to "Z 9" for Nikon
+func stripBrand(brand, model string) string {
+	model = collapseSpaces(model)
+	if brand == "" {
+		return model
+	}
+
+	first, _, _ := strings.Cut(brand, " ")
+	prefix := regexp.MustCompile(`(?i)^(?:` + regexp.QuoteMeta(brand) + `|` + regexp.QuoteMeta(first) + `)(?:\s+corporation)?(?:\s+|$)`)
+	for {
+		stripped := prefix.ReplaceAllString(model, "")
+		if stripped == model {
+			return model
+		}
+		model = stripped
+	}
+}
+
+// gearKey builds a stable key from a name: lowercase ASCII letters, digits
+// and dots. Names too short to identify a gear give an empty key.
+func gearKey(label string) string {
+	key := keyPattern.ReplaceAllString(strings.ToLower(label), "")
+	if len(key) < 2 {
+		return ""
+	}
+	return key
+}
+
+// collapseSpaces trims a string and collapses inner whitespace
+func collapseSpaces(s string) string {
+	return spacePattern.ReplaceAllString(strings.TrimSpace(s), " ")
+}
+
+// titleCase upper-cases the first letter of every word and lower-cases the
+// rest, words being runs of letters and digits
+func titleCase(s string) string {
+	var b strings.Builder
+	start := true
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start {
+				b.WriteRune(unicode.ToUpper(r))
+			} else {
+				b.WriteRune(unicode.ToLower(r))
+			}
+			start = false
+		} else {
+			b.WriteRune(r)
+			start = true
+		}
+	}
+	return b.String()
+}
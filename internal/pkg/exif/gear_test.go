@@ -0,0 +1,168 @@
+package exif
+
+import "testing"
+
+func TestNormalizeGearCamera(t *testing.T) {
+	tests := []struct {
+		name        string
+		cameraMake  string
+		cameraModel string
+		expectName  string
+		expectKey   string
+	}{
+		{
+			name:        "Make repeated in the model",
+			cameraMake:  "NIKON CORPORATION",
+			cameraModel: "NIKON Z 9",
+			expectName:  "Nikon Z 9",
+			expectKey:   "nikon-z9",
+		},
+		{
+			name:        "Full make repeated before the brand",
+			cameraMake:  "NIKON CORPORATION",
+			cameraModel: "NIKON CORPORATION NIKON Z 9",
+			expectName:  "Nikon Z 9",
+			expectKey:   "nikon-z9",
+		},
+		{
+			name:        "Model without the make",
+			cameraMake:  "NIKON CORPORATION",
+			cameraModel: "Z 9",
+			expectName:  "Nikon Z 9",
+			expectKey:   "nikon-z9",
+		},
+		{
+			name:        "Mixed case make in the model",
+			cameraMake:  "Canon",
+			cameraModel: "Canon EOS R5",
+			expectName:  "Canon EOS R5",
+			expectKey:   "canon-eosr5",
+		},
+		{
+			name:        "Fujifilm alias",
+			cameraMake:  "FUJIFILM",
+			cameraModel: "X-H2S",
+			expectName:  "Fujifilm X-H2S",
+			expectKey:   "fujifilm-xh2s",
+		},
+		{
+			name:        "Olympus imaging alias",
+			cameraMake:  "OLYMPUS IMAGING CORP.",
+			cameraModel: "E-M1MarkII",
+			expectName:  "Olympus E-M1MarkII",
+			expectKey:   "olympus-em1markii",
+		},
+		{
+			name:        "OM System model starting with the brand",
+			cameraMake:  "OM Digital Solutions",
+			cameraModel: "OM-1",
+			expectName:  "OM System OM-1",
+			expectKey:   "omsystem-om1",
+		},
+		{
+			name:        "Ricoh makes Pentax bodies",
+			cameraMake:  "RICOH IMAGING COMPANY, LTD.",
+			cameraModel: "PENTAX K-3 Mark III",
+			expectName:  "Pentax K-3 Mark III",
+			expectKey:   "pentax-k3markiii",
+		},
+		{
+			name:        "Unknown make keeps its first word",
+			cameraMake:  "PHASE ONE",
+			cameraModel: "IQ4 150MP",
+			expectName:  "Phase IQ4 150MP",
+			expectKey:   "phase-iq4150mp",
+		},
+		{
+			name:        "Extra whitespace is collapsed",
+			cameraMake:  " SONY ",
+			cameraModel: "  ILCE-1   ",
+			expectName:  "Sony ILCE-1",
+			expectKey:   "sony-ilce1",
+		},
+		{
+			name:        "Missing make",
+			cameraModel: "Z 9",
+			expectName:  "Z 9",
+			expectKey:   "z9",
+		},
+		{
+			name:        "Model that is only the make",
+			cameraMake:  "Canon",
+			cameraModel: "Canon",
+		},
+		{
+			name:       "Missing model",
+			cameraMake: "Canon",
+		},
+		{
+			name:        "Model too short for a key",
+			cameraMake:  "Sony",
+			cameraModel: "-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NormalizeGear(tt.cameraMake, tt.cameraModel, "")
+			if g.CameraName != tt.expectName || g.CameraKey != tt.expectKey {
+				t.Errorf("camera = %q, %q, expected %q, %q", g.CameraName, g.CameraKey, tt.expectName, tt.expectKey)
+			}
+		})
+	}
+}
+
+func TestNormalizeGearLens(t *testing.T) {
+	tests := []struct {
+		lensModel  string
+		expectName string
+		expectKey  string
+	}{
+		{"NIKKOR Z 100-400mm f/4.5-5.6 VR S", "NIKKOR Z 100-400mm f/4.5-5.6 VR S", "nikkorz100400mmf4.55.6vrs"},
+		{"  RF100-500mm   F4.5-7.1 L IS USM ", "RF100-500mm F4.5-7.1 L IS USM", "rf100500mmf4.57.1lisusm"},
+		{"----", "", ""},
+		{"- -", "", ""},
+		{"0.0 mm f/0.0", "", ""},
+		{"0mm", "", ""},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lensModel, func(t *testing.T) {
+			g := NormalizeGear("", "", tt.lensModel)
+			if g.LensName != tt.expectName || g.LensKey != tt.expectKey {
+				t.Errorf("lens = %q, %q, expected %q, %q", g.LensName, g.LensKey, tt.expectName, tt.expectKey)
+			}
+		})
+	}
+}
+
+func TestGearBrand(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"NIKON CORPORATION", "Nikon"},
+		{"Canon", "Canon"},
+		{"SONY", "Sony"},
+		{"FUJIFILM", "Fujifilm"},
+		{"OM Digital Solutions", "OM System"},
+		{"Panasonic", "Panasonic"},
+		{"LEICA CAMERA AG", "Leica"},
+		{"PENTAX Corporation", "Pentax"},
+		{"DJI", "DJI"},
+		{"Apple", "Apple"},
+		{"motorola", "Motorola"},
+		{"PHASE ONE", "Phase"},
+		{"", ""},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := GearBrand(tt.input); got != tt.expected {
+				t.Errorf("GearBrand(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
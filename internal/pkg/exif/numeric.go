@@ -0,0 +1,56 @@
+package exif
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var numberPattern = regexp.MustCompile(`[0-9]+(?:\.[0-9]+)?`)
+
+// ParseFocalLength extracts the focal length in millimetres from strings
+// such as "500 mm" or "500mm".
+func ParseFocalLength(s string) (float64, bool) {
+	return parsePositive(numberPattern.FindString(s))
+}
+
+// ParseAperture extracts the f-number from strings such as "f/5.6", "F5.6" or "5.6".
+func ParseAperture(s string) (float64, bool) {
+	return parsePositive(numberPattern.FindString(s))
+}
+
+// ParseShutterSpeed converts strings such as "1/1000 s", "1/1000", "2.0 s"
+// or 2" to an exposure time in seconds.
+func ParseShutterSpeed(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "s")
+	s = strings.TrimSuffix(s, `"`)
+	s = strings.TrimSpace(s)
+
+	if num, denom, found := strings.Cut(s, "/"); found {
+		n, ok := parsePositive(strings.TrimSpace(num))
+		if !ok {
+			return 0, false
+		}
+		d, ok := parsePositive(strings.TrimSpace(denom))
+		if !ok {
+			return 0, false
+		}
+		return n / d, true
+	}
+
+	return parsePositive(s)
+}
+
+// parsePositive parses a strictly positive, finite decimal number
+func parsePositive(s string) (float64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || !(v > 0) || math.IsInf(v, 1) {
+		return 0, false
+	}
+	return v, true
+}
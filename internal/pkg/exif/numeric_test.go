@@ -0,0 +1,89 @@
+package exif
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseShutterSpeed(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		ok       bool
+	}{
+		{"1/250", 1.0 / 250, true},
+		{"1/1000 s", 0.001, true},
+		{"1/1000s", 0.001, true},
+		{" 1 / 60 ", 1.0 / 60, true},
+		{"0.5", 0.5, true},
+		{"2.0 s", 2, true},
+		{`2"`, 2, true},
+		{`30" `, 30, true},
+		{"", 0, false},
+		{"s", 0, false},
+		{"0", 0, false},
+		{"1/0", 0, false},
+		{"-1/250", 0, false},
+		{"1/", 0, false},
+		{"fast", 0, false},
+		{"Inf", 0, false},
+		{"NaN", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseShutterSpeed(tt.input)
+			if ok != tt.ok || math.Abs(got-tt.expected) > 1e-12 {
+				t.Errorf("ParseShutterSpeed(%q) = %v, %v, expected %v, %v", tt.input, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseAperture(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		ok       bool
+	}{
+		{"f/2.8", 2.8, true},
+		{"F5.6", 5.6, true},
+		{"11", 11, true},
+		{"f/", 0, false},
+		{"f/0", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseAperture(tt.input)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("ParseAperture(%q) = %v, %v, expected %v, %v", tt.input, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseFocalLength(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		ok       bool
+	}{
+		{"200 mm", 200, true},
+		{"500mm", 500, true},
+		{"35.5 mm", 35.5, true},
+		{"mm", 0, false},
+		{"0 mm", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseFocalLength(tt.input)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("ParseFocalLength(%q) = %v, %v, expected %v, %v", tt.input, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
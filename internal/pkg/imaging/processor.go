@@ -30,6 +30,8 @@ type ProcessResult struct {
 	Width int
 	// Height is the height of the processed main image
 	Height int
+	// Quality contains screening metrics of the source image, nil when disabled
+	Quality *QualityMetrics
 }
 
 // Process processes an image file: auto-rotates, resizes if needed, and generates thumbnails
@@ -43,6 +45,12 @@ func (p *Processor) Process(srcPath, destDir, baseName string, orientation int)
 	// Auto-rotate based on EXIF orientation
 	src = p.autoRotate(src, orientation)
 
+	// Screen quality on the full-resolution source
+	var quality *QualityMetrics
+	if p.config.AnalyzeQuality {
+		quality = AnalyzeQuality(src)
+	}
+
 	// Resize if needed
 	src = p.resizeIfNeeded(src)
 
@@ -73,6 +81,7 @@ func (p *Processor) Process(srcPath, destDir, baseName string, orientation int)
 		ThumbnailPaths: thumbnailPaths,
 		Width:          bounds.Dx(),
 		Height:         bounds.Dy(),
+		Quality:        quality,
 	}, nil
 }
 
@@ -87,6 +96,12 @@ func (p *Processor) ProcessToSeparateDirs(srcPath, photoDir, thumbnailDir, baseN
 	// Auto-rotate based on EXIF orientation
 	src = p.autoRotate(src, orientation)
 
+	// Screen quality on the full-resolution source
+	var quality *QualityMetrics
+	if p.config.AnalyzeQuality {
+		quality = AnalyzeQuality(src)
+	}
+
 	// Resize if needed
 	src = p.resizeIfNeeded(src)
 
@@ -120,6 +135,7 @@ func (p *Processor) ProcessToSeparateDirs(srcPath, photoDir, thumbnailDir, baseN
 		ThumbnailPaths: thumbnailPaths,
 		Width:          bounds.Dx(),
 		Height:         bounds.Dy(),
+		Quality:        quality,
 	}, nil
 }
 
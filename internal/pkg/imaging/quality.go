@@ -0,0 +1,182 @@
+package imaging
+
+import (
+	"image"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// qualityAnalysisSize is the long side of the working copy used for quality analysis.
+// Scores are computed at this fixed scale so they are comparable across uploads.
+const qualityAnalysisSize = 1024
+
+// maxTiltDegrees is the largest horizon tilt considered by the estimator
+const maxTiltDegrees = 15.0
+
+// QualityMetrics contains heuristic image quality measurements
+type QualityMetrics struct {
+	// Width and Height are the dimensions before resizing
+	Width  int
+	Height int
+	// Sharpness is the variance of the Laplacian, low values indicate blur
+	Sharpness float64
+	// ShadowClipping and HighlightClipping are the fractions of crushed and blown pixels
+	ShadowClipping    float64
+	HighlightClipping float64
+	// TiltDegrees is the estimated horizon tilt, positive when rising to the right
+	TiltDegrees float64
+	// TiltConfidence is the share (0-1) of near-horizontal edge energy supporting the estimate
+	TiltConfidence float64
+	// NoiseSigma is the estimated standard deviation of luminance noise
+	NoiseSigma float64
+}
+
+// AnalyzeQuality computes quality metrics for an image
+func AnalyzeQuality(img image.Image) *QualityMetrics {
+	bounds := img.Bounds()
+	metrics := &QualityMetrics{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+	}
+
+	var work *image.NRGBA
+	if metrics.Width > qualityAnalysisSize || metrics.Height > qualityAnalysisSize {
+		work = imaging.Fit(img, qualityAnalysisSize, qualityAnalysisSize, imaging.Box)
+	} else {
+		work = imaging.Clone(img)
+	}
+
+	w, h, luma := toLuma(work)
+	if w < 3 || h < 3 {
+		return metrics
+	}
+
+	metrics.ShadowClipping, metrics.HighlightClipping = clipping(luma)
+	metrics.Sharpness = laplacianVariance(w, h, luma)
+	metrics.NoiseSigma = noiseSigma(w, h, luma)
+	metrics.TiltDegrees, metrics.TiltConfidence = horizonTilt(w, h, luma)
+
+	return metrics
+}
+
+// toLuma converts an image to Rec. 601 luminance values in the 0-255 range
+func toLuma(img *image.NRGBA) (int, int, []float64) {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	luma := make([]float64, w*h)
+	for y := 0; y < h; y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+w*4]
+		for x := 0; x < w; x++ {
+			r, g, b := float64(row[x*4]), float64(row[x*4+1]), float64(row[x*4+2])
+			luma[y*w+x] = 0.299*r + 0.587*g + 0.114*b
+		}
+	}
+	return w, h, luma
+}
+
+// clipping returns the fractions of pixels at the ends of the histogram
+func clipping(luma []float64) (shadows, highlights float64) {
+	var low, high int
+	for _, v := range luma {
+		if v <= 2 {
+			low++
+		} else if v >= 253 {
+			high++
+		}
+	}
+	n := float64(len(luma))
+	return float64(low) / n, float64(high) / n
+}
+
+// laplacianVariance returns the variance of the 4-neighbour Laplacian
+func laplacianVariance(w, h int, luma []float64) float64 {
+	var sum, sumSq float64
+	n := 0
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			v := luma[i-w] + luma[i+w] + luma[i-1] + luma[i+1] - 4*luma[i]
+			sum += v
+			sumSq += v * v
+			n++
+		}
+	}
+	mean := sum / float64(n)
+	return sumSq/float64(n) - mean*mean
+}
+
+// noiseSigma estimates Gaussian noise with Immerkær's fast method
+func noiseSigma(w, h int, luma []float64) float64 {
+	var sum float64
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			v := luma[i-w-1] - 2*luma[i-w] + luma[i-w+1] -
+				2*luma[i-1] + 4*luma[i] - 2*luma[i+1] +
+				luma[i+w-1] - 2*luma[i+w] + luma[i+w+1]
+			sum += math.Abs(v)
+		}
+	}
+	return sum * math.Sqrt(math.Pi/2) / (6 * float64(w-2) * float64(h-2))
+}
+
+// horizonTilt estimates the dominant angle of near-horizontal edges from a
+// magnitude-weighted histogram of Sobel edge orientations
+func horizonTilt(w, h int, luma []float64) (degrees, confidence float64) {
+	const binsPerDegree = 2
+	const bins = int(2*maxTiltDegrees*binsPerDegree) + 1
+	var hist [bins]float64
+	var total float64
+
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			gx := (luma[i-w+1] + 2*luma[i+1] + luma[i+w+1]) - (luma[i-w-1] + 2*luma[i-1] + luma[i+w-1])
+			gy := (luma[i+w-1] + 2*luma[i+w] + luma[i+w+1]) - (luma[i-w-1] + 2*luma[i-w] + luma[i-w+1])
+
+			mag := math.Hypot(gx, gy)
+			// Ignore weak gradients, mostly texture and noise
+			if mag < 100 || gy == 0 {
+				continue
+			}
+
+			// A line rising to the right has a gradient with gx/gy = tan(angle)
+			angle := math.Atan(gx/gy) * 180 / math.Pi
+			if math.Abs(angle) > maxTiltDegrees {
+				continue
+			}
+
+			hist[int(math.Round((angle+maxTiltDegrees)*binsPerDegree))] += mag
+			total += mag
+		}
+	}
+
+	if total == 0 {
+		return 0, 0
+	}
+
+	// Peak of a 3-bin sliding window
+	best, bestWeight := 0, 0.0
+	for b := 0; b < bins; b++ {
+		weight := hist[b]
+		if b > 0 {
+			weight += hist[b-1]
+		}
+		if b < bins-1 {
+			weight += hist[b+1]
+		}
+		if weight > bestWeight {
+			best, bestWeight = b, weight
+		}
+	}
+
+	// Refine with the weighted centre of the window
+	var centre float64
+	for b := best - 1; b <= best+1; b++ {
+		if b >= 0 && b < bins {
+			centre += float64(b) * hist[b]
+		}
+	}
+	degrees = (centre/bestWeight)/binsPerDegree - maxTiltDegrees
+	return degrees, bestWeight / total
+}
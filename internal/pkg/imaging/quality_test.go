@@ -0,0 +1,229 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// grayImage builds a grayscale test image from a pixel function
+func grayImage(w, h int, pixel func(x, y int) float64) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := math.Round(pixel(x, y))
+			img.SetGray(x, y, color.Gray{Y: uint8(math.Max(0, math.Min(255, v)))})
+		}
+	}
+	return img
+}
+
+// horizonImage is dark sky over bright ground split by a line through the
+// centre, rising to the right by the given angle
+func horizonImage(w, h int, degrees float64) *image.Gray {
+	slope := math.Tan(degrees * math.Pi / 180)
+	return grayImage(w, h, func(x, y int) float64 {
+		// Signed distance below the line, blended over one pixel
+		d := float64(y) - (float64(h)/2 - slope*(float64(x)-float64(w)/2))
+		t := math.Max(0, math.Min(1, d+0.5))
+		return 40 + t*160
+	})
+}
+
+func TestAnalyzeQualitySharpness(t *testing.T) {
+	tests := []struct {
+		name     string
+		img      image.Image
+		minSharp float64
+		maxSharp float64
+	}{
+		{
+			name:     "Flat image has no detail",
+			img:      grayImage(200, 200, func(x, y int) float64 { return 128 }),
+			maxSharp: 0.001,
+		},
+		{
+			name:     "Smooth gradient reads as blurred",
+			img:      grayImage(200, 200, func(x, y int) float64 { return float64(x) }),
+			maxSharp: 1,
+		},
+		{
+			name: "Checkerboard is sharp",
+			img: grayImage(200, 200, func(x, y int) float64 {
+				return float64((x+y)%2) * 255
+			}),
+			minSharp: 100000,
+			maxSharp: math.Inf(1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := AnalyzeQuality(tt.img)
+			if m.Sharpness < tt.minSharp || m.Sharpness > tt.maxSharp {
+				t.Errorf("Sharpness = %f, expected between %f and %f", m.Sharpness, tt.minSharp, tt.maxSharp)
+			}
+		})
+	}
+}
+
+func TestAnalyzeQualityClipping(t *testing.T) {
+	tests := []struct {
+		name             string
+		img              image.Image
+		expectShadows    float64
+		expectHighlights float64
+	}{
+		{
+			name:             "All white",
+			img:              grayImage(100, 100, func(x, y int) float64 { return 255 }),
+			expectHighlights: 1,
+		},
+		{
+			name:          "All black",
+			img:           grayImage(100, 100, func(x, y int) float64 { return 0 }),
+			expectShadows: 1,
+		},
+		{
+			name: "Near ends still count",
+			img: grayImage(100, 100, func(x, y int) float64 {
+				switch {
+				case y < 25:
+					return 2
+				case y < 50:
+					return 253
+				}
+				return 128
+			}),
+			expectShadows:    0.25,
+			expectHighlights: 0.25,
+		},
+		{
+			name: "Midtones are not clipped",
+			img:  grayImage(100, 100, func(x, y int) float64 { return 3 + float64(x)*2.49 }),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := AnalyzeQuality(tt.img)
+			if math.Abs(m.ShadowClipping-tt.expectShadows) > 1e-9 {
+				t.Errorf("ShadowClipping = %f, expected %f", m.ShadowClipping, tt.expectShadows)
+			}
+			if math.Abs(m.HighlightClipping-tt.expectHighlights) > 1e-9 {
+				t.Errorf("HighlightClipping = %f, expected %f", m.HighlightClipping, tt.expectHighlights)
+			}
+		})
+	}
+}
+
+func TestAnalyzeQualityTilt(t *testing.T) {
+	tests := []struct {
+		name          string
+		degrees       float64
+		expectDegrees float64
+		tolerance     float64
+		minConfidence float64
+	}{
+		{name: "Level horizon", degrees: 0, expectDegrees: 0, tolerance: 0.25, minConfidence: 0.9},
+		{name: "Rising to the right", degrees: 3, expectDegrees: 3, tolerance: 0.5, minConfidence: 0.5},
+		{name: "Falling to the right", degrees: -5, expectDegrees: -5, tolerance: 0.5, minConfidence: 0.5},
+		{name: "Steep tilt within range", degrees: 12, expectDegrees: 12, tolerance: 0.5, minConfidence: 0.4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := AnalyzeQuality(horizonImage(400, 300, tt.degrees))
+			if math.Abs(m.TiltDegrees-tt.expectDegrees) > tt.tolerance {
+				t.Errorf("TiltDegrees = %f, expected %f ± %f", m.TiltDegrees, tt.expectDegrees, tt.tolerance)
+			}
+			if m.TiltConfidence < tt.minConfidence || m.TiltConfidence > 1 {
+				t.Errorf("TiltConfidence = %f, expected at least %f", m.TiltConfidence, tt.minConfidence)
+			}
+		})
+	}
+}
+
+func TestAnalyzeQualityTiltWithoutHorizon(t *testing.T) {
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{
+			name: "Flat image",
+			img:  grayImage(200, 200, func(x, y int) float64 { return 128 }),
+		},
+		{
+			name: "Vertical edge only",
+			img: grayImage(200, 200, func(x, y int) float64 {
+				if x < 100 {
+					return 40
+				}
+				return 200
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := AnalyzeQuality(tt.img)
+			if m.TiltDegrees != 0 || m.TiltConfidence != 0 {
+				t.Errorf("tilt = %f (confidence %f), expected no estimate", m.TiltDegrees, m.TiltConfidence)
+			}
+		})
+	}
+}
+
+func TestAnalyzeQualityNoise(t *testing.T) {
+	tests := []struct {
+		name        string
+		sigma       float64
+		expectSigma float64
+		tolerance   float64
+	}{
+		{name: "Clean image", sigma: 0, expectSigma: 0, tolerance: 0.001},
+		{name: "Light noise", sigma: 4, expectSigma: 4, tolerance: 0.6},
+		{name: "Heavy noise", sigma: 15, expectSigma: 15, tolerance: 1.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			img := grayImage(300, 300, func(x, y int) float64 {
+				return 128 + rng.NormFloat64()*tt.sigma
+			})
+
+			m := AnalyzeQuality(img)
+			if math.Abs(m.NoiseSigma-tt.expectSigma) > tt.tolerance {
+				t.Errorf("NoiseSigma = %f, expected %f ± %f", m.NoiseSigma, tt.expectSigma, tt.tolerance)
+			}
+		})
+	}
+}
+
+func TestAnalyzeQualitySize(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h         int
+		expectScored bool
+	}{
+		{name: "Large image is analysed downscaled", w: 2400, h: 1200, expectScored: true},
+		{name: "Tiny image is not scored", w: 2, h: 2, expectScored: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := grayImage(tt.w, tt.h, func(x, y int) float64 { return float64((x/4+y/4)%2) * 255 })
+
+			m := AnalyzeQuality(img)
+			if m.Width != tt.w || m.Height != tt.h {
+				t.Errorf("size = %dx%d, expected %dx%d", m.Width, m.Height, tt.w, tt.h)
+			}
+			if scored := m.Sharpness > 0; scored != tt.expectScored {
+				t.Errorf("Sharpness = %f, expected scored %v", m.Sharpness, tt.expectScored)
+			}
+		})
+	}
+}
@@ -23,6 +23,8 @@ type ProcessorConfig struct {
 	Quality int
 	// ThumbnailSizes defines the thumbnail sizes to generate
 	ThumbnailSizes []ThumbnailSize
+	// AnalyzeQuality enables heuristic quality screening of the source image
+	AnalyzeQuality bool
 }
 
 // DefaultProcessorConfig returns the default processor configuration
@@ -0,0 +1,85 @@
+// Package permission provides a cached lookup of admin permissions.
+package permission
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Loader loads the permissions granted to an admin
+type Loader func(ctx context.Context, adminID int64) ([]string, error)
+
+// cacheEntry holds the permissions of an admin
+type cacheEntry struct {
+	permissions []string
+	expiresAt   time.Time
+}
+
+// Cache caches admin permissions in memory.
+// Entries are dropped on grant and revoke through Invalidate; the TTL bounds
+// how long other instances keep serving a stale entry.
+type Cache struct {
+	load    Loader
+	ttl     time.Duration
+	entries map[int64]*cacheEntry
+	// version changes on every invalidation so loads racing with a
+	// grant or revoke are not cached
+	version uint64
+	mu      sync.RWMutex
+}
+
+// NewCache creates a new permission cache
+func NewCache(load Loader, ttl time.Duration) *Cache {
+	return &Cache{
+		load:    load,
+		ttl:     ttl,
+		entries: make(map[int64]*cacheEntry),
+	}
+}
+
+// Permissions returns the permissions granted to an admin
+func (c *Cache) Permissions(ctx context.Context, adminID int64) ([]string, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[adminID]
+	version := c.version
+	c.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.permissions, nil
+	}
+
+	permissions, err := c.load(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.version == version {
+		c.entries[adminID] = &cacheEntry{
+			permissions: permissions,
+			expiresAt:   time.Now().Add(c.ttl),
+		}
+	}
+	c.mu.Unlock()
+
+	return permissions, nil
+}
+
+// Has reports whether an admin holds a permission
+func (c *Cache) Has(ctx context.Context, adminID int64, permission string) (bool, error) {
+	permissions, err := c.Permissions(ctx, adminID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(permissions, permission), nil
+}
+
+// Invalidate drops the cached permissions of an admin
+func (c *Cache) Invalidate(adminID int64) {
+	c.mu.Lock()
+	delete(c.entries, adminID)
+	c.version++
+	c.mu.Unlock()
+}
@@ -0,0 +1,191 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLoader serves the current grants of each admin and counts loads
+type fakeLoader struct {
+	mu     sync.Mutex
+	grants map[int64][]string
+	err    error
+	loads  int
+}
+
+func (f *fakeLoader) load(_ context.Context, adminID int64) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.loads++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return slices.Clone(f.grants[adminID]), nil
+}
+
+func (f *fakeLoader) grant(adminID int64, permissions ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.grants[adminID] = permissions
+}
+
+func (f *fakeLoader) loadCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.loads
+}
+
+func TestCacheTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		wait        time.Duration
+		expectLoads int
+		expectPerms []string
+	}{
+		{
+			name:        "Entry is served until it expires",
+			ttl:         time.Hour,
+			expectLoads: 1,
+			expectPerms: []string{"review_photos"},
+		},
+		{
+			name:        "Expired entry is reloaded",
+			ttl:         time.Millisecond,
+			wait:        5 * time.Millisecond,
+			expectLoads: 2,
+			expectPerms: []string{"review_photos", "delete_photos"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			loader := &fakeLoader{grants: map[int64][]string{}}
+			loader.grant(2, "review_photos")
+			cache := NewCache(loader.load, tt.ttl)
+
+			if _, err := cache.Permissions(ctx, 2); err != nil {
+				t.Fatalf("Permissions() error = %v", err)
+			}
+
+			// Granted without Invalidate, as on another instance
+			loader.grant(2, "review_photos", "delete_photos")
+			time.Sleep(tt.wait)
+
+			perms, err := cache.Permissions(ctx, 2)
+			if err != nil {
+				t.Fatalf("Permissions() error = %v", err)
+			}
+			if !slices.Equal(perms, tt.expectPerms) {
+				t.Errorf("Permissions() = %v, expected %v", perms, tt.expectPerms)
+			}
+			if loads := loader.loadCount(); loads != tt.expectLoads {
+				t.Errorf("loads = %d, expected %d", loads, tt.expectLoads)
+			}
+		})
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	loader := &fakeLoader{grants: map[int64][]string{}}
+	loader.grant(2, "review_photos")
+	loader.grant(3, "manage_tags")
+	cache := NewCache(loader.load, time.Hour)
+
+	if ok, _ := cache.Has(ctx, 2, "delete_photos"); ok {
+		t.Fatal("Has() = true before the grant")
+	}
+	if _, err := cache.Permissions(ctx, 3); err != nil {
+		t.Fatalf("Permissions() error = %v", err)
+	}
+
+	loader.grant(2, "review_photos", "delete_photos")
+	cache.Invalidate(2)
+
+	ok, err := cache.Has(ctx, 2, "delete_photos")
+	if err != nil {
+		t.Fatalf("Has() error = %v", err)
+	}
+	if !ok {
+		t.Error("Has() = false after grant and invalidate")
+	}
+
+	// Other admins keep their entries
+	if _, err := cache.Permissions(ctx, 3); err != nil {
+		t.Fatalf("Permissions() error = %v", err)
+	}
+	if loads := loader.loadCount(); loads != 3 {
+		t.Errorf("loads = %d, expected 3", loads)
+	}
+}
+
+func TestCacheLoadError(t *testing.T) {
+	ctx := context.Background()
+	loadErr := errors.New("connection refused")
+	loader := &fakeLoader{grants: map[int64][]string{}, err: loadErr}
+	cache := NewCache(loader.load, time.Hour)
+
+	if ok, err := cache.Has(ctx, 2, "review_photos"); !errors.Is(err, loadErr) || ok {
+		t.Fatalf("Has() = %v, %v, expected false, %v", ok, err, loadErr)
+	}
+
+	// Failures are not cached
+	loader.err = nil
+	loader.grant(2, "review_photos")
+	if ok, err := cache.Has(ctx, 2, "review_photos"); err != nil || !ok {
+		t.Errorf("Has() = %v, %v, expected true after recovery", ok, err)
+	}
+}
+
+func TestCacheLoadRacingInvalidate(t *testing.T) {
+	ctx := context.Background()
+	loader := &fakeLoader{grants: map[int64][]string{}}
+	loader.grant(2, "review_photos")
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blocking := true
+	cache := NewCache(func(ctx context.Context, adminID int64) ([]string, error) {
+		perms, err := loader.load(ctx, adminID)
+		if blocking {
+			blocking = false
+			close(started)
+			<-release
+		}
+		return perms, err
+	}, time.Hour)
+
+	done := make(chan []string)
+	go func() {
+		perms, _ := cache.Permissions(ctx, 2)
+		done <- perms
+	}()
+
+	// Revoke while the first load still holds the old grants
+	<-started
+	loader.grant(2)
+	cache.Invalidate(2)
+	close(release)
+
+	if perms := <-done; !slices.Equal(perms, []string{"review_photos"}) {
+		t.Fatalf("racing load = %v, expected the grants it read", perms)
+	}
+
+	// The stale result must not have been cached
+	ok, err := cache.Has(ctx, 2, "review_photos")
+	if err != nil {
+		t.Fatalf("Has() error = %v", err)
+	}
+	if ok {
+		t.Error("Has() = true, stale load was cached after the revoke")
+	}
+	if loads := loader.loadCount(); loads != 2 {
+		t.Errorf("loads = %d, expected 2", loads)
+	}
+}
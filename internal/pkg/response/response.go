@@ -16,6 +16,7 @@ const (
 	CodeTokenExpired      = 40102
 	CodeTokenInvalid      = 40103
 	CodeForbidden         = 40301
+	CodeQuotaExceeded     = 40302
 	CodeNotFound          = 40401
 	CodeConflict          = 40901
 	CodeUnsupportedFormat = 42201
@@ -35,6 +36,7 @@ var errorMessages = map[int]string{
 	CodeTokenExpired:      "token expired",
 	CodeTokenInvalid:      "invalid token",
 	CodeForbidden:         "forbidden",
+	CodeQuotaExceeded:     "storage quota exceeded",
 	CodeNotFound:          "resource not found",
 	CodeConflict:          "resource conflict",
 	CodeUnsupportedFormat: "unsupported file format",
@@ -0,0 +1,537 @@
+package album
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	// ownerPhotoFilter matches the album photos its owner can see
+	ownerPhotoFilter = `p.deleted_at IS NULL`
+	// visiblePhotoFilter matches the album photos other users can see,
+	// unlisted photos are shown as the owner linked them in the album
+	visiblePhotoFilter = postgresql.VisiblePhotoFilter("p")
+)
+
+// AlbumRepository handles album database operations
+type AlbumRepository struct {
+	*postgresql.BaseRepository
+}
+
+// NewAlbumRepository creates a new album repository
+func NewAlbumRepository(db *sqlx.DB) *AlbumRepository {
+	return &AlbumRepository{
+		BaseRepository: postgresql.NewBaseRepository(db),
+	}
+}
+
+// CreateParams contains parameters for creating an album
+type CreateParams struct {
+	UserID      int64
+	Title       string
+	Description *string
+	Visibility  model.PhotoVisibility
+}
+
+// UpdateParams contains the new values of an album
+type UpdateParams struct {
+	Title        string
+	Description  *string
+	CoverPhotoID *int64 // Nil uses the first photo as cover
+	Visibility   model.PhotoVisibility
+}
+
+// ListParams contains parameters for listing albums
+type ListParams struct {
+	Page       int
+	PageSize   int
+	UserID     int64
+	PublicOnly bool // Only public albums, counting photos visible to everyone
+}
+
+// ListResult contains the result of listing albums
+type ListResult struct {
+	Albums     []*model.Album
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
+
+// PhotoListResult contains the result of listing the photos of an album
+type PhotoListResult struct {
+	Photos     []*model.Photo
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
+
+// albumColumns selects an album with its photo count and cover thumbnail.
+// The cover is the chosen cover photo, or the first photo when none is chosen
+// or the viewer cannot see it.
+func albumColumns(ownerView bool) string {
+	filter := visiblePhotoFilter
+	if ownerView {
+		filter = ownerPhotoFilter
+	}
+
+	return fmt.Sprintf(`
+		a.*,
+		(SELECT COUNT(*) FROM album_photos ap JOIN photos p ON p.id = ap.photo_id
+			WHERE ap.album_id = a.id AND %s) AS photo_count,
+		(SELECT p.thumbnail_path FROM album_photos ap JOIN photos p ON p.id = ap.photo_id
+			WHERE ap.album_id = a.id AND %s
+			ORDER BY (p.id = a.cover_photo_id) IS TRUE DESC, ap.position ASC
+			LIMIT 1) AS cover_path
+	`, filter, filter)
+}
+
+// Create creates a new empty album
+func (r *AlbumRepository) Create(ctx context.Context, params *CreateParams) (int64, error) {
+	query := `
+		INSERT INTO albums (user_id, title, description, visibility)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.DB().QueryRowContext(ctx, query,
+		params.UserID,
+		params.Title,
+		toNullString(params.Description),
+		params.Visibility,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID retrieves an album by ID. ownerView counts every photo that is not
+// in the trash, otherwise only photos visible to everyone.
+func (r *AlbumRepository) GetByID(ctx context.Context, id int64, ownerView bool) (*model.Album, error) {
+	query := fmt.Sprintf(`SELECT %s FROM albums a WHERE a.id = $1`, albumColumns(ownerView))
+
+	var a model.Album
+	err := r.DB().GetContext(ctx, &a, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+// List retrieves a user's albums, most recently updated first
+func (r *AlbumRepository) List(ctx context.Context, params ListParams) (*ListResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	conditions := []string{"a.user_id = $1"}
+	if params.PublicOnly {
+		conditions = append(conditions, "a.visibility = 'public'")
+	}
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int64
+	err := r.DB().GetContext(ctx, &total, "SELECT COUNT(*) FROM albums a "+whereClause, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate pagination
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize > 0 {
+		totalPages++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM albums a
+		%s
+		ORDER BY a.updated_at DESC, a.id DESC
+		LIMIT $2 OFFSET $3
+	`, albumColumns(!params.PublicOnly), whereClause)
+
+	var albums []*model.Album
+	err = r.DB().SelectContext(ctx, &albums, query, params.UserID, params.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResult{
+		Albums:     albums,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Update sets the title, description, cover and visibility of an album
+func (r *AlbumRepository) Update(ctx context.Context, id int64, params *UpdateParams) error {
+	var cover sql.NullInt64
+	if params.CoverPhotoID != nil {
+		cover = sql.NullInt64{Int64: *params.CoverPhotoID, Valid: true}
+	}
+
+	query := `
+		UPDATE albums
+		SET title = $1, description = $2, cover_photo_id = $3, visibility = $4
+		WHERE id = $5
+	`
+
+	result, err := r.DB().ExecContext(ctx, query,
+		params.Title,
+		toNullString(params.Description),
+		cover,
+		params.Visibility,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an album, its photos are kept
+func (r *AlbumRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.DB().ExecContext(ctx, `DELETE FROM albums WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return nil
+}
+
+// CountOwnedPhotos counts how many of the given photos belong to a user and
+// are not in the trash
+func (r *AlbumRepository) CountOwnedPhotos(ctx context.Context, userID int64, photoIDs []int64) (int, error) {
+	if len(photoIDs) == 0 {
+		return 0, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT COUNT(*) FROM photos WHERE id IN (?) AND user_id = ? AND deleted_at IS NULL
+	`, photoIDs, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := r.DB().GetContext(ctx, &count, r.DB().Rebind(query), args...); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// HasPhoto checks if a photo is in an album
+func (r *AlbumRepository) HasPhoto(ctx context.Context, albumID, photoID int64) (bool, error) {
+	var exists bool
+	err := r.DB().GetContext(ctx, &exists, `
+		SELECT EXISTS(SELECT 1 FROM album_photos WHERE album_id = $1 AND photo_id = $2)
+	`, albumID, photoID)
+	return exists, err
+}
+
+// AddPhotos appends photos to the end of an album in the given order,
+// skipping photos already in it. Returns the number of photos added.
+func (r *AlbumRepository) AddPhotos(ctx context.Context, albumID int64, photoIDs []int64) (int, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := lockAlbum(ctx, tx, albumID); err != nil {
+		return 0, err
+	}
+
+	var position int
+	err = tx.GetContext(ctx, &position, `
+		SELECT COALESCE(MAX(position), 0) FROM album_photos WHERE album_id = $1
+	`, albumID)
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for _, photoID := range photoIDs {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO album_photos (album_id, photo_id, position)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (album_id, photo_id) DO NOTHING
+		`, albumID, photoID, position+1)
+		if err != nil {
+			return 0, err
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return 0, err
+		} else if rowsAffected > 0 {
+			position++
+			added++
+		}
+	}
+
+	if added > 0 {
+		if err := touchAlbum(ctx, tx, albumID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return added, nil
+}
+
+// RemovePhotos removes photos from an album and closes the gaps they leave
+// in its order. A removed cover falls back to the first photo. Returns the
+// number of photos removed.
+func (r *AlbumRepository) RemovePhotos(ctx context.Context, albumID int64, photoIDs []int64) (int, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := lockAlbum(ctx, tx, albumID); err != nil {
+		return 0, err
+	}
+
+	query, args, err := sqlx.In(`DELETE FROM album_photos WHERE album_id = ? AND photo_id IN (?)`, albumID, photoIDs)
+	if err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+
+	// Renumber the remaining photos
+	_, err = tx.ExecContext(ctx, `
+		UPDATE album_photos ap SET position = ordered.position
+		FROM (
+			SELECT photo_id, ROW_NUMBER() OVER (ORDER BY position ASC, added_at ASC) AS position
+			FROM album_photos WHERE album_id = $1
+		) ordered
+		WHERE ap.album_id = $1 AND ap.photo_id = ordered.photo_id AND ap.position <> ordered.position
+	`, albumID)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args, err = sqlx.In(`
+		UPDATE albums SET cover_photo_id = NULL WHERE id = ? AND cover_photo_id IN (?)
+	`, albumID, photoIDs)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+		return 0, err
+	}
+
+	if err := touchAlbum(ctx, tx, albumID); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(removed), nil
+}
+
+// MovePhoto moves a photo to a new position in an album, shifting the photos
+// in between. Positions past the end move the photo to the end. Returns
+// ErrNotFound if the photo is not in the album.
+func (r *AlbumRepository) MovePhoto(ctx context.Context, albumID, photoID int64, position int) error {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockAlbum(ctx, tx, albumID); err != nil {
+		return err
+	}
+
+	var current struct {
+		Position int `db:"position"`
+		Last     int `db:"last"`
+	}
+	err = tx.GetContext(ctx, &current, `
+		SELECT position, (SELECT MAX(position) FROM album_photos WHERE album_id = $1) AS last
+		FROM album_photos WHERE album_id = $1 AND photo_id = $2
+	`, albumID, photoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postgresql.ErrNotFound
+		}
+		return err
+	}
+
+	if position < 1 {
+		position = 1
+	}
+	if position > current.Last {
+		position = current.Last
+	}
+	if position == current.Position {
+		return nil
+	}
+
+	if position < current.Position {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE album_photos SET position = position + 1
+			WHERE album_id = $1 AND position >= $2 AND position < $3
+		`, albumID, position, current.Position)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE album_photos SET position = position - 1
+			WHERE album_id = $1 AND position > $2 AND position <= $3
+		`, albumID, current.Position, position)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE album_photos SET position = $3 WHERE album_id = $1 AND photo_id = $2
+	`, albumID, photoID, position)
+	if err != nil {
+		return err
+	}
+
+	if err := touchAlbum(ctx, tx, albumID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListPhotos retrieves the photos of an album in their manual order.
+// ownerView includes every photo that is not in the trash, otherwise only
+// photos visible to everyone.
+func (r *AlbumRepository) ListPhotos(ctx context.Context, albumID int64, ownerView bool, page, pageSize int) (*PhotoListResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	filter := visiblePhotoFilter
+	if ownerView {
+		filter = ownerPhotoFilter
+	}
+
+	var total int64
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*) FROM album_photos ap JOIN photos p ON p.id = ap.photo_id
+		WHERE ap.album_id = $1 AND %s
+	`, filter)
+	if err := r.DB().GetContext(ctx, &total, countQuery, albumID); err != nil {
+		return nil, err
+	}
+
+	// Calculate pagination
+	offset := (page - 1) * pageSize
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.* FROM album_photos ap JOIN photos p ON p.id = ap.photo_id
+		WHERE ap.album_id = $1 AND %s
+		ORDER BY ap.position ASC
+		LIMIT $2 OFFSET $3
+	`, filter)
+
+	var photos []*model.Photo
+	if err := r.DB().SelectContext(ctx, &photos, query, albumID, pageSize, offset); err != nil {
+		return nil, err
+	}
+
+	return &PhotoListResult{
+		Photos:     photos,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// lockAlbum locks an album row so concurrent changes to its order serialize
+func lockAlbum(ctx context.Context, tx *sqlx.Tx, albumID int64) error {
+	var id int64
+	err := tx.GetContext(ctx, &id, `SELECT id FROM albums WHERE id = $1 FOR UPDATE`, albumID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postgresql.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// touchAlbum marks an album as updated after its photos changed
+func touchAlbum(ctx context.Context, tx *sqlx.Tx, albumID int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE albums SET updated_at = NOW() WHERE id = $1`, albumID)
+	return err
+}
+
+// Helper function for converting pointer to sql.NullString
+func toNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
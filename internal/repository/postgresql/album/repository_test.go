@@ -0,0 +1,156 @@
+package album
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+func TestMovePhoto(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewAlbumRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+
+	photos := make([]int64, 4)
+	for i := range photos {
+		photos[i] = pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+	}
+	a, b, c, d := photos[0], photos[1], photos[2], photos[3]
+	outside := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+
+	tests := []struct {
+		name      string
+		photoID   int64
+		position  int
+		expect    []int64
+		expectErr error
+	}{
+		{"Move forward", a, 3, []int64{b, c, a, d}, nil},
+		{"Move backward", d, 2, []int64{a, d, b, c}, nil},
+		{"Same position", b, 2, []int64{a, b, c, d}, nil},
+		{"Past the end", b, 10, []int64{a, c, d, b}, nil},
+		{"Before the start", c, 0, []int64{c, a, b, d}, nil},
+		{"Photo not in the album", outside, 1, []int64{a, b, c, d}, postgresql.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			albumID, err := repo.Create(ctx, &CreateParams{UserID: ownerID, Title: "Album", Visibility: model.PhotoVisibilityPublic})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if _, err := repo.AddPhotos(ctx, albumID, photos); err != nil {
+				t.Fatalf("AddPhotos() error = %v", err)
+			}
+
+			err = repo.MovePhoto(ctx, albumID, tt.photoID, tt.position)
+			if !errors.Is(err, tt.expectErr) || (tt.expectErr == nil && err != nil) {
+				t.Fatalf("MovePhoto() error = %v, expected %v", err, tt.expectErr)
+			}
+
+			result, err := repo.ListPhotos(ctx, albumID, true, 1, 20)
+			if err != nil {
+				t.Fatalf("ListPhotos() error = %v", err)
+			}
+			got := make([]int64, len(result.Photos))
+			for i, p := range result.Photos {
+				got[i] = p.ID
+			}
+			if len(got) != len(tt.expect) {
+				t.Fatalf("ListPhotos() = %v, expected %v", got, tt.expect)
+			}
+			for i := range got {
+				if got[i] != tt.expect[i] {
+					t.Errorf("ListPhotos() = %v, expected %v", got, tt.expect)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestAlbumCoverAndVisibility(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewAlbumRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+
+	photo := func(thumbnail string, update string) int64 {
+		id := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+		pgtest.Exec(t, db, `UPDATE photos SET thumbnail_path = $1`+update+` WHERE id = $2`, thumbnail, id)
+		return id
+	}
+	private := photo("private.jpg", ", visibility = 'private'")
+	first := photo("first.jpg", "")
+	cover := photo("cover.jpg", "")
+	trashed := photo("trashed.jpg", ", deleted_at = NOW()")
+
+	albumID, err := repo.Create(ctx, &CreateParams{UserID: ownerID, Title: "Album", Visibility: model.PhotoVisibilityPublic})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := repo.AddPhotos(ctx, albumID, []int64{private, first, cover, trashed}); err != nil {
+		t.Fatalf("AddPhotos() error = %v", err)
+	}
+
+	check := func(step string, ownerView bool, expectCount int, expectCover string) {
+		t.Helper()
+		a, err := repo.GetByID(ctx, albumID, ownerView)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if a.PhotoCount != expectCount {
+			t.Errorf("%s: photo_count = %d, expected %d", step, a.PhotoCount, expectCount)
+		}
+		if a.CoverPath.String != expectCover {
+			t.Errorf("%s: cover_path = %q, expected %q", step, a.CoverPath.String, expectCover)
+		}
+	}
+	setCover := func(photoID *int64) {
+		t.Helper()
+		err := repo.Update(ctx, albumID, &UpdateParams{Title: "Album", CoverPhotoID: photoID, Visibility: model.PhotoVisibilityPublic})
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	// Without a chosen cover the first photo the viewer can see is used
+	check("Owner, no cover", true, 3, "private.jpg")
+	check("Public, no cover", false, 2, "first.jpg")
+
+	setCover(&cover)
+	check("Owner, chosen cover", true, 3, "cover.jpg")
+	check("Public, chosen cover", false, 2, "cover.jpg")
+
+	// A cover hidden from the viewer falls back to the first visible photo
+	setCover(&private)
+	check("Owner, private cover", true, 3, "private.jpg")
+	check("Public, private cover", false, 2, "first.jpg")
+
+	// Removing the cover clears it and closes the gap in the order
+	setCover(&first)
+	if removed, err := repo.RemovePhotos(ctx, albumID, []int64{first}); err != nil || removed != 1 {
+		t.Fatalf("RemovePhotos() = %d, %v, expected 1 removed", removed, err)
+	}
+	a, err := repo.GetByID(ctx, albumID, true)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if a.CoverPhotoID.Valid {
+		t.Errorf("cover_photo_id = %d after removing the cover, expected none", a.CoverPhotoID.Int64)
+	}
+	check("Public, cover removed", false, 1, "cover.jpg")
+
+	var positions []int
+	if err := db.Select(&positions, `SELECT position FROM album_photos WHERE album_id = $1 ORDER BY position`, albumID); err != nil {
+		t.Fatalf("select positions: %v", err)
+	}
+	if len(positions) != 3 || positions[0] != 1 || positions[1] != 2 || positions[2] != 3 {
+		t.Errorf("positions = %v, expected [1 2 3]", positions)
+	}
+}
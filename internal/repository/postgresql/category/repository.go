@@ -70,7 +70,7 @@ func (r *CategoryRepository) List(ctx context.Context, params ListParams) (*List
 	query := `
 		SELECT c.*, COALESCE(COUNT(p.id), 0) as photo_count
 		FROM categories c
-		LEFT JOIN photos p ON p.category_id = c.id AND p.status = 'approved'
+		LEFT JOIN photos p ON p.category_id = c.id AND ` + postgresql.PublicPhotoFilter("p") + `
 		GROUP BY c.id
 		ORDER BY c.sort_order ASC, c.id ASC
 		LIMIT $1 OFFSET $2
@@ -96,7 +96,7 @@ func (r *CategoryRepository) GetByID(ctx context.Context, id int32) (*model.Cate
 	query := `
 		SELECT c.*, COALESCE(COUNT(p.id), 0) as photo_count
 		FROM categories c
-		LEFT JOIN photos p ON p.category_id = c.id AND p.status = 'approved'
+		LEFT JOIN photos p ON p.category_id = c.id AND ` + postgresql.PublicPhotoFilter("p") + `
 		WHERE c.id = $1
 		GROUP BY c.id
 	`
@@ -200,10 +200,10 @@ func (r *CategoryRepository) Exists(ctx context.Context, id int32) (bool, error)
 	return exists, err
 }
 
-// GetPhotoCount returns the number of approved photos in a category
+// GetPhotoCount returns the number of approved public photos in a category
 func (r *CategoryRepository) GetPhotoCount(ctx context.Context, id int32) (int, error) {
 	var count int
-	err := r.DB().GetContext(ctx, &count, `SELECT COUNT(*) FROM photos WHERE category_id = $1 AND status = 'approved'`, id)
+	err := r.DB().GetContext(ctx, &count, `SELECT COUNT(*) FROM photos WHERE category_id = $1 AND `+postgresql.PublicPhotoFilter(""), id)
 	return count, err
 }
 
@@ -256,7 +256,7 @@ func (r *CategoryRepository) ListPhotos(ctx context.Context, params ListPhotosPa
 
 	// Count total
 	var total int64
-	err := r.DB().GetContext(ctx, &total, `SELECT COUNT(*) FROM photos WHERE category_id = $1 AND status = 'approved'`, params.CategoryID)
+	err := r.DB().GetContext(ctx, &total, `SELECT COUNT(*) FROM photos WHERE category_id = $1 AND `+postgresql.PublicPhotoFilter(""), params.CategoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -271,7 +271,7 @@ func (r *CategoryRepository) ListPhotos(ctx context.Context, params ListPhotosPa
 	// Query photos
 	query := fmt.Sprintf(`
 		SELECT * FROM photos
-		WHERE category_id = $1 AND status = 'approved'
+		WHERE category_id = $1 AND `+postgresql.PublicPhotoFilter("")+`
 		ORDER BY %s %s
 		LIMIT $2 OFFSET $3
 	`, params.SortBy, params.SortOrder)
@@ -14,10 +14,11 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-// Comment represents a photo comment
+// Comment represents a comment on a photo or a photo series
 type Comment struct {
 	ID         int64          `db:"id" json:"id"`
-	PhotoID    int64          `db:"photo_id" json:"photo_id"`
+	PhotoID    sql.NullInt64  `db:"photo_id" json:"-"`
+	SeriesID   sql.NullInt64  `db:"series_id" json:"-"`
 	UserID     int64          `db:"user_id" json:"user_id"`
 	ParentID   sql.NullInt64  `db:"parent_id" json:"-"`
 	Content    string         `db:"content" json:"content"`
@@ -40,9 +41,23 @@ func NewCommentRepository(db *sqlx.DB) *CommentRepository {
 	}
 }
 
+// Target is what a comment is on, either a photo or a photo series
+type Target struct {
+	PhotoID  int64
+	SeriesID int64
+}
+
+// column returns the comment column referencing the target and its ID
+func (t Target) column() (string, int64) {
+	if t.SeriesID > 0 {
+		return "series_id", t.SeriesID
+	}
+	return "photo_id", t.PhotoID
+}
+
 // ListParams contains parameters for listing comments
 type ListParams struct {
-	PhotoID  int64
+	Target
 	ParentID *int64 // nil = top-level comments only
 	Page     int
 	PageSize int
@@ -58,7 +73,7 @@ type ListResult struct {
 	TotalPages int
 }
 
-// List retrieves comments for a photo
+// List retrieves comments for a photo or a series
 func (r *CommentRepository) List(ctx context.Context, params ListParams) (*ListResult, error) {
 	if params.Page < 1 {
 		params.Page = 1
@@ -75,8 +90,9 @@ func (r *CommentRepository) List(ctx context.Context, params ListParams) (*ListR
 	var args []interface{}
 	argIndex := 1
 
-	conditions = append(conditions, fmt.Sprintf("photo_id = $%d", argIndex))
-	args = append(args, params.PhotoID)
+	column, targetID := params.column()
+	conditions = append(conditions, fmt.Sprintf("%s = $%d", column, argIndex))
+	args = append(args, targetID)
 	argIndex++
 
 	conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
@@ -153,20 +169,21 @@ func (r *CommentRepository) GetByID(ctx context.Context, id int64) (*Comment, er
 }
 
 // Create creates a new comment
-func (r *CommentRepository) Create(ctx context.Context, photoID, userID int64, parentID *int64, content string) (*Comment, error) {
+func (r *CommentRepository) Create(ctx context.Context, target Target, userID int64, parentID *int64, content string) (*Comment, error) {
 	var comment Comment
-	query := `
-		INSERT INTO photo_comments (photo_id, user_id, parent_id, content)
+	column, targetID := target.column()
+	query := fmt.Sprintf(`
+		INSERT INTO photo_comments (%s, user_id, parent_id, content)
 		VALUES ($1, $2, $3, $4)
 		RETURNING *
-	`
+	`, column)
 
 	var parentIDValue sql.NullInt64
 	if parentID != nil {
 		parentIDValue = sql.NullInt64{Int64: *parentID, Valid: true}
 	}
 
-	err := r.DB().GetContext(ctx, &comment, query, photoID, userID, parentIDValue, content)
+	err := r.DB().GetContext(ctx, &comment, query, targetID, userID, parentIDValue, content)
 	if err != nil {
 		return nil, err
 	}
@@ -213,6 +230,15 @@ func (r *CommentRepository) Exists(ctx context.Context, id int64) (bool, error)
 	return exists, err
 }
 
+// ExistsOn checks if a comment exists on the given photo or series
+func (r *CommentRepository) ExistsOn(ctx context.Context, id int64, target Target) (bool, error) {
+	var exists bool
+	column, targetID := target.column()
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM photo_comments WHERE id = $1 AND %s = $2 AND status = 'visible')`, column)
+	err := r.DB().GetContext(ctx, &exists, query, id, targetID)
+	return exists, err
+}
+
 // IsOwnedBy checks if a comment is owned by a user
 func (r *CommentRepository) IsOwnedBy(ctx context.Context, commentID, userID int64) (bool, error) {
 	var isOwner bool
@@ -301,7 +327,7 @@ func (r *CommentRepository) GetReplies(ctx context.Context, parentID int64, limi
 // GetPhotoUserID gets the user ID of the photo owner
 func (r *CommentRepository) GetPhotoUserID(ctx context.Context, photoID int64) (int64, error) {
 	var userID int64
-	err := r.DB().GetContext(ctx, &userID, `SELECT user_id FROM photos WHERE id = $1`, photoID)
+	err := r.DB().GetContext(ctx, &userID, `SELECT user_id FROM photos WHERE id = $1 AND deleted_at IS NULL`, photoID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, postgresql.ErrNotFound
@@ -311,9 +337,26 @@ func (r *CommentRepository) GetPhotoUserID(ctx context.Context, photoID int64) (
 	return userID, nil
 }
 
-// PhotoExists checks if a photo exists
-func (r *CommentRepository) PhotoExists(ctx context.Context, photoID int64) (bool, error) {
+// PhotoExists checks if a photo exists and is visible to the user, i.e. it is
+// visible to everyone or the user owns it
+func (r *CommentRepository) PhotoExists(ctx context.Context, photoID, userID int64) (bool, error) {
+	var exists bool
+	err := r.DB().GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1 AND `+postgresql.VisibleToFilter("", "$2")+`)`, photoID, userID)
+	return exists, err
+}
+
+// SeriesExists checks if a photo series exists and is visible to the user,
+// i.e. one of its frames is visible to everyone or the user owns it
+func (r *CommentRepository) SeriesExists(ctx context.Context, seriesID, userID int64) (bool, error) {
 	var exists bool
-	err := r.DB().GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1)`, photoID)
+	err := r.DB().GetContext(ctx, &exists, `
+		SELECT EXISTS(
+			SELECT 1 FROM photo_series s
+			WHERE s.id = $1 AND (s.user_id = $2 OR EXISTS(
+				SELECT 1 FROM series_frames sf JOIN photos p ON p.id = sf.photo_id
+				WHERE sf.series_id = s.id AND `+postgresql.VisiblePhotoFilter("p")+`
+			))
+		)
+	`, seriesID, userID)
 	return exists, err
 }
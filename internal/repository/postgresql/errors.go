@@ -14,6 +14,18 @@ var (
 	ErrTokenExpired  = errors.New("refresh token expired")
 )
 
+// Review errors
+var (
+	ErrClaimNotHeld   = errors.New("review claim not held")
+	ErrAlreadyDecided = errors.New("reviewer already decided on this attempt")
+	ErrNotReviewable  = errors.New("photo is not awaiting manual review")
+)
+
+// Storage errors
+var (
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+)
+
 // Common errors
 var (
 	ErrNotFound     = errors.New("record not found")
@@ -0,0 +1,41 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PublicPhotoFilter returns the condition matching photos listed publicly:
+// approved, public, published and not in the trash. alias qualifies the
+// photos columns, empty for unqualified columns.
+func PublicPhotoFilter(alias string) string {
+	return qualify(alias, "status = 'approved'", "visibility = 'public'", "publish_at IS NULL", "deleted_at IS NULL")
+}
+
+// VisiblePhotoFilter returns the condition matching photos other users can
+// open: approved, published, not private and not in the trash. Unlike
+// PublicPhotoFilter it includes unlisted photos, which are reached by link.
+func VisiblePhotoFilter(alias string) string {
+	return qualify(alias, "status = 'approved'", "visibility <> 'private'", "publish_at IS NULL", "deleted_at IS NULL")
+}
+
+// VisibleToFilter returns the condition matching photos the user whose ID is
+// bound to the placeholder userArg can open: photos visible to everyone and
+// the user's own photos outside the trash.
+func VisibleToFilter(alias, userArg string) string {
+	prefix := ""
+	if alias != "" {
+		prefix = alias + "."
+	}
+	return fmt.Sprintf("(%s OR (%suser_id = %s AND %sdeleted_at IS NULL))", VisiblePhotoFilter(alias), prefix, userArg, prefix)
+}
+
+// qualify joins conditions on columns of a table alias with AND
+func qualify(alias string, conditions ...string) string {
+	if alias != "" {
+		for i, condition := range conditions {
+			conditions[i] = alias + "." + condition
+		}
+	}
+	return strings.Join(conditions, " AND ")
+}
@@ -0,0 +1,45 @@
+package postgresql
+
+import "testing"
+
+func TestPhotoFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		expected string
+	}{
+		{
+			name:     "Public unqualified",
+			filter:   PublicPhotoFilter(""),
+			expected: "status = 'approved' AND visibility = 'public' AND publish_at IS NULL AND deleted_at IS NULL",
+		},
+		{
+			name:     "Public qualified",
+			filter:   PublicPhotoFilter("p"),
+			expected: "p.status = 'approved' AND p.visibility = 'public' AND p.publish_at IS NULL AND p.deleted_at IS NULL",
+		},
+		{
+			name:     "Visible unqualified",
+			filter:   VisiblePhotoFilter(""),
+			expected: "status = 'approved' AND visibility <> 'private' AND publish_at IS NULL AND deleted_at IS NULL",
+		},
+		{
+			name:     "Visible qualified",
+			filter:   VisiblePhotoFilter("p"),
+			expected: "p.status = 'approved' AND p.visibility <> 'private' AND p.publish_at IS NULL AND p.deleted_at IS NULL",
+		},
+		{
+			name:     "Visible to user qualified",
+			filter:   VisibleToFilter("p", "$1"),
+			expected: "(p.status = 'approved' AND p.visibility <> 'private' AND p.publish_at IS NULL AND p.deleted_at IS NULL OR (p.user_id = $1 AND p.deleted_at IS NULL))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.filter != tt.expected {
+				t.Errorf("filter = %q, expected %q", tt.filter, tt.expected)
+			}
+		})
+	}
+}
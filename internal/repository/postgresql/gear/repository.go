@@ -0,0 +1,276 @@
+package gear
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// FocalLengthBounds are the lower bounds (in mm) of the focal length distribution buckets
+var FocalLengthBounds = []float64{24, 35, 70, 135, 200, 300, 400, 600}
+
+// GearRepository handles camera and lens statistics database operations
+type GearRepository struct {
+	*postgresql.BaseRepository
+}
+
+// NewGearRepository creates a new gear repository
+func NewGearRepository(db *sqlx.DB) *GearRepository {
+	return &GearRepository{
+		BaseRepository: postgresql.NewBaseRepository(db),
+	}
+}
+
+// ListParams contains parameters for listing gear
+type ListParams struct {
+	Type     model.GearType
+	Page     int
+	PageSize int
+	Keyword  string
+}
+
+// ListResult contains the result of listing gear
+type ListResult struct {
+	Items      []*model.GearItem
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
+
+// Summary contains aggregated numbers of a gear
+type Summary struct {
+	Name              string          `db:"name"`
+	PhotoCount        int             `db:"photo_count"`
+	PhotographerCount int             `db:"photographer_count"`
+	MedianISO         sql.NullFloat64 `db:"median_iso"`
+}
+
+// Photographer contains a user who used a gear and the number of photos
+type Photographer struct {
+	UserID     int64          `db:"user_id"`
+	Username   string         `db:"username"`
+	Avatar     sql.NullString `db:"avatar"`
+	PhotoCount int            `db:"photo_count"`
+}
+
+// columns returns the normalized key and name columns for a gear type
+func columns(gearType model.GearType) (keyCol, nameCol string) {
+	if gearType == model.GearTypeLens {
+		return "gear_lens_key", "gear_lens_name"
+	}
+	return "gear_camera_key", "gear_camera_name"
+}
+
+// List retrieves gear used in approved photos, most used first
+func (r *GearRepository) List(ctx context.Context, params ListParams) (*ListResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	keyCol, nameCol := columns(params.Type)
+
+	// Build WHERE clause
+	conditions := []string{
+		postgresql.PublicPhotoFilter(""),
+		keyCol + " IS NOT NULL",
+	}
+	var args []interface{}
+	argIndex := 1
+
+	if params.Keyword != "" {
+		conditions = append(conditions, fmt.Sprintf("%s ILIKE $%d", nameCol, argIndex))
+		args = append(args, "%"+params.Keyword+"%")
+		argIndex++
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total
+	countQuery := fmt.Sprintf("SELECT COUNT(DISTINCT %s) FROM photos %s", keyCol, whereClause)
+	var total int64
+	err := r.DB().GetContext(ctx, &total, countQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate pagination
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize > 0 {
+		totalPages++
+	}
+
+	// The most common spelling is used as display name
+	query := fmt.Sprintf(`
+		SELECT
+			%s AS key,
+			MODE() WITHIN GROUP (ORDER BY %s) AS name,
+			COUNT(*) AS photo_count
+		FROM photos
+		%s
+		GROUP BY %s
+		ORDER BY photo_count DESC, key ASC
+		LIMIT $%d OFFSET $%d
+	`, keyCol, nameCol, whereClause, keyCol, argIndex, argIndex+1)
+
+	args = append(args, params.PageSize, offset)
+
+	var items []*model.GearItem
+	err = r.DB().SelectContext(ctx, &items, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResult{
+		Items:      items,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// GetSummary retrieves aggregated numbers of a gear over approved photos
+func (r *GearRepository) GetSummary(ctx context.Context, gearType model.GearType, key string) (*Summary, error) {
+	keyCol, nameCol := columns(gearType)
+
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE(MODE() WITHIN GROUP (ORDER BY %s), '') AS name,
+			COUNT(*) AS photo_count,
+			COUNT(DISTINCT user_id) AS photographer_count,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY exif_iso) AS median_iso
+		FROM photos
+		WHERE `+postgresql.PublicPhotoFilter("")+` AND %s = $1
+	`, nameCol, keyCol)
+
+	var summary Summary
+	err := r.DB().GetContext(ctx, &summary, query, key)
+	if err != nil {
+		return nil, err
+	}
+	if summary.PhotoCount == 0 {
+		return nil, postgresql.ErrNotFound
+	}
+
+	return &summary, nil
+}
+
+// GetFocalLengthDistribution counts approved photos per focal length bucket.
+// The result has len(FocalLengthBounds)+1 entries, index 0 is below the first bound.
+func (r *GearRepository) GetFocalLengthDistribution(ctx context.Context, gearType model.GearType, key string) ([]int, error) {
+	keyCol, _ := columns(gearType)
+
+	bounds := make([]string, len(FocalLengthBounds))
+	for i, b := range FocalLengthBounds {
+		bounds[i] = fmt.Sprintf("%g", b)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT WIDTH_BUCKET(exif_focal_length_35mm_num, ARRAY[%s]::DOUBLE PRECISION[]) AS bucket, COUNT(*) AS count
+		FROM photos
+		WHERE `+postgresql.PublicPhotoFilter("")+` AND %s = $1 AND exif_focal_length_35mm_num IS NOT NULL
+		GROUP BY bucket
+	`, strings.Join(bounds, ","), keyCol)
+
+	var rows []struct {
+		Bucket int `db:"bucket"`
+		Count  int `db:"count"`
+	}
+	err := r.DB().SelectContext(ctx, &rows, query, key)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, len(FocalLengthBounds)+1)
+	for _, row := range rows {
+		if row.Bucket >= 0 && row.Bucket < len(counts) {
+			counts[row.Bucket] = row.Count
+		}
+	}
+
+	return counts, nil
+}
+
+// ListTopPhotographers retrieves users with the most approved photos taken with a gear
+func (r *GearRepository) ListTopPhotographers(ctx context.Context, gearType model.GearType, key string, limit int) ([]*Photographer, error) {
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	keyCol, _ := columns(gearType)
+
+	query := fmt.Sprintf(`
+		SELECT p.user_id, u.username, u.avatar, COUNT(*) AS photo_count
+		FROM photos p
+		INNER JOIN users u ON u.id = p.user_id
+		WHERE `+postgresql.PublicPhotoFilter("p")+` AND p.%s = $1
+		GROUP BY p.user_id, u.username, u.avatar
+		ORDER BY photo_count DESC, p.user_id ASC
+		LIMIT $2
+	`, keyCol)
+
+	var photographers []*Photographer
+	err := r.DB().SelectContext(ctx, &photographers, query, key, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return photographers, nil
+}
+
+// UnnormalizedPhoto contains the EXIF gear fields of a photo stored before
+// uploads wrote its gear columns
+type UnnormalizedPhoto struct {
+	ID              int64          `db:"id"`
+	ExifCameraMake  sql.NullString `db:"exif_camera_make"`
+	ExifCameraModel sql.NullString `db:"exif_camera_model"`
+	ExifLensModel   sql.NullString `db:"exif_lens_model"`
+}
+
+// ListUnnormalized retrieves photos after afterID that have EXIF camera or
+// lens models but no gear columns, including photos in the trash. Photos
+// whose models normalize to nothing keep matching, so callers page by ID.
+func (r *GearRepository) ListUnnormalized(ctx context.Context, afterID int64, limit int) ([]*UnnormalizedPhoto, error) {
+	var photos []*UnnormalizedPhoto
+	err := r.DB().SelectContext(ctx, &photos, `
+		SELECT id, exif_camera_make, exif_camera_model, exif_lens_model
+		FROM photos
+		WHERE id > $1
+			AND gear_camera_key IS NULL AND gear_lens_key IS NULL
+			AND (exif_camera_model IS NOT NULL OR exif_lens_model IS NOT NULL)
+		ORDER BY id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return photos, nil
+}
+
+// SetGear records the normalized gear of a photo. Empty names and keys are
+// stored as NULL.
+func (r *GearRepository) SetGear(ctx context.Context, photoID int64, cameraKey, cameraName, lensKey, lensName string) error {
+	_, err := r.DB().ExecContext(ctx, `
+		UPDATE photos
+		SET gear_camera_key = NULLIF($2, ''),
+			gear_camera_name = NULLIF($3, ''),
+			gear_lens_key = NULLIF($4, ''),
+			gear_lens_name = NULLIF($5, '')
+		WHERE id = $1
+	`, photoID, cameraKey, cameraName, lensKey, lensName)
+	return err
+}
@@ -0,0 +1,75 @@
+package postgresql
+
+import (
+	"fmt"
+	"math"
+)
+
+// earthRadiusKm is the mean Earth radius used for distance calculations
+const earthRadiusKm = 6371.0
+
+// kmPerDegree is the length of a degree of latitude
+const kmPerDegree = 111.045
+
+// DistanceExpr returns a haversine distance expression (in km) between the
+// latCol/lngCol columns and the point bound to the two placeholders
+func DistanceExpr(latCol, lngCol string, latArg, lngArg int) string {
+	return fmt.Sprintf(`(%f * 2 * ASIN(SQRT(
+		POWER(SIN(RADIANS(%s - $%d) / 2), 2) +
+		COS(RADIANS($%d)) * COS(RADIANS(%s)) *
+		POWER(SIN(RADIANS(%s - $%d) / 2), 2)
+	)))`, earthRadiusKm, latCol, latArg, latArg, latCol, lngCol, lngArg)
+}
+
+// LongitudeRange returns the condition limiting lngCol to the range from
+// minLng to maxLng, bound from argIndex on. A range whose minLng is greater
+// than maxLng crosses the antimeridian.
+func LongitudeRange(lngCol string, minLng, maxLng float64, argIndex int) (string, []interface{}) {
+	args := []interface{}{minLng, maxLng}
+	if minLng <= maxLng {
+		return fmt.Sprintf("%s BETWEEN $%d AND $%d", lngCol, argIndex, argIndex+1), args
+	}
+	return fmt.Sprintf("(%s >= $%d OR %s <= $%d)", lngCol, argIndex, lngCol, argIndex+1), args
+}
+
+// RadiusConditions returns the conditions limiting latCol/lngCol to radiusKm
+// around a point, with placeholders numbered from argIndex. A coarse bounding
+// box comes first so a location index can be used, wrapping around the
+// antimeridian, followed by the exact great-circle distance. Returns the
+// conditions, their args and the next placeholder index.
+func RadiusConditions(latCol, lngCol string, lat, lng, radiusKm float64, argIndex int) ([]string, []interface{}, int) {
+	latDelta := radiusKm / kmPerDegree
+	lngDelta := 180.0
+	if cos := math.Cos(lat * math.Pi / 180); cos > 0.0001 {
+		lngDelta = math.Min(radiusKm/(kmPerDegree*cos), 180.0)
+	}
+
+	conditions := []string{fmt.Sprintf("%s BETWEEN $%d AND $%d", latCol, argIndex, argIndex+1)}
+	args := []interface{}{lat - latDelta, lat + latDelta}
+	argIndex += 2
+
+	// Near the poles the box spans every longitude
+	if lngDelta < 180.0 {
+		condition, lngArgs := LongitudeRange(lngCol, wrapLongitude(lng-lngDelta), wrapLongitude(lng+lngDelta), argIndex)
+		conditions = append(conditions, condition)
+		args = append(args, lngArgs...)
+		argIndex += 2
+	}
+
+	conditions = append(conditions, fmt.Sprintf("%s <= $%d", DistanceExpr(latCol, lngCol, argIndex, argIndex+1), argIndex+2))
+	args = append(args, lat, lng, radiusKm)
+	argIndex += 3
+
+	return conditions, args, argIndex
+}
+
+// wrapLongitude normalizes a longitude into [-180, 180]
+func wrapLongitude(lng float64) float64 {
+	for lng < -180 {
+		lng += 360
+	}
+	for lng > 180 {
+		lng -= 360
+	}
+	return lng
+}
@@ -0,0 +1,83 @@
+package postgresql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRadiusConditions(t *testing.T) {
+	tests := []struct {
+		name            string
+		lat, lng        float64
+		radiusKm        float64
+		expectLngFilter string
+		expectNext      int
+	}{
+		{
+			name:            "Radius away from the antimeridian",
+			lat:             40,
+			lng:             116,
+			radiusKm:        50,
+			expectLngFilter: "s.longitude BETWEEN $5 AND $6",
+			expectNext:      10,
+		},
+		{
+			name:            "Radius crossing the antimeridian wraps",
+			lat:             -17,
+			lng:             -179.5,
+			radiusKm:        200,
+			expectLngFilter: "(s.longitude >= $5 OR s.longitude <= $6)",
+			expectNext:      10,
+		},
+		{
+			name:       "Radius at the pole skips the longitude filter",
+			lat:        -90,
+			lng:        0,
+			radiusKm:   100,
+			expectNext: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, args, next := RadiusConditions("s.latitude", "s.longitude", tt.lat, tt.lng, tt.radiusKm, 3)
+
+			if conditions[0] != "s.latitude BETWEEN $3 AND $4" {
+				t.Errorf("latitude condition = %q", conditions[0])
+			}
+			var lngFilter string
+			if len(conditions) == 3 {
+				lngFilter = conditions[1]
+			}
+			if lngFilter != tt.expectLngFilter {
+				t.Errorf("longitude filter = %q, expected %q", lngFilter, tt.expectLngFilter)
+			}
+			if !strings.Contains(conditions[len(conditions)-1], "ASIN") {
+				t.Error("missing great-circle distance condition")
+			}
+			if next != tt.expectNext || len(args) != next-3 {
+				t.Errorf("next arg index = %d with %d args, expected %d", next, len(args), tt.expectNext)
+			}
+		})
+	}
+}
+
+func TestWrapLongitude(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected float64
+	}{
+		{0, 0},
+		{180, 180},
+		{-180, -180},
+		{181, -179},
+		{-181, 179},
+		{540, 180},
+	}
+
+	for _, tt := range tests {
+		if got := wrapLongitude(tt.input); got != tt.expected {
+			t.Errorf("wrapLongitude(%v) = %v, expected %v", tt.input, got, tt.expected)
+		}
+	}
+}
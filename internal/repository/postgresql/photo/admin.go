@@ -3,6 +3,7 @@ package photo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -10,15 +11,33 @@ import (
 
 	"QuanPhotos/internal/model"
 	"QuanPhotos/internal/repository/postgresql"
+
+	"github.com/jmoiron/sqlx"
 )
 
 // ReviewListParams contains parameters for listing pending reviews
 type ReviewListParams struct {
 	Page     int
 	PageSize int
-	Status   string // pending, ai_passed, ai_rejected
+	Status   string // pending, ai_passed, ai_rejected, all; empty or all for photos awaiting manual review
+
+	ClaimedBy int64 // Only photos actively claimed by this reviewer
+	Unclaimed bool  // Only photos without an active claim
+
+	CategoryReviewerID int64 // Only photos in categories assigned to this reviewer
+
+	Stage       model.ReviewStage // Only photos in this review stage, empty for any
+	UndecidedBy int64             // Only photos this reviewer has not decided on in their current stage
 }
 
+// undecidedCondition matches photos the reviewer bound to the placeholder has
+// not decided on in the current attempt and review stage
+const undecidedCondition = `NOT EXISTS (
+	SELECT 1 FROM photo_reviews pr
+	WHERE pr.photo_id = p.id AND pr.reviewer_id = $%d AND pr.review_type = 'manual'
+		AND pr.attempt = p.attempt AND pr.stage = p.review_stage
+)`
+
 // ReviewListResult contains the result of listing pending reviews
 type ReviewListResult struct {
 	Photos     []*model.Photo
@@ -40,31 +59,56 @@ func (r *PhotoRepository) ListPendingReviews(ctx context.Context, params ReviewL
 		params.PageSize = 100
 	}
 
-	// Build WHERE clause
-	var conditions []string
+	// Build WHERE clause, photos in the trash are not reviewed
+	conditions := []string{"deleted_at IS NULL"}
 	var args []interface{}
 	argIndex := 1
 
-	// Default: show ai_passed photos (waiting for manual review)
-	if params.Status == "" {
-		params.Status = string(model.PhotoStatusAIPassed)
-	}
-
-	if params.Status != "all" {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
-		args = append(args, params.Status)
-		argIndex++
-	} else {
-		// Show all pending/ai_passed/ai_rejected
+	// Default and "all": photos waiting for manual review. Pending photos are
+	// included because they stay pending when the AI service is unavailable,
+	// photos rejected by screening are listed last.
+	if params.Status == "" || params.Status == "all" {
 		conditions = append(conditions, fmt.Sprintf("status IN ($%d, $%d, $%d)", argIndex, argIndex+1, argIndex+2))
 		args = append(args, model.PhotoStatusPending, model.PhotoStatusAIPassed, model.PhotoStatusAIRejected)
 		argIndex += 3
+	} else {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, params.Status)
+		argIndex++
+	}
+
+	if params.ClaimedBy > 0 {
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT photo_id FROM review_claims WHERE reviewer_id = $%d AND expires_at > NOW())", argIndex))
+		args = append(args, params.ClaimedBy)
+		argIndex++
+	}
+
+	if params.Unclaimed {
+		conditions = append(conditions, "id NOT IN (SELECT photo_id FROM review_claims WHERE expires_at > NOW())")
+	}
+
+	if params.CategoryReviewerID > 0 {
+		conditions = append(conditions, fmt.Sprintf("category_id IN (SELECT category_id FROM reviewer_categories WHERE reviewer_id = $%d)", argIndex))
+		args = append(args, params.CategoryReviewerID)
+		argIndex++
+	}
+
+	if params.Stage != "" {
+		conditions = append(conditions, fmt.Sprintf("review_stage = $%d", argIndex))
+		args = append(args, params.Stage)
+		argIndex++
+	}
+
+	if params.UndecidedBy > 0 {
+		conditions = append(conditions, fmt.Sprintf(undecidedCondition, argIndex))
+		args = append(args, params.UndecidedBy)
+		argIndex++
 	}
 
 	whereClause := "WHERE " + strings.Join(conditions, " AND ")
 
 	// Count total
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM photos %s", whereClause)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM photos p %s", whereClause)
 	var total int64
 	err := r.DB().GetContext(ctx, &total, countQuery, args...)
 	if err != nil {
@@ -80,11 +124,11 @@ func (r *PhotoRepository) ListPendingReviews(ctx context.Context, params ReviewL
 
 	// Query photos
 	query := fmt.Sprintf(`
-		SELECT * FROM photos
+		SELECT * FROM photos p
 		%s
-		ORDER BY created_at ASC
+		ORDER BY status = '%s' ASC, created_at ASC
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argIndex, argIndex+1)
+	`, whereClause, model.PhotoStatusAIRejected, argIndex, argIndex+1)
 
 	args = append(args, params.PageSize, offset)
 
@@ -103,29 +147,218 @@ func (r *PhotoRepository) ListPendingReviews(ctx context.Context, params ReviewL
 	}, nil
 }
 
-// ReviewPhoto performs a manual review on a photo
-func (r *PhotoRepository) ReviewPhoto(ctx context.Context, photoID, reviewerID int64, action, reason string) error {
+// ReviewOutcome is the effect of a manual decision under the review policy
+type ReviewOutcome struct {
+	Status   model.PhotoStatus // Final status, empty while the photo awaits more decisions
+	Escalate bool              // Move the photo to the senior queue
+}
+
+// ReviewParams contains parameters for a manual review decision
+type ReviewParams struct {
+	PhotoID    int64
+	ReviewerID int64
+	Action     string // approve, reject, escalate
+	Reason     string
+	ReasonIDs  []int32 // Rejection reasons linked to the review
+
+	// Resolve applies the review policy to the actions taken on the current
+	// attempt in the photo's review stage, oldest first, including this one
+	Resolve func(stage model.ReviewStage, actions []string) ReviewOutcome
+}
+
+// ReviewResult contains the state of a photo after a manual decision
+type ReviewResult struct {
+	ReviewOutcome
+	Stage     model.ReviewStage // Stage the decision was made in
+	Decisions int               // Decisions made on the current attempt in that stage
+}
+
+// ReviewPhoto records a manual decision on a photo and applies its outcome.
+// The reviewer must hold an active claim, which is consumed by the decision;
+// returns ErrClaimNotHeld otherwise, ErrNotReviewable if the photo was decided
+// meanwhile, and ErrAlreadyDecided if the reviewer already decided on the
+// current attempt in the photo's review stage.
+func (r *PhotoRepository) ReviewPhoto(ctx context.Context, params ReviewParams) (*ReviewResult, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Consume the claim, the row lock serializes concurrent decisions
+	claimResult, err := tx.ExecContext(ctx,
+		`DELETE FROM review_claims WHERE photo_id = $1 AND reviewer_id = $2 AND expires_at > NOW()`,
+		params.PhotoID, params.ReviewerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := claimResult.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if claimed == 0 {
+		return nil, postgresql.ErrClaimNotHeld
+	}
+
+	var current struct {
+		Status  model.PhotoStatus `db:"status"`
+		Attempt int               `db:"attempt"`
+		Stage   model.ReviewStage `db:"review_stage"`
+	}
+	err = tx.GetContext(ctx, &current,
+		`SELECT status, attempt, review_stage FROM photos WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, params.PhotoID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Status changes made outside the queue do not release claims, so
+	// check under the row lock that the photo still awaits a decision
+	if !current.Status.AwaitsReview() {
+		return nil, postgresql.ErrNotReviewable
+	}
+
+	// A second opinion must come from a different reviewer
+	var decided bool
+	err = tx.GetContext(ctx, &decided, `
+		SELECT EXISTS(
+			SELECT 1 FROM photo_reviews
+			WHERE photo_id = $1 AND reviewer_id = $2 AND review_type = 'manual'
+				AND attempt = $3 AND stage = $4
+		)
+	`, params.PhotoID, params.ReviewerID, current.Attempt, current.Stage)
+	if err != nil {
+		return nil, err
+	}
+	if decided {
+		return nil, postgresql.ErrAlreadyDecided
+	}
+
+	// Insert review record
+	insertQuery := `
+		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, reason, attempt, stage)
+		VALUES ($1, $2, 'manual', $3, $4, $5, $6)
+		RETURNING id
+	`
+	var reviewID int64
+	err = tx.QueryRowContext(ctx, insertQuery,
+		params.PhotoID, params.ReviewerID, params.Action, toNullString(&params.Reason), current.Attempt, current.Stage,
+	).Scan(&reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reasonID := range params.ReasonIDs {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO photo_review_reasons (review_id, reason_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			reviewID, reasonID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var actions []string
+	err = tx.SelectContext(ctx, &actions, `
+		SELECT action FROM photo_reviews
+		WHERE photo_id = $1 AND review_type = 'manual' AND attempt = $2 AND stage = $3
+		ORDER BY created_at ASC, id ASC
+	`, params.PhotoID, current.Attempt, current.Stage)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := params.Resolve(current.Stage, actions)
+	switch {
+	case outcome.Status == model.PhotoStatusApproved:
+		_, err = tx.ExecContext(ctx,
+			`UPDATE photos SET status = $1, approved_at = NOW(), updated_at = NOW() WHERE id = $2`,
+			outcome.Status, params.PhotoID,
+		)
+	case outcome.Status != "":
+		_, err = tx.ExecContext(ctx,
+			`UPDATE photos SET status = $1, updated_at = NOW() WHERE id = $2`,
+			outcome.Status, params.PhotoID,
+		)
+	case outcome.Escalate:
+		_, err = tx.ExecContext(ctx,
+			`UPDATE photos SET review_stage = $1, updated_at = NOW() WHERE id = $2`,
+			model.ReviewStageSenior, params.PhotoID,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &ReviewResult{
+		ReviewOutcome: outcome,
+		Stage:         current.Stage,
+		Decisions:     len(actions),
+	}, nil
+}
+
+// OverturnRejection approves a rejected photo on appeal. The decision is
+// recorded as a senior review linked to the appeal ticket. Returns ErrNotFound
+// if the photo does not exist or is not rejected.
+func (r *PhotoRepository) OverturnRejection(ctx context.Context, photoID, reviewerID, ticketID int64, note string) error {
 	tx, err := r.DB().BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	// Determine new status
-	var newStatus model.PhotoStatus
-	if action == "approve" {
-		newStatus = model.PhotoStatusApproved
-	} else {
-		newStatus = model.PhotoStatusRejected
+	var attempt int
+	err = tx.QueryRowContext(ctx, `
+		UPDATE photos SET status = $1, approved_at = NOW(), updated_at = NOW()
+		WHERE id = $2 AND status IN ($3, $4) AND deleted_at IS NULL
+		RETURNING attempt
+	`, model.PhotoStatusApproved, photoID, model.PhotoStatusRejected, model.PhotoStatusAIRejected).Scan(&attempt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postgresql.ErrNotFound
+		}
+		return err
 	}
 
-	// Update photo status
-	updateQuery := `UPDATE photos SET status = $1, updated_at = NOW() WHERE id = $2`
-	if newStatus == model.PhotoStatusApproved {
-		updateQuery = `UPDATE photos SET status = $1, approved_at = NOW(), updated_at = NOW() WHERE id = $2`
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, reason, attempt, stage, ticket_id)
+		VALUES ($1, $2, 'manual', 'approve', $3, $4, $5, $6)
+	`, photoID, reviewerID, toNullString(&note), attempt, model.ReviewStageSenior, ticketID)
+	if err != nil {
+		return err
 	}
 
-	result, err := tx.ExecContext(ctx, updateQuery, newStatus, photoID)
+	return tx.Commit()
+}
+
+// ApplyAIReview records an AI review and moves a pending photo to ai_passed or ai_rejected.
+// Returns ErrNotFound if the photo does not exist or no longer awaits AI review.
+func (r *PhotoRepository) ApplyAIReview(ctx context.Context, photoID int64, passed bool, reason *string, aiResult []byte) error {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	newStatus := model.PhotoStatusAIRejected
+	action := "reject"
+	if passed {
+		newStatus = model.PhotoStatusAIPassed
+		action = "approve"
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE photos SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3 AND deleted_at IS NULL`,
+		newStatus, photoID, model.PhotoStatusPending,
+	)
 	if err != nil {
 		return err
 	}
@@ -138,20 +371,216 @@ func (r *PhotoRepository) ReviewPhoto(ctx context.Context, photoID, reviewerID i
 		return postgresql.ErrNotFound
 	}
 
-	// Insert review record
 	insertQuery := `
-		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, reason)
-		VALUES ($1, $2, 'manual', $3, $4)
+		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, reason, ai_result, attempt)
+		VALUES ($1, NULL, 'ai', $2, $3, $4, (SELECT attempt FROM photos WHERE id = $1))
+	`
+	// Passed as text, lib/pq would otherwise encode []byte as bytea
+	var resultJSON sql.NullString
+	if len(aiResult) > 0 {
+		resultJSON = sql.NullString{String: string(aiResult), Valid: true}
+	}
+	_, err = tx.ExecContext(ctx, insertQuery, photoID, action, toNullString(reason), resultJSON)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RecordScreening stores a quality screening result. Rejected photos still
+// pending are moved to ai_rejected with the given rejection reason codes;
+// other verdicts leave the status unchanged.
+func (r *PhotoRepository) RecordScreening(ctx context.Context, photoID int64, screening *model.QualityScreening, reason *string, reasonCodes []string) error {
+	aiResult, err := json.Marshal(screening)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	action := "approve"
+	switch screening.Verdict {
+	case model.ScreeningFlag:
+		action = "flag"
+	case model.ScreeningReject:
+		action = "reject"
+		_, err = tx.ExecContext(ctx,
+			`UPDATE photos SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3`,
+			model.PhotoStatusAIRejected, photoID, model.PhotoStatusPending,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	insertQuery := `
+		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, reason, ai_result, attempt)
+		VALUES ($1, NULL, 'ai', $2, $3, $4, (SELECT attempt FROM photos WHERE id = $1))
+		RETURNING id
 	`
-	_, err = tx.ExecContext(ctx, insertQuery, photoID, reviewerID, action, toNullString(&reason))
+	var reviewID int64
+	err = tx.QueryRowContext(ctx, insertQuery, photoID, action, toNullString(reason), string(aiResult)).Scan(&reviewID)
 	if err != nil {
 		return err
 	}
 
+	if action == "reject" && len(reasonCodes) > 0 {
+		query, args, err := sqlx.In(`
+			INSERT INTO photo_review_reasons (review_id, reason_id)
+			SELECT ?, id FROM rejection_reasons WHERE code IN (?)
+		`, reviewID, reasonCodes)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit()
 }
 
-// AdminDeletePhoto deletes a photo with reason (admin action)
+// GetScreeningMap retrieves the latest quality screening result of each photo
+func (r *PhotoRepository) GetScreeningMap(ctx context.Context, photoIDs []int64) (map[int64]*model.QualityScreening, error) {
+	screenings := make(map[int64]*model.QualityScreening)
+	if len(photoIDs) == 0 {
+		return screenings, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT DISTINCT ON (photo_id) photo_id, ai_result
+		FROM photo_reviews
+		WHERE photo_id IN (?) AND review_type = 'ai' AND ai_result->>'source' = ?
+		ORDER BY photo_id, created_at DESC
+	`, photoIDs, model.ScreeningSource)
+	if err != nil {
+		return nil, err
+	}
+	query = r.DB().Rebind(query)
+
+	var rows []struct {
+		PhotoID  int64  `db:"photo_id"`
+		AIResult []byte `db:"ai_result"`
+	}
+	if err := r.DB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		var screening model.QualityScreening
+		if err := json.Unmarshal(row.AIResult, &screening); err != nil {
+			continue
+		}
+		screenings[row.PhotoID] = &screening
+	}
+
+	return screenings, nil
+}
+
+// GetRejectionMap retrieves the latest rejection of the current attempt of each photo
+func (r *PhotoRepository) GetRejectionMap(ctx context.Context, photoIDs []int64) (map[int64]*model.PhotoRejection, error) {
+	rejections := make(map[int64]*model.PhotoRejection)
+	if len(photoIDs) == 0 {
+		return rejections, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT DISTINCT ON (pr.photo_id) pr.id, pr.photo_id, pr.review_type, pr.reason, pr.attempt, pr.created_at
+		FROM photo_reviews pr
+		INNER JOIN photos p ON p.id = pr.photo_id AND p.attempt = pr.attempt
+		WHERE pr.photo_id IN (?) AND pr.action = 'reject'
+		ORDER BY pr.photo_id, pr.created_at DESC, pr.id DESC
+	`, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+	query = r.DB().Rebind(query)
+
+	var rows []struct {
+		ID         int64          `db:"id"`
+		PhotoID    int64          `db:"photo_id"`
+		ReviewType string         `db:"review_type"`
+		Reason     sql.NullString `db:"reason"`
+		Attempt    int            `db:"attempt"`
+		CreatedAt  time.Time      `db:"created_at"`
+	}
+	if err := r.DB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return rejections, nil
+	}
+
+	reviewIDs := make([]int64, len(rows))
+	byReview := make(map[int64]*model.PhotoRejection, len(rows))
+	for i, row := range rows {
+		rejection := &model.PhotoRejection{
+			Attempt:    row.Attempt,
+			ReviewType: row.ReviewType,
+			Reasons:    []*model.RejectionReasonBrief{},
+			RejectedAt: row.CreatedAt.Format(time.RFC3339),
+		}
+		if row.Reason.Valid {
+			rejection.Note = &row.Reason.String
+		}
+		reviewIDs[i] = row.ID
+		byReview[row.ID] = rejection
+		rejections[row.PhotoID] = rejection
+	}
+
+	// Attach selected reasons
+	reasons, err := r.GetReviewReasonMap(ctx, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+	for reviewID, rejection := range byReview {
+		if selected, ok := reasons[reviewID]; ok {
+			rejection.Reasons = selected
+		}
+	}
+
+	return rejections, nil
+}
+
+// GetDecisionMap retrieves the manual decisions made on the current attempt of
+// multiple photos, oldest first
+func (r *PhotoRepository) GetDecisionMap(ctx context.Context, photoIDs []int64) (map[int64][]*model.ReviewDecision, error) {
+	decisions := make(map[int64][]*model.ReviewDecision)
+	if len(photoIDs) == 0 {
+		return decisions, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT pr.photo_id, pr.reviewer_id, pr.action, pr.stage, pr.reason, pr.created_at
+		FROM photo_reviews pr
+		INNER JOIN photos p ON p.id = pr.photo_id AND p.attempt = pr.attempt
+		WHERE pr.photo_id IN (?) AND pr.review_type = 'manual'
+		ORDER BY pr.created_at ASC, pr.id ASC
+	`, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+	query = r.DB().Rebind(query)
+
+	var rows []*model.ReviewDecision
+	if err := r.DB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, d := range rows {
+		decisions[d.PhotoID] = append(decisions[d.PhotoID], d)
+	}
+	return decisions, nil
+}
+
+// AdminDeletePhoto moves a photo to the trash with reason (admin action). The
+// takedown is recorded in the review history and the photo's metadata is kept
+// as a snapshot.
 func (r *PhotoRepository) AdminDeletePhoto(ctx context.Context, photoID, adminID int64, reason string) error {
 	tx, err := r.DB().BeginTxx(ctx, nil)
 	if err != nil {
@@ -160,27 +589,31 @@ func (r *PhotoRepository) AdminDeletePhoto(ctx context.Context, photoID, adminID
 	defer tx.Rollback()
 
 	// Check if photo exists
-	var exists bool
-	err = tx.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1)`, photoID)
+	var attempt int
+	err = tx.GetContext(ctx, &attempt, `SELECT attempt FROM photos WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, photoID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postgresql.ErrNotFound
+		}
 		return err
 	}
-	if !exists {
-		return postgresql.ErrNotFound
+
+	if err := snapshotDeleted(ctx, tx, photoID, adminID, &reason); err != nil {
+		return err
 	}
 
-	// Insert review record as rejection before deletion
+	// Insert takedown record
 	insertQuery := `
-		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, reason)
-		VALUES ($1, $2, 'manual', 'reject', $3)
+		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, reason, attempt)
+		VALUES ($1, $2, 'manual', 'delete', $3, $4)
 	`
-	_, err = tx.ExecContext(ctx, insertQuery, photoID, adminID, reason)
+	_, err = tx.ExecContext(ctx, insertQuery, photoID, adminID, reason, attempt)
 	if err != nil {
 		return err
 	}
 
-	// Delete photo
-	_, err = tx.ExecContext(ctx, `DELETE FROM photos WHERE id = $1`, photoID)
+	// Move photo to the trash
+	_, err = tx.ExecContext(ctx, `UPDATE photos SET deleted_at = NOW(), deleted_by = $2 WHERE id = $1`, photoID, adminID)
 	if err != nil {
 		return err
 	}
@@ -480,7 +913,7 @@ func (r *PhotoRepository) ListFeaturedPhotos(ctx context.Context, params Feature
 	countQuery := `
 		SELECT COUNT(*) FROM featured_photos fp
 		INNER JOIN photos p ON fp.photo_id = p.id
-		WHERE p.status = 'approved' AND (fp.expires_at IS NULL OR fp.expires_at > NOW())
+		WHERE ` + postgresql.PublicPhotoFilter("p") + ` AND (fp.expires_at IS NULL OR fp.expires_at > NOW())
 	`
 	var total int64
 	err := r.DB().GetContext(ctx, &total, countQuery)
@@ -499,7 +932,7 @@ func (r *PhotoRepository) ListFeaturedPhotos(ctx context.Context, params Feature
 	query := `
 		SELECT p.* FROM photos p
 		INNER JOIN featured_photos fp ON p.id = fp.photo_id
-		WHERE p.status = 'approved' AND (fp.expires_at IS NULL OR fp.expires_at > NOW())
+		WHERE ` + postgresql.PublicPhotoFilter("p") + ` AND (fp.expires_at IS NULL OR fp.expires_at > NOW())
 		ORDER BY fp.sort_order ASC, fp.featured_at DESC
 		LIMIT $1 OFFSET $2
 	`
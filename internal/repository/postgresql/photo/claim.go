@@ -0,0 +1,163 @@
+package photo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// ClaimParams contains parameters for claiming photos to review
+type ClaimParams struct {
+	ReviewerID int64
+	Count      int           // Photos to claim
+	MaxClaims  int           // Maximum active claims held by the reviewer
+	TTL        time.Duration // Lease duration
+
+	CategoryReviewerID int64             // Only photos in categories assigned to this reviewer
+	Stage              model.ReviewStage // Only photos in this review stage, empty for any
+}
+
+// ClaimReviews renews the reviewer's active claims and claims up to Count more
+// photos from the manual review queue, never exceeding MaxClaims in total.
+// Photos the reviewer already decided on are skipped, the senior queue is
+// served first and photos rejected by screening last. Returns the reviewer's
+// active claims.
+func (r *PhotoRepository) ClaimReviews(ctx context.Context, params ClaimParams) ([]*model.ReviewClaim, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	reviewerID := params.ReviewerID
+	seconds := int64(params.TTL / time.Second)
+
+	// Renew held claims
+	result, err := tx.ExecContext(ctx, `
+		UPDATE review_claims SET expires_at = NOW() + $1 * INTERVAL '1 second'
+		WHERE reviewer_id = $2 AND expires_at > NOW()
+	`, seconds, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	held, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := min(params.Count, params.MaxClaims-int(held)); limit > 0 {
+		scope := "AND " + fmt.Sprintf(undecidedCondition, 1)
+		args := []interface{}{reviewerID, seconds, model.PhotoStatusPending, model.PhotoStatusAIPassed, model.PhotoStatusAIRejected, limit}
+		if params.CategoryReviewerID > 0 {
+			args = append(args, params.CategoryReviewerID)
+			scope += fmt.Sprintf(" AND p.category_id IN (SELECT category_id FROM reviewer_categories WHERE reviewer_id = $%d)", len(args))
+		}
+		if params.Stage != "" {
+			args = append(args, params.Stage)
+			scope += fmt.Sprintf(" AND p.review_stage = $%d", len(args))
+		}
+
+		// Stale claims are taken over. A photo claimed concurrently by another
+		// reviewer conflicts on the primary key and is skipped.
+		query := fmt.Sprintf(`
+			INSERT INTO review_claims (photo_id, reviewer_id, claimed_at, expires_at)
+			SELECT p.id, $1, NOW(), NOW() + $2 * INTERVAL '1 second'
+			FROM photos p
+			LEFT JOIN review_claims c ON c.photo_id = p.id AND c.expires_at > NOW()
+			WHERE p.status IN ($3, $4, $5) AND p.deleted_at IS NULL AND c.photo_id IS NULL %s
+			ORDER BY p.review_stage = 'senior' DESC, p.status = $5 ASC, p.created_at ASC
+			LIMIT $6
+			ON CONFLICT (photo_id) DO UPDATE
+				SET reviewer_id = EXCLUDED.reviewer_id,
+					claimed_at = EXCLUDED.claimed_at,
+					expires_at = EXCLUDED.expires_at
+				WHERE review_claims.expires_at <= NOW()
+		`, scope)
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	var claims []*model.ReviewClaim
+	err = tx.SelectContext(ctx, &claims, `
+		SELECT * FROM review_claims
+		WHERE reviewer_id = $1 AND expires_at > NOW()
+		ORDER BY claimed_at ASC, photo_id ASC
+	`, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// ReleaseClaim gives up a reviewer's active claim on a photo
+func (r *PhotoRepository) ReleaseClaim(ctx context.Context, photoID, reviewerID int64) error {
+	result, err := r.DB().ExecContext(ctx, `
+		DELETE FROM review_claims
+		WHERE photo_id = $1 AND reviewer_id = $2 AND expires_at > NOW()
+	`, photoID, reviewerID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrClaimNotHeld
+	}
+
+	return nil
+}
+
+// ListActiveClaims retrieves unexpired claims, optionally of a single reviewer
+func (r *PhotoRepository) ListActiveClaims(ctx context.Context, reviewerID int64) ([]*model.ReviewClaim, error) {
+	query := `SELECT * FROM review_claims WHERE expires_at > NOW()`
+	args := []interface{}{}
+	if reviewerID > 0 {
+		query += ` AND reviewer_id = $1`
+		args = append(args, reviewerID)
+	}
+	query += ` ORDER BY reviewer_id ASC, claimed_at ASC`
+
+	var claims []*model.ReviewClaim
+	if err := r.DB().SelectContext(ctx, &claims, query, args...); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// GetActiveClaimMap retrieves unexpired claims for multiple photos
+func (r *PhotoRepository) GetActiveClaimMap(ctx context.Context, photoIDs []int64) (map[int64]*model.ReviewClaim, error) {
+	claims := make(map[int64]*model.ReviewClaim)
+	if len(photoIDs) == 0 {
+		return claims, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM review_claims WHERE photo_id IN (?) AND expires_at > NOW()`, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+	query = r.DB().Rebind(query)
+
+	var rows []*model.ReviewClaim
+	if err := r.DB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, c := range rows {
+		claims[c.PhotoID] = c
+	}
+	return claims, nil
+}
@@ -0,0 +1,202 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+// claimFixture is a review queue with two pending photos and two reviewers
+type claimFixture struct {
+	db       *sqlx.DB
+	repo     *PhotoRepository
+	alice    int64
+	bob      int64
+	photoIDs []int64
+}
+
+func newClaimFixture(t *testing.T) *claimFixture {
+	db := pgtest.Open(t)
+	uploader := pgtest.CreateUser(t, db, "uploader", "user")
+	f := &claimFixture{
+		db:    db,
+		repo:  NewPhotoRepository(db),
+		alice: pgtest.CreateUser(t, db, "alice", "reviewer"),
+		bob:   pgtest.CreateUser(t, db, "bob", "reviewer"),
+	}
+	for range 2 {
+		f.photoIDs = append(f.photoIDs, pgtest.CreatePhoto(t, db, uploader, string(model.PhotoStatusPending)))
+	}
+	return f
+}
+
+// claim claims up to count photos for a reviewer and returns the held photo IDs
+func (f *claimFixture) claim(t *testing.T, reviewerID int64, count, maxClaims int) []int64 {
+	t.Helper()
+
+	claims, err := f.repo.ClaimReviews(context.Background(), ClaimParams{
+		ReviewerID: reviewerID,
+		Count:      count,
+		MaxClaims:  maxClaims,
+		TTL:        time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("ClaimReviews() error = %v", err)
+	}
+
+	ids := make([]int64, len(claims))
+	for i, c := range claims {
+		ids[i] = c.PhotoID
+	}
+	return ids
+}
+
+// review records an approval by a reviewer
+func (f *claimFixture) review(reviewerID, photoID int64) (*ReviewResult, error) {
+	return f.repo.ReviewPhoto(context.Background(), ReviewParams{
+		PhotoID:    photoID,
+		ReviewerID: reviewerID,
+		Action:     "approve",
+		Resolve: func(stage model.ReviewStage, actions []string) ReviewOutcome {
+			return ReviewOutcome{Status: model.PhotoStatusApproved}
+		},
+	})
+}
+
+func TestClaimReviewsHeldClaimRefused(t *testing.T) {
+	f := newClaimFixture(t)
+
+	if got := f.claim(t, f.alice, 10, 10); len(got) != 2 {
+		t.Fatalf("alice claimed %v, expected both photos", got)
+	}
+	if got := f.claim(t, f.bob, 10, 10); len(got) != 0 {
+		t.Errorf("bob claimed %v, expected none while alice holds the claims", got)
+	}
+
+	// Claiming again renews instead of duplicating
+	if got := f.claim(t, f.alice, 10, 10); len(got) != 2 {
+		t.Errorf("alice holds %v after renewing, expected both photos", got)
+	}
+}
+
+func TestClaimReviewsMaxClaims(t *testing.T) {
+	f := newClaimFixture(t)
+
+	if got := f.claim(t, f.alice, 10, 1); len(got) != 1 || got[0] != f.photoIDs[0] {
+		t.Fatalf("alice claimed %v, expected only the oldest photo", got)
+	}
+	if got := f.claim(t, f.alice, 10, 1); len(got) != 1 {
+		t.Errorf("alice holds %v, expected the limit to include held claims", got)
+	}
+	if got := f.claim(t, f.bob, 10, 10); len(got) != 1 || got[0] != f.photoIDs[1] {
+		t.Errorf("bob claimed %v, expected the photo alice could not take", got)
+	}
+}
+
+func TestClaimReviewsExpiredLeaseReclaimed(t *testing.T) {
+	f := newClaimFixture(t)
+
+	f.claim(t, f.alice, 10, 10)
+	pgtest.Exec(t, f.db, `UPDATE review_claims SET expires_at = NOW() - INTERVAL '1 second' WHERE reviewer_id = $1`, f.alice)
+
+	if got := f.claim(t, f.bob, 10, 10); len(got) != 2 {
+		t.Fatalf("bob claimed %v, expected both expired claims", got)
+	}
+
+	claims, err := f.repo.ListActiveClaims(context.Background(), f.alice)
+	if err != nil {
+		t.Fatalf("ListActiveClaims() error = %v", err)
+	}
+	if len(claims) != 0 {
+		t.Errorf("alice still holds %d claims", len(claims))
+	}
+
+	// The expired claimant can no longer decide or release
+	if _, err := f.review(f.alice, f.photoIDs[0]); !errors.Is(err, postgresql.ErrClaimNotHeld) {
+		t.Errorf("ReviewPhoto() by expired claimant error = %v, expected ErrClaimNotHeld", err)
+	}
+	if err := f.repo.ReleaseClaim(context.Background(), f.photoIDs[0], f.alice); !errors.Is(err, postgresql.ErrClaimNotHeld) {
+		t.Errorf("ReleaseClaim() by expired claimant error = %v, expected ErrClaimNotHeld", err)
+	}
+}
+
+func TestClaimReviewsScreeningRejectedLast(t *testing.T) {
+	f := newClaimFixture(t)
+	screened := f.photoIDs[0]
+	pgtest.Exec(t, f.db, `UPDATE photos SET status = $1 WHERE id = $2`, model.PhotoStatusAIRejected, screened)
+
+	// The older photo rejected by screening is queued behind the pending one
+	if got := f.claim(t, f.alice, 1, 10); len(got) != 1 || got[0] != f.photoIDs[1] {
+		t.Fatalf("alice claimed %v, expected the pending photo first", got)
+	}
+	if got := f.claim(t, f.bob, 1, 10); len(got) != 1 || got[0] != screened {
+		t.Fatalf("bob claimed %v, expected the photo rejected by screening", got)
+	}
+
+	// A reviewer can overturn the automatic verdict
+	result, err := f.review(f.bob, screened)
+	if err != nil {
+		t.Fatalf("ReviewPhoto() error = %v", err)
+	}
+	if result.Status != model.PhotoStatusApproved {
+		t.Errorf("ReviewPhoto() status = %s, expected %s", result.Status, model.PhotoStatusApproved)
+	}
+}
+
+func TestReviewPhotoRequiresClaimant(t *testing.T) {
+	f := newClaimFixture(t)
+	photoID := f.photoIDs[0]
+
+	f.claim(t, f.alice, 1, 10)
+
+	if _, err := f.review(f.bob, photoID); !errors.Is(err, postgresql.ErrClaimNotHeld) {
+		t.Errorf("ReviewPhoto() by bob error = %v, expected ErrClaimNotHeld", err)
+	}
+	if err := f.repo.ReleaseClaim(context.Background(), photoID, f.bob); !errors.Is(err, postgresql.ErrClaimNotHeld) {
+		t.Errorf("ReleaseClaim() by bob error = %v, expected ErrClaimNotHeld", err)
+	}
+
+	result, err := f.review(f.alice, photoID)
+	if err != nil {
+		t.Fatalf("ReviewPhoto() by alice error = %v", err)
+	}
+	if result.Status != model.PhotoStatusApproved || result.Decisions != 1 {
+		t.Errorf("ReviewPhoto() = %+v, expected one approving decision", result)
+	}
+
+	// The decision consumed the claim
+	if _, err := f.review(f.alice, photoID); !errors.Is(err, postgresql.ErrClaimNotHeld) {
+		t.Errorf("second ReviewPhoto() error = %v, expected ErrClaimNotHeld", err)
+	}
+}
+
+func TestReviewPhotoRechecksStatus(t *testing.T) {
+	f := newClaimFixture(t)
+	photoID := f.photoIDs[0]
+
+	f.claim(t, f.alice, 1, 10)
+	pgtest.Exec(t, f.db, `UPDATE photos SET status = $1 WHERE id = $2`, model.PhotoStatusRejected, photoID)
+
+	if _, err := f.review(f.alice, photoID); !errors.Is(err, postgresql.ErrNotReviewable) {
+		t.Fatalf("ReviewPhoto() error = %v, expected ErrNotReviewable", err)
+	}
+
+	// Nothing was recorded and the claim is kept
+	var reviews int
+	if err := f.db.Get(&reviews, `SELECT COUNT(*) FROM photo_reviews WHERE photo_id = $1 AND review_type = 'manual'`, photoID); err != nil {
+		t.Fatalf("count reviews: %v", err)
+	}
+	if reviews != 0 {
+		t.Errorf("recorded %d reviews, expected none", reviews)
+	}
+	if err := f.repo.ReleaseClaim(context.Background(), photoID, f.alice); err != nil {
+		t.Errorf("ReleaseClaim() error = %v, expected the claim to be kept", err)
+	}
+}
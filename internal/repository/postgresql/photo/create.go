@@ -4,62 +4,168 @@ import (
 	"context"
 	"database/sql"
 	"strings"
+	"time"
 
 	"QuanPhotos/internal/model"
 )
 
 // CreatePhotoParams contains parameters for creating a photo
 type CreatePhotoParams struct {
-	UserID        int64
-	CategoryID    *int32
-	Title         string
-	Description   *string
-	FilePath      string
-	ThumbnailPath *string
-	RawFilePath   *string
-	FileSize      *int64
-	AircraftType  *string
-	Airline       *string
-	Registration  *string
-	Airport       *string
+	UserID       int64
+	CategoryID   *int32
+	Title        string
+	Description  *string
+	AircraftType *string
+	Airline      *string
+	Registration *string
+	Airport      *string
+	SpotID       *int64
+
+	// Flight info
+	FlightNumber *string
+	Origin       *string
+	Destination  *string
+	FlightPhase  *string
+
+	// Visibility, empty means public. Unlisted photos need a share token.
+	Visibility model.PhotoVisibility
+	ShareToken *string
+
+	// Scheduled publication, nil publishes the photo once approved
+	PublishAt *time.Time
+
+	// Stored files and EXIF data
+	FileParams
+
+	// Tags
+	Tags []string
+
+	// Storage quota of the owner in bytes, checked when the photo is saved.
+	// 0 is unlimited.
+	QuotaBytes int64
+}
+
+// visibility returns the visibility the photo is created with
+func (p *CreatePhotoParams) visibility() model.PhotoVisibility {
+	if p.Visibility == "" {
+		return model.PhotoVisibilityPublic
+	}
+	return p.Visibility
+}
+
+// FileParams contains the stored files of a photo and the EXIF data read from the image
+type FileParams struct {
+	FilePath      string  `json:"file_path"`
+	ThumbnailPath *string `json:"thumbnail_path"`
+	RawFilePath   *string `json:"raw_file_path"`
+	FileSize      *int64  `json:"file_size"`
+
+	// Stored sizes counted against the owner's storage quota
+	RawFileSize    *int64 `json:"raw_file_size"`
+	DerivativeSize *int64 `json:"derivative_size"` // Thumbnails
 
 	// EXIF Camera info
-	ExifCameraMake   *string
-	ExifCameraModel  *string
-	ExifSerialNumber *string
+	ExifCameraMake   *string `json:"exif_camera_make"`
+	ExifCameraModel  *string `json:"exif_camera_model"`
+	ExifSerialNumber *string `json:"exif_serial_number"`
 
 	// EXIF Lens info
-	ExifLensMake        *string
-	ExifLensModel       *string
-	ExifFocalLength     *string
-	ExifFocalLength35mm *string
+	ExifLensMake        *string `json:"exif_lens_make"`
+	ExifLensModel       *string `json:"exif_lens_model"`
+	ExifFocalLength     *string `json:"exif_focal_length"`
+	ExifFocalLength35mm *string `json:"exif_focal_length_35mm"`
 
 	// EXIF Shooting parameters
-	ExifAperture        *string
-	ExifShutterSpeed    *string
-	ExifISO             *int32
-	ExifExposureMode    *string
-	ExifExposureProgram *string
-	ExifMeteringMode    *string
-	ExifWhiteBalance    *string
-	ExifFlash           *string
-	ExifExposureBias    *string
+	ExifAperture        *string `json:"exif_aperture"`
+	ExifShutterSpeed    *string `json:"exif_shutter_speed"`
+	ExifISO             *int32  `json:"exif_iso"`
+	ExifExposureMode    *string `json:"exif_exposure_mode"`
+	ExifExposureProgram *string `json:"exif_exposure_program"`
+	ExifMeteringMode    *string `json:"exif_metering_mode"`
+	ExifWhiteBalance    *string `json:"exif_white_balance"`
+	ExifFlash           *string `json:"exif_flash"`
+	ExifExposureBias    *string `json:"exif_exposure_bias"`
 
 	// EXIF Time and location
-	ExifTakenAt      *string // RFC3339 format
-	ExifGPSLatitude  *float64
-	ExifGPSLongitude *float64
-	ExifGPSAltitude  *float64
+	ExifTakenAt      *string  `json:"exif_taken_at"` // RFC3339 format
+	ExifGPSLatitude  *float64 `json:"exif_gps_latitude"`
+	ExifGPSLongitude *float64 `json:"exif_gps_longitude"`
+	ExifGPSAltitude  *float64 `json:"exif_gps_altitude"`
 
 	// EXIF Image info
-	ExifImageWidth  *int32
-	ExifImageHeight *int32
-	ExifOrientation *int32
-	ExifColorSpace  *string
-	ExifSoftware    *string
+	ExifImageWidth  *int32  `json:"exif_image_width"`
+	ExifImageHeight *int32  `json:"exif_image_height"`
+	ExifOrientation *int32  `json:"exif_orientation"`
+	ExifColorSpace  *string `json:"exif_color_space"`
+	ExifSoftware    *string `json:"exif_software"`
 
-	// Tags
-	Tags []string
+	// Numeric EXIF values for range filters
+	ExifFocalLength35mmNum *float64 `json:"exif_focal_length_35mm_num"` // Millimetres
+	ExifApertureNum        *float64 `json:"exif_aperture_num"`          // f-number
+	ExifShutterSpeedNum    *float64 `json:"exif_shutter_speed_num"`     // Seconds
+
+	// Normalized gear derived from the EXIF make and models
+	GearCameraKey  *string `json:"gear_camera_key"`
+	GearCameraName *string `json:"gear_camera_name"`
+	GearLensKey    *string `json:"gear_lens_key"`
+	GearLensName   *string `json:"gear_lens_name"`
+}
+
+// columns returns the photo columns written from the file params and their values
+func (f *FileParams) columns() ([]string, []interface{}) {
+	columns := []string{
+		"file_path", "thumbnail_path", "raw_file_path", "file_size",
+		"exif_camera_make", "exif_camera_model", "exif_serial_number",
+		"exif_lens_make", "exif_lens_model", "exif_focal_length", "exif_focal_length_35mm",
+		"exif_aperture", "exif_shutter_speed", "exif_iso", "exif_exposure_mode", "exif_exposure_program",
+		"exif_metering_mode", "exif_white_balance", "exif_flash", "exif_exposure_bias",
+		"exif_taken_at", "exif_gps_latitude", "exif_gps_longitude", "exif_gps_altitude",
+		"exif_image_width", "exif_image_height", "exif_orientation", "exif_color_space", "exif_software",
+		"exif_focal_length_35mm_num", "exif_aperture_num", "exif_shutter_speed_num",
+		"raw_file_size", "derivative_size",
+		"gear_camera_key", "gear_camera_name", "gear_lens_key", "gear_lens_name",
+	}
+	values := []interface{}{
+		f.FilePath,
+		toNullString(f.ThumbnailPath),
+		toNullString(f.RawFilePath),
+		toNullInt64(f.FileSize),
+		toNullString(f.ExifCameraMake),
+		toNullString(f.ExifCameraModel),
+		toNullString(f.ExifSerialNumber),
+		toNullString(f.ExifLensMake),
+		toNullString(f.ExifLensModel),
+		toNullString(f.ExifFocalLength),
+		toNullString(f.ExifFocalLength35mm),
+		toNullString(f.ExifAperture),
+		toNullString(f.ExifShutterSpeed),
+		toNullInt32(f.ExifISO),
+		toNullString(f.ExifExposureMode),
+		toNullString(f.ExifExposureProgram),
+		toNullString(f.ExifMeteringMode),
+		toNullString(f.ExifWhiteBalance),
+		toNullString(f.ExifFlash),
+		toNullString(f.ExifExposureBias),
+		toNullString(f.ExifTakenAt),
+		toNullFloat64(f.ExifGPSLatitude),
+		toNullFloat64(f.ExifGPSLongitude),
+		toNullFloat64(f.ExifGPSAltitude),
+		toNullInt32(f.ExifImageWidth),
+		toNullInt32(f.ExifImageHeight),
+		toNullInt32(f.ExifOrientation),
+		toNullString(f.ExifColorSpace),
+		toNullString(f.ExifSoftware),
+		toNullFloat64(f.ExifFocalLength35mmNum),
+		toNullFloat64(f.ExifApertureNum),
+		toNullFloat64(f.ExifShutterSpeedNum),
+		toNullInt64(f.RawFileSize),
+		toNullInt64(f.DerivativeSize),
+		toNullString(f.GearCameraKey),
+		toNullString(f.GearCameraName),
+		toNullString(f.GearLensKey),
+		toNullString(f.GearLensName),
+	}
+	return columns, values
 }
 
 // Create creates a new photo record
@@ -74,7 +180,12 @@ func (r *PhotoRepository) Create(ctx context.Context, params *CreatePhotoParams)
 			exif_metering_mode, exif_white_balance, exif_flash, exif_exposure_bias,
 			exif_taken_at, exif_gps_latitude, exif_gps_longitude, exif_gps_altitude,
 			exif_image_width, exif_image_height, exif_orientation, exif_color_space, exif_software,
-			status
+			status, spot_id,
+			flight_number, origin, destination, flight_phase,
+			exif_focal_length_35mm_num, exif_aperture_num, exif_shutter_speed_num,
+			visibility, share_token, publish_at,
+			raw_file_size, derivative_size,
+			gear_camera_key, gear_camera_name, gear_lens_key, gear_lens_name
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8,
 			$9, $10, $11, $12,
@@ -84,7 +195,12 @@ func (r *PhotoRepository) Create(ctx context.Context, params *CreatePhotoParams)
 			$25, $26, $27, $28,
 			$29, $30, $31, $32,
 			$33, $34, $35, $36, $37,
-			$38
+			$38, $39,
+			$40, $41, $42, $43,
+			$44, $45, $46,
+			$47, $48, $49,
+			$50, $51,
+			$52, $53, $54, $55
 		) RETURNING id
 	`
 
@@ -128,6 +244,23 @@ func (r *PhotoRepository) Create(ctx context.Context, params *CreatePhotoParams)
 		toNullString(params.ExifColorSpace),
 		toNullString(params.ExifSoftware),
 		model.PhotoStatusPending,
+		toNullInt64(params.SpotID),
+		toNullString(params.FlightNumber),
+		toNullString(params.Origin),
+		toNullString(params.Destination),
+		toNullString(params.FlightPhase),
+		toNullFloat64(params.ExifFocalLength35mmNum),
+		toNullFloat64(params.ExifApertureNum),
+		toNullFloat64(params.ExifShutterSpeedNum),
+		params.visibility(),
+		toNullString(params.ShareToken),
+		toNullTime(params.PublishAt),
+		toNullInt64(params.RawFileSize),
+		toNullInt64(params.DerivativeSize),
+		toNullString(params.GearCameraKey),
+		toNullString(params.GearCameraName),
+		toNullString(params.GearLensKey),
+		toNullString(params.GearLensName),
 	).Scan(&id)
 
 	if err != nil {
@@ -137,7 +270,8 @@ func (r *PhotoRepository) Create(ctx context.Context, params *CreatePhotoParams)
 	return id, nil
 }
 
-// CreateWithTags creates a photo with tags in a transaction
+// CreateWithTags creates a photo with tags in a transaction.
+// Returns ErrQuotaExceeded if its files do not fit the owner's storage quota.
 func (r *PhotoRepository) CreateWithTags(ctx context.Context, params *CreatePhotoParams) (int64, error) {
 	tx, err := r.DB().BeginTxx(ctx, nil)
 	if err != nil {
@@ -145,6 +279,10 @@ func (r *PhotoRepository) CreateWithTags(ctx context.Context, params *CreatePhot
 	}
 	defer tx.Rollback()
 
+	if err := reserveStorage(ctx, tx, params.UserID, params.storedBytes(), params.QuotaBytes); err != nil {
+		return 0, err
+	}
+
 	// Create photo
 	query := `
 		INSERT INTO photos (
@@ -156,7 +294,12 @@ func (r *PhotoRepository) CreateWithTags(ctx context.Context, params *CreatePhot
 			exif_metering_mode, exif_white_balance, exif_flash, exif_exposure_bias,
 			exif_taken_at, exif_gps_latitude, exif_gps_longitude, exif_gps_altitude,
 			exif_image_width, exif_image_height, exif_orientation, exif_color_space, exif_software,
-			status
+			status, spot_id,
+			flight_number, origin, destination, flight_phase,
+			exif_focal_length_35mm_num, exif_aperture_num, exif_shutter_speed_num,
+			visibility, share_token, publish_at,
+			raw_file_size, derivative_size,
+			gear_camera_key, gear_camera_name, gear_lens_key, gear_lens_name
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8,
 			$9, $10, $11, $12,
@@ -166,7 +309,12 @@ func (r *PhotoRepository) CreateWithTags(ctx context.Context, params *CreatePhot
 			$25, $26, $27, $28,
 			$29, $30, $31, $32,
 			$33, $34, $35, $36, $37,
-			$38
+			$38, $39,
+			$40, $41, $42, $43,
+			$44, $45, $46,
+			$47, $48, $49,
+			$50, $51,
+			$52, $53, $54, $55
 		) RETURNING id
 	`
 
@@ -210,6 +358,23 @@ func (r *PhotoRepository) CreateWithTags(ctx context.Context, params *CreatePhot
 		toNullString(params.ExifColorSpace),
 		toNullString(params.ExifSoftware),
 		model.PhotoStatusPending,
+		toNullInt64(params.SpotID),
+		toNullString(params.FlightNumber),
+		toNullString(params.Origin),
+		toNullString(params.Destination),
+		toNullString(params.FlightPhase),
+		toNullFloat64(params.ExifFocalLength35mmNum),
+		toNullFloat64(params.ExifApertureNum),
+		toNullFloat64(params.ExifShutterSpeedNum),
+		params.visibility(),
+		toNullString(params.ShareToken),
+		toNullTime(params.PublishAt),
+		toNullInt64(params.RawFileSize),
+		toNullInt64(params.DerivativeSize),
+		toNullString(params.GearCameraKey),
+		toNullString(params.GearCameraName),
+		toNullString(params.GearLensKey),
+		toNullString(params.GearLensName),
 	).Scan(&photoID)
 
 	if err != nil {
@@ -282,3 +447,10 @@ func toNullFloat64(f *float64) sql.NullFloat64 {
 	}
 	return sql.NullFloat64{Float64: *f, Valid: true}
 }
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
@@ -8,14 +8,44 @@ import (
 	"QuanPhotos/internal/repository/postgresql"
 )
 
-// AddFavorite adds a photo to user's favorites
+// AddFavorite adds a photo to user's favorites, filed in their default
+// folder. Favorites already filed elsewhere are left as they are.
 func (r *PhotoRepository) AddFavorite(ctx context.Context, userID, photoID int64) error {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `INSERT INTO favorites (user_id, photo_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
-	_, err := r.DB().ExecContext(ctx, query, userID, photoID)
-	return err
+	result, err := tx.ExecContext(ctx, query, userID, photoID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return nil
+	}
+
+	folderID, err := ensureDefaultFolder(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO favorite_folder_photos (folder_id, user_id, photo_id) VALUES ($1, $2, $3)
+	`, folderID, userID, photoID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// RemoveFavorite removes a photo from user's favorites
+// RemoveFavorite removes a photo from user's favorites and all their folders
 func (r *PhotoRepository) RemoveFavorite(ctx context.Context, userID, photoID int64) error {
 	query := `DELETE FROM favorites WHERE user_id = $1 AND photo_id = $2`
 	result, err := r.DB().ExecContext(ctx, query, userID, photoID)
@@ -62,10 +92,10 @@ func (r *PhotoRepository) RemoveLike(ctx context.Context, userID, photoID int64)
 	return nil
 }
 
-// Exists checks if a photo exists
+// Exists checks if a photo exists and is not in the trash
 func (r *PhotoRepository) Exists(ctx context.Context, photoID int64) (bool, error) {
 	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1 AND deleted_at IS NULL)`
 	err := r.DB().GetContext(ctx, &exists, query, photoID)
 	return exists, err
 }
@@ -73,15 +103,29 @@ func (r *PhotoRepository) Exists(ctx context.Context, photoID int64) (bool, erro
 // IsOwnedBy checks if a photo is owned by a user
 func (r *PhotoRepository) IsOwnedBy(ctx context.Context, photoID, userID int64) (bool, error) {
 	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1 AND user_id = $2)`
+	query := `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL)`
 	err := r.DB().GetContext(ctx, &exists, query, photoID, userID)
 	return exists, err
 }
 
-// Delete soft deletes a photo (or hard delete based on requirements)
-func (r *PhotoRepository) Delete(ctx context.Context, photoID int64) error {
-	query := `DELETE FROM photos WHERE id = $1`
-	result, err := r.DB().ExecContext(ctx, query, photoID)
+// Delete moves a photo to the trash, keeping a snapshot of its metadata for
+// the review history
+func (r *PhotoRepository) Delete(ctx context.Context, photoID, deletedBy int64) error {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Keep the metadata for the review history
+	if err := snapshotDeleted(ctx, tx, photoID, deletedBy, nil); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE photos SET deleted_at = NOW(), deleted_by = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, photoID, deletedBy)
 	if err != nil {
 		return err
 	}
@@ -95,7 +139,7 @@ func (r *PhotoRepository) Delete(ctx context.Context, photoID int64) error {
 		return postgresql.ErrNotFound
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 // GetFilePaths retrieves file paths for a photo (for deletion)
@@ -0,0 +1,381 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// favoriteVisibleFilter matches the favorites of user $1 they can still see,
+// photos of other users that are no longer visible are left out
+var favoriteVisibleFilter = postgresql.VisibleToFilter("p", "$1")
+
+// favoriteFolderColumns selects a folder of user $1 with its visible photo
+// count, cover and whether it holds photo $2
+var favoriteFolderColumns = fmt.Sprintf(`
+	ff.*,
+	(SELECT COUNT(*) FROM favorite_folder_photos fp JOIN photos p ON p.id = fp.photo_id
+		WHERE fp.folder_id = ff.id AND %s) AS photo_count,
+	(SELECT p.thumbnail_path FROM favorite_folder_photos fp JOIN photos p ON p.id = fp.photo_id
+		WHERE fp.folder_id = ff.id AND %s
+		ORDER BY fp.added_at DESC LIMIT 1) AS cover_path,
+	EXISTS(SELECT 1 FROM favorite_folder_photos fp WHERE fp.folder_id = ff.id AND fp.photo_id = $2) AS contains
+`, favoriteVisibleFilter, favoriteVisibleFilter)
+
+// ListFavoriteFolders retrieves a user's favorite folders, default folder
+// first. A positive photoID reports which folders hold that photo.
+func (r *PhotoRepository) ListFavoriteFolders(ctx context.Context, userID, photoID int64) ([]*model.FavoriteFolder, error) {
+	// Users see their default folder before their first favorite
+	if _, err := ensureDefaultFolder(ctx, r.DB(), userID); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM favorite_folders ff
+		WHERE ff.user_id = $1
+		ORDER BY ff.is_default DESC, ff.created_at ASC, ff.id ASC
+	`, favoriteFolderColumns)
+
+	var folders []*model.FavoriteFolder
+	if err := r.DB().SelectContext(ctx, &folders, query, userID, photoID); err != nil {
+		return nil, err
+	}
+	return folders, nil
+}
+
+// GetFavoriteFolder retrieves one of a user's favorite folders. Returns
+// ErrNotFound if the folder does not exist or belongs to another user.
+func (r *PhotoRepository) GetFavoriteFolder(ctx context.Context, folderID, userID int64) (*model.FavoriteFolder, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM favorite_folders ff
+		WHERE ff.user_id = $1 AND ff.id = $3
+	`, favoriteFolderColumns)
+
+	var folder model.FavoriteFolder
+	err := r.DB().GetContext(ctx, &folder, query, userID, 0, folderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// CountFavoriteFolders counts a user's favorite folders, the default folder
+// included
+func (r *PhotoRepository) CountFavoriteFolders(ctx context.Context, userID int64) (int, error) {
+	if _, err := ensureDefaultFolder(ctx, r.DB(), userID); err != nil {
+		return 0, err
+	}
+
+	var count int
+	err := r.DB().GetContext(ctx, &count, `SELECT COUNT(*) FROM favorite_folders WHERE user_id = $1`, userID)
+	return count, err
+}
+
+// FavoriteFolderNameExists checks if a user has a folder with the name,
+// other than the given folder
+func (r *PhotoRepository) FavoriteFolderNameExists(ctx context.Context, userID int64, name string, exceptID int64) (bool, error) {
+	var exists bool
+	err := r.DB().GetContext(ctx, &exists, `
+		SELECT EXISTS(SELECT 1 FROM favorite_folders WHERE user_id = $1 AND LOWER(name) = LOWER($2) AND id <> $3)
+	`, userID, name, exceptID)
+	return exists, err
+}
+
+// CreateFavoriteFolder creates an empty favorite folder
+func (r *PhotoRepository) CreateFavoriteFolder(ctx context.Context, userID int64, name string) (int64, error) {
+	var id int64
+	err := r.DB().GetContext(ctx, &id, `
+		INSERT INTO favorite_folders (user_id, name) VALUES ($1, $2) RETURNING id
+	`, userID, name)
+	return id, err
+}
+
+// RenameFavoriteFolder renames one of a user's favorite folders
+func (r *PhotoRepository) RenameFavoriteFolder(ctx context.Context, folderID, userID int64, name string) error {
+	result, err := r.DB().ExecContext(ctx, `
+		UPDATE favorite_folders SET name = $3 WHERE id = $1 AND user_id = $2
+	`, folderID, userID, name)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteFavoriteFolder deletes one of a user's folders other than the
+// default one. Favorites only filed in it move to the default folder.
+func (r *PhotoRepository) DeleteFavoriteFolder(ctx context.Context, folderID, userID int64) error {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	defaultID, err := ensureDefaultFolder(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	if defaultID == folderID {
+		return postgresql.ErrNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO favorite_folder_photos (folder_id, user_id, photo_id)
+		SELECT $3::bigint, fp.user_id, fp.photo_id FROM favorite_folder_photos fp
+		WHERE fp.folder_id = $1 AND fp.user_id = $2
+			AND NOT EXISTS (
+				SELECT 1 FROM favorite_folder_photos other
+				WHERE other.user_id = fp.user_id AND other.photo_id = fp.photo_id AND other.folder_id <> $1
+			)
+		ON CONFLICT DO NOTHING
+	`, folderID, userID, defaultID)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM favorite_folders WHERE id = $1 AND user_id = $2`, folderID, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return tx.Commit()
+}
+
+// AddToFavoriteFolder files photos in one of a user's folders, favoriting
+// those that are not favorites yet. Returns the number of photos added to
+// the folder.
+func (r *PhotoRepository) AddToFavoriteFolder(ctx context.Context, userID, folderID int64, photoIDs []int64) (int, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sqlx.In(`
+		INSERT INTO favorites (user_id, photo_id)
+		SELECT ?::bigint, id FROM photos WHERE id IN (?)
+		ON CONFLICT DO NOTHING
+	`, userID, photoIDs)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+		return 0, err
+	}
+
+	query, args, err = sqlx.In(`
+		INSERT INTO favorite_folder_photos (folder_id, user_id, photo_id)
+		SELECT ?::bigint, ?::bigint, id FROM photos WHERE id IN (?)
+		ON CONFLICT DO NOTHING
+	`, folderID, userID, photoIDs)
+	if err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+	added, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(added), nil
+}
+
+// RemoveFromFavoriteFolder takes photos out of one of a user's folders.
+// Photos no longer filed in any folder are unfavorited. Returns the number of
+// photos removed from the folder.
+func (r *PhotoRepository) RemoveFromFavoriteFolder(ctx context.Context, userID, folderID int64, photoIDs []int64) (int, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sqlx.In(`
+		DELETE FROM favorite_folder_photos WHERE folder_id = ? AND user_id = ? AND photo_id IN (?)
+	`, folderID, userID, photoIDs)
+	if err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := deleteUnfiledFavorites(ctx, tx, userID, photoIDs); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(removed), nil
+}
+
+// MoveFavorites moves photos from one of a user's folders to another. The
+// photos stay favorites. Returns the number of photos moved.
+func (r *PhotoRepository) MoveFavorites(ctx context.Context, userID, fromID, toID int64, photoIDs []int64) (int, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sqlx.In(`
+		INSERT INTO favorite_folder_photos (folder_id, user_id, photo_id)
+		SELECT ?::bigint, user_id, photo_id FROM favorite_folder_photos
+		WHERE folder_id = ? AND user_id = ? AND photo_id IN (?)
+		ON CONFLICT DO NOTHING
+	`, toID, fromID, userID, photoIDs)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+		return 0, err
+	}
+
+	query, args, err = sqlx.In(`
+		DELETE FROM favorite_folder_photos WHERE folder_id = ? AND user_id = ? AND photo_id IN (?)
+	`, fromID, userID, photoIDs)
+	if err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(moved), nil
+}
+
+// ListFavoriteFolderPhotos retrieves the photos of one of a user's folders,
+// most recently added first. Private photos of other users are left out.
+func (r *PhotoRepository) ListFavoriteFolderPhotos(ctx context.Context, userID, folderID int64, page, pageSize int) (*ListResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	var total int64
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*) FROM favorite_folder_photos fp
+		INNER JOIN photos p ON p.id = fp.photo_id
+		WHERE fp.user_id = $1 AND fp.folder_id = $2 AND %s
+	`, favoriteVisibleFilter)
+	if err := r.DB().GetContext(ctx, &total, countQuery, userID, folderID); err != nil {
+		return nil, err
+	}
+
+	// Calculate pagination
+	offset := (page - 1) * pageSize
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.* FROM photos p
+		INNER JOIN favorite_folder_photos fp ON fp.photo_id = p.id
+		WHERE fp.user_id = $1 AND fp.folder_id = $2 AND %s
+		ORDER BY fp.added_at DESC
+		LIMIT $3 OFFSET $4
+	`, favoriteVisibleFilter)
+
+	var photos []*model.Photo
+	if err := r.DB().SelectContext(ctx, &photos, query, userID, folderID, pageSize, offset); err != nil {
+		return nil, err
+	}
+
+	return &ListResult{
+		Photos:     photos,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// ensureDefaultFolder returns the ID of a user's default folder, creating
+// it if needed
+func ensureDefaultFolder(ctx context.Context, db sqlx.ExtContext, userID int64) (int64, error) {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO favorite_folders (user_id, name, is_default) VALUES ($1, $2, TRUE)
+		ON CONFLICT DO NOTHING
+	`, userID, model.DefaultFavoriteFolderName)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = sqlx.GetContext(ctx, db, &id, `
+		SELECT id FROM favorite_folders WHERE user_id = $1 AND is_default = TRUE
+	`, userID)
+	return id, err
+}
+
+// deleteUnfiledFavorites unfavorites the given photos of a user that are no
+// longer filed in any folder
+func deleteUnfiledFavorites(ctx context.Context, tx *sqlx.Tx, userID int64, photoIDs []int64) error {
+	query, args, err := sqlx.In(`
+		DELETE FROM favorites f
+		WHERE f.user_id = ? AND f.photo_id IN (?)
+			AND NOT EXISTS (
+				SELECT 1 FROM favorite_folder_photos fp
+				WHERE fp.user_id = f.user_id AND fp.photo_id = f.photo_id
+			)
+	`, userID, photoIDs)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), args...)
+	return err
+}
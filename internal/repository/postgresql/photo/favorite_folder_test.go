@@ -0,0 +1,117 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+func TestFavoriteFolderMoves(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ctx := context.Background()
+	userID := pgtest.CreateUser(t, db, "collector", "user")
+	otherID := pgtest.CreateUser(t, db, "other", "user")
+
+	own := pgtest.CreatePhoto(t, db, userID, string(model.PhotoStatusApproved))
+	public := pgtest.CreatePhoto(t, db, otherID, string(model.PhotoStatusApproved))
+	private := pgtest.CreatePhoto(t, db, otherID, string(model.PhotoStatusApproved))
+	pgtest.Exec(t, db, `UPDATE photos SET visibility = 'private' WHERE id = $1`, private)
+
+	folders, err := repo.ListFavoriteFolders(ctx, userID, 0)
+	if err != nil {
+		t.Fatalf("ListFavoriteFolders() error = %v", err)
+	}
+	if len(folders) != 1 || !folders[0].IsDefault {
+		t.Fatalf("ListFavoriteFolders() returned %d folders, expected the default one", len(folders))
+	}
+	defaultID := folders[0].ID
+	tripsID, err := repo.CreateFavoriteFolder(ctx, userID, "Trips")
+	if err != nil {
+		t.Fatalf("CreateFavoriteFolder() error = %v", err)
+	}
+
+	photoCount := func(folderID int64) int {
+		t.Helper()
+		folder, err := repo.GetFavoriteFolder(ctx, folderID, userID)
+		if err != nil {
+			t.Fatalf("GetFavoriteFolder() error = %v", err)
+		}
+		result, err := repo.ListFavoriteFolderPhotos(ctx, userID, folderID, 1, 20)
+		if err != nil {
+			t.Fatalf("ListFavoriteFolderPhotos() error = %v", err)
+		}
+		if int(result.Total) != folder.PhotoCount {
+			t.Errorf("folder %d lists %d photos, photo_count = %d", folderID, result.Total, folder.PhotoCount)
+		}
+		return folder.PhotoCount
+	}
+	favorited := func(photoID int64) bool {
+		t.Helper()
+		ok, err := repo.IsFavorited(ctx, userID, photoID)
+		if err != nil {
+			t.Fatalf("IsFavorited() error = %v", err)
+		}
+		return ok
+	}
+
+	// Filing photos favorites them, private photos of others are not listed
+	if added, err := repo.AddToFavoriteFolder(ctx, userID, tripsID, []int64{own, public, private}); err != nil || added != 3 {
+		t.Fatalf("AddToFavoriteFolder() = %d, %v, expected 3 added", added, err)
+	}
+	if !favorited(own) || !favorited(public) {
+		t.Error("filed photos not favorited")
+	}
+	if n := photoCount(tripsID); n != 2 {
+		t.Errorf("Trips photo_count = %d, expected 2 visible photos", n)
+	}
+
+	// Moved photos stay favorites, photos not in the source folder are skipped
+	if moved, err := repo.MoveFavorites(ctx, userID, tripsID, defaultID, []int64{own, public}); err != nil || moved != 2 {
+		t.Fatalf("MoveFavorites() = %d, %v, expected 2 moved", moved, err)
+	}
+	if moved, err := repo.MoveFavorites(ctx, userID, tripsID, defaultID, []int64{own}); err != nil || moved != 0 {
+		t.Errorf("second MoveFavorites() = %d, %v, expected nothing moved", moved, err)
+	}
+	if n := photoCount(tripsID); n != 0 {
+		t.Errorf("Trips photo_count = %d after the move, expected 0", n)
+	}
+	if n := photoCount(defaultID); n != 2 {
+		t.Errorf("default photo_count = %d after the move, expected 2", n)
+	}
+	if !favorited(own) || !favorited(public) {
+		t.Error("moved photos unfavorited")
+	}
+
+	// A photo filed in another folder stays a favorite when removed from one
+	if _, err := repo.AddToFavoriteFolder(ctx, userID, tripsID, []int64{public}); err != nil {
+		t.Fatalf("AddToFavoriteFolder() error = %v", err)
+	}
+	if removed, err := repo.RemoveFromFavoriteFolder(ctx, userID, defaultID, []int64{own, public}); err != nil || removed != 2 {
+		t.Fatalf("RemoveFromFavoriteFolder() = %d, %v, expected 2 removed", removed, err)
+	}
+	if favorited(own) {
+		t.Error("photo no longer filed anywhere still favorited")
+	}
+	if !favorited(public) {
+		t.Error("photo still filed in Trips unfavorited")
+	}
+
+	// Deleting a folder moves photos only filed there to the default folder
+	if err := repo.DeleteFavoriteFolder(ctx, tripsID, userID); err != nil {
+		t.Fatalf("DeleteFavoriteFolder() error = %v", err)
+	}
+	if n := photoCount(defaultID); n != 1 {
+		t.Errorf("default photo_count = %d after deleting Trips, expected 1", n)
+	}
+	if !favorited(public) || !favorited(private) {
+		t.Error("photos of the deleted folder unfavorited")
+	}
+	if err := repo.DeleteFavoriteFolder(ctx, defaultID, userID); !errors.Is(err, postgresql.ErrNotFound) {
+		t.Errorf("DeleteFavoriteFolder(default) error = %v, expected ErrNotFound", err)
+	}
+}
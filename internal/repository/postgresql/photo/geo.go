@@ -0,0 +1,203 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// BoundingBox describes a rectangular area in degrees.
+// MinLng may be greater than MaxLng when the box crosses the antimeridian.
+type BoundingBox struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+}
+
+// GeoSearchParams contains parameters for searching photos by location
+type GeoSearchParams struct {
+	Page     int
+	PageSize int
+
+	// Bounding box search (optional)
+	Box *BoundingBox
+
+	// Radius search around a center point (optional)
+	CenterLat *float64
+	CenterLng *float64
+	RadiusKm  float64
+}
+
+// MapClusterParams contains parameters for building map clusters
+type MapClusterParams struct {
+	Precision int // Geohash prefix length used as the cluster bucket
+	Box       *BoundingBox
+	Limit     int
+}
+
+// MapCluster represents a group of photos sharing a geohash prefix
+type MapCluster struct {
+	Geohash       string         `db:"geohash"`
+	Count         int64          `db:"count"`
+	Latitude      float64        `db:"latitude"`
+	Longitude     float64        `db:"longitude"`
+	PhotoID       int64          `db:"photo_id"`
+	ThumbnailPath sql.NullString `db:"thumbnail_path"`
+}
+
+// buildGeoConditions builds the shared WHERE conditions for geo queries.
+// Only approved public photos with GPS data are included, and photos of users who
+// hide their photo locations are always excluded.
+func buildGeoConditions(box *BoundingBox, centerLat, centerLng *float64, radiusKm float64) ([]string, []interface{}, int) {
+	conditions := []string{
+		postgresql.PublicPhotoFilter("p"),
+		"p.exif_gps_latitude IS NOT NULL",
+		"p.exif_gps_longitude IS NOT NULL",
+		"u.hide_photo_location = FALSE",
+	}
+	var args []interface{}
+	argIndex := 1
+
+	if box != nil {
+		conditions = append(conditions, fmt.Sprintf("p.exif_gps_latitude BETWEEN $%d AND $%d", argIndex, argIndex+1))
+		args = append(args, box.MinLat, box.MaxLat)
+		argIndex += 2
+
+		condition, lngArgs := postgresql.LongitudeRange("p.exif_gps_longitude", box.MinLng, box.MaxLng, argIndex)
+		conditions = append(conditions, condition)
+		args = append(args, lngArgs...)
+		argIndex += 2
+	}
+
+	if centerLat != nil && centerLng != nil && radiusKm > 0 {
+		radius, radiusArgs, next := postgresql.RadiusConditions("p.exif_gps_latitude", "p.exif_gps_longitude", *centerLat, *centerLng, radiusKm, argIndex)
+		conditions = append(conditions, radius...)
+		args = append(args, radiusArgs...)
+		argIndex = next
+	}
+
+	return conditions, args, argIndex
+}
+
+// SearchByLocation retrieves approved photos within a bounding box or radius
+func (r *PhotoRepository) SearchByLocation(ctx context.Context, params GeoSearchParams) (*ListResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	conditions, args, argIndex := buildGeoConditions(params.Box, params.CenterLat, params.CenterLng, params.RadiusKm)
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*) FROM photos p
+		INNER JOIN users u ON u.id = p.user_id
+		%s
+	`, whereClause)
+	var total int64
+	err := r.DB().GetContext(ctx, &total, countQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate pagination
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize > 0 {
+		totalPages++
+	}
+
+	// Radius searches are ordered by distance, everything else by recency
+	orderBy := "p.created_at DESC"
+	if params.CenterLat != nil && params.CenterLng != nil && params.RadiusKm > 0 {
+		orderBy = fmt.Sprintf("%s ASC, p.created_at DESC", postgresql.DistanceExpr("p.exif_gps_latitude", "p.exif_gps_longitude", argIndex, argIndex+1))
+		args = append(args, *params.CenterLat, *params.CenterLng)
+		argIndex += 2
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.* FROM photos p
+		INNER JOIN users u ON u.id = p.user_id
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, whereClause, orderBy, argIndex, argIndex+1)
+
+	args = append(args, params.PageSize, offset)
+
+	var photos []*model.Photo
+	err = r.DB().SelectContext(ctx, &photos, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResult{
+		Photos:     photos,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// ListMapClusters groups approved photos into geohash buckets for map display.
+// Each cluster carries its centroid and the most liked photo as a representative.
+func (r *PhotoRepository) ListMapClusters(ctx context.Context, params MapClusterParams) ([]*MapCluster, error) {
+	if params.Precision < 1 {
+		params.Precision = 1
+	}
+	if params.Precision > 12 {
+		params.Precision = 12
+	}
+	if params.Limit < 1 {
+		params.Limit = 500
+	}
+	if params.Limit > 2000 {
+		params.Limit = 2000
+	}
+
+	conditions, args, argIndex := buildGeoConditions(params.Box, nil, nil, 0)
+	conditions = append(conditions, "p.exif_geohash IS NOT NULL")
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	query := fmt.Sprintf(`
+		SELECT c.geohash, c.count, c.latitude, c.longitude, c.photo_id, rp.thumbnail_path
+		FROM (
+			SELECT
+				LEFT(p.exif_geohash, $%d) AS geohash,
+				COUNT(*) AS count,
+				AVG(p.exif_gps_latitude)::DOUBLE PRECISION AS latitude,
+				AVG(p.exif_gps_longitude)::DOUBLE PRECISION AS longitude,
+				(ARRAY_AGG(p.id ORDER BY p.like_count DESC, p.id DESC))[1] AS photo_id
+			FROM photos p
+			INNER JOIN users u ON u.id = p.user_id
+			%s
+			GROUP BY 1
+			ORDER BY count DESC
+			LIMIT $%d
+		) c
+		INNER JOIN photos rp ON rp.id = c.photo_id
+		ORDER BY c.count DESC
+	`, argIndex, whereClause, argIndex+1)
+
+	args = append(args, params.Precision, params.Limit)
+
+	var clusters []*MapCluster
+	err := r.DB().SelectContext(ctx, &clusters, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
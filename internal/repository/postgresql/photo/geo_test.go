@@ -0,0 +1,173 @@
+package photo
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+func TestBuildGeoConditionsBoundingBox(t *testing.T) {
+	tests := []struct {
+		name            string
+		box             *BoundingBox
+		expectCondition string
+		expectArgs      []interface{}
+	}{
+		{
+			name:            "Regular box",
+			box:             &BoundingBox{MinLat: 39.7, MaxLat: 40.2, MinLng: 116.1, MaxLng: 116.8},
+			expectCondition: "p.exif_gps_longitude BETWEEN $3 AND $4",
+			expectArgs:      []interface{}{39.7, 40.2, 116.1, 116.8},
+		},
+		{
+			name:            "Box crossing the antimeridian",
+			box:             &BoundingBox{MinLat: -20, MaxLat: -10, MinLng: 170, MaxLng: -170},
+			expectCondition: "(p.exif_gps_longitude >= $3 OR p.exif_gps_longitude <= $4)",
+			expectArgs:      []interface{}{-20.0, -10.0, 170.0, -170.0},
+		},
+		{
+			name:            "Whole world",
+			box:             &BoundingBox{MinLat: -90, MaxLat: 90, MinLng: -180, MaxLng: 180},
+			expectCondition: "p.exif_gps_longitude BETWEEN $3 AND $4",
+			expectArgs:      []interface{}{-90.0, 90.0, -180.0, 180.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, args, next := buildGeoConditions(tt.box, nil, nil, 0)
+
+			last := conditions[len(conditions)-1]
+			if last != tt.expectCondition {
+				t.Errorf("longitude condition = %q, expected %q", last, tt.expectCondition)
+			}
+			if lat := conditions[len(conditions)-2]; lat != "p.exif_gps_latitude BETWEEN $1 AND $2" {
+				t.Errorf("latitude condition = %q", lat)
+			}
+			if !reflect.DeepEqual(args, tt.expectArgs) {
+				t.Errorf("args = %v, expected %v", args, tt.expectArgs)
+			}
+			if next != 5 {
+				t.Errorf("next arg index = %d, expected 5", next)
+			}
+		})
+	}
+}
+
+func TestBuildGeoConditionsRadius(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name             string
+		lat, lng         float64
+		radiusKm         float64
+		expectLngFilter  string
+		expectLngInRange func(args []interface{}) bool
+	}{
+		{
+			name:            "Radius away from the antimeridian",
+			lat:             40,
+			lng:             116,
+			radiusKm:        50,
+			expectLngFilter: "p.exif_gps_longitude BETWEEN $3 AND $4",
+			expectLngInRange: func(args []interface{}) bool {
+				return args[2].(float64) < 116 && args[3].(float64) > 116
+			},
+		},
+		{
+			name:            "Radius crossing the antimeridian wraps",
+			lat:             -17,
+			lng:             179.5,
+			radiusKm:        200,
+			expectLngFilter: "(p.exif_gps_longitude >= $3 OR p.exif_gps_longitude <= $4)",
+			expectLngInRange: func(args []interface{}) bool {
+				return args[2].(float64) < 179.5 && args[3].(float64) < -178
+			},
+		},
+		{
+			name:            "Radius at the pole skips the longitude filter",
+			lat:             90,
+			lng:             0,
+			radiusKm:        100,
+			expectLngFilter: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, args, _ := buildGeoConditions(nil, f(tt.lat), f(tt.lng), tt.radiusKm)
+
+			var lngFilter string
+			for _, c := range conditions {
+				if strings.Contains(c, "p.exif_gps_longitude") && !strings.Contains(c, "IS NOT NULL") && !strings.Contains(c, "ASIN") {
+					lngFilter = c
+				}
+			}
+			if lngFilter != tt.expectLngFilter {
+				t.Fatalf("longitude filter = %q, expected %q", lngFilter, tt.expectLngFilter)
+			}
+			if tt.expectLngInRange != nil && !tt.expectLngInRange(args) {
+				t.Errorf("longitude args = %v", args[2:4])
+			}
+			if !strings.Contains(conditions[len(conditions)-1], "ASIN") {
+				t.Error("missing great-circle distance condition")
+			}
+		})
+	}
+}
+
+func TestSearchByLocationAcrossAntimeridian(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	owner := pgtest.CreateUser(t, db, "owner", "user")
+
+	// Fiji on both sides of the antimeridian, and Greenwich
+	locate := func(lat, lng float64) int64 {
+		id := pgtest.CreatePhoto(t, db, owner, string(model.PhotoStatusApproved))
+		pgtest.Exec(t, db, `UPDATE photos SET exif_gps_latitude = $1, exif_gps_longitude = $2 WHERE id = $3`, lat, lng, id)
+		return id
+	}
+	east := locate(-17.8, 178.4)
+	west := locate(-16.5, -179.9)
+	greenwich := locate(51.5, 0)
+
+	tests := []struct {
+		name   string
+		box    *BoundingBox
+		expect []int64
+	}{
+		{
+			name:   "Box crossing the antimeridian",
+			box:    &BoundingBox{MinLat: -20, MaxLat: -10, MinLng: 170, MaxLng: -170},
+			expect: []int64{east, west},
+		},
+		{
+			name:   "Same longitudes without crossing",
+			box:    &BoundingBox{MinLat: -20, MaxLat: 60, MinLng: -170, MaxLng: 170},
+			expect: []int64{greenwich},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repo.SearchByLocation(context.Background(), GeoSearchParams{Box: tt.box})
+			if err != nil {
+				t.Fatalf("SearchByLocation() error = %v", err)
+			}
+
+			var got []int64
+			for _, p := range result.Photos {
+				got = append(got, p.ID)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.expect) {
+				t.Errorf("SearchByLocation() = %v, expected %v", got, tt.expect)
+			}
+		})
+	}
+}
@@ -9,10 +9,10 @@ import (
 	"QuanPhotos/internal/repository/postgresql"
 )
 
-// GetByID retrieves a photo by ID
+// GetByID retrieves a photo by ID, photos in the trash are not found
 func (r *PhotoRepository) GetByID(ctx context.Context, id int64) (*model.Photo, error) {
 	var photo model.Photo
-	query := `SELECT * FROM photos WHERE id = $1`
+	query := `SELECT * FROM photos WHERE id = $1 AND deleted_at IS NULL`
 
 	err := r.DB().GetContext(ctx, &photo, query, id)
 	if err != nil {
@@ -61,6 +61,22 @@ func (r *PhotoRepository) GetCategoryByID(ctx context.Context, id int32) (*model
 	return &category, nil
 }
 
+// GetSpotByID retrieves a spotting spot by ID
+func (r *PhotoRepository) GetSpotByID(ctx context.Context, id int64) (*model.SpottingSpot, error) {
+	var spot model.SpottingSpot
+	query := `SELECT * FROM spotting_spots WHERE id = $1`
+
+	err := r.DB().GetContext(ctx, &spot, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &spot, nil
+}
+
 // GetTagsByPhotoID retrieves tags for a photo
 func (r *PhotoRepository) GetTagsByPhotoID(ctx context.Context, photoID int64) ([]string, error) {
 	var tags []string
@@ -0,0 +1,98 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// snapshotDeleted records the metadata of a photo about to be deleted, so its
+// review history stays readable afterwards
+func snapshotDeleted(ctx context.Context, tx *sqlx.Tx, photoID, deletedBy int64, reason *string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO deleted_photos (
+			photo_id, user_id, category_id, title, description,
+			aircraft_type, airline, registration, airport,
+			status, attempt, uploaded_at, deleted_by, reason
+		)
+		SELECT id, user_id, category_id, title, description,
+			aircraft_type, airline, registration, airport,
+			status, attempt, created_at, $2, $3
+		FROM photos WHERE id = $1
+		ON CONFLICT (photo_id) DO NOTHING
+	`, photoID, deletedBy, toNullString(reason))
+	return err
+}
+
+// GetDeletedPhoto retrieves the snapshot of a deleted photo
+func (r *PhotoRepository) GetDeletedPhoto(ctx context.Context, photoID int64) (*model.DeletedPhoto, error) {
+	var photo model.DeletedPhoto
+	err := r.DB().GetContext(ctx, &photo, `SELECT * FROM deleted_photos WHERE photo_id = $1`, photoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// ListReviewHistory retrieves every review record of a photo, oldest first.
+// Records are kept after the photo is deleted.
+func (r *PhotoRepository) ListReviewHistory(ctx context.Context, photoID int64) ([]*model.PhotoReview, error) {
+	var reviews []*model.PhotoReview
+	err := r.DB().SelectContext(ctx, &reviews, `
+		SELECT id, photo_id, reviewer_id, review_type, action, reason, ai_result, attempt, stage, ticket_id, created_at
+		FROM photo_reviews
+		WHERE photo_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, photoID)
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// GetReviewReasonMap retrieves the rejection reasons linked to multiple reviews
+func (r *PhotoRepository) GetReviewReasonMap(ctx context.Context, reviewIDs []int64) (map[int64][]*model.RejectionReasonBrief, error) {
+	reasonMap := make(map[int64][]*model.RejectionReasonBrief)
+	if len(reviewIDs) == 0 {
+		return reasonMap, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT prr.review_id, rr.code, rr.name, rr.name_en
+		FROM photo_review_reasons prr
+		INNER JOIN rejection_reasons rr ON rr.id = prr.reason_id
+		WHERE prr.review_id IN (?)
+		ORDER BY rr.sort_order ASC, rr.id ASC
+	`, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+	query = r.DB().Rebind(query)
+
+	var rows []struct {
+		ReviewID int64  `db:"review_id"`
+		Code     string `db:"code"`
+		Name     string `db:"name"`
+		NameEN   string `db:"name_en"`
+	}
+	if err := r.DB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		reasonMap[row.ReviewID] = append(reasonMap[row.ReviewID], &model.RejectionReasonBrief{
+			Code:   row.Code,
+			Name:   row.Name,
+			NameEN: row.NameEN,
+		})
+	}
+	return reasonMap, nil
+}
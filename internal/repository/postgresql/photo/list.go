@@ -8,6 +8,7 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
 )
 
 // ListParams contains parameters for listing photos
@@ -15,17 +16,37 @@ type ListParams struct {
 	Page         int
 	PageSize     int
 	Status       string
+	Visibility   string // Empty means public only, "all" for every visibility
+	Schedule     string // Empty means published only, "scheduled" for queued photos, "all" for both
 	CategoryID   int32
 	UserID       int64
 	AircraftType string
 	Airline      string
 	Airport      string
 	Registration string
+	FlightNumber string
+	Origin       string
+	Destination  string
+	FlightPhase  string
+	CameraKey    string // Normalized camera key, see gear pages
+	LensKey      string // Normalized lens key, see gear pages
+	CameraModel  string // Exact EXIF camera model, case-insensitive
+	LensModel    string // Exact EXIF lens model, case-insensitive
 	Keyword      string
 	TakenFrom    string // Date in format "2006-01-02"
 	TakenTo      string // Date in format "2006-01-02"
 	SortBy       string // created_at, view_count, like_count, favorite_count
 	SortOrder    string // asc, desc
+
+	// EXIF ranges, bounds are inclusive
+	FocalLengthMin *float64 // 35mm-equivalent, millimetres
+	FocalLengthMax *float64
+	ApertureMin    *float64 // f-number
+	ApertureMax    *float64
+	ShutterMin     *float64 // Exposure time in seconds
+	ShutterMax     *float64
+	ISOMin         *int32
+	ISOMax         *int32
 }
 
 // ListResult contains the result of listing photos
@@ -70,20 +91,42 @@ func (r *PhotoRepository) List(ctx context.Context, params ListParams) (*ListRes
 		params.SortOrder = "desc"
 	}
 
-	// Build WHERE clause
-	var conditions []string
+	// Build WHERE clause, photos in the trash are never listed
+	conditions := []string{"deleted_at IS NULL"}
 	var args []interface{}
 	argIndex := 1
 
-	// Default: only show approved photos for public listing
-	if params.Status == "" {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
-		args = append(args, model.PhotoStatusApproved)
-		argIndex++
-	} else if params.Status != "all" {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
-		args = append(args, params.Status)
-		argIndex++
+	if params.Status == "" && params.Visibility == "" && params.Schedule == "" {
+		// Default: public listing
+		conditions = []string{postgresql.PublicPhotoFilter("")}
+	} else {
+		// Unset filters keep their public listing default
+		status := params.Status
+		if status == "" {
+			status = string(model.PhotoStatusApproved)
+		}
+		if status != "all" {
+			conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+			args = append(args, status)
+			argIndex++
+		}
+
+		visibility := params.Visibility
+		if visibility == "" {
+			visibility = string(model.PhotoVisibilityPublic)
+		}
+		if visibility != "all" {
+			conditions = append(conditions, fmt.Sprintf("visibility = $%d", argIndex))
+			args = append(args, visibility)
+			argIndex++
+		}
+
+		switch params.Schedule {
+		case "":
+			conditions = append(conditions, "publish_at IS NULL")
+		case "scheduled":
+			conditions = append(conditions, "publish_at IS NOT NULL")
+		}
 	}
 
 	if params.CategoryID > 0 {
@@ -122,8 +165,104 @@ func (r *PhotoRepository) List(ctx context.Context, params ListParams) (*ListRes
 		argIndex++
 	}
 
+	if params.FlightNumber != "" {
+		conditions = append(conditions, fmt.Sprintf("flight_number = $%d", argIndex))
+		args = append(args, params.FlightNumber)
+		argIndex++
+	}
+
+	if params.Origin != "" {
+		conditions = append(conditions, fmt.Sprintf("origin = $%d", argIndex))
+		args = append(args, params.Origin)
+		argIndex++
+	}
+
+	if params.Destination != "" {
+		conditions = append(conditions, fmt.Sprintf("destination = $%d", argIndex))
+		args = append(args, params.Destination)
+		argIndex++
+	}
+
+	if params.FlightPhase != "" {
+		conditions = append(conditions, fmt.Sprintf("flight_phase = $%d", argIndex))
+		args = append(args, params.FlightPhase)
+		argIndex++
+	}
+
+	if params.CameraKey != "" {
+		conditions = append(conditions, fmt.Sprintf("gear_camera_key = $%d", argIndex))
+		args = append(args, params.CameraKey)
+		argIndex++
+	}
+
+	if params.LensKey != "" {
+		conditions = append(conditions, fmt.Sprintf("gear_lens_key = $%d", argIndex))
+		args = append(args, params.LensKey)
+		argIndex++
+	}
+
+	if params.CameraModel != "" {
+		conditions = append(conditions, fmt.Sprintf("LOWER(exif_camera_model) = LOWER($%d)", argIndex))
+		args = append(args, params.CameraModel)
+		argIndex++
+	}
+
+	if params.LensModel != "" {
+		conditions = append(conditions, fmt.Sprintf("LOWER(exif_lens_model) = LOWER($%d)", argIndex))
+		args = append(args, params.LensModel)
+		argIndex++
+	}
+
+	if params.FocalLengthMin != nil {
+		conditions = append(conditions, fmt.Sprintf("exif_focal_length_35mm_num >= $%d", argIndex))
+		args = append(args, *params.FocalLengthMin)
+		argIndex++
+	}
+
+	if params.FocalLengthMax != nil {
+		conditions = append(conditions, fmt.Sprintf("exif_focal_length_35mm_num <= $%d", argIndex))
+		args = append(args, *params.FocalLengthMax)
+		argIndex++
+	}
+
+	if params.ApertureMin != nil {
+		conditions = append(conditions, fmt.Sprintf("exif_aperture_num >= $%d", argIndex))
+		args = append(args, *params.ApertureMin)
+		argIndex++
+	}
+
+	if params.ApertureMax != nil {
+		conditions = append(conditions, fmt.Sprintf("exif_aperture_num <= $%d", argIndex))
+		args = append(args, *params.ApertureMax)
+		argIndex++
+	}
+
+	if params.ShutterMin != nil {
+		conditions = append(conditions, fmt.Sprintf("exif_shutter_speed_num >= $%d", argIndex))
+		args = append(args, *params.ShutterMin)
+		argIndex++
+	}
+
+	if params.ShutterMax != nil {
+		conditions = append(conditions, fmt.Sprintf("exif_shutter_speed_num <= $%d", argIndex))
+		args = append(args, *params.ShutterMax)
+		argIndex++
+	}
+
+	if params.ISOMin != nil {
+		conditions = append(conditions, fmt.Sprintf("exif_iso >= $%d", argIndex))
+		args = append(args, *params.ISOMin)
+		argIndex++
+	}
+
+	if params.ISOMax != nil {
+		conditions = append(conditions, fmt.Sprintf("exif_iso <= $%d", argIndex))
+		args = append(args, *params.ISOMax)
+		argIndex++
+	}
+
 	if params.Keyword != "" {
-		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR description ILIKE $%d OR aircraft_type ILIKE $%d OR registration ILIKE $%d)", argIndex, argIndex, argIndex, argIndex))
+		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR description ILIKE $%d OR aircraft_type ILIKE $%d OR registration ILIKE $%d OR flight_number ILIKE $%d)", argIndex, argIndex, argIndex, argIndex, argIndex))
 		args = append(args, "%"+params.Keyword+"%")
 		argIndex++
 	}
@@ -185,7 +324,8 @@ func (r *PhotoRepository) List(ctx context.Context, params ListParams) (*ListRes
 	}, nil
 }
 
-// ListUserFavorites retrieves user's favorite photos
+// ListUserFavorites retrieves user's favorite photos. Photos of other users
+// that are no longer visible are left out.
 func (r *PhotoRepository) ListUserFavorites(ctx context.Context, userID int64, page, pageSize int) (*ListResult, error) {
 	if page < 1 {
 		page = 1
@@ -199,7 +339,11 @@ func (r *PhotoRepository) ListUserFavorites(ctx context.Context, userID int64, p
 
 	// Count total
 	var total int64
-	countQuery := `SELECT COUNT(*) FROM favorites WHERE user_id = $1`
+	countQuery := `
+		SELECT COUNT(*) FROM favorites f
+		INNER JOIN photos p ON p.id = f.photo_id
+		WHERE f.user_id = $1 AND ` + postgresql.VisibleToFilter("p", "$1") + `
+	`
 	err := r.DB().GetContext(ctx, &total, countQuery, userID)
 	if err != nil {
 		return nil, err
@@ -216,7 +360,7 @@ func (r *PhotoRepository) ListUserFavorites(ctx context.Context, userID int64, p
 	query := `
 		SELECT p.* FROM photos p
 		INNER JOIN favorites f ON f.photo_id = p.id
-		WHERE f.user_id = $1
+		WHERE f.user_id = $1 AND ` + postgresql.VisibleToFilter("p", "$1") + `
 		ORDER BY f.created_at DESC
 		LIMIT $2 OFFSET $3
 	`
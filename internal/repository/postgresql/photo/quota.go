@@ -0,0 +1,60 @@
+package photo
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// storedBytes returns the size of the stored files counted against the
+// owner's storage quota
+func (f *FileParams) storedBytes() int64 {
+	var size int64
+	for _, s := range []*int64{f.FileSize, f.RawFileSize, f.DerivativeSize} {
+		if s != nil {
+			size += *s
+		}
+	}
+	return size
+}
+
+// reserveStorage checks that size more bytes fit the user's quotaBytes, 0 is
+// unlimited. Stored photos and pending replacements count as used. The
+// user's usage row stays locked until the transaction ends, so concurrent
+// uploads are checked one after another and cannot exceed the quota together.
+func reserveStorage(ctx context.Context, tx *sqlx.Tx, userID, size, quotaBytes int64) error {
+	if quotaBytes == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO user_storage (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING
+	`, userID)
+	if err != nil {
+		return err
+	}
+
+	var used int64
+	err = tx.GetContext(ctx, &used, `
+		SELECT used_bytes + `+postgresql.PendingReplacementBytes("user_id = $1")+`
+		FROM user_storage WHERE user_id = $1 FOR UPDATE
+	`, userID)
+	if err != nil {
+		return err
+	}
+
+	if used+size > quotaBytes {
+		return postgresql.ErrQuotaExceeded
+	}
+	return nil
+}
+
+// PendingReplacementSize returns the size of the stored files of a photo's
+// pending replacement, 0 when it has none
+func (r *PhotoRepository) PendingReplacementSize(ctx context.Context, photoID int64) (int64, error) {
+	var size int64
+	err := r.DB().GetContext(ctx, &size, `SELECT `+postgresql.PendingReplacementBytes("photo_id = $1"), photoID)
+	return size, err
+}
@@ -0,0 +1,102 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+// quotaPhoto returns creation parameters of a photo whose files take size bytes
+func quotaPhoto(userID, size, quotaBytes int64) *CreatePhotoParams {
+	return &CreatePhotoParams{
+		UserID:     userID,
+		Title:      "Quota photo",
+		FileParams: FileParams{FilePath: "quota.jpg", FileSize: &size},
+		QuotaBytes: quotaBytes,
+	}
+}
+
+func TestCreateWithTagsConcurrentQuota(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+
+	// Each upload fits the quota on its own, but not together
+	const uploads = 4
+	var wg sync.WaitGroup
+	errs := make([]error, uploads)
+	for i := range uploads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = repo.CreateWithTags(context.Background(), quotaPhoto(ownerID, 600, 1000))
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, postgresql.ErrQuotaExceeded):
+			t.Errorf("CreateWithTags() error = %v, expected ErrQuotaExceeded", err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("created %d photos, expected 1", created)
+	}
+
+	var used int64
+	if err := db.Get(&used, `SELECT used_bytes FROM user_storage WHERE user_id = $1`, ownerID); err != nil {
+		t.Fatalf("get usage: %v", err)
+	}
+	if used != 600 {
+		t.Errorf("used_bytes = %d, expected 600", used)
+	}
+}
+
+func TestReserveStorageCountsPendingReplacements(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+
+	photoID, err := repo.CreateWithTags(ctx, quotaPhoto(ownerID, 400, 1000))
+	if err != nil {
+		t.Fatalf("CreateWithTags() error = %v", err)
+	}
+	pgtest.Exec(t, db, `UPDATE photos SET status = $1 WHERE id = $2`, model.PhotoStatusApproved, photoID)
+
+	replacementSize := int64(500)
+	replace := func() error {
+		_, _, err := repo.CreateReplacement(ctx, &CreateReplacementParams{
+			PhotoID:    photoID,
+			UserID:     ownerID,
+			File:       FileParams{FilePath: "replacement.jpg", FileSize: &replacementSize},
+			QuotaBytes: 1000,
+		})
+		return err
+	}
+
+	// The current files stay stored next to a pending replacement
+	if err := replace(); err != nil {
+		t.Fatalf("CreateReplacement() error = %v", err)
+	}
+	// A new replacement supersedes the pending one instead of adding to it
+	if err := replace(); err != nil {
+		t.Errorf("second CreateReplacement() error = %v, expected the first to be superseded", err)
+	}
+
+	if _, err := repo.CreateWithTags(ctx, quotaPhoto(ownerID, 200, 1000)); !errors.Is(err, postgresql.ErrQuotaExceeded) {
+		t.Errorf("CreateWithTags() error = %v, expected ErrQuotaExceeded with the pending replacement", err)
+	}
+	if _, err := repo.CreateWithTags(ctx, quotaPhoto(ownerID, 100, 1000)); err != nil {
+		t.Errorf("CreateWithTags() error = %v, expected the upload to fit", err)
+	}
+}
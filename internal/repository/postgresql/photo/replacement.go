@@ -0,0 +1,348 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// CreateReplacementParams contains a replacement image uploaded for a photo
+type CreateReplacementParams struct {
+	PhotoID   int64
+	UserID    int64
+	File      FileParams
+	Screening *model.QualityScreening // Nil when not screened
+
+	// Rejected records a replacement that failed quality screening
+	Rejected bool
+	Reason   *string
+
+	// Storage quota of the owner in bytes, checked for pending replacements.
+	// 0 is unlimited.
+	QuotaBytes int64
+}
+
+// CreateReplacement records a replacement image for an approved photo of the
+// user. A pending replacement of the photo is superseded and returned, so its
+// files can be removed. Returns ErrNotFound if the user has no approved photo
+// with this ID, ErrQuotaExceeded if the new files do not fit the user's
+// storage quota next to the current ones.
+func (r *PhotoRepository) CreateReplacement(ctx context.Context, params *CreateReplacementParams) (*model.PhotoReplacement, []*model.PhotoReplacement, error) {
+	fileParams, err := json.Marshal(params.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	var screening []byte
+	if params.Screening != nil {
+		if screening, err = json.Marshal(params.Screening); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	var photoID int64
+	err = tx.GetContext(ctx, &photoID, `
+		SELECT id FROM photos
+		WHERE id = $1 AND user_id = $2 AND status = $3 AND deleted_at IS NULL
+		FOR UPDATE
+	`, params.PhotoID, params.UserID, model.PhotoStatusApproved)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, postgresql.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	status := model.ReplacementStatusPending
+	var reviewType *string
+	var superseded []*model.PhotoReplacement
+	if params.Rejected {
+		status = model.ReplacementStatusRejected
+		screened := "screening"
+		reviewType = &screened
+	} else {
+		err = tx.SelectContext(ctx, &superseded, `
+			UPDATE photo_replacements SET status = $1, decided_at = NOW()
+			WHERE photo_id = $2 AND status = $3
+			RETURNING *
+		`, model.ReplacementStatusSuperseded, params.PhotoID, model.ReplacementStatusPending)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Superseded files no longer count, the photo's current files do
+		if err := reserveStorage(ctx, tx, params.UserID, params.File.storedBytes(), params.QuotaBytes); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var replacement model.PhotoReplacement
+	err = tx.GetContext(ctx, &replacement, `
+		INSERT INTO photo_replacements (
+			photo_id, user_id, status, file_path, thumbnail_path, raw_file_path,
+			file_params, screening, review_type, reason, decided_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CASE WHEN $11 THEN NOW() END)
+		RETURNING *
+	`,
+		params.PhotoID, params.UserID, status,
+		params.File.FilePath, toNullString(params.File.ThumbnailPath), toNullString(params.File.RawFilePath),
+		fileParams, screening, toNullString(reviewType), toNullString(params.Reason), params.Rejected,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return &replacement, superseded, nil
+}
+
+// GetReplacement retrieves a replacement by ID
+func (r *PhotoRepository) GetReplacement(ctx context.Context, id int64) (*model.PhotoReplacement, error) {
+	var replacement model.PhotoReplacement
+	err := r.DB().GetContext(ctx, &replacement, `SELECT * FROM photo_replacements WHERE id = $1`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &replacement, nil
+}
+
+// ReplacementListParams contains parameters for listing replacements
+type ReplacementListParams struct {
+	Page               int
+	PageSize           int
+	PhotoID            int64
+	Status             string
+	CategoryReviewerID int64 // Only photos in categories assigned to this reviewer
+}
+
+// ReplacementListResult contains a page of replacements
+type ReplacementListResult struct {
+	Replacements []*model.PhotoReplacement
+	Total        int64
+	Page         int
+	PageSize     int
+	TotalPages   int
+}
+
+// ListReplacements retrieves replacements, pending ones oldest first and
+// others newest first
+func (r *PhotoRepository) ListReplacements(ctx context.Context, params ReplacementListParams) (*ReplacementListResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	conditions := []string{"p.deleted_at IS NULL"}
+	args := []interface{}{}
+	argIndex := 1
+
+	if params.PhotoID > 0 {
+		conditions = append(conditions, fmt.Sprintf("pr.photo_id = $%d", argIndex))
+		args = append(args, params.PhotoID)
+		argIndex++
+	}
+	if params.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("pr.status = $%d", argIndex))
+		args = append(args, params.Status)
+		argIndex++
+	}
+	if params.CategoryReviewerID > 0 {
+		conditions = append(conditions, fmt.Sprintf("p.category_id IN (SELECT category_id FROM reviewer_categories WHERE reviewer_id = $%d)", argIndex))
+		args = append(args, params.CategoryReviewerID)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*) FROM photo_replacements pr
+		INNER JOIN photos p ON p.id = pr.photo_id
+		%s
+	`, whereClause)
+	if err := r.DB().GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, err
+	}
+
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize > 0 {
+		totalPages++
+	}
+
+	order := "pr.id DESC"
+	if params.Status == string(model.ReplacementStatusPending) {
+		order = "pr.created_at ASC"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pr.* FROM photo_replacements pr
+		INNER JOIN photos p ON p.id = pr.photo_id
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, whereClause, order, argIndex, argIndex+1)
+	args = append(args, params.PageSize, offset)
+
+	var replacements []*model.PhotoReplacement
+	if err := r.DB().SelectContext(ctx, &replacements, query, args...); err != nil {
+		return nil, err
+	}
+
+	return &ReplacementListResult{
+		Replacements: replacements,
+		Total:        total,
+		Page:         params.Page,
+		PageSize:     params.PageSize,
+		TotalPages:   totalPages,
+	}, nil
+}
+
+// GetPhotoMap retrieves photos by ID
+func (r *PhotoRepository) GetPhotoMap(ctx context.Context, photoIDs []int64) (map[int64]*model.Photo, error) {
+	photos := make(map[int64]*model.Photo)
+	if len(photoIDs) == 0 {
+		return photos, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM photos WHERE id IN (?)`, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+	query = r.DB().Rebind(query)
+
+	var rows []*model.Photo
+	if err := r.DB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, p := range rows {
+		photos[p.ID] = p
+	}
+
+	return photos, nil
+}
+
+// DecideReplacementParams contains parameters for a replacement decision
+type DecideReplacementParams struct {
+	ReplacementID int64
+	Approve       bool
+	ReviewType    string // manual, trust
+	ReviewerID    *int64 // Nil for trust approvals
+	Reason        *string
+	SpotCheck     bool // Sample the photo for a spot check after approval
+}
+
+// DecideReplacement approves or rejects a pending replacement. Approval writes
+// the replacement's file and EXIF columns to the photo, leaving its metadata,
+// counters, tags, comments and featured status untouched, and records the
+// replaced paths. Returns ErrNotFound if the replacement is not pending.
+func (r *PhotoRepository) DecideReplacement(ctx context.Context, params *DecideReplacementParams) (*model.PhotoReplacement, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Lock the photo before the replacement, in the order uploads do
+	var photoID int64
+	err = tx.GetContext(ctx, &photoID, `SELECT photo_id FROM photo_replacements WHERE id = $1`, params.ReplacementID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	current, err := lockPhoto(ctx, tx, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var replacement model.PhotoReplacement
+	err = tx.GetContext(ctx, &replacement, `
+		SELECT * FROM photo_replacements WHERE id = $1 AND status = $2 FOR UPDATE
+	`, params.ReplacementID, model.ReplacementStatusPending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+
+	status := model.ReplacementStatusRejected
+	var previousFile, previousThumbnail, previousRaw sql.NullString
+	if params.Approve {
+		status = model.ReplacementStatusApproved
+		previousFile = sql.NullString{String: current.FilePath, Valid: true}
+		previousThumbnail = current.ThumbnailPath
+		previousRaw = current.RawFilePath
+
+		var file FileParams
+		if err := json.Unmarshal(replacement.FileParams, &file); err != nil {
+			return nil, err
+		}
+
+		sets := []string{"updated_at = NOW()"}
+		args := []interface{}{}
+		columns, values := file.columns()
+		for i, column := range columns {
+			args = append(args, values[i])
+			sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+		}
+		if params.SpotCheck {
+			sets = append(sets, "spot_check_pending = TRUE")
+		}
+		args = append(args, replacement.PhotoID)
+
+		query := fmt.Sprintf(`UPDATE photos SET %s WHERE id = $%d`, strings.Join(sets, ", "), len(args))
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	var decided model.PhotoReplacement
+	err = tx.GetContext(ctx, &decided, `
+		UPDATE photo_replacements
+		SET status = $1, review_type = $2, reviewer_id = $3, reason = $4, decided_at = NOW(),
+			previous_file_path = $5, previous_thumbnail_path = $6, previous_raw_file_path = $7
+		WHERE id = $8
+		RETURNING *
+	`, status, params.ReviewType, toNullInt64(params.ReviewerID), toNullString(params.Reason),
+		previousFile, previousThumbnail, previousRaw, params.ReplacementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &decided, nil
+}
@@ -0,0 +1,115 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+// replacementParams returns a replacement image upload for a photo
+func replacementParams(photoID, userID int64, filePath string) *CreateReplacementParams {
+	thumbnailPath := "thumbnails/" + filePath
+	return &CreateReplacementParams{
+		PhotoID: photoID,
+		UserID:  userID,
+		File:    FileParams{FilePath: filePath, ThumbnailPath: &thumbnailPath},
+	}
+}
+
+func TestReplacementHistory(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	reviewerID := pgtest.CreateUser(t, db, "reviewer", "reviewer")
+	photoID := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+
+	first, superseded, err := repo.CreateReplacement(ctx, replacementParams(photoID, ownerID, "first.jpg"))
+	if err != nil {
+		t.Fatalf("CreateReplacement() error = %v", err)
+	}
+	if first.Status != model.ReplacementStatusPending || len(superseded) != 0 {
+		t.Fatalf("first replacement = %s, %d superseded, expected pending, none", first.Status, len(superseded))
+	}
+
+	// A new upload supersedes the pending one
+	second, superseded, err := repo.CreateReplacement(ctx, replacementParams(photoID, ownerID, "second.jpg"))
+	if err != nil {
+		t.Fatalf("CreateReplacement() error = %v", err)
+	}
+	if len(superseded) != 1 || superseded[0].ID != first.ID || superseded[0].Status != model.ReplacementStatusSuperseded {
+		t.Fatalf("superseded = %+v, expected the first replacement", superseded)
+	}
+
+	approved, err := repo.DecideReplacement(ctx, &DecideReplacementParams{
+		ReplacementID: second.ID,
+		Approve:       true,
+		ReviewType:    "manual",
+		ReviewerID:    &reviewerID,
+	})
+	if err != nil {
+		t.Fatalf("DecideReplacement() error = %v", err)
+	}
+	if approved.Status != model.ReplacementStatusApproved {
+		t.Errorf("status = %s, expected approved", approved.Status)
+	}
+	if approved.PreviousFilePath.String != "test.jpg" {
+		t.Errorf("previous file = %q, expected the replaced test.jpg", approved.PreviousFilePath.String)
+	}
+
+	p, err := repo.GetByID(ctx, photoID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if p.FilePath != "second.jpg" || p.Status != model.PhotoStatusApproved {
+		t.Errorf("photo = %s, %s, expected second.jpg, approved", p.FilePath, p.Status)
+	}
+
+	// A decided replacement cannot be decided again
+	_, err = repo.DecideReplacement(ctx, &DecideReplacementParams{ReplacementID: second.ID, ReviewType: "manual", ReviewerID: &reviewerID})
+	if !errors.Is(err, postgresql.ErrNotFound) {
+		t.Errorf("second DecideReplacement() error = %v, expected ErrNotFound", err)
+	}
+
+	history, err := repo.ListReplacements(ctx, ReplacementListParams{PhotoID: photoID})
+	if err != nil {
+		t.Fatalf("ListReplacements() error = %v", err)
+	}
+	if history.Total != 2 || history.Replacements[0].ID != second.ID || history.Replacements[1].ID != first.ID {
+		t.Errorf("history = %d rows, expected the second then the first replacement", history.Total)
+	}
+}
+
+func TestCreateReplacementRefused(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	otherID := pgtest.CreateUser(t, db, "other", "user")
+
+	deleted := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+	pgtest.Exec(t, db, `UPDATE photos SET deleted_at = NOW() WHERE id = $1`, deleted)
+
+	tests := []struct {
+		name    string
+		photoID int64
+		userID  int64
+	}{
+		{"Other user", pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved)), otherID},
+		{"Pending photo", pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusPending)), ownerID},
+		{"Rejected photo", pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusRejected)), ownerID},
+		{"Photo in the trash", deleted, ownerID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := repo.CreateReplacement(context.Background(), replacementParams(tt.photoID, tt.userID, "new.jpg"))
+			if !errors.Is(err, postgresql.ErrNotFound) {
+				t.Errorf("CreateReplacement() error = %v, expected ErrNotFound", err)
+			}
+		})
+	}
+}
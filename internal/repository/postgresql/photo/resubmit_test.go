@@ -0,0 +1,98 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+func TestResubmitStartsNewAttempt(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	photoID := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusRejected))
+	pgtest.Exec(t, db, `UPDATE photos SET review_stage = 'senior' WHERE id = $1`, photoID)
+
+	title := "Corrected title"
+	previous, err := repo.Resubmit(context.Background(), photoID, ownerID, &ResubmitParams{Title: &title})
+	if err != nil {
+		t.Fatalf("Resubmit() error = %v", err)
+	}
+	if previous.Attempt != 1 || previous.Status != model.PhotoStatusRejected {
+		t.Errorf("previous photo = attempt %d, %s, expected attempt 1, rejected", previous.Attempt, previous.Status)
+	}
+
+	p, err := repo.GetByID(context.Background(), photoID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if p.Attempt != 2 {
+		t.Errorf("attempt = %d, expected 2", p.Attempt)
+	}
+	if p.Status != model.PhotoStatusPending {
+		t.Errorf("status = %s, expected pending", p.Status)
+	}
+	if p.ReviewStage != model.ReviewStageStandard {
+		t.Errorf("review stage = %s, expected standard", p.ReviewStage)
+	}
+	if p.Title != title {
+		t.Errorf("title = %q, expected %q", p.Title, title)
+	}
+
+	var revisions int
+	if err := db.Get(&revisions, `
+		SELECT COUNT(*) FROM photo_revisions WHERE photo_id = $1 AND editor_id = $2 AND source = $3
+	`, photoID, ownerID, model.RevisionSourceResubmit); err != nil {
+		t.Fatalf("count revisions: %v", err)
+	}
+	if revisions != 1 {
+		t.Errorf("resubmit revisions = %d, expected 1", revisions)
+	}
+
+	// The photo is back in review and cannot be resubmitted again
+	if _, err := repo.Resubmit(context.Background(), photoID, ownerID, &ResubmitParams{}); !errors.Is(err, postgresql.ErrNotFound) {
+		t.Errorf("second Resubmit() error = %v, expected ErrNotFound", err)
+	}
+}
+
+func TestResubmitRefused(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	otherID := pgtest.CreateUser(t, db, "other", "user")
+
+	deleted := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusRejected))
+	pgtest.Exec(t, db, `UPDATE photos SET deleted_at = NOW() WHERE id = $1`, deleted)
+
+	tests := []struct {
+		name    string
+		photoID int64
+		userID  int64
+	}{
+		{"Other user", pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusRejected)), otherID},
+		{"Pending photo", pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusPending)), ownerID},
+		{"Approved photo", pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved)), ownerID},
+		{"Photo in the trash", deleted, ownerID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.Resubmit(context.Background(), tt.photoID, tt.userID, &ResubmitParams{})
+			if !errors.Is(err, postgresql.ErrNotFound) {
+				t.Fatalf("Resubmit() error = %v, expected ErrNotFound", err)
+			}
+
+			var attempt int
+			if err := db.Get(&attempt, `SELECT attempt FROM photos WHERE id = $1`, tt.photoID); err != nil {
+				t.Fatalf("get attempt: %v", err)
+			}
+			if attempt != 1 {
+				t.Errorf("attempt = %d, expected 1", attempt)
+			}
+		})
+	}
+}
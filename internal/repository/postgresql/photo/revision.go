@@ -0,0 +1,462 @@
+package photo
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// Revision field names of the editable metadata, text fields match their column
+const (
+	FieldTitle      = "title"
+	FieldCategoryID = "category_id"
+	FieldTags       = "tags"
+)
+
+// textFields are the editable optional text columns of a photo
+var textFields = []string{
+	"description", "aircraft_type", "airline", "registration", "airport",
+	"flight_number", "origin", "destination", "flight_phase",
+}
+
+// MetadataParams contains edited metadata of a photo.
+// Nil fields are left unchanged, empty values clear optional fields.
+type MetadataParams struct {
+	Title        *string
+	Description  *string
+	CategoryID   *int32 // 0 clears the category
+	AircraftType *string
+	Airline      *string
+	Registration *string
+	Airport      *string
+	FlightNumber *string
+	Origin       *string
+	Destination  *string
+	FlightPhase  *string
+	Tags         *[]string // Replaces all tags of the photo
+
+	// ReviewFields are the fields whose change sends an approved photo back to review
+	ReviewFields []string
+}
+
+// values returns the submitted fields by revision field name
+func (p *MetadataParams) values() map[string]interface{} {
+	values := make(map[string]interface{})
+	if p.Title != nil {
+		values[FieldTitle] = *p.Title
+	}
+	if p.CategoryID != nil {
+		categoryID := p.CategoryID
+		if *categoryID == 0 {
+			categoryID = nil
+		}
+		values[FieldCategoryID] = categoryID
+	}
+	text := map[string]*string{
+		"description":   p.Description,
+		"aircraft_type": p.AircraftType,
+		"airline":       p.Airline,
+		"registration":  p.Registration,
+		"airport":       p.Airport,
+		"flight_number": p.FlightNumber,
+		"origin":        p.Origin,
+		"destination":   p.Destination,
+		"flight_phase":  p.FlightPhase,
+	}
+	for field, value := range text {
+		if value != nil {
+			values[field] = emptyToNil(value)
+		}
+	}
+	if p.Tags != nil {
+		tags := append([]string{}, *p.Tags...)
+		sort.Strings(tags)
+		values[FieldTags] = tags
+	}
+	return values
+}
+
+// UpdateMetadata applies edited metadata to a photo and records the changes as
+// a revision. An approved photo goes back to pending as a new review attempt
+// when one of params.ReviewFields changed. Returns nil without a revision if
+// nothing changed; ErrNotFound if the photo does not exist.
+func (r *PhotoRepository) UpdateMetadata(ctx context.Context, photoID, editorID int64, params *MetadataParams) (*model.PhotoRevision, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	current, err := lockPhoto(ctx, tx, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	values := params.values()
+	target := make(map[string]json.RawMessage, len(values))
+	for field, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		target[field] = encoded
+	}
+
+	changes, err := diffMetadata(ctx, tx, current, target)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+
+	reReview := false
+	if current.Status == model.PhotoStatusApproved {
+		for _, field := range params.ReviewFields {
+			if _, ok := changes[field]; ok {
+				reReview = true
+				break
+			}
+		}
+	}
+
+	if err := applyChanges(ctx, tx, photoID, changes, reReview); err != nil {
+		return nil, err
+	}
+
+	revision, err := insertRevision(ctx, tx, &model.PhotoRevision{
+		PhotoID:  photoID,
+		EditorID: sql.NullInt64{Int64: editorID, Valid: true},
+		Source:   model.RevisionSourceEdit,
+		ReReview: reReview,
+	}, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// RollbackRevision restores the metadata a photo had before the given revision,
+// undoing it and every later revision, and records the rollback as a revision.
+// Rollbacks never send the photo back to review. Returns nil without a revision
+// if nothing changed; ErrNotFound if the photo has no such revision.
+func (r *PhotoRepository) RollbackRevision(ctx context.Context, photoID, revisionID, editorID int64) (*model.PhotoRevision, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	current, err := lockPhoto(ctx, tx, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []*model.PhotoRevision
+	err = tx.SelectContext(ctx, &revisions, `
+		SELECT * FROM photo_revisions
+		WHERE photo_id = $1 AND id >= $2
+		ORDER BY id ASC
+	`, photoID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 || revisions[0].ID != revisionID {
+		return nil, postgresql.ErrNotFound
+	}
+
+	// The oldest undone revision of a field holds the value to restore
+	target := make(map[string]json.RawMessage)
+	for _, revision := range revisions {
+		changes, err := revision.FieldChanges()
+		if err != nil {
+			return nil, err
+		}
+		for field, change := range changes {
+			if _, ok := target[field]; ok {
+				continue
+			}
+			// Re-encode values read back from JSONB so they compare equal
+			var value interface{}
+			if err := json.Unmarshal(change.Old, &value); err != nil {
+				return nil, err
+			}
+			if target[field], err = json.Marshal(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	changes, err := diffMetadata(ctx, tx, current, target)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+
+	if err := applyChanges(ctx, tx, photoID, changes, false); err != nil {
+		return nil, err
+	}
+
+	revision, err := insertRevision(ctx, tx, &model.PhotoRevision{
+		PhotoID:    photoID,
+		EditorID:   sql.NullInt64{Int64: editorID, Valid: true},
+		Source:     model.RevisionSourceRollback,
+		RollbackOf: sql.NullInt64{Int64: revisionID, Valid: true},
+	}, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// ListRevisions retrieves the revisions of a photo, newest first
+func (r *PhotoRepository) ListRevisions(ctx context.Context, photoID int64) ([]*model.PhotoRevision, error) {
+	var revisions []*model.PhotoRevision
+	err := r.DB().SelectContext(ctx, &revisions, `
+		SELECT * FROM photo_revisions
+		WHERE photo_id = $1
+		ORDER BY id DESC
+	`, photoID)
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// lockPhoto retrieves a photo and locks it for the rest of the transaction
+func lockPhoto(ctx context.Context, tx *sqlx.Tx, photoID int64) (*model.Photo, error) {
+	var p model.Photo
+	err := tx.GetContext(ctx, &p, `SELECT * FROM photos WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, photoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// metadataSnapshot returns the JSON encoded editable fields of a photo.
+// Tags are only included when not nil.
+func metadataSnapshot(p *model.Photo, tags []string) (map[string]json.RawMessage, error) {
+	values := map[string]interface{}{
+		FieldTitle:      p.Title,
+		FieldCategoryID: nullInt32Value(p.CategoryID),
+		"description":   nullStringValue(p.Description),
+		"aircraft_type": nullStringValue(p.AircraftType),
+		"airline":       nullStringValue(p.Airline),
+		"registration":  nullStringValue(p.Registration),
+		"airport":       nullStringValue(p.Airport),
+		"flight_number": nullStringValue(p.FlightNumber),
+		"origin":        nullStringValue(p.Origin),
+		"destination":   nullStringValue(p.Destination),
+		"flight_phase":  nullStringValue(p.FlightPhase),
+	}
+	if tags != nil {
+		values[FieldTags] = tags
+	}
+
+	snapshot := make(map[string]json.RawMessage, len(values))
+	for field, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[field] = encoded
+	}
+	return snapshot, nil
+}
+
+// diffMetadata compares target values with the current metadata of a locked
+// photo and returns the changes by field. Unknown fields are ignored.
+func diffMetadata(ctx context.Context, tx *sqlx.Tx, current *model.Photo, target map[string]json.RawMessage) (map[string]model.FieldChange, error) {
+	var tags []string
+	if _, ok := target[FieldTags]; ok {
+		err := tx.SelectContext(ctx, &tags, `
+			SELECT t.name FROM tags t
+			INNER JOIN photo_tags pt ON pt.tag_id = t.id
+			WHERE pt.photo_id = $1
+		`, current.ID)
+		if err != nil {
+			return nil, err
+		}
+		if tags == nil {
+			tags = []string{}
+		}
+		sort.Strings(tags)
+	}
+
+	before, err := metadataSnapshot(current, tags)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(before, target), nil
+}
+
+// diffSnapshots returns the fields of after that differ from before
+func diffSnapshots(before, after map[string]json.RawMessage) map[string]model.FieldChange {
+	changes := make(map[string]model.FieldChange)
+	for field, value := range after {
+		old, ok := before[field]
+		if ok && !bytes.Equal(old, value) {
+			changes[field] = model.FieldChange{Old: old, New: value}
+		}
+	}
+	return changes
+}
+
+// applyChanges writes changed metadata fields to a photo, optionally moving it
+// back to pending as a new review attempt in the standard queue
+func applyChanges(ctx context.Context, tx *sqlx.Tx, photoID int64, changes map[string]model.FieldChange, reReview bool) error {
+	sets := []string{"updated_at = NOW()"}
+	args := []interface{}{}
+	argIndex := 1
+
+	set := func(column string, value interface{}) {
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, argIndex))
+		args = append(args, value)
+		argIndex++
+	}
+
+	if reReview {
+		sets = append(sets, "attempt = attempt + 1", "review_stage = 'standard'", "approved_at = NULL", "spot_check_pending = FALSE")
+		set("status", model.PhotoStatusPending)
+	}
+
+	var tags []string
+	for field, change := range changes {
+		switch field {
+		case FieldTitle:
+			var title string
+			if err := json.Unmarshal(change.New, &title); err != nil {
+				return err
+			}
+			set(FieldTitle, title)
+		case FieldCategoryID:
+			var categoryID *int32
+			if err := json.Unmarshal(change.New, &categoryID); err != nil {
+				return err
+			}
+			set(FieldCategoryID, toNullInt32(categoryID))
+		case FieldTags:
+			if err := json.Unmarshal(change.New, &tags); err != nil {
+				return err
+			}
+			if tags == nil {
+				tags = []string{}
+			}
+		default:
+			if !isTextField(field) {
+				continue
+			}
+			var value *string
+			if err := json.Unmarshal(change.New, &value); err != nil {
+				return err
+			}
+			set(field, toNullString(emptyToNil(value)))
+		}
+	}
+
+	query := fmt.Sprintf(`UPDATE photos SET %s WHERE id = $%d`, strings.Join(sets, ", "), argIndex)
+	args = append(args, photoID)
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if tags != nil {
+		return replaceTags(ctx, tx, photoID, tags)
+	}
+	return nil
+}
+
+// replaceTags replaces all tags of a photo, creating missing tags
+func replaceTags(ctx context.Context, tx *sqlx.Tx, photoID int64, tags []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM photo_tags WHERE photo_id = $1`, photoID); err != nil {
+		return err
+	}
+
+	for _, tagName := range tags {
+		var tagID int32
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO tags (name) VALUES ($1)
+			ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id
+		`, tagName).Scan(&tagID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO photo_tags (photo_id, tag_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, photoID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertRevision stores a revision with its changes
+func insertRevision(ctx context.Context, tx *sqlx.Tx, revision *model.PhotoRevision, changes map[string]model.FieldChange) (*model.PhotoRevision, error) {
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored model.PhotoRevision
+	err = tx.GetContext(ctx, &stored, `
+		INSERT INTO photo_revisions (photo_id, editor_id, source, changes, rereview, rollback_of)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING *
+	`, revision.PhotoID, revision.EditorID, revision.Source, encoded, revision.ReReview, revision.RollbackOf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stored, nil
+}
+
+// isTextField reports whether a revision field is an optional text column
+func isTextField(field string) bool {
+	for _, f := range textFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// nullStringValue returns the string of a nullable column, nil when NULL
+func nullStringValue(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+// nullInt32Value returns the integer of a nullable column, nil when NULL
+func nullInt32Value(i sql.NullInt32) *int32 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int32
+}
@@ -0,0 +1,251 @@
+package photo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+// encodeTarget returns the JSON encoded values of edited metadata
+func encodeTarget(t *testing.T, params *MetadataParams) map[string]json.RawMessage {
+	t.Helper()
+
+	target := make(map[string]json.RawMessage)
+	for field, value := range params.values() {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		target[field] = encoded
+	}
+	return target
+}
+
+// changedFields returns the sorted field names of changes
+func changedFields(changes map[string]model.FieldChange) []string {
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func TestDiffMetadata(t *testing.T) {
+	db := pgtest.Open(t)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	photoID := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+	pgtest.Exec(t, db, `UPDATE photos SET airline = 'Air China' WHERE id = $1`, photoID)
+	pgtest.Exec(t, db, `INSERT INTO tags (name) VALUES ('b737'), ('sunset') ON CONFLICT (name) DO NOTHING`)
+	pgtest.Exec(t, db, `INSERT INTO photo_tags (photo_id, tag_id) SELECT $1, id FROM tags WHERE name IN ('b737', 'sunset')`, photoID)
+
+	title := "Test photo"
+	newTitle := "New title"
+	airline := "Air China"
+	empty := ""
+	var noCategory int32
+	sameTags := []string{"sunset", "b737"}
+	newTags := []string{"b737"}
+
+	tests := []struct {
+		name   string
+		params *MetadataParams
+		expect []string
+	}{
+		{"Unchanged values", &MetadataParams{Title: &title, Airline: &airline}, []string{}},
+		{"Changed title", &MetadataParams{Title: &newTitle, Airline: &airline}, []string{"title"}},
+		{"Cleared text field", &MetadataParams{Airline: &empty}, []string{"airline"}},
+		{"Empty text field already NULL", &MetadataParams{Description: &empty}, []string{}},
+		{"Cleared category already NULL", &MetadataParams{CategoryID: &noCategory}, []string{}},
+		{"Same tags in another order", &MetadataParams{Tags: &sameTags}, []string{}},
+		{"Removed tag", &MetadataParams{Tags: &newTags}, []string{"tags"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := db.BeginTxx(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tx.Rollback()
+
+			current, err := lockPhoto(ctx, tx, photoID)
+			if err != nil {
+				t.Fatalf("lockPhoto() error = %v", err)
+			}
+			changes, err := diffMetadata(ctx, tx, current, encodeTarget(t, tt.params))
+			if err != nil {
+				t.Fatalf("diffMetadata() error = %v", err)
+			}
+			if fields := changedFields(changes); strings.Join(fields, ",") != strings.Join(tt.expect, ",") {
+				t.Errorf("diffMetadata() fields = %v, expected %v", fields, tt.expect)
+			}
+		})
+	}
+}
+
+func TestApplyChanges(t *testing.T) {
+	db := pgtest.Open(t)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+
+	tests := []struct {
+		name          string
+		reReview      bool
+		expectStatus  model.PhotoStatus
+		expectAttempt int
+	}{
+		{"Edit keeps the approval", false, model.PhotoStatusApproved, 1},
+		{"Re-review starts a new attempt", true, model.PhotoStatusPending, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			photoID := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+			pgtest.Exec(t, db, `UPDATE photos SET registration = 'B-1234', approved_at = NOW() WHERE id = $1`, photoID)
+
+			title := "New title"
+			empty := ""
+			tags := []string{"a380", "night"}
+
+			tx, err := db.BeginTxx(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tx.Rollback()
+
+			current, err := lockPhoto(ctx, tx, photoID)
+			if err != nil {
+				t.Fatalf("lockPhoto() error = %v", err)
+			}
+			changes, err := diffMetadata(ctx, tx, current, encodeTarget(t, &MetadataParams{Title: &title, Registration: &empty, Tags: &tags}))
+			if err != nil {
+				t.Fatalf("diffMetadata() error = %v", err)
+			}
+			if err := applyChanges(ctx, tx, photoID, changes, tt.reReview); err != nil {
+				t.Fatalf("applyChanges() error = %v", err)
+			}
+			if err := tx.Commit(); err != nil {
+				t.Fatal(err)
+			}
+
+			var stored struct {
+				Title        string
+				Registration *string
+				Status       model.PhotoStatus
+				Attempt      int
+				Approved     bool
+			}
+			err = db.QueryRow(`
+				SELECT title, registration, status, attempt, approved_at IS NOT NULL FROM photos WHERE id = $1
+			`, photoID).Scan(&stored.Title, &stored.Registration, &stored.Status, &stored.Attempt, &stored.Approved)
+			if err != nil {
+				t.Fatalf("get photo: %v", err)
+			}
+			if stored.Title != title || stored.Registration != nil {
+				t.Errorf("photo = %q, registration %v, expected %q, NULL", stored.Title, stored.Registration, title)
+			}
+			if stored.Status != tt.expectStatus || stored.Attempt != tt.expectAttempt {
+				t.Errorf("photo = %s, attempt %d, expected %s, attempt %d", stored.Status, stored.Attempt, tt.expectStatus, tt.expectAttempt)
+			}
+			if stored.Approved == tt.reReview {
+				t.Errorf("approved_at set = %v, expected %v", stored.Approved, !tt.reReview)
+			}
+
+			var storedTags []string
+			if err := db.Select(&storedTags, `
+				SELECT t.name FROM tags t INNER JOIN photo_tags pt ON pt.tag_id = t.id
+				WHERE pt.photo_id = $1 ORDER BY t.name
+			`, photoID); err != nil {
+				t.Fatalf("get tags: %v", err)
+			}
+			if len(storedTags) != 2 || storedTags[0] != "a380" || storedTags[1] != "night" {
+				t.Errorf("tags = %v, expected %v", storedTags, tags)
+			}
+		})
+	}
+}
+
+func TestRollbackRevision(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	adminID := pgtest.CreateUser(t, db, "admin", "admin")
+	photoID := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+	otherID := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+
+	second := "Second title"
+	third := "Third title"
+	description := "Added later"
+	reviewFields := []string{FieldTitle}
+
+	first, err := repo.UpdateMetadata(ctx, photoID, ownerID, &MetadataParams{Title: &second})
+	if err != nil || first == nil {
+		t.Fatalf("UpdateMetadata() = %v, %v, expected a revision", first, err)
+	}
+	if _, err := repo.UpdateMetadata(ctx, photoID, ownerID, &MetadataParams{Title: &third, Description: &description}); err != nil {
+		t.Fatalf("UpdateMetadata() error = %v", err)
+	}
+
+	// Unchanged metadata records no revision
+	unchanged, err := repo.UpdateMetadata(ctx, photoID, ownerID, &MetadataParams{Title: &third, ReviewFields: reviewFields})
+	if err != nil || unchanged != nil {
+		t.Fatalf("unchanged UpdateMetadata() = %v, %v, expected no revision", unchanged, err)
+	}
+
+	// Rolling back the first revision undoes every later one too
+	rollback, err := repo.RollbackRevision(ctx, photoID, first.ID, adminID)
+	if err != nil {
+		t.Fatalf("RollbackRevision() error = %v", err)
+	}
+	if rollback.Source != model.RevisionSourceRollback || rollback.RollbackOf.Int64 != first.ID || rollback.ReReview {
+		t.Errorf("rollback = %s of %d, rereview %v, expected rollback of %d without re-review",
+			rollback.Source, rollback.RollbackOf.Int64, rollback.ReReview, first.ID)
+	}
+	changes, err := rollback.FieldChanges()
+	if err != nil {
+		t.Fatalf("FieldChanges() error = %v", err)
+	}
+	if fields := changedFields(changes); len(fields) != 2 || fields[0] != "description" || fields[1] != FieldTitle {
+		t.Errorf("rollback fields = %v, expected [description title]", fields)
+	}
+
+	p, err := repo.GetByID(ctx, photoID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if p.Title != "Test photo" || p.Description.Valid {
+		t.Errorf("photo = %q, description %v, expected the original metadata", p.Title, p.Description)
+	}
+	if p.Status != model.PhotoStatusApproved || p.Attempt != 1 {
+		t.Errorf("photo = %s, attempt %d, expected approved, attempt 1", p.Status, p.Attempt)
+	}
+
+	// The metadata already matches, a second rollback changes nothing
+	again, err := repo.RollbackRevision(ctx, photoID, first.ID, adminID)
+	if err != nil || again != nil {
+		t.Errorf("second RollbackRevision() = %v, %v, expected no revision", again, err)
+	}
+
+	// Revisions of another photo are not found
+	if _, err := repo.RollbackRevision(ctx, otherID, first.ID, adminID); !errors.Is(err, postgresql.ErrNotFound) {
+		t.Errorf("RollbackRevision() of another photo error = %v, expected ErrNotFound", err)
+	}
+
+	revisions, err := repo.ListRevisions(ctx, photoID)
+	if err != nil {
+		t.Fatalf("ListRevisions() error = %v", err)
+	}
+	if len(revisions) != 3 || revisions[0].ID != rollback.ID {
+		t.Errorf("revisions = %d, expected 3 with the rollback first", len(revisions))
+	}
+}
@@ -0,0 +1,52 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// UpdatePublishAt reschedules the publication of a photo of the user that is
+// not published yet, nil publishes it once approved. Returns ErrNotFound if
+// the user has no such photo.
+func (r *PhotoRepository) UpdatePublishAt(ctx context.Context, photoID, userID int64, publishAt *time.Time) (*model.Photo, error) {
+	var p model.Photo
+	err := r.DB().GetContext(ctx, &p, `
+		UPDATE photos SET publish_at = $1, updated_at = NOW()
+		WHERE id = $2 AND user_id = $3 AND (status <> $4 OR publish_at IS NOT NULL) AND deleted_at IS NULL
+		RETURNING *
+	`, toNullTime(publishAt), photoID, userID, model.PhotoStatusApproved)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// PublishDue publishes up to limit approved photos whose publication time has
+// come, oldest schedule first, and returns them. Photos still in review keep
+// their schedule and are published once approved.
+func (r *PhotoRepository) PublishDue(ctx context.Context, limit int) ([]*model.Photo, error) {
+	var photos []*model.Photo
+	err := r.DB().SelectContext(ctx, &photos, `
+		UPDATE photos SET publish_at = NULL, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM photos
+			WHERE publish_at <= NOW() AND status = $1 AND deleted_at IS NULL
+			ORDER BY publish_at ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, model.PhotoStatusApproved, limit)
+	if err != nil {
+		return nil, err
+	}
+	return photos, nil
+}
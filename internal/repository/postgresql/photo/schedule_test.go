@@ -0,0 +1,136 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+func TestPublishDue(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	otherID := pgtest.CreateUser(t, db, "other", "user")
+
+	scheduled := func(status model.PhotoStatus, publishAt, update string) int64 {
+		id := pgtest.CreatePhoto(t, db, ownerID, string(status))
+		pgtest.Exec(t, db, `UPDATE photos SET publish_at = NOW() + $1::INTERVAL`+update+` WHERE id = $2`, publishAt, id)
+		return id
+	}
+	earlier := scheduled(model.PhotoStatusApproved, "-2 hours", "")
+	due := scheduled(model.PhotoStatusApproved, "-1 minute", "")
+	future := scheduled(model.PhotoStatusApproved, "1 day", "")
+	inReview := scheduled(model.PhotoStatusPending, "-1 hour", "")
+	trashed := scheduled(model.PhotoStatusApproved, "-1 hour", ", deleted_at = NOW()")
+
+	visible := func(photoID int64) bool {
+		t.Helper()
+		ok, err := repo.IsVisibleTo(ctx, photoID, otherID)
+		if err != nil {
+			t.Fatalf("IsVisibleTo() error = %v", err)
+		}
+		return ok
+	}
+	publish := func(limit int) []int64 {
+		t.Helper()
+		photos, err := repo.PublishDue(ctx, limit)
+		if err != nil {
+			t.Fatalf("PublishDue() error = %v", err)
+		}
+		ids := make([]int64, len(photos))
+		for i, p := range photos {
+			ids[i] = p.ID
+			if p.IsScheduled() {
+				t.Errorf("published photo %d keeps its schedule", p.ID)
+			}
+		}
+		return ids
+	}
+
+	if visible(earlier) || visible(due) {
+		t.Fatal("scheduled photos visible before their publication time")
+	}
+
+	// The oldest schedule is published first
+	if got := publish(1); len(got) != 1 || got[0] != earlier {
+		t.Errorf("PublishDue(1) = %v, expected [%d]", got, earlier)
+	}
+	if got := publish(10); len(got) != 1 || got[0] != due {
+		t.Errorf("PublishDue(10) = %v, expected [%d]", got, due)
+	}
+	if !visible(earlier) || !visible(due) {
+		t.Error("published photos not visible")
+	}
+	if visible(future) {
+		t.Error("photo scheduled in the future visible")
+	}
+
+	// A photo still in review keeps its schedule until approved
+	p, err := repo.GetByID(ctx, inReview)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !p.IsScheduled() {
+		t.Error("photo in review lost its schedule")
+	}
+	pgtest.Exec(t, db, `UPDATE photos SET status = $1 WHERE id = $2`, model.PhotoStatusApproved, inReview)
+	if got := publish(10); len(got) != 1 || got[0] != inReview {
+		t.Errorf("PublishDue() after approval = %v, expected [%d]", got, inReview)
+	}
+
+	if visible(trashed) {
+		t.Error("photo in the trash published")
+	}
+}
+
+func TestUpdatePublishAt(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	otherID := pgtest.CreateUser(t, db, "other", "user")
+
+	later := time.Now().Add(48 * time.Hour)
+
+	tests := []struct {
+		name      string
+		status    model.PhotoStatus
+		update    string
+		userID    int64
+		publishAt *time.Time
+		expectErr error
+	}{
+		{"Reschedule a scheduled photo", model.PhotoStatusApproved, "publish_at = NOW() + INTERVAL '1 day'", ownerID, &later, nil},
+		{"Publish a scheduled photo on approval", model.PhotoStatusApproved, "publish_at = NOW() + INTERVAL '1 day'", ownerID, nil, nil},
+		{"Schedule a photo in review", model.PhotoStatusPending, "", ownerID, &later, nil},
+		{"Published photo", model.PhotoStatusApproved, "", ownerID, &later, postgresql.ErrNotFound},
+		{"Photo of another user", model.PhotoStatusPending, "", otherID, &later, postgresql.ErrNotFound},
+		{"Photo in the trash", model.PhotoStatusPending, "deleted_at = NOW()", ownerID, &later, postgresql.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			photoID := pgtest.CreatePhoto(t, db, ownerID, string(tt.status))
+			if tt.update != "" {
+				pgtest.Exec(t, db, `UPDATE photos SET `+tt.update+` WHERE id = $1`, photoID)
+			}
+
+			p, err := repo.UpdatePublishAt(ctx, photoID, tt.userID, tt.publishAt)
+			if !errors.Is(err, tt.expectErr) || (tt.expectErr == nil && err != nil) {
+				t.Fatalf("UpdatePublishAt() error = %v, expected %v", err, tt.expectErr)
+			}
+			if err != nil {
+				return
+			}
+			if scheduled := p.IsScheduled(); scheduled != (tt.publishAt != nil) {
+				t.Errorf("UpdatePublishAt() scheduled = %v, expected %v", scheduled, tt.publishAt != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package photo
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// GetSeriesBriefs retrieves the series the given photos are frames of, keyed
+// by photo ID. Only series with frames visible to everyone are returned,
+// with the number of those frames.
+func (r *PhotoRepository) GetSeriesBriefs(ctx context.Context, photoIDs []int64) (map[int64]*model.SeriesBrief, error) {
+	briefs := make(map[int64]*model.SeriesBrief)
+	if len(photoIDs) == 0 {
+		return briefs, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT * FROM (
+			SELECT sf.photo_id, s.id, s.title,
+				(SELECT COUNT(*) FROM series_frames f JOIN photos p ON p.id = f.photo_id
+					WHERE f.series_id = s.id AND `+postgresql.VisiblePhotoFilter("p")+`) AS frame_count
+			FROM series_frames sf JOIN photo_series s ON s.id = sf.series_id
+			WHERE sf.photo_id IN (?)
+		) frames
+		WHERE frame_count > 0
+	`, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		PhotoID int64 `db:"photo_id"`
+		model.SeriesBrief
+	}
+	if err := r.DB().SelectContext(ctx, &rows, r.DB().Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		briefs[rows[i].PhotoID] = &rows[i].SeriesBrief
+	}
+	return briefs, nil
+}
@@ -0,0 +1,166 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// AutoApprove publishes a pending photo of a trusted uploader without manual
+// review, optionally sampling it for a spot check. The approval is recorded
+// with the trust review type. Returns ErrNotFound if the photo is no longer
+// pending, e.g. after failing quality screening.
+func (r *PhotoRepository) AutoApprove(ctx context.Context, photoID int64, tier string, spotCheck bool) error {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var attempt int
+	err = tx.QueryRowContext(ctx, `
+		UPDATE photos SET status = $1, approved_at = NOW(), spot_check_pending = $2, updated_at = NOW()
+		WHERE id = $3 AND status = $4 AND deleted_at IS NULL
+		RETURNING attempt
+	`, model.PhotoStatusApproved, spotCheck, photoID, model.PhotoStatusPending).Scan(&attempt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postgresql.ErrNotFound
+		}
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, reason, attempt)
+		VALUES ($1, NULL, 'trust', 'approve', $2, $3)
+	`, photoID, "trust tier: "+tier, attempt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SpotCheckListParams contains parameters for listing photos awaiting a spot check
+type SpotCheckListParams struct {
+	Page               int
+	PageSize           int
+	CategoryReviewerID int64 // Only photos in categories assigned to this reviewer
+}
+
+// ListSpotChecks retrieves published photos sampled for a spot check, oldest first
+func (r *PhotoRepository) ListSpotChecks(ctx context.Context, params SpotCheckListParams) (*ReviewListResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	whereClause := "WHERE spot_check_pending = TRUE AND status = $1 AND deleted_at IS NULL"
+	args := []interface{}{model.PhotoStatusApproved}
+	if params.CategoryReviewerID > 0 {
+		args = append(args, params.CategoryReviewerID)
+		whereClause += fmt.Sprintf(" AND category_id IN (SELECT category_id FROM reviewer_categories WHERE reviewer_id = $%d)", len(args))
+	}
+
+	var total int64
+	err := r.DB().GetContext(ctx, &total, "SELECT COUNT(*) FROM photos "+whereClause, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize > 0 {
+		totalPages++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM photos
+		%s
+		ORDER BY approved_at ASC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, len(args)+1, len(args)+2)
+	args = append(args, params.PageSize, offset)
+
+	var photos []*model.Photo
+	if err := r.DB().SelectContext(ctx, &photos, query, args...); err != nil {
+		return nil, err
+	}
+
+	return &ReviewListResult{
+		Photos:     photos,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// SpotCheckParams contains parameters for a spot check decision
+type SpotCheckParams struct {
+	PhotoID    int64
+	ReviewerID int64
+	Action     string // approve, reject
+	Reason     string
+	ReasonIDs  []int32 // Rejection reasons linked to the review
+}
+
+// DecideSpotCheck records a manual decision on a photo sampled for a spot
+// check. Approval keeps the photo published, rejection unpublishes it.
+// Returns ErrNotFound if the photo does not await a spot check.
+func (r *PhotoRepository) DecideSpotCheck(ctx context.Context, params SpotCheckParams) error {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	status := model.PhotoStatusApproved
+	if params.Action == "reject" {
+		status = model.PhotoStatusRejected
+	}
+
+	var attempt int
+	err = tx.QueryRowContext(ctx, `
+		UPDATE photos SET status = $1, spot_check_pending = FALSE, updated_at = NOW()
+		WHERE id = $2 AND spot_check_pending = TRUE AND status = $3 AND deleted_at IS NULL
+		RETURNING attempt
+	`, status, params.PhotoID, model.PhotoStatusApproved).Scan(&attempt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postgresql.ErrNotFound
+		}
+		return err
+	}
+
+	var reviewID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, reason, attempt)
+		VALUES ($1, $2, 'manual', $3, $4, $5)
+		RETURNING id
+	`, params.PhotoID, params.ReviewerID, params.Action, toNullString(&params.Reason), attempt).Scan(&reviewID)
+	if err != nil {
+		return err
+	}
+
+	for _, reasonID := range params.ReasonIDs {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO photo_review_reasons (review_id, reason_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			reviewID, reasonID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
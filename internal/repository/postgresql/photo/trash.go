@@ -0,0 +1,184 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// TrashListParams contains parameters for listing photos in the trash
+type TrashListParams struct {
+	Page     int
+	PageSize int
+	UserID   int64 // Only photos of this user, 0 for all
+}
+
+// ListTrash retrieves photos in the trash, most recently deleted first
+func (r *PhotoRepository) ListTrash(ctx context.Context, params TrashListParams) (*ListResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	conditions := []string{"deleted_at IS NOT NULL"}
+	args := []interface{}{}
+	argIndex := 1
+
+	if params.UserID > 0 {
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIndex))
+		args = append(args, params.UserID)
+		argIndex++
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int64
+	if err := r.DB().GetContext(ctx, &total, "SELECT COUNT(*) FROM photos "+whereClause, args...); err != nil {
+		return nil, err
+	}
+
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize > 0 {
+		totalPages++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM photos
+		%s
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argIndex, argIndex+1)
+	args = append(args, params.PageSize, offset)
+
+	var photos []*model.Photo
+	if err := r.DB().SelectContext(ctx, &photos, query, args...); err != nil {
+		return nil, err
+	}
+
+	return &ListResult{
+		Photos:     photos,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Restore takes a photo out of the trash with its comments, likes, favorites
+// and shares. The restore is recorded in the review history and the deletion
+// snapshot is dropped. Returns ErrNotFound if the photo is not in the trash.
+func (r *PhotoRepository) Restore(ctx context.Context, photoID, adminID int64) (*model.Photo, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var p model.Photo
+	err = tx.GetContext(ctx, &p, `
+		UPDATE photos SET deleted_at = NULL, deleted_by = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING *
+	`, photoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM deleted_photos WHERE photo_id = $1`, photoID); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO photo_reviews (photo_id, reviewer_id, review_type, action, attempt)
+		VALUES ($1, $2, 'manual', 'restore', $3)
+	`, photoID, adminID, p.Attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// PurgeResult contains the photos removed from the trash, whose files can be
+// removed from storage
+type PurgeResult struct {
+	Photos []*model.Photo
+	// Replacements still awaiting review, their files were never served
+	Replacements []*model.PhotoReplacement
+}
+
+// PurgeDeleted permanently deletes up to limit photos that were moved to the
+// trash before the given time, cascading through their comments, likes,
+// favorites and shares. Their deletion snapshots and review history are kept.
+func (r *PhotoRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (*PurgeResult, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result := &PurgeResult{}
+	err = tx.SelectContext(ctx, &result.Photos, `
+		SELECT * FROM photos
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		ORDER BY deleted_at ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Photos) == 0 {
+		return result, nil
+	}
+
+	photoIDs := make([]int64, len(result.Photos))
+	for i, p := range result.Photos {
+		photoIDs[i] = p.ID
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT * FROM photo_replacements WHERE photo_id IN (?) AND status = ?
+	`, photoIDs, model.ReplacementStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.SelectContext(ctx, &result.Replacements, tx.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	query, args, err = sqlx.In(`DELETE FROM photos WHERE id IN (?)`, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
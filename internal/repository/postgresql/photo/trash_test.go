@@ -0,0 +1,179 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+func TestDeleteAndRestore(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	otherID := pgtest.CreateUser(t, db, "other", "user")
+	adminID := pgtest.CreateUser(t, db, "admin", "admin")
+
+	photoID := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+	if err := repo.AddFavorite(ctx, otherID, photoID); err != nil {
+		t.Fatalf("AddFavorite() error = %v", err)
+	}
+
+	visible := func() bool {
+		t.Helper()
+		ok, err := repo.IsVisibleTo(ctx, photoID, otherID)
+		if err != nil {
+			t.Fatalf("IsVisibleTo() error = %v", err)
+		}
+		return ok
+	}
+	count := func(query string) int {
+		t.Helper()
+		var n int
+		if err := db.Get(&n, query, photoID); err != nil {
+			t.Fatalf("count: %v", err)
+		}
+		return n
+	}
+	inTrash := func() bool {
+		t.Helper()
+		result, err := repo.ListTrash(ctx, TrashListParams{UserID: ownerID})
+		if err != nil {
+			t.Fatalf("ListTrash() error = %v", err)
+		}
+		for _, p := range result.Photos {
+			if p.ID == photoID {
+				return true
+			}
+		}
+		return false
+	}
+
+	if err := repo.Delete(ctx, photoID, adminID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if visible() {
+		t.Error("photo in the trash visible")
+	}
+	if !inTrash() {
+		t.Error("ListTrash() does not include the deleted photo")
+	}
+	if n := count(`SELECT COUNT(*) FROM deleted_photos WHERE photo_id = $1`); n != 1 {
+		t.Errorf("deleted_photos has %d snapshots, expected 1", n)
+	}
+	if err := repo.Delete(ctx, photoID, adminID); !errors.Is(err, postgresql.ErrNotFound) {
+		t.Errorf("second Delete() error = %v, expected ErrNotFound", err)
+	}
+
+	p, err := repo.Restore(ctx, photoID, adminID)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if p.DeletedAt.Valid {
+		t.Error("restored photo keeps its deletion time")
+	}
+	if !visible() {
+		t.Error("restored photo not visible")
+	}
+	if inTrash() {
+		t.Error("ListTrash() still includes the restored photo")
+	}
+	if n := count(`SELECT COUNT(*) FROM deleted_photos WHERE photo_id = $1`); n != 0 {
+		t.Errorf("deleted_photos keeps %d snapshots after restore", n)
+	}
+	if n := count(`SELECT COUNT(*) FROM photo_reviews WHERE photo_id = $1 AND action = 'restore'`); n != 1 {
+		t.Errorf("photo_reviews has %d restore records, expected 1", n)
+	}
+	if favorited, err := repo.IsFavorited(ctx, otherID, photoID); err != nil || !favorited {
+		t.Errorf("IsFavorited() = %v, %v, expected the favorite kept", favorited, err)
+	}
+
+	if _, err := repo.Restore(ctx, photoID, adminID); !errors.Is(err, postgresql.ErrNotFound) {
+		t.Errorf("second Restore() error = %v, expected ErrNotFound", err)
+	}
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+
+	trashed := func(deletedAt string) int64 {
+		id := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+		if err := repo.Delete(ctx, id, ownerID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		pgtest.Exec(t, db, `UPDATE photos SET deleted_at = NOW() + $1::INTERVAL WHERE id = $2`, deletedAt, id)
+		return id
+	}
+	oldest := trashed("-40 days")
+	old := trashed("-31 days")
+	recent := trashed("-1 day")
+	kept := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+
+	pgtest.Exec(t, db, `
+		INSERT INTO photo_replacements (photo_id, user_id, status, file_path, file_params)
+		VALUES ($1, $2, 'pending', 'replacement.jpg', '{}')
+	`, oldest, ownerID)
+
+	before := time.Now().Add(-30 * 24 * time.Hour)
+	purge := func(limit int) *PurgeResult {
+		t.Helper()
+		result, err := repo.PurgeDeleted(ctx, before, limit)
+		if err != nil {
+			t.Fatalf("PurgeDeleted() error = %v", err)
+		}
+		return result
+	}
+
+	// The longest deleted photo is purged first, with its pending replacement
+	result := purge(1)
+	if len(result.Photos) != 1 || result.Photos[0].ID != oldest {
+		t.Errorf("PurgeDeleted(1) purged %d photos, expected [%d]", len(result.Photos), oldest)
+	}
+	if len(result.Replacements) != 1 || result.Replacements[0].PhotoID != oldest {
+		t.Errorf("PurgeDeleted(1) replacements = %d, expected the pending one of photo %d", len(result.Replacements), oldest)
+	}
+
+	result = purge(10)
+	if len(result.Photos) != 1 || result.Photos[0].ID != old {
+		t.Errorf("PurgeDeleted(10) purged %d photos, expected [%d]", len(result.Photos), old)
+	}
+	if len(result.Replacements) != 0 {
+		t.Errorf("PurgeDeleted(10) replacements = %d, expected none", len(result.Replacements))
+	}
+
+	exists := func(photoID int64) bool {
+		t.Helper()
+		var ok bool
+		if err := db.Get(&ok, `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1)`, photoID); err != nil {
+			t.Fatalf("photo exists: %v", err)
+		}
+		return ok
+	}
+	for _, tt := range []struct {
+		name    string
+		photoID int64
+		expect  bool
+	}{
+		{"Oldest photo in the trash", oldest, false},
+		{"Photo past retention", old, false},
+		{"Recently deleted photo", recent, true},
+		{"Photo not in the trash", kept, true},
+	} {
+		if got := exists(tt.photoID); got != tt.expect {
+			t.Errorf("%s: exists = %v, expected %v", tt.name, got, tt.expect)
+		}
+	}
+
+	// The deletion snapshot outlives the purged photo
+	if _, err := repo.GetDeletedPhoto(ctx, oldest); err != nil {
+		t.Errorf("GetDeletedPhoto() error = %v, expected the snapshot kept", err)
+	}
+}
@@ -0,0 +1,169 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// UpdateFlightInfoParams contains normalized flight info of a photo, nil for absent fields
+type UpdateFlightInfoParams struct {
+	FlightNumber *string
+	Origin       *string
+	Destination  *string
+	FlightPhase  *string
+}
+
+// ResubmitParams contains corrections for resubmitting a rejected photo.
+// Nil fields are left unchanged, empty values clear optional fields.
+type ResubmitParams struct {
+	Title        *string
+	Description  *string
+	CategoryID   *int32 // 0 clears the category
+	AircraftType *string
+	Airline      *string
+	Registration *string
+	Airport      *string
+	FlightNumber *string
+	Origin       *string
+	Destination  *string
+	FlightPhase  *string
+
+	// File replaces the stored image and its EXIF data when set
+	File *FileParams
+
+	// Storage quota of the owner in bytes, checked when File is set. 0 is unlimited.
+	QuotaBytes int64
+}
+
+// Resubmit applies corrections to a rejected photo of the user and moves it back
+// to pending as a new review attempt in the standard queue. Returns the photo
+// as it was before, so replaced files can be removed; ErrNotFound if the user
+// has no rejected photo with this ID, ErrQuotaExceeded if a new file does not
+// fit the user's storage quota.
+func (r *PhotoRepository) Resubmit(ctx context.Context, photoID, userID int64, params *ResubmitParams) (*model.Photo, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var previous model.Photo
+	err = tx.GetContext(ctx, &previous, `
+		SELECT * FROM photos
+		WHERE id = $1 AND user_id = $2 AND status IN ($3, $4) AND deleted_at IS NULL
+		FOR UPDATE
+	`, photoID, userID, model.PhotoStatusRejected, model.PhotoStatusAIRejected)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// The replaced files are removed once the resubmission is saved
+	if params.File != nil {
+		if err := reserveStorage(ctx, tx, userID, params.File.storedBytes()-previous.StoredBytes(), params.QuotaBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	// Build SET clause
+	sets := []string{"status = $1", "attempt = attempt + 1", "review_stage = 'standard'", "approved_at = NULL", "updated_at = NOW()"}
+	args := []interface{}{model.PhotoStatusPending}
+	argIndex := 2
+
+	set := func(column string, value interface{}) {
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, argIndex))
+		args = append(args, value)
+		argIndex++
+	}
+
+	if params.Title != nil {
+		set("title", *params.Title)
+	}
+	if params.Description != nil {
+		set("description", toNullString(emptyToNil(params.Description)))
+	}
+	if params.CategoryID != nil {
+		categoryID := params.CategoryID
+		if *categoryID == 0 {
+			categoryID = nil
+		}
+		set("category_id", toNullInt32(categoryID))
+	}
+	optional := []struct {
+		column string
+		value  *string
+	}{
+		{"aircraft_type", params.AircraftType},
+		{"airline", params.Airline},
+		{"registration", params.Registration},
+		{"airport", params.Airport},
+		{"flight_number", params.FlightNumber},
+		{"origin", params.Origin},
+		{"destination", params.Destination},
+		{"flight_phase", params.FlightPhase},
+	}
+	for _, field := range optional {
+		if field.value != nil {
+			set(field.column, toNullString(emptyToNil(field.value)))
+		}
+	}
+	if params.File != nil {
+		columns, values := params.File.columns()
+		for i, column := range columns {
+			set(column, values[i])
+		}
+	}
+
+	query := fmt.Sprintf(`UPDATE photos SET %s WHERE id = $%d`, strings.Join(sets, ", "), argIndex)
+	args = append(args, photoID)
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	// Record the corrected fields as a revision
+	updated, err := lockPhoto(ctx, tx, photoID)
+	if err != nil {
+		return nil, err
+	}
+	before, err := metadataSnapshot(&previous, nil)
+	if err != nil {
+		return nil, err
+	}
+	after, err := metadataSnapshot(updated, nil)
+	if err != nil {
+		return nil, err
+	}
+	if changes := diffSnapshots(before, after); len(changes) > 0 {
+		_, err = insertRevision(ctx, tx, &model.PhotoRevision{
+			PhotoID:  photoID,
+			EditorID: sql.NullInt64{Int64: userID, Valid: true},
+			Source:   model.RevisionSourceResubmit,
+		}, changes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &previous, nil
+}
+
+// emptyToNil treats an empty string as an absent value
+func emptyToNil(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
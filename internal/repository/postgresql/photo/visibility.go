@@ -0,0 +1,97 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// UpdateVisibility changes who can see a photo of the user and sets the share
+// token of its link, nil clears it. Returns ErrNotFound if the user has no
+// photo with this ID.
+func (r *PhotoRepository) UpdateVisibility(ctx context.Context, photoID, userID int64, visibility model.PhotoVisibility, shareToken *string) (*model.Photo, error) {
+	var p model.Photo
+	err := r.DB().GetContext(ctx, &p, `
+		UPDATE photos SET visibility = $1, share_token = $2, updated_at = NOW()
+		WHERE id = $3 AND user_id = $4 AND deleted_at IS NULL
+		RETURNING *
+	`, visibility, toNullString(shareToken), photoID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetByShareToken retrieves the photo a share link points to
+func (r *PhotoRepository) GetByShareToken(ctx context.Context, token string) (*model.Photo, error) {
+	var p model.Photo
+	err := r.DB().GetContext(ctx, &p, `SELECT * FROM photos WHERE share_token = $1 AND deleted_at IS NULL`, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// IsVisibleTo checks if a photo exists and the user may see it, i.e. it is
+// visible to everyone or the user owns it
+func (r *PhotoRepository) IsVisibleTo(ctx context.Context, photoID, userID int64) (bool, error) {
+	var visible bool
+	err := r.DB().GetContext(ctx, &visible, `
+		SELECT EXISTS(
+			SELECT 1 FROM photos
+			WHERE id = $1 AND `+postgresql.VisibleToFilter("", "$2")+`
+		)
+	`, photoID, userID)
+	return visible, err
+}
+
+// CountVisibleTo counts how many of the given photos exist and the user may
+// see, by the same rules as IsVisibleTo
+func (r *PhotoRepository) CountVisibleTo(ctx context.Context, photoIDs []int64, userID int64) (int, error) {
+	if len(photoIDs) == 0 {
+		return 0, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT COUNT(*) FROM photos
+		WHERE id IN (?) AND `+postgresql.VisibleToFilter("", "?")+`
+	`, photoIDs, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := r.DB().GetContext(ctx, &count, r.DB().Rebind(query), args...); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetHiddenFileOwner retrieves the owner of the private, scheduled or trashed
+// photo stored at a file path. Returns ErrNotFound if no such photo uses the
+// file.
+func (r *PhotoRepository) GetHiddenFileOwner(ctx context.Context, filePath string) (int64, error) {
+	var userID int64
+	err := r.DB().GetContext(ctx, &userID, `
+		SELECT user_id FROM photos
+		WHERE file_path = $1 AND (visibility = $2 OR publish_at IS NOT NULL OR deleted_at IS NOT NULL)
+	`, filePath, model.PhotoVisibilityPrivate)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, postgresql.ErrNotFound
+		}
+		return 0, err
+	}
+	return userID, nil
+}
@@ -0,0 +1,99 @@
+package photo
+
+import (
+	"context"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+func TestVisibleTo(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewPhotoRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+	otherID := pgtest.CreateUser(t, db, "other", "user")
+
+	create := func(status model.PhotoStatus, update string) int64 {
+		id := pgtest.CreatePhoto(t, db, ownerID, string(status))
+		if update != "" {
+			pgtest.Exec(t, db, `UPDATE photos SET `+update+` WHERE id = $1`, id)
+		}
+		return id
+	}
+
+	tests := []struct {
+		name          string
+		photoID       int64
+		expectOther   bool
+		expectOwner   bool
+		expectListing bool
+	}{
+		{"Approved public photo", create(model.PhotoStatusApproved, ""), true, true, true},
+		{"Approved unlisted photo", create(model.PhotoStatusApproved, "visibility = 'unlisted'"), true, true, false},
+		{"Approved private photo", create(model.PhotoStatusApproved, "visibility = 'private'"), false, true, false},
+		{"Scheduled photo", create(model.PhotoStatusApproved, "publish_at = NOW() + INTERVAL '1 day'"), false, true, false},
+		{"Pending public photo", create(model.PhotoStatusPending, ""), false, true, false},
+		{"Rejected public photo", create(model.PhotoStatusRejected, ""), false, true, false},
+		{"Photo rejected by AI review", create(model.PhotoStatusAIRejected, ""), false, true, false},
+		{"Photo in the trash", create(model.PhotoStatusApproved, "deleted_at = NOW()"), false, false, false},
+	}
+
+	listed, err := repo.List(ctx, ListParams{PageSize: 100})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pgtest.Exec(t, db, `INSERT INTO favorites (user_id, photo_id) VALUES ($1, $2)`, otherID, tt.photoID)
+
+			visible, err := repo.IsVisibleTo(ctx, tt.photoID, otherID)
+			if err != nil {
+				t.Fatalf("IsVisibleTo() error = %v", err)
+			}
+			if visible != tt.expectOther {
+				t.Errorf("IsVisibleTo() other user = %v, expected %v", visible, tt.expectOther)
+			}
+
+			visible, err = repo.IsVisibleTo(ctx, tt.photoID, ownerID)
+			if err != nil {
+				t.Fatalf("IsVisibleTo() error = %v", err)
+			}
+			if visible != tt.expectOwner {
+				t.Errorf("IsVisibleTo() owner = %v, expected %v", visible, tt.expectOwner)
+			}
+
+			count, err := repo.CountVisibleTo(ctx, []int64{tt.photoID}, otherID)
+			if err != nil {
+				t.Fatalf("CountVisibleTo() error = %v", err)
+			}
+			if (count == 1) != tt.expectOther {
+				t.Errorf("CountVisibleTo() other user = %d, expected visible %v", count, tt.expectOther)
+			}
+
+			favorites, err := repo.ListUserFavorites(ctx, otherID, 1, 100)
+			if err != nil {
+				t.Fatalf("ListUserFavorites() error = %v", err)
+			}
+			if found := containsPhoto(favorites.Photos, tt.photoID); found != tt.expectOther {
+				t.Errorf("ListUserFavorites() lists photo = %v, expected %v", found, tt.expectOther)
+			}
+
+			if found := containsPhoto(listed.Photos, tt.photoID); found != tt.expectListing {
+				t.Errorf("List() lists photo = %v, expected %v", found, tt.expectListing)
+			}
+		})
+	}
+}
+
+// containsPhoto reports whether photos include the photo with the given ID
+func containsPhoto(photos []*model.Photo, photoID int64) bool {
+	for _, p := range photos {
+		if p.ID == photoID {
+			return true
+		}
+	}
+	return false
+}
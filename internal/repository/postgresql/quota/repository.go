@@ -0,0 +1,167 @@
+package quota
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// storedBytes sums the stored file sizes of photos
+const storedBytes = `COALESCE(SUM(photo_stored_bytes(file_size, raw_file_size, derivative_size)), 0)`
+
+// QuotaRepository handles storage usage and quota database operations
+type QuotaRepository struct {
+	*postgresql.BaseRepository
+}
+
+// NewQuotaRepository creates a new quota repository
+func NewQuotaRepository(db *sqlx.DB) *QuotaRepository {
+	return &QuotaRepository{
+		BaseRepository: postgresql.NewBaseRepository(db),
+	}
+}
+
+// GetUserStorage retrieves the storage usage of a user with the role, trust
+// tier and override deciding the quota. Users without usage have no bytes
+// stored. Pending replacement files count as used.
+func (r *QuotaRepository) GetUserStorage(ctx context.Context, userID int64) (*model.UserStorage, error) {
+	var s model.UserStorage
+	err := r.DB().GetContext(ctx, &s, `
+		SELECT u.id AS user_id, u.role, t.tier,
+			COALESCE(us.used_bytes, 0) + `+postgresql.PendingReplacementBytes("user_id = u.id")+` AS used_bytes,
+			t.storage_quota_mb AS tier_quota_mb,
+			us.quota_override_mb, us.override_by, us.override_note, us.reconciled_at
+		FROM users u
+		LEFT JOIN user_storage us ON us.user_id = u.id
+		LEFT JOIN user_trust ut ON ut.user_id = u.id
+		JOIN trust_tiers t ON t.tier = COALESCE(ut.override_tier, ut.tier, $2)
+		WHERE u.id = $1
+	`, userID, model.TrustTierNewcomer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SetOverride sets or clears the quota override of a user
+func (r *QuotaRepository) SetOverride(ctx context.Context, userID int64, quotaMB *int64, overrideBy int64, note string) error {
+	var overrideNote sql.NullString
+	if quotaMB != nil {
+		overrideNote = sql.NullString{String: note, Valid: note != ""}
+	}
+
+	_, err := r.DB().ExecContext(ctx, `
+		INSERT INTO user_storage (user_id, quota_override_mb, override_by, override_note)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+			SET quota_override_mb = EXCLUDED.quota_override_mb,
+				override_by = EXCLUDED.override_by,
+				override_note = EXCLUDED.override_note
+	`, userID, quotaMB, overrideBy, overrideNote)
+	return err
+}
+
+// ListUnmeasured retrieves photos whose RAW file or thumbnail sizes have not
+// been recorded, including photos in the trash
+func (r *QuotaRepository) ListUnmeasured(ctx context.Context, limit int) ([]*model.Photo, error) {
+	var photos []*model.Photo
+	err := r.DB().SelectContext(ctx, &photos, `
+		SELECT * FROM photos
+		WHERE derivative_size IS NULL OR (raw_file_path IS NOT NULL AND raw_file_size IS NULL)
+		ORDER BY id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	return photos, nil
+}
+
+// SetMeasuredSizes records the measured sizes of a photo's stored files.
+// Sizes recorded before are kept, the usage triggers add the difference.
+func (r *QuotaRepository) SetMeasuredSizes(ctx context.Context, photoID, fileSize, rawSize, derivativeSize int64) error {
+	_, err := r.DB().ExecContext(ctx, `
+		UPDATE photos
+		SET file_size = COALESCE(file_size, $2),
+			raw_file_size = CASE WHEN raw_file_path IS NULL THEN raw_file_size ELSE COALESCE(raw_file_size, $3) END,
+			derivative_size = COALESCE(derivative_size, $4)
+		WHERE id = $1
+	`, photoID, fileSize, rawSize, derivativeSize)
+	return err
+}
+
+// ListDrifted retrieves the users whose recorded usage differs from the
+// sizes of their stored photos
+func (r *QuotaRepository) ListDrifted(ctx context.Context) ([]int64, error) {
+	var userIDs []int64
+	err := r.DB().SelectContext(ctx, &userIDs, `
+		SELECT COALESCE(us.user_id, p.user_id)
+		FROM user_storage us
+		FULL JOIN (
+			SELECT user_id, `+storedBytes+` AS bytes FROM photos GROUP BY user_id
+		) p ON p.user_id = us.user_id
+		WHERE COALESCE(us.used_bytes, 0) <> COALESCE(p.bytes, 0)
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// Recompute sets the recorded usage of a user to the sizes of the user's
+// stored photos. The usage row is locked first, so uploads committed
+// meanwhile are counted exactly once.
+func (r *QuotaRepository) Recompute(ctx context.Context, userID int64) (*model.StorageDrift, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_storage (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := &model.StorageDrift{UserID: userID}
+	err = tx.GetContext(ctx, &drift.Recorded, `
+		SELECT used_bytes FROM user_storage WHERE user_id = $1 FOR UPDATE
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.GetContext(ctx, &drift.Actual, `SELECT `+storedBytes+` FROM photos WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE user_storage SET used_bytes = $2, reconciled_at = NOW() WHERE user_id = $1
+	`, userID, drift.Actual)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return drift, nil
+}
+
+// MarkReconciled records when the usage of all users was last verified
+func (r *QuotaRepository) MarkReconciled(ctx context.Context, at time.Time) error {
+	_, err := r.DB().ExecContext(ctx, `UPDATE user_storage SET reconciled_at = $1`, at)
+	return err
+}
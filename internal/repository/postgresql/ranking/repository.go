@@ -100,7 +100,7 @@ func (r *RankingRepository) GetPhotoRanking(ctx context.Context, params RankingP
 	var args []interface{}
 	argIndex := 1
 
-	whereClause = "WHERE status = 'approved'"
+	whereClause = "WHERE " + postgresql.PublicPhotoFilter("")
 
 	startTime := GetTimeRange(params.Period)
 	if startTime != nil {
@@ -172,7 +172,7 @@ func (r *RankingRepository) GetUserRanking(ctx context.Context, params RankingPa
 			COALESCE(SUM(p.like_count), 0) as total_likes,
 			COALESCE(SUM(p.view_count), 0) as total_views
 		FROM users u
-		LEFT JOIN photos p ON u.id = p.user_id AND p.status = 'approved' %s
+		LEFT JOIN photos p ON u.id = p.user_id AND `+postgresql.PublicPhotoFilter("p")+` %s
 		WHERE u.status = 'active' AND u.role != 'guest'
 		GROUP BY u.id, u.username, u.avatar
 		HAVING COUNT(DISTINCT p.id) > 0
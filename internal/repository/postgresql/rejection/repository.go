@@ -0,0 +1,159 @@
+package rejection
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RejectionRepository handles rejection reason database operations
+type RejectionRepository struct {
+	*postgresql.BaseRepository
+}
+
+// NewRejectionRepository creates a new rejection reason repository
+func NewRejectionRepository(db *sqlx.DB) *RejectionRepository {
+	return &RejectionRepository{
+		BaseRepository: postgresql.NewBaseRepository(db),
+	}
+}
+
+// ReasonParams contains parameters for creating or updating a rejection reason
+type ReasonParams struct {
+	Code        string
+	Name        string
+	NameEN      string
+	Description string
+	SortOrder   int
+	IsActive    bool
+}
+
+// List retrieves the rejection reason catalogue
+func (r *RejectionRepository) List(ctx context.Context, includeInactive bool) ([]*model.RejectionReason, error) {
+	query := `SELECT * FROM rejection_reasons`
+	if !includeInactive {
+		query += ` WHERE is_active = TRUE`
+	}
+	query += ` ORDER BY sort_order ASC, id ASC`
+
+	var reasons []*model.RejectionReason
+	if err := r.DB().SelectContext(ctx, &reasons, query); err != nil {
+		return nil, err
+	}
+	return reasons, nil
+}
+
+// GetByID retrieves a rejection reason by ID
+func (r *RejectionRepository) GetByID(ctx context.Context, id int32) (*model.RejectionReason, error) {
+	var reason model.RejectionReason
+	err := r.DB().GetContext(ctx, &reason, `SELECT * FROM rejection_reasons WHERE id = $1`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &reason, nil
+}
+
+// GetByCodes retrieves rejection reasons by code, unknown codes are skipped
+func (r *RejectionRepository) GetByCodes(ctx context.Context, codes []string) ([]*model.RejectionReason, error) {
+	if len(codes) == 0 {
+		return []*model.RejectionReason{}, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM rejection_reasons WHERE code IN (?) ORDER BY sort_order ASC, id ASC`, codes)
+	if err != nil {
+		return nil, err
+	}
+	query = r.DB().Rebind(query)
+
+	var reasons []*model.RejectionReason
+	if err := r.DB().SelectContext(ctx, &reasons, query, args...); err != nil {
+		return nil, err
+	}
+	return reasons, nil
+}
+
+// Create creates a new rejection reason
+func (r *RejectionRepository) Create(ctx context.Context, params ReasonParams) (*model.RejectionReason, error) {
+	query := `
+		INSERT INTO rejection_reasons (code, name, name_en, description, sort_order, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING *
+	`
+
+	var reason model.RejectionReason
+	err := r.DB().GetContext(ctx, &reason, query,
+		params.Code, params.Name, params.NameEN, toNullString(&params.Description), params.SortOrder, params.IsActive,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint") {
+			return nil, postgresql.ErrDuplicateKey
+		}
+		return nil, err
+	}
+	return &reason, nil
+}
+
+// Update updates a rejection reason
+func (r *RejectionRepository) Update(ctx context.Context, id int32, params ReasonParams) (*model.RejectionReason, error) {
+	query := `
+		UPDATE rejection_reasons
+		SET code = $1, name = $2, name_en = $3, description = $4, sort_order = $5, is_active = $6, updated_at = NOW()
+		WHERE id = $7
+		RETURNING *
+	`
+
+	var reason model.RejectionReason
+	err := r.DB().GetContext(ctx, &reason, query,
+		params.Code, params.Name, params.NameEN, toNullString(&params.Description), params.SortOrder, params.IsActive, id,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint") {
+			return nil, postgresql.ErrDuplicateKey
+		}
+		return nil, err
+	}
+	return &reason, nil
+}
+
+// Delete deletes a rejection reason
+func (r *RejectionRepository) Delete(ctx context.Context, id int32) error {
+	result, err := r.DB().ExecContext(ctx, `DELETE FROM rejection_reasons WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+	return nil
+}
+
+// IsUsed checks if a rejection reason has been given in any review
+func (r *RejectionRepository) IsUsed(ctx context.Context, id int32) (bool, error) {
+	var used bool
+	err := r.DB().GetContext(ctx, &used, `SELECT EXISTS(SELECT 1 FROM photo_review_reasons WHERE reason_id = $1)`, id)
+	return used, err
+}
+
+// toNullString helper function
+func toNullString(s *string) sql.NullString {
+	if s == nil || *s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
@@ -0,0 +1,546 @@
+package series
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	// ownerFrameFilter matches the frames the series owner can see
+	ownerFrameFilter = `p.deleted_at IS NULL`
+	// visibleFrameFilter matches the frames other users can see, unlisted
+	// photos are shown as the owner posted them in the series
+	visibleFrameFilter = postgresql.VisiblePhotoFilter("p")
+)
+
+// SeriesRepository handles photo series database operations
+type SeriesRepository struct {
+	*postgresql.BaseRepository
+}
+
+// NewSeriesRepository creates a new series repository
+func NewSeriesRepository(db *sqlx.DB) *SeriesRepository {
+	return &SeriesRepository{
+		BaseRepository: postgresql.NewBaseRepository(db),
+	}
+}
+
+// CreateParams contains parameters for creating a series
+type CreateParams struct {
+	UserID      int64
+	Title       string
+	Description *string
+	PhotoIDs    []int64 // Frames in order
+}
+
+// ListParams contains parameters for listing series
+type ListParams struct {
+	Page       int
+	PageSize   int
+	UserID     int64 // 0 lists the series of all users
+	PublicOnly bool  // Only series with frames visible to everyone, counting those frames
+}
+
+// ListResult contains the result of listing series
+type ListResult struct {
+	Series     []*model.Series
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
+
+// seriesColumns selects a series with its frame count and the thumbnail of
+// its first frame
+func seriesColumns(ownerView bool) string {
+	filter := visibleFrameFilter
+	if ownerView {
+		filter = ownerFrameFilter
+	}
+
+	return fmt.Sprintf(`
+		s.*,
+		(SELECT COUNT(*) FROM series_frames sf JOIN photos p ON p.id = sf.photo_id
+			WHERE sf.series_id = s.id AND %s) AS frame_count,
+		(SELECT p.thumbnail_path FROM series_frames sf JOIN photos p ON p.id = sf.photo_id
+			WHERE sf.series_id = s.id AND %s
+			ORDER BY sf.position ASC
+			LIMIT 1) AS cover_path
+	`, filter, filter)
+}
+
+// Create creates a series with its frames in the given order
+func (r *SeriesRepository) Create(ctx context.Context, params *CreateParams) (int64, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO photo_series (user_id, title, description)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, params.UserID, params.Title, toNullString(params.Description)).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := insertFrames(ctx, tx, id, 0, params.PhotoIDs); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID retrieves a series by ID. ownerView counts every frame that is not
+// in the trash, otherwise only frames visible to everyone.
+func (r *SeriesRepository) GetByID(ctx context.Context, id int64, ownerView bool) (*model.Series, error) {
+	query := fmt.Sprintf(`SELECT %s FROM photo_series s WHERE s.id = $1`, seriesColumns(ownerView))
+
+	var s model.Series
+	err := r.DB().GetContext(ctx, &s, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// List retrieves series, newest first
+func (r *SeriesRepository) List(ctx context.Context, params ListParams) (*ListResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if params.UserID > 0 {
+		conditions = append(conditions, fmt.Sprintf("s.user_id = $%d", argIndex))
+		args = append(args, params.UserID)
+		argIndex++
+	}
+	if params.PublicOnly {
+		conditions = append(conditions, fmt.Sprintf(`EXISTS(
+			SELECT 1 FROM series_frames sf JOIN photos p ON p.id = sf.photo_id
+			WHERE sf.series_id = s.id AND %s
+		)`, visibleFrameFilter))
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	err := r.DB().GetContext(ctx, &total, "SELECT COUNT(*) FROM photo_series s "+whereClause, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate pagination
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize > 0 {
+		totalPages++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM photo_series s
+		%s
+		ORDER BY s.created_at DESC, s.id DESC
+		LIMIT $%d OFFSET $%d
+	`, seriesColumns(!params.PublicOnly), whereClause, argIndex, argIndex+1)
+	args = append(args, params.PageSize, offset)
+
+	var series []*model.Series
+	if err := r.DB().SelectContext(ctx, &series, query, args...); err != nil {
+		return nil, err
+	}
+
+	return &ListResult{
+		Series:     series,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Update sets the title and description of a series
+func (r *SeriesRepository) Update(ctx context.Context, id int64, title string, description *string) error {
+	result, err := r.DB().ExecContext(ctx, `
+		UPDATE photo_series SET title = $1, description = $2 WHERE id = $3
+	`, title, toNullString(description), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a series with its likes and comments, its frames are kept
+// as single photos
+func (r *SeriesRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.DB().ExecContext(ctx, `DELETE FROM photo_series WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return nil
+}
+
+// CountOwnedPhotos counts how many of the given photos belong to a user and
+// are not in the trash
+func (r *SeriesRepository) CountOwnedPhotos(ctx context.Context, userID int64, photoIDs []int64) (int, error) {
+	if len(photoIDs) == 0 {
+		return 0, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT COUNT(*) FROM photos WHERE id IN (?) AND user_id = ? AND deleted_at IS NULL
+	`, photoIDs, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := r.DB().GetContext(ctx, &count, r.DB().Rebind(query), args...); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountTakenPhotos counts how many of the given photos are frames of a
+// series other than the given one
+func (r *SeriesRepository) CountTakenPhotos(ctx context.Context, seriesID int64, photoIDs []int64) (int, error) {
+	if len(photoIDs) == 0 {
+		return 0, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT COUNT(*) FROM series_frames WHERE photo_id IN (?) AND series_id <> ?
+	`, photoIDs, seriesID)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := r.DB().GetContext(ctx, &count, r.DB().Rebind(query), args...); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountFrames counts the frames of a series, including those in the trash
+func (r *SeriesRepository) CountFrames(ctx context.Context, seriesID int64) (int, error) {
+	var count int
+	err := r.DB().GetContext(ctx, &count, `SELECT COUNT(*) FROM series_frames WHERE series_id = $1`, seriesID)
+	return count, err
+}
+
+// AddFrames appends frames to the end of a series in the given order,
+// skipping photos already in it. Returns the number of frames added.
+func (r *SeriesRepository) AddFrames(ctx context.Context, seriesID int64, photoIDs []int64) (int, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := lockSeries(ctx, tx, seriesID); err != nil {
+		return 0, err
+	}
+
+	var position int
+	err = tx.GetContext(ctx, &position, `
+		SELECT COALESCE(MAX(position), 0) FROM series_frames WHERE series_id = $1
+	`, seriesID)
+	if err != nil {
+		return 0, err
+	}
+
+	added, err := insertFrames(ctx, tx, seriesID, position, photoIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	if added > 0 {
+		if err := touchSeries(ctx, tx, seriesID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return added, nil
+}
+
+// RemoveFrames removes frames from a series and closes the gaps they leave
+// in its order. Returns the number of frames removed.
+func (r *SeriesRepository) RemoveFrames(ctx context.Context, seriesID int64, photoIDs []int64) (int, error) {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := lockSeries(ctx, tx, seriesID); err != nil {
+		return 0, err
+	}
+
+	query, args, err := sqlx.In(`DELETE FROM series_frames WHERE series_id = ? AND photo_id IN (?)`, seriesID, photoIDs)
+	if err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+
+	// Renumber the remaining frames
+	_, err = tx.ExecContext(ctx, `
+		UPDATE series_frames sf SET position = ordered.position
+		FROM (
+			SELECT photo_id, ROW_NUMBER() OVER (ORDER BY position ASC, added_at ASC) AS position
+			FROM series_frames WHERE series_id = $1
+		) ordered
+		WHERE sf.series_id = $1 AND sf.photo_id = ordered.photo_id AND sf.position <> ordered.position
+	`, seriesID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := touchSeries(ctx, tx, seriesID); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(removed), nil
+}
+
+// MoveFrame moves a frame to a new position in a series, shifting the frames
+// in between. Positions past the end move the frame to the end. Returns
+// ErrNotFound if the photo is not a frame of the series.
+func (r *SeriesRepository) MoveFrame(ctx context.Context, seriesID, photoID int64, position int) error {
+	tx, err := r.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockSeries(ctx, tx, seriesID); err != nil {
+		return err
+	}
+
+	var current struct {
+		Position int `db:"position"`
+		Last     int `db:"last"`
+	}
+	err = tx.GetContext(ctx, &current, `
+		SELECT position, (SELECT MAX(position) FROM series_frames WHERE series_id = $1) AS last
+		FROM series_frames WHERE series_id = $1 AND photo_id = $2
+	`, seriesID, photoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postgresql.ErrNotFound
+		}
+		return err
+	}
+
+	if position < 1 {
+		position = 1
+	}
+	if position > current.Last {
+		position = current.Last
+	}
+	if position == current.Position {
+		return nil
+	}
+
+	if position < current.Position {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE series_frames SET position = position + 1
+			WHERE series_id = $1 AND position >= $2 AND position < $3
+		`, seriesID, position, current.Position)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE series_frames SET position = position - 1
+			WHERE series_id = $1 AND position > $2 AND position <= $3
+		`, seriesID, current.Position, position)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE series_frames SET position = $3 WHERE series_id = $1 AND photo_id = $2
+	`, seriesID, photoID, position)
+	if err != nil {
+		return err
+	}
+
+	if err := touchSeries(ctx, tx, seriesID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListFrames retrieves the frames of a series in order. ownerView includes
+// every frame that is not in the trash, otherwise only frames visible to
+// everyone.
+func (r *SeriesRepository) ListFrames(ctx context.Context, seriesID int64, ownerView bool) ([]*model.Photo, error) {
+	filter := visibleFrameFilter
+	if ownerView {
+		filter = ownerFrameFilter
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.* FROM series_frames sf JOIN photos p ON p.id = sf.photo_id
+		WHERE sf.series_id = $1 AND %s
+		ORDER BY sf.position ASC
+	`, filter)
+
+	var photos []*model.Photo
+	if err := r.DB().SelectContext(ctx, &photos, query, seriesID); err != nil {
+		return nil, err
+	}
+	return photos, nil
+}
+
+// AddLike adds a like to a series
+func (r *SeriesRepository) AddLike(ctx context.Context, userID, seriesID int64) error {
+	_, err := r.DB().ExecContext(ctx, `
+		INSERT INTO series_likes (user_id, series_id) VALUES ($1, $2) ON CONFLICT DO NOTHING
+	`, userID, seriesID)
+	return err
+}
+
+// RemoveLike removes a like from a series
+func (r *SeriesRepository) RemoveLike(ctx context.Context, userID, seriesID int64) error {
+	result, err := r.DB().ExecContext(ctx, `DELETE FROM series_likes WHERE user_id = $1 AND series_id = $2`, userID, seriesID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return nil
+}
+
+// IsLiked checks if a user has liked a series
+func (r *SeriesRepository) IsLiked(ctx context.Context, userID, seriesID int64) (bool, error) {
+	var liked bool
+	err := r.DB().GetContext(ctx, &liked, `
+		SELECT EXISTS(SELECT 1 FROM series_likes WHERE user_id = $1 AND series_id = $2)
+	`, userID, seriesID)
+	return liked, err
+}
+
+// insertFrames inserts frames after the given position, skipping photos
+// already in this or another series. Returns the number of frames inserted.
+func insertFrames(ctx context.Context, tx *sqlx.Tx, seriesID int64, position int, photoIDs []int64) (int, error) {
+	added := 0
+	for _, photoID := range photoIDs {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO series_frames (series_id, photo_id, position)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		`, seriesID, photoID, position+1)
+		if err != nil {
+			return 0, err
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return 0, err
+		} else if rowsAffected > 0 {
+			position++
+			added++
+		}
+	}
+	return added, nil
+}
+
+// lockSeries locks a series row so concurrent changes to its order serialize
+func lockSeries(ctx context.Context, tx *sqlx.Tx, seriesID int64) error {
+	var id int64
+	err := tx.GetContext(ctx, &id, `SELECT id FROM photo_series WHERE id = $1 FOR UPDATE`, seriesID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postgresql.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// touchSeries marks a series as updated after its frames changed
+func touchSeries(ctx context.Context, tx *sqlx.Tx, seriesID int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE photo_series SET updated_at = NOW() WHERE id = $1`, seriesID)
+	return err
+}
+
+// Helper function for converting pointer to sql.NullString
+func toNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
@@ -0,0 +1,101 @@
+package series
+
+import (
+	"context"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+func TestSeriesPublicOnceFrameApproved(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewSeriesRepository(db)
+	ctx := context.Background()
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+
+	frame := func(thumbnail, update string) int64 {
+		id := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusPending))
+		pgtest.Exec(t, db, `UPDATE photos SET thumbnail_path = $1`+update+` WHERE id = $2`, thumbnail, id)
+		return id
+	}
+	first := frame("first.jpg", "")
+	second := frame("second.jpg", "")
+	scheduled := frame("scheduled.jpg", ", publish_at = NOW() + INTERVAL '1 day'")
+	trashed := frame("trashed.jpg", ", deleted_at = NOW()")
+
+	seriesID, err := repo.Create(ctx, &CreateParams{
+		UserID:   ownerID,
+		Title:    "Go-around",
+		PhotoIDs: []int64{first, second, scheduled, trashed},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	approve := func(photoID int64) {
+		pgtest.Exec(t, db, `UPDATE photos SET status = $1 WHERE id = $2`, model.PhotoStatusApproved, photoID)
+	}
+	listed := func() bool {
+		t.Helper()
+		result, err := repo.List(ctx, ListParams{PublicOnly: true})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		for _, s := range result.Series {
+			if s.ID == seriesID {
+				return true
+			}
+		}
+		return false
+	}
+	check := func(step string, ownerView bool, expectCount int, expectCover string) {
+		t.Helper()
+		s, err := repo.GetByID(ctx, seriesID, ownerView)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if s.FrameCount != expectCount {
+			t.Errorf("%s: frame_count = %d, expected %d", step, s.FrameCount, expectCount)
+		}
+		if s.CoverPath.String != expectCover {
+			t.Errorf("%s: cover_path = %q, expected %q", step, s.CoverPath.String, expectCover)
+		}
+		frames, err := repo.ListFrames(ctx, seriesID, ownerView)
+		if err != nil {
+			t.Fatalf("ListFrames() error = %v", err)
+		}
+		if len(frames) != expectCount {
+			t.Errorf("%s: ListFrames() returned %d frames, expected %d", step, len(frames), expectCount)
+		}
+	}
+
+	// The owner sees every frame outside the trash while they are in review
+	check("Owner, in review", true, 3, "first.jpg")
+	check("Public, in review", false, 0, "")
+	if listed() {
+		t.Error("series without an approved frame listed publicly")
+	}
+
+	// Approved and published frames are shown, scheduled ones wait
+	approve(scheduled)
+	if listed() {
+		t.Error("series with only a scheduled frame listed publicly")
+	}
+	approve(second)
+	if !listed() {
+		t.Error("series with an approved frame not listed publicly")
+	}
+	check("Public, one frame approved", false, 1, "second.jpg")
+
+	approve(first)
+	approve(trashed)
+	check("Public, frames approved", false, 2, "first.jpg")
+	check("Owner, frames approved", true, 3, "first.jpg")
+
+	// Rejecting every shown frame takes the series out of the public list
+	pgtest.Exec(t, db, `UPDATE photos SET status = $1 WHERE id IN ($2, $3)`, model.PhotoStatusRejected, first, second)
+	if listed() {
+		t.Error("series without a visible frame still listed publicly")
+	}
+}
@@ -67,10 +67,10 @@ func (r *ShareRepository) GetShareCount(ctx context.Context, photoID int64) (int
 	return count, err
 }
 
-// PhotoExists checks if a photo exists and is visible
+// PhotoExists checks if a photo exists and is visible. Private and scheduled photos cannot be shared.
 func (r *ShareRepository) PhotoExists(ctx context.Context, photoID int64) (bool, error) {
 	var exists bool
-	err := r.DB().GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1 AND status = 'approved')`, photoID)
+	err := r.DB().GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM photos WHERE id = $1 AND `+postgresql.VisiblePhotoFilter("")+`)`, photoID)
 	return exists, err
 }
 
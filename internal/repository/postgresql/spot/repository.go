@@ -0,0 +1,411 @@
+package spot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// spotColumns selects a spot with its approved photo count
+var spotColumns = `
+	s.*,
+	(SELECT COUNT(*) FROM photos p WHERE p.spot_id = s.id AND ` + postgresql.PublicPhotoFilter("p") + `) AS photo_count
+`
+
+// SpotRepository handles spotting spot database operations
+type SpotRepository struct {
+	*postgresql.BaseRepository
+}
+
+// NewSpotRepository creates a new spot repository
+func NewSpotRepository(db *sqlx.DB) *SpotRepository {
+	return &SpotRepository{
+		BaseRepository: postgresql.NewBaseRepository(db),
+	}
+}
+
+// CreateSpotParams contains parameters for creating a spot
+type CreateSpotParams struct {
+	UserID         int64
+	Airport        string
+	Name           string
+	Latitude       float64
+	Longitude      float64
+	Description    *string
+	AccessNotes    *string
+	BestLightTimes *string
+}
+
+// ListParams contains parameters for listing spots
+type ListParams struct {
+	Page     int
+	PageSize int
+	Airport  string
+	Status   string // Empty means approved only
+	UserID   int64  // Filter by submitter (optional)
+}
+
+// ListResult contains the result of listing spots
+type ListResult struct {
+	Spots      []*model.SpottingSpot
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
+
+// PhotoListResult contains the result of listing photos at a spot
+type PhotoListResult struct {
+	Photos     []*model.Photo
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
+
+// Create creates a new spot pending moderation
+func (r *SpotRepository) Create(ctx context.Context, params *CreateSpotParams) (int64, error) {
+	query := `
+		INSERT INTO spotting_spots (user_id, airport, name, latitude, longitude, description, access_notes, best_light_times, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.DB().QueryRowContext(ctx, query,
+		params.UserID,
+		params.Airport,
+		params.Name,
+		params.Latitude,
+		params.Longitude,
+		toNullString(params.Description),
+		toNullString(params.AccessNotes),
+		toNullString(params.BestLightTimes),
+		model.SpotStatusPending,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID retrieves a spot by ID
+func (r *SpotRepository) GetByID(ctx context.Context, id int64) (*model.SpottingSpot, error) {
+	query := fmt.Sprintf(`SELECT %s FROM spotting_spots s WHERE s.id = $1`, spotColumns)
+
+	var s model.SpottingSpot
+	err := r.DB().GetContext(ctx, &s, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// List retrieves spots with pagination and filters
+func (r *SpotRepository) List(ctx context.Context, params ListParams) (*ListResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	// Build WHERE clause
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	switch params.Status {
+	case "":
+		conditions = append(conditions, "s.status = 'approved'")
+	case "all":
+	default:
+		conditions = append(conditions, fmt.Sprintf("s.status = $%d", argIndex))
+		args = append(args, params.Status)
+		argIndex++
+	}
+
+	if params.Airport != "" {
+		conditions = append(conditions, fmt.Sprintf("UPPER(s.airport) = UPPER($%d)", argIndex))
+		args = append(args, params.Airport)
+		argIndex++
+	}
+
+	if params.UserID > 0 {
+		conditions = append(conditions, fmt.Sprintf("s.user_id = $%d", argIndex))
+		args = append(args, params.UserID)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Count total
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM spotting_spots s %s", whereClause)
+	var total int64
+	err := r.DB().GetContext(ctx, &total, countQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate pagination
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize > 0 {
+		totalPages++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM spotting_spots s
+		%s
+		ORDER BY s.airport ASC, s.created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, spotColumns, whereClause, argIndex, argIndex+1)
+
+	args = append(args, params.PageSize, offset)
+
+	var spots []*model.SpottingSpot
+	err = r.DB().SelectContext(ctx, &spots, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResult{
+		Spots:      spots,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// FindNearby retrieves approved spots within radiusKm of a point, nearest first
+func (r *SpotRepository) FindNearby(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]*model.SpottingSpot, error) {
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	// Distance to the point ($1, $2) for ordering, radius conditions follow
+	args := []interface{}{lat, lng}
+	conditions, radiusArgs, argIndex := postgresql.RadiusConditions("s.latitude", "s.longitude", lat, lng, radiusKm, 3)
+	args = append(args, radiusArgs...)
+
+	query := fmt.Sprintf(`
+		SELECT %s, %s AS distance_km
+		FROM spotting_spots s
+		WHERE s.status = 'approved' AND %s
+		ORDER BY distance_km ASC
+		LIMIT $%d
+	`, spotColumns, postgresql.DistanceExpr("s.latitude", "s.longitude", 1, 2), strings.Join(conditions, " AND "), argIndex)
+
+	args = append(args, limit)
+
+	var spots []*model.SpottingSpot
+	err := r.DB().SelectContext(ctx, &spots, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return spots, nil
+}
+
+// Review sets the moderation result of a spot
+func (r *SpotRepository) Review(ctx context.Context, id, reviewerID int64, status model.SpotStatus, reason *string) error {
+	query := `
+		UPDATE spotting_spots
+		SET status = $1, reviewer_id = $2, reject_reason = $3, reviewed_at = NOW()
+		WHERE id = $4
+	`
+
+	result, err := r.DB().ExecContext(ctx, query, status, reviewerID, toNullString(reason), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a spot, photos linked to it are unlinked
+func (r *SpotRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.DB().ExecContext(ctx, `DELETE FROM spotting_spots WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListPhotos retrieves approved public photos taken from a spot
+func (r *SpotRepository) ListPhotos(ctx context.Context, spotID int64, page, pageSize int) (*PhotoListResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	var total int64
+	err := r.DB().GetContext(ctx, &total, `SELECT COUNT(*) FROM photos WHERE spot_id = $1 AND `+postgresql.PublicPhotoFilter(""), spotID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate pagination
+	offset := (page - 1) * pageSize
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	query := `
+		SELECT * FROM photos
+		WHERE spot_id = $1 AND ` + postgresql.PublicPhotoFilter("") + `
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var photos []*model.Photo
+	err = r.DB().SelectContext(ctx, &photos, query, spotID, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PhotoListResult{
+		Photos:     photos,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// GetFocalLengthStats computes the 35mm focal lengths used by approved photos at a spot.
+// Returns nil if no photo at the spot carries focal length data.
+func (r *SpotRepository) GetFocalLengthStats(ctx context.Context, spotID int64, topN int) (*model.FocalLengthStats, error) {
+	var summary struct {
+		SampleCount int             `db:"sample_count"`
+		Min         sql.NullFloat64 `db:"min"`
+		Median      sql.NullFloat64 `db:"median"`
+		Max         sql.NullFloat64 `db:"max"`
+	}
+
+	query := `
+		SELECT
+			COUNT(exif_focal_length_35mm_num) AS sample_count,
+			MIN(exif_focal_length_35mm_num) AS min,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY exif_focal_length_35mm_num) AS median,
+			MAX(exif_focal_length_35mm_num) AS max
+		FROM photos
+		WHERE spot_id = $1 AND ` + postgresql.PublicPhotoFilter("") + ` AND exif_focal_length_35mm_num IS NOT NULL
+	`
+
+	err := r.DB().GetContext(ctx, &summary, query, spotID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.SampleCount == 0 {
+		return nil, nil
+	}
+
+	commonQuery := `
+		SELECT ROUND(exif_focal_length_35mm_num) AS focal_length, COUNT(*) AS count
+		FROM photos
+		WHERE spot_id = $1 AND ` + postgresql.PublicPhotoFilter("") + ` AND exif_focal_length_35mm_num IS NOT NULL
+		GROUP BY focal_length
+		ORDER BY count DESC, focal_length ASC
+		LIMIT $2
+	`
+
+	var common []*model.FocalLengthCount
+	err = r.DB().SelectContext(ctx, &common, commonQuery, spotID, topN)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.FocalLengthStats{
+		SampleCount: summary.SampleCount,
+		Min:         summary.Min.Float64,
+		Median:      summary.Median.Float64,
+		Max:         summary.Max.Float64,
+		Common:      common,
+	}, nil
+}
+
+// GetPhotoLocation retrieves owner and GPS coordinates of a photo
+func (r *SpotRepository) GetPhotoLocation(ctx context.Context, photoID int64) (ownerID int64, lat, lng sql.NullFloat64, err error) {
+	query := `SELECT user_id, exif_gps_latitude, exif_gps_longitude FROM photos WHERE id = $1 AND deleted_at IS NULL`
+	err = r.DB().QueryRowContext(ctx, query, photoID).Scan(&ownerID, &lat, &lng)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, lat, lng, postgresql.ErrNotFound
+		}
+		return 0, lat, lng, err
+	}
+	return ownerID, lat, lng, nil
+}
+
+// SetPhotoSpot links a photo to a spot, nil spotID removes the link
+func (r *SpotRepository) SetPhotoSpot(ctx context.Context, photoID int64, spotID *int64) error {
+	var value sql.NullInt64
+	if spotID != nil {
+		value = sql.NullInt64{Int64: *spotID, Valid: true}
+	}
+
+	result, err := r.DB().ExecContext(ctx, `UPDATE photos SET spot_id = $1 WHERE id = $2 AND deleted_at IS NULL`, value, photoID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+
+	return nil
+}
+
+// Helper function for converting pointer to sql.NullString
+func toNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
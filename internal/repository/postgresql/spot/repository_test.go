@@ -0,0 +1,59 @@
+package spot
+
+import (
+	"context"
+	"testing"
+
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+)
+
+func TestFindNearbyAcrossAntimeridian(t *testing.T) {
+	db := pgtest.Open(t)
+	repo := NewSpotRepository(db)
+
+	spot := func(name, status string, lat, lng float64) int64 {
+		var id int64
+		err := db.QueryRow(`
+			INSERT INTO spotting_spots (airport, name, latitude, longitude, status)
+			VALUES ('NFFN', $1, $2, $3, $4) RETURNING id
+		`, name, lat, lng, status).Scan(&id)
+		if err != nil {
+			t.Fatalf("create spot: %v", err)
+		}
+		return id
+	}
+	// Taveuni, Fiji on both sides of the antimeridian
+	east := spot("East", "approved", -16.80, 179.90)
+	west := spot("West", "approved", -16.85, -179.95)
+	spot("Pending", "pending", -16.80, 179.95)
+	spot("Far", "approved", -16.80, 175.00)
+
+	tests := []struct {
+		name   string
+		lng    float64
+		expect []int64
+	}{
+		{"Center east of the antimeridian", 179.95, []int64{east, west}},
+		{"Center west of the antimeridian", -179.98, []int64{west, east}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spots, err := repo.FindNearby(context.Background(), -16.82, tt.lng, 50, 10)
+			if err != nil {
+				t.Fatalf("FindNearby() error = %v", err)
+			}
+
+			got := make([]int64, len(spots))
+			for i, s := range spots {
+				got[i] = s.ID
+				if s.DistanceKm == nil || *s.DistanceKm > 50 {
+					t.Errorf("spot %d distance = %v, expected within 50 km", s.ID, s.DistanceKm)
+				}
+			}
+			if len(got) != len(tt.expect) || got[0] != tt.expect[0] || got[1] != tt.expect[1] {
+				t.Errorf("FindNearby() = %v, expected %v nearest first", got, tt.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package postgresql
+
+import "fmt"
+
+// PendingReplacementBytes returns a subquery summing the stored files of the
+// pending replacements matching condition, e.g. "user_id = $1". They are kept
+// on disk next to the photos they replace until decided, so they count
+// against the user's storage quota.
+func PendingReplacementBytes(condition string) string {
+	return fmt.Sprintf(`(
+		SELECT COALESCE(SUM(photo_stored_bytes(
+			(file_params->>'file_size')::BIGINT,
+			(file_params->>'raw_file_size')::BIGINT,
+			(file_params->>'derivative_size')::BIGINT
+		)), 0)
+		FROM photo_replacements WHERE %s AND status = 'pending'
+	)`, condition)
+}
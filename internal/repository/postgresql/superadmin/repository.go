@@ -28,6 +28,10 @@ const (
 	PermManageTags          = "manage_tags"
 	PermViewStatistics      = "view_statistics"
 	PermViewUserDetails     = "view_user_details"
+	PermManageRoles         = "manage_roles"
+	PermManageSpots         = "manage_spots"
+	PermManageUserStorage   = "manage_user_storage"
+	PermManageUserTrust     = "manage_user_trust"
 )
 
 // AllPermissions is a list of all available permissions
@@ -46,6 +50,10 @@ var AllPermissions = []string{
 	PermManageTags,
 	PermViewStatistics,
 	PermViewUserDetails,
+	PermManageRoles,
+	PermManageSpots,
+	PermManageUserStorage,
+	PermManageUserTrust,
 }
 
 // AdminPermission represents an admin permission record
@@ -132,11 +140,14 @@ func (r *SuperadminRepository) GetAdminByID(ctx context.Context, adminID int64)
 	return &user, nil
 }
 
-// GetAdminPermissions retrieves all permissions for an admin
+// GetAdminPermissions retrieves all permissions for an admin.
+// Rows left behind by a user demoted from admin are ignored.
 func (r *SuperadminRepository) GetAdminPermissions(ctx context.Context, adminID int64) ([]string, error) {
 	var permissions []string
 	err := r.DB().SelectContext(ctx, &permissions, `
-		SELECT permission FROM admin_permissions WHERE admin_id = $1
+		SELECT ap.permission FROM admin_permissions ap
+		INNER JOIN users u ON u.id = ap.admin_id
+		WHERE ap.admin_id = $1 AND u.role = 'admin'
 	`, adminID)
 	return permissions, err
 }
@@ -333,3 +344,63 @@ func (r *SuperadminRepository) GetUserRestrictions(ctx context.Context, userID i
 	}
 	return user.CanComment, user.CanMessage, user.CanUpload, nil
 }
+
+// ListReviewPolicies retrieves all category review policies
+func (r *SuperadminRepository) ListReviewPolicies(ctx context.Context) ([]*model.ReviewPolicy, error) {
+	var policies []*model.ReviewPolicy
+	err := r.DB().SelectContext(ctx, &policies, `
+		SELECT * FROM review_policies ORDER BY category_id ASC
+	`)
+	return policies, err
+}
+
+// GetReviewPolicy retrieves the review policy of a category
+func (r *SuperadminRepository) GetReviewPolicy(ctx context.Context, categoryID int) (*model.ReviewPolicy, error) {
+	var policy model.ReviewPolicy
+	err := r.DB().GetContext(ctx, &policy, `
+		SELECT * FROM review_policies WHERE category_id = $1
+	`, categoryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// SetReviewPolicy creates or replaces the review policy of a category
+func (r *SuperadminRepository) SetReviewPolicy(ctx context.Context, categoryID, requiredDecisions int, allowEscalation bool, updatedBy int64) (*model.ReviewPolicy, error) {
+	var policy model.ReviewPolicy
+	err := r.DB().GetContext(ctx, &policy, `
+		INSERT INTO review_policies (category_id, required_decisions, allow_escalation, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (category_id) DO UPDATE
+			SET required_decisions = EXCLUDED.required_decisions,
+				allow_escalation = EXCLUDED.allow_escalation,
+				updated_by = EXCLUDED.updated_by
+		RETURNING *
+	`, categoryID, requiredDecisions, allowEscalation, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// DeleteReviewPolicy removes the review policy of a category
+func (r *SuperadminRepository) DeleteReviewPolicy(ctx context.Context, categoryID int) error {
+	result, err := r.DB().ExecContext(ctx, `
+		DELETE FROM review_policies WHERE category_id = $1
+	`, categoryID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgresql.ErrNotFound
+	}
+	return nil
+}
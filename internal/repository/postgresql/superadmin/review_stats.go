@@ -0,0 +1,209 @@
+package superadmin
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"QuanPhotos/internal/model"
+)
+
+// ReviewerDayStats contains the manual decisions of a reviewer on one day
+type ReviewerDayStats struct {
+	ReviewerID  int64     `db:"reviewer_id"`
+	Day         time.Time `db:"day"`
+	Approvals   int64     `db:"approvals"`
+	Rejections  int64     `db:"rejections"`
+	Escalations int64     `db:"escalations"`
+}
+
+// ReviewerAppealStats contains how a reviewer's decisions fared on appeal
+type ReviewerAppealStats struct {
+	ReviewerID int64 `db:"reviewer_id"`
+	Appealed   int64 `db:"appealed"`   // Decisions followed by an appeal ticket on the photo
+	Overturned int64 `db:"overturned"` // Decisions reversed by a later appeal decision
+}
+
+// CategoryDecisionStats contains the manual decisions made in a category
+type CategoryDecisionStats struct {
+	CategoryID    sql.NullInt32   `db:"category_id"`
+	Approvals     int64           `db:"approvals"`
+	Rejections    int64           `db:"rejections"`
+	DecidedPhotos int64           `db:"decided_photos"` // First attempts with a decision
+	MedianSeconds sql.NullFloat64 `db:"median_seconds"` // Median time from upload to decision
+}
+
+// QueueStats contains the manual review queue of a category
+type QueueStats struct {
+	CategoryID    sql.NullInt32 `db:"category_id"`
+	Pending       int64         `db:"pending"`
+	Senior        int64         `db:"senior"`
+	OldestAt      time.Time     `db:"oldest_at"`
+	AvgAgeSeconds float64       `db:"avg_age_seconds"`
+}
+
+// decisionCTE selects the review decisions in [$1, $2) with the category and
+// upload time of their photo, deleted photos are described by their snapshot.
+// Appeal decisions are left out. decision_times holds the time from upload to
+// the latest decision on the first attempt of each photo.
+const decisionCTE = `
+	WITH decisions AS (
+		SELECT r.photo_id, r.action, r.attempt, r.created_at,
+			COALESCE(p.category_id, d.category_id) AS category_id,
+			COALESCE(p.created_at, d.uploaded_at) AS uploaded_at
+		FROM photo_reviews r
+		LEFT JOIN photos p ON p.id = r.photo_id
+		LEFT JOIN deleted_photos d ON d.photo_id = r.photo_id
+		WHERE r.review_type = 'manual' AND r.action IN ('approve', 'reject')
+			AND r.ticket_id IS NULL
+			AND r.created_at >= $1 AND r.created_at < $2
+	),
+	decision_times AS (
+		SELECT category_id, EXTRACT(EPOCH FROM MAX(created_at) - MIN(uploaded_at))::float8 AS seconds
+		FROM decisions
+		WHERE attempt = 1 AND uploaded_at IS NOT NULL
+		GROUP BY photo_id, category_id
+	)
+`
+
+// ReviewerDailyStats counts the manual decisions of each reviewer per day
+func (r *SuperadminRepository) ReviewerDailyStats(ctx context.Context, from, to time.Time) ([]*ReviewerDayStats, error) {
+	var stats []*ReviewerDayStats
+	err := r.DB().SelectContext(ctx, &stats, `
+		SELECT reviewer_id, DATE(created_at) AS day,
+			COUNT(*) FILTER (WHERE action = 'approve') AS approvals,
+			COUNT(*) FILTER (WHERE action = 'reject') AS rejections,
+			COUNT(*) FILTER (WHERE action = 'escalate') AS escalations
+		FROM photo_reviews
+		WHERE review_type = 'manual' AND reviewer_id IS NOT NULL
+			AND action IN ('approve', 'reject', 'escalate')
+			AND created_at >= $1 AND created_at < $2
+		GROUP BY reviewer_id, day
+		ORDER BY reviewer_id ASC, day ASC
+	`, from, to)
+	return stats, err
+}
+
+// ReviewerAppealStats counts how many decisions of each reviewer were
+// appealed and how many of those were overturned
+func (r *SuperadminRepository) ReviewerAppealStats(ctx context.Context, from, to time.Time) ([]*ReviewerAppealStats, error) {
+	var stats []*ReviewerAppealStats
+	err := r.DB().SelectContext(ctx, &stats, `
+		SELECT r.reviewer_id,
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM tickets t
+				WHERE t.photo_id = r.photo_id AND t.type = 'appeal' AND t.created_at > r.created_at
+			)) AS appealed,
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM photo_reviews o
+				WHERE o.photo_id = r.photo_id AND o.attempt = r.attempt AND o.ticket_id IS NOT NULL
+					AND o.created_at > r.created_at AND o.action IN ('approve', 'reject') AND o.action <> r.action
+			)) AS overturned
+		FROM photo_reviews r
+		WHERE r.review_type = 'manual' AND r.reviewer_id IS NOT NULL
+			AND r.action IN ('approve', 'reject') AND r.ticket_id IS NULL
+			AND r.created_at >= $1 AND r.created_at < $2
+		GROUP BY r.reviewer_id
+	`, from, to)
+	return stats, err
+}
+
+// CategoryDecisionStats counts decisions and the median time from upload to
+// decision per category
+func (r *SuperadminRepository) CategoryDecisionStats(ctx context.Context, from, to time.Time) ([]*CategoryDecisionStats, error) {
+	var stats []*CategoryDecisionStats
+	err := r.DB().SelectContext(ctx, &stats, decisionCTE+`
+		SELECT c.category_id, c.approvals, c.rejections,
+			COALESCE(t.decided_photos, 0) AS decided_photos, t.median_seconds
+		FROM (
+			SELECT category_id,
+				COUNT(*) FILTER (WHERE action = 'approve') AS approvals,
+				COUNT(*) FILTER (WHERE action = 'reject') AS rejections
+			FROM decisions
+			GROUP BY category_id
+		) c
+		LEFT JOIN (
+			SELECT category_id, COUNT(*) AS decided_photos,
+				PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY seconds) AS median_seconds
+			FROM decision_times
+			GROUP BY category_id
+		) t ON t.category_id IS NOT DISTINCT FROM c.category_id
+		ORDER BY c.category_id ASC NULLS LAST
+	`, from, to)
+	return stats, err
+}
+
+// OverallDecisionStats counts decisions and the median time from upload to
+// decision across all categories
+func (r *SuperadminRepository) OverallDecisionStats(ctx context.Context, from, to time.Time) (*CategoryDecisionStats, error) {
+	var stats CategoryDecisionStats
+	err := r.DB().GetContext(ctx, &stats, decisionCTE+`
+		SELECT
+			(SELECT COUNT(*) FROM decisions WHERE action = 'approve') AS approvals,
+			(SELECT COUNT(*) FROM decisions WHERE action = 'reject') AS rejections,
+			COUNT(*) AS decided_photos,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY seconds) AS median_seconds
+		FROM decision_times
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ReviewQueueStats measures the manual review queue per category
+func (r *SuperadminRepository) ReviewQueueStats(ctx context.Context) ([]*QueueStats, error) {
+	var stats []*QueueStats
+	err := r.DB().SelectContext(ctx, &stats, `
+		SELECT category_id,
+			COUNT(*) AS pending,
+			COUNT(*) FILTER (WHERE review_stage = $1) AS senior,
+			MIN(created_at) AS oldest_at,
+			EXTRACT(EPOCH FROM AVG(NOW() - created_at))::float8 AS avg_age_seconds
+		FROM photos
+		WHERE status IN ($2, $3) AND deleted_at IS NULL
+		GROUP BY category_id
+		ORDER BY category_id ASC NULLS LAST
+	`, model.ReviewStageSenior, model.PhotoStatusPending, model.PhotoStatusAIPassed)
+	return stats, err
+}
+
+// GetCategoryMap retrieves categories by ID
+func (r *SuperadminRepository) GetCategoryMap(ctx context.Context) (map[int32]*model.Category, error) {
+	var categories []*model.Category
+	if err := r.DB().SelectContext(ctx, &categories, `SELECT * FROM categories`); err != nil {
+		return nil, err
+	}
+
+	categoryMap := make(map[int32]*model.Category, len(categories))
+	for _, c := range categories {
+		categoryMap[c.ID] = c
+	}
+	return categoryMap, nil
+}
+
+// GetUserMap retrieves users by ID
+func (r *SuperadminRepository) GetUserMap(ctx context.Context, userIDs []int64) (map[int64]*model.User, error) {
+	users := make(map[int64]*model.User)
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM users WHERE id IN (?)`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	query = r.DB().Rebind(query)
+
+	var rows []*model.User
+	if err := r.DB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, u := range rows {
+		users[u.ID] = u
+	}
+	return users, nil
+}
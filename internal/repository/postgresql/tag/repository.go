@@ -255,7 +255,7 @@ func (r *TagRepository) ListPhotos(ctx context.Context, params ListPhotosParams)
 	countQuery := `
 		SELECT COUNT(*) FROM photos p
 		JOIN photo_tags pt ON pt.photo_id = p.id
-		WHERE pt.tag_id = $1 AND p.status = 'approved'
+		WHERE pt.tag_id = $1 AND ` + postgresql.PublicPhotoFilter("p") + `
 	`
 	var total int64
 	err := r.DB().GetContext(ctx, &total, countQuery, params.TagID)
@@ -274,7 +274,7 @@ func (r *TagRepository) ListPhotos(ctx context.Context, params ListPhotosParams)
 	query := fmt.Sprintf(`
 		SELECT p.* FROM photos p
 		JOIN photo_tags pt ON pt.photo_id = p.id
-		WHERE pt.tag_id = $1 AND p.status = 'approved'
+		WHERE pt.tag_id = $1 AND `+postgresql.PublicPhotoFilter("p")+`
 		ORDER BY p.%s %s
 		LIMIT $2 OFFSET $3
 	`, params.SortBy, params.SortOrder)
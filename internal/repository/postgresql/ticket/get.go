@@ -88,7 +88,7 @@ func (r *TicketRepository) IsOwnedBy(ctx context.Context, ticketID, userID int64
 
 // GetPhoto retrieves photo brief info for a ticket (if photo_id exists)
 func (r *TicketRepository) GetPhoto(ctx context.Context, photoID int64, baseURL string) (*model.TicketPhotoBrief, error) {
-	query := `SELECT id, title, thumbnail_path FROM photos WHERE id = $1`
+	query := `SELECT id, title, thumbnail_path FROM photos WHERE id = $1 AND deleted_at IS NULL`
 
 	var photo struct {
 		ID            int64          `db:"id"`
@@ -0,0 +1,164 @@
+package trust
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TrustRepository handles trust tier and user reputation database operations
+type TrustRepository struct {
+	*postgresql.BaseRepository
+}
+
+// NewTrustRepository creates a new trust repository
+func NewTrustRepository(db *sqlx.DB) *TrustRepository {
+	return &TrustRepository{
+		BaseRepository: postgresql.NewBaseRepository(db),
+	}
+}
+
+// TierParams contains parameters for updating a trust tier
+type TierParams struct {
+	MinScore      int
+	AutoApprove   bool
+	SpotCheckRate int
+	StorageQuota  *int64 // Megabytes, nil leaves the role limit
+	UpdatedBy     int64
+}
+
+// ListTiers retrieves all trust tiers, lowest rank first
+func (r *TrustRepository) ListTiers(ctx context.Context) ([]*model.TrustTier, error) {
+	var tiers []*model.TrustTier
+	err := r.DB().SelectContext(ctx, &tiers, `SELECT * FROM trust_tiers ORDER BY rank ASC`)
+	if err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+// UpdateTier updates the score threshold and review policy of a tier
+func (r *TrustRepository) UpdateTier(ctx context.Context, tier string, params TierParams) (*model.TrustTier, error) {
+	var t model.TrustTier
+	err := r.DB().GetContext(ctx, &t, `
+		UPDATE trust_tiers
+		SET min_score = $1, auto_approve = $2, spot_check_rate = $3, storage_quota_mb = $4, updated_by = $5
+		WHERE tier = $6
+		RETURNING *
+	`, params.MinScore, params.AutoApprove, params.SpotCheckRate, params.StorageQuota, params.UpdatedBy, tier)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetUserTrust retrieves the trust record of a user.
+// Returns ErrNotFound if the user has never been evaluated.
+func (r *TrustRepository) GetUserTrust(ctx context.Context, userID int64) (*model.UserTrust, error) {
+	var t model.UserTrust
+	err := r.DB().GetContext(ctx, &t, `SELECT * FROM user_trust WHERE user_id = $1`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetStats retrieves the inputs of a user's reputation score. Approvals are
+// also counted from the given time, usually the last demotion.
+// Returns ErrNotFound if the user does not exist.
+func (r *TrustRepository) GetStats(ctx context.Context, userID int64, since sql.NullTime) (*model.TrustStats, error) {
+	var stats model.TrustStats
+	err := r.DB().GetContext(ctx, &stats, `
+		SELECT
+			u.created_at AS joined_at,
+			(SELECT COUNT(*) FROM photos WHERE user_id = u.id AND status = $2 AND deleted_at IS NULL) AS approved,
+			(SELECT COUNT(*) FROM photos WHERE user_id = u.id AND status = $3 AND deleted_at IS NULL) AS rejected,
+			(SELECT COUNT(*) FROM deleted_photos
+				WHERE user_id = u.id AND deleted_by IS DISTINCT FROM u.id) AS taken_down,
+			(SELECT COUNT(*) FROM tickets t
+				WHERE t.user_id = u.id AND t.type = 'appeal'
+					AND EXISTS (SELECT 1 FROM photo_reviews pr WHERE pr.ticket_id = t.id)) AS appeals_upheld,
+			(SELECT COUNT(*) FROM tickets t
+				WHERE t.user_id = u.id AND t.type = 'appeal' AND t.status IN ('resolved', 'closed')
+					AND NOT EXISTS (SELECT 1 FROM photo_reviews pr WHERE pr.ticket_id = t.id)) AS appeals_denied,
+			(SELECT COUNT(*) FROM photos
+				WHERE user_id = u.id AND status = $2 AND approved_at > $4 AND deleted_at IS NULL) AS approved_since
+		FROM users u
+		WHERE u.id = $1
+	`, userID, model.PhotoStatusApproved, model.PhotoStatusRejected, since)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postgresql.ErrNotFound
+		}
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// SaveEvaluation stores the evaluated score and tier of a user
+func (r *TrustRepository) SaveEvaluation(ctx context.Context, userID int64, score int, tier string) (*model.UserTrust, error) {
+	var t model.UserTrust
+	err := r.DB().GetContext(ctx, &t, `
+		INSERT INTO user_trust (user_id, score, tier)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+			SET score = EXCLUDED.score, tier = EXCLUDED.tier
+		RETURNING *
+	`, userID, score, tier)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Demote sets the tier of a user after a rejection or takedown and clears
+// any admin override
+func (r *TrustRepository) Demote(ctx context.Context, userID int64, tier, reason string) error {
+	_, err := r.DB().ExecContext(ctx, `
+		INSERT INTO user_trust (user_id, tier, demoted_at, demotion_reason)
+		VALUES ($1, $2, NOW(), $3)
+		ON CONFLICT (user_id) DO UPDATE
+			SET tier = EXCLUDED.tier,
+				demoted_at = EXCLUDED.demoted_at,
+				demotion_reason = EXCLUDED.demotion_reason,
+				override_tier = NULL,
+				override_by = NULL,
+				override_note = NULL
+	`, userID, tier, reason)
+	return err
+}
+
+// SetOverride sets or, with a nil tier, clears the admin override of a user
+func (r *TrustRepository) SetOverride(ctx context.Context, userID int64, tier *string, overrideBy int64, note string) (*model.UserTrust, error) {
+	var overrideTier, overrideNote sql.NullString
+	if tier != nil {
+		overrideTier = sql.NullString{String: *tier, Valid: true}
+		overrideNote = sql.NullString{String: note, Valid: note != ""}
+	}
+
+	var t model.UserTrust
+	err := r.DB().GetContext(ctx, &t, `
+		INSERT INTO user_trust (user_id, override_tier, override_by, override_note)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+			SET override_tier = EXCLUDED.override_tier,
+				override_by = EXCLUDED.override_by,
+				override_note = EXCLUDED.override_note
+		RETURNING *
+	`, userID, overrideTier, overrideBy, overrideNote)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -81,17 +81,18 @@ func (r *UserRepository) UpdateLastLogin(ctx context.Context, userID int64) erro
 }
 
 // UpdateProfile updates user's profile information
-func (r *UserRepository) UpdateProfile(ctx context.Context, userID int64, avatar, bio, location *string) error {
+func (r *UserRepository) UpdateProfile(ctx context.Context, userID int64, avatar, bio, location *string, hidePhotoLocation *bool) error {
 	query := `
 		UPDATE users SET
 			avatar = COALESCE($1, avatar),
 			bio = COALESCE($2, bio),
 			location = COALESCE($3, location),
+			hide_photo_location = COALESCE($4, hide_photo_location),
 			updated_at = NOW()
-		WHERE id = $4
+		WHERE id = $5
 	`
 
-	result, err := r.DB().ExecContext(ctx, query, avatar, bio, location, userID)
+	result, err := r.DB().ExecContext(ctx, query, avatar, bio, location, hidePhotoLocation, userID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,145 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// defaultClaimCount is the number of photos claimed when the request omits it
+const defaultClaimCount = 10
+
+// ReviewStore is the part of the photo repository that leases review claims
+// and records decisions
+type ReviewStore interface {
+	GetByID(ctx context.Context, id int64) (*model.Photo, error)
+	ClaimReviews(ctx context.Context, params photo.ClaimParams) ([]*model.ReviewClaim, error)
+	ReleaseClaim(ctx context.Context, photoID, reviewerID int64) error
+	ReviewPhoto(ctx context.Context, params photo.ReviewParams) (*photo.ReviewResult, error)
+}
+
+// ReviewClaimInfo represents an active claim on a photo in the review queue
+type ReviewClaimInfo struct {
+	ReviewerID   int64  `json:"reviewer_id"`
+	ReviewerName string `json:"reviewer_name"`
+	ClaimedAt    string `json:"claimed_at"`
+	ExpiresAt    string `json:"expires_at"`
+}
+
+// newReviewClaimInfo builds claim info from a claim and a user map
+func newReviewClaimInfo(c *model.ReviewClaim, users map[int64]*model.User) *ReviewClaimInfo {
+	info := &ReviewClaimInfo{
+		ReviewerID: c.ReviewerID,
+		ClaimedAt:  c.ClaimedAt.Format(time.RFC3339),
+		ExpiresAt:  c.ExpiresAt.Format(time.RFC3339),
+	}
+	if u, ok := users[c.ReviewerID]; ok {
+		info.ReviewerName = u.Username
+	}
+	return info
+}
+
+// ClaimReviewsRequest represents request for claiming photos to review
+type ClaimReviewsRequest struct {
+	Count int `json:"count" binding:"omitempty,min=1,max=100"`
+}
+
+// ClaimReviews claims the next photos in the review queue for a reviewer and
+// renews the reviewer's existing claims. Returns every photo the reviewer holds.
+func (s *Service) ClaimReviews(ctx context.Context, reviewerID int64, role model.UserRole, req *ClaimReviewsRequest) (*ListReviewsResponse, error) {
+	scope, err := s.loadReviewScope(ctx, reviewerID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := s.reviews.ClaimReviews(ctx, s.claimParams(reviewerID, scope, req.Count))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listReviews(ctx, reviewerID, scope, &ListReviewsRequest{
+		Page:     1,
+		PageSize: max(len(claims), 1),
+		Claimed:  "mine",
+	})
+}
+
+// claimParams builds the claim parameters of a reviewer, limited to the
+// reviewer's scope
+func (s *Service) claimParams(reviewerID int64, scope *ReviewScope, count int) photo.ClaimParams {
+	if count == 0 {
+		count = defaultClaimCount
+	}
+
+	params := photo.ClaimParams{
+		ReviewerID: reviewerID,
+		Count:      count,
+		MaxClaims:  s.reviewCfg.MaxClaims,
+		TTL:        s.reviewCfg.ClaimTTL,
+	}
+	if !scope.All {
+		params.CategoryReviewerID = scope.ReviewerID
+	}
+	if !scope.Senior() {
+		params.Stage = model.ReviewStageStandard
+	}
+	return params
+}
+
+// ReleaseClaim returns a claimed photo to the review queue
+func (s *Service) ReleaseClaim(ctx context.Context, photoID, reviewerID int64) error {
+	err := s.reviews.ReleaseClaim(ctx, photoID, reviewerID)
+	if errors.Is(err, postgresql.ErrClaimNotHeld) {
+		return ErrClaimNotHeld
+	}
+	return err
+}
+
+// ListClaimsRequest represents request for listing active claims
+type ListClaimsRequest struct {
+	ReviewerID int64 `form:"reviewer_id"`
+}
+
+// ClaimListItem represents an active claim in the admin overview
+type ClaimListItem struct {
+	PhotoID int64 `json:"photo_id"`
+	ReviewClaimInfo
+}
+
+// ListClaims retrieves who is currently reviewing which photo
+func (s *Service) ListClaims(ctx context.Context, req *ListClaimsRequest) ([]ClaimListItem, error) {
+	claims, err := s.photoRepo.ListActiveClaims(ctx, req.ReviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get reviewer IDs
+	reviewerIDs := make([]int64, 0)
+	reviewerIDMap := make(map[int64]bool)
+	for _, c := range claims {
+		if !reviewerIDMap[c.ReviewerID] {
+			reviewerIDs = append(reviewerIDs, c.ReviewerID)
+			reviewerIDMap[c.ReviewerID] = true
+		}
+	}
+
+	// Get reviewers
+	users, err := s.photoRepo.GetUserMap(ctx, reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]ClaimListItem, len(claims))
+	for i, c := range claims {
+		list[i] = ClaimListItem{
+			PhotoID:         c.PhotoID,
+			ReviewClaimInfo: *newReviewClaimInfo(c, users),
+		}
+	}
+
+	return list, nil
+}
@@ -0,0 +1,351 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"QuanPhotos/internal/config"
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/permission"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// fakeReviewStore keeps photos, claims and decisions in memory
+type fakeReviewStore struct {
+	photos    map[int64]*model.Photo
+	claims    map[int64]int64    // Photo ID to claimant
+	actions   map[int64][]string // Decisions on the current attempt
+	reviewErr error              // Returned by ReviewPhoto after the claim check
+	reviews   int
+}
+
+func newFakeReviewStore(photos ...*model.Photo) *fakeReviewStore {
+	store := &fakeReviewStore{
+		photos:  make(map[int64]*model.Photo),
+		claims:  make(map[int64]int64),
+		actions: make(map[int64][]string),
+	}
+	for _, p := range photos {
+		store.photos[p.ID] = p
+	}
+	return store
+}
+
+func (f *fakeReviewStore) GetByID(_ context.Context, id int64) (*model.Photo, error) {
+	p, ok := f.photos[id]
+	if !ok {
+		return nil, postgresql.ErrNotFound
+	}
+	return p, nil
+}
+
+func (f *fakeReviewStore) ClaimReviews(_ context.Context, params photo.ClaimParams) ([]*model.ReviewClaim, error) {
+	return nil, nil
+}
+
+func (f *fakeReviewStore) ReleaseClaim(_ context.Context, photoID, reviewerID int64) error {
+	if f.claims[photoID] != reviewerID {
+		return postgresql.ErrClaimNotHeld
+	}
+	delete(f.claims, photoID)
+	return nil
+}
+
+func (f *fakeReviewStore) ReviewPhoto(_ context.Context, params photo.ReviewParams) (*photo.ReviewResult, error) {
+	f.reviews++
+	if f.claims[params.PhotoID] != params.ReviewerID {
+		return nil, postgresql.ErrClaimNotHeld
+	}
+	if f.reviewErr != nil {
+		return nil, f.reviewErr
+	}
+	delete(f.claims, params.PhotoID)
+
+	p := f.photos[params.PhotoID]
+	actions := append(f.actions[params.PhotoID], params.Action)
+	f.actions[params.PhotoID] = actions
+	outcome := params.Resolve(p.ReviewStage, actions)
+	return &photo.ReviewResult{ReviewOutcome: outcome, Stage: p.ReviewStage, Decisions: len(actions)}, nil
+}
+
+// fakeDemoter records demoted users
+type fakeDemoter struct {
+	demoted []int64
+}
+
+func (f *fakeDemoter) Demote(_ context.Context, userID int64, _ string) error {
+	f.demoted = append(f.demoted, userID)
+	return nil
+}
+
+// Users of the review tests
+const (
+	uploaderID    int64 = 1
+	aliceID       int64 = 10 // Admin with review_photos
+	bobID         int64 = 11 // Admin with review_photos
+	moderatorID   int64 = 12 // Admin without review_photos
+	pendingID     int64 = 100
+	approvedID    int64 = 101
+	seniorPhotoID int64 = 102
+)
+
+func newReviewTestService(store ReviewStore, demoter Demoter, requiredDecisions int) *Service {
+	grants := map[int64][]string{
+		aliceID:     {"review_photos"},
+		bobID:       {"review_photos"},
+		moderatorID: {"delete_comments"},
+	}
+	s := &Service{
+		reviews: store,
+		permissions: permission.NewCache(func(_ context.Context, adminID int64) ([]string, error) {
+			return grants[adminID], nil
+		}, time.Minute),
+		reviewCfg: config.ReviewConfig{
+			ClaimTTL:          10 * time.Minute,
+			MaxClaims:         20,
+			RequiredDecisions: requiredDecisions,
+		},
+	}
+	s.SetDemoter(demoter)
+	return s
+}
+
+func TestReviewPhoto(t *testing.T) {
+	tests := []struct {
+		name              string
+		reviewerID        int64
+		role              model.UserRole
+		photoID           int64
+		req               ReviewRequest
+		requiredDecisions int
+		reviewErr         error
+		expectErr         error
+		expectStatus      string
+		expectFinal       bool
+		expectEscalated   bool
+		expectDemoted     bool
+		expectStoreCalled bool
+	}{
+		{
+			name:              "Claimant approves",
+			reviewerID:        aliceID,
+			role:              model.RoleAdmin,
+			photoID:           pendingID,
+			req:               ReviewRequest{Action: "approve"},
+			expectStatus:      "approved",
+			expectFinal:       true,
+			expectStoreCalled: true,
+		},
+		{
+			name:              "Claimant rejects with a note and the uploader is demoted",
+			reviewerID:        aliceID,
+			role:              model.RoleAdmin,
+			photoID:           pendingID,
+			req:               ReviewRequest{Action: "reject", Reason: "Heavily cropped"},
+			expectStatus:      "rejected",
+			expectFinal:       true,
+			expectDemoted:     true,
+			expectStoreCalled: true,
+		},
+		{
+			name:              "First of two required decisions is not final",
+			reviewerID:        aliceID,
+			role:              model.RoleAdmin,
+			photoID:           pendingID,
+			req:               ReviewRequest{Action: "approve"},
+			requiredDecisions: 2,
+			expectStatus:      "pending",
+			expectStoreCalled: true,
+		},
+		{
+			name:              "Escalation moves the photo to the senior queue",
+			reviewerID:        aliceID,
+			role:              model.RoleAdmin,
+			photoID:           pendingID,
+			req:               ReviewRequest{Action: "escalate"},
+			expectStatus:      "pending",
+			expectEscalated:   true,
+			expectStoreCalled: true,
+		},
+		{
+			name:              "Another reviewer cannot decide a held claim",
+			reviewerID:        bobID,
+			role:              model.RoleAdmin,
+			photoID:           pendingID,
+			req:               ReviewRequest{Action: "approve"},
+			expectErr:         ErrClaimNotHeld,
+			expectStoreCalled: true,
+		},
+		{
+			name:       "Decided photo is refused before touching the claim",
+			reviewerID: aliceID,
+			role:       model.RoleAdmin,
+			photoID:    approvedID,
+			req:        ReviewRequest{Action: "approve"},
+			expectErr:  ErrNotReviewable,
+		},
+		{
+			name:              "Photo decided after it was read is refused",
+			reviewerID:        aliceID,
+			role:              model.RoleAdmin,
+			photoID:           pendingID,
+			req:               ReviewRequest{Action: "approve"},
+			reviewErr:         postgresql.ErrNotReviewable,
+			expectErr:         ErrNotReviewable,
+			expectStoreCalled: true,
+		},
+		{
+			name:              "Second decision by the same reviewer",
+			reviewerID:        aliceID,
+			role:              model.RoleAdmin,
+			photoID:           pendingID,
+			req:               ReviewRequest{Action: "approve"},
+			reviewErr:         postgresql.ErrAlreadyDecided,
+			expectErr:         ErrAlreadyDecided,
+			expectStoreCalled: true,
+		},
+		{
+			name:       "Rejection needs a reason",
+			reviewerID: aliceID,
+			role:       model.RoleAdmin,
+			photoID:    pendingID,
+			req:        ReviewRequest{Action: "reject"},
+			expectErr:  ErrReasonRequired,
+		},
+		{
+			name:       "Escalating a senior photo",
+			reviewerID: aliceID,
+			role:       model.RoleAdmin,
+			photoID:    seniorPhotoID,
+			req:        ReviewRequest{Action: "escalate"},
+			expectErr:  ErrAlreadyEscalated,
+		},
+		{
+			name:       "Admin without review_photos",
+			reviewerID: moderatorID,
+			role:       model.RoleAdmin,
+			photoID:    pendingID,
+			req:        ReviewRequest{Action: "approve"},
+			expectErr:  ErrInsufficientPerm,
+		},
+		{
+			name:       "Missing photo",
+			reviewerID: aliceID,
+			role:       model.RoleAdmin,
+			photoID:    999,
+			req:        ReviewRequest{Action: "approve"},
+			expectErr:  ErrPhotoNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeReviewStore(
+				&model.Photo{ID: pendingID, UserID: uploaderID, Status: model.PhotoStatusPending, ReviewStage: model.ReviewStageStandard},
+				&model.Photo{ID: approvedID, UserID: uploaderID, Status: model.PhotoStatusApproved, ReviewStage: model.ReviewStageStandard},
+				&model.Photo{ID: seniorPhotoID, UserID: uploaderID, Status: model.PhotoStatusAIPassed, ReviewStage: model.ReviewStageSenior},
+			)
+			store.claims[pendingID] = aliceID
+			store.claims[approvedID] = aliceID
+			store.claims[seniorPhotoID] = aliceID
+			store.reviewErr = tt.reviewErr
+			demoter := &fakeDemoter{}
+
+			s := newReviewTestService(store, demoter, tt.requiredDecisions)
+
+			result, err := s.ReviewPhoto(context.Background(), tt.photoID, tt.reviewerID, tt.role, &tt.req)
+
+			if !errors.Is(err, tt.expectErr) || (tt.expectErr == nil && err != nil) {
+				t.Fatalf("ReviewPhoto() error = %v, expected %v", err, tt.expectErr)
+			}
+			if called := store.reviews > 0; called != tt.expectStoreCalled {
+				t.Errorf("store called = %v, expected %v", called, tt.expectStoreCalled)
+			}
+			if demoted := len(demoter.demoted) > 0; demoted != tt.expectDemoted {
+				t.Errorf("demoted = %v, expected %v", demoter.demoted, tt.expectDemoted)
+			}
+			if err != nil {
+				return
+			}
+
+			if result.Status != tt.expectStatus || result.Final != tt.expectFinal || result.Escalated != tt.expectEscalated {
+				t.Errorf("ReviewPhoto() = %+v, expected status %s, final %v, escalated %v",
+					result, tt.expectStatus, tt.expectFinal, tt.expectEscalated)
+			}
+			if _, held := store.claims[tt.photoID]; held {
+				t.Error("claim was not consumed by the decision")
+			}
+		})
+	}
+}
+
+func TestReleaseClaim(t *testing.T) {
+	tests := []struct {
+		name       string
+		reviewerID int64
+		expectErr  error
+	}{
+		{name: "Claimant releases", reviewerID: aliceID},
+		{name: "Other reviewer cannot release", reviewerID: bobID, expectErr: ErrClaimNotHeld},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeReviewStore(&model.Photo{ID: pendingID, Status: model.PhotoStatusPending})
+			store.claims[pendingID] = aliceID
+			s := newReviewTestService(store, nil, 1)
+
+			err := s.ReleaseClaim(context.Background(), pendingID, tt.reviewerID)
+			if !errors.Is(err, tt.expectErr) || (tt.expectErr == nil && err != nil) {
+				t.Errorf("ReleaseClaim() error = %v, expected %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestClaimParams(t *testing.T) {
+	s := &Service{reviewCfg: config.ReviewConfig{ClaimTTL: 10 * time.Minute, MaxClaims: 20}}
+
+	tests := []struct {
+		name   string
+		scope  *ReviewScope
+		count  int
+		expect photo.ClaimParams
+	}{
+		{
+			name:  "Unrestricted reviewer claims from every queue",
+			scope: &ReviewScope{All: true},
+			count: 5,
+			expect: photo.ClaimParams{
+				ReviewerID: aliceID, Count: 5, MaxClaims: 20, TTL: 10 * time.Minute,
+			},
+		},
+		{
+			name:  "Category reviewer is limited to assigned categories and the standard queue",
+			scope: &ReviewScope{ReviewerID: aliceID, CategoryIDs: []int{1, 2}},
+			count: 5,
+			expect: photo.ClaimParams{
+				ReviewerID: aliceID, Count: 5, MaxClaims: 20, TTL: 10 * time.Minute,
+				CategoryReviewerID: aliceID, Stage: model.ReviewStageStandard,
+			},
+		},
+		{
+			name:  "Count defaults",
+			scope: &ReviewScope{All: true},
+			expect: photo.ClaimParams{
+				ReviewerID: aliceID, Count: defaultClaimCount, MaxClaims: 20, TTL: 10 * time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.claimParams(aliceID, tt.scope, tt.count); got != tt.expect {
+				t.Errorf("claimParams() = %+v, expected %+v", got, tt.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// ReviewHistoryPhoto represents the photo of a review history, which may
+// have been deleted
+type ReviewHistoryPhoto struct {
+	ID           int64   `json:"id"`
+	Title        string  `json:"title"`
+	UserID       *int64  `json:"user_id,omitempty"`
+	Username     string  `json:"username,omitempty"`
+	CategoryID   *int32  `json:"category_id,omitempty"`
+	Status       string  `json:"status"`
+	Attempt      int     `json:"attempt"`
+	Stage        string  `json:"stage,omitempty"`
+	Deleted      bool    `json:"deleted"`
+	DeletedAt    *string `json:"deleted_at,omitempty"`
+	DeletedBy    *int64  `json:"deleted_by,omitempty"`
+	DeleteReason *string `json:"delete_reason,omitempty"`
+}
+
+// ReviewHistoryItem represents a record in a photo's review history
+type ReviewHistoryItem struct {
+	ID           int64                         `json:"id"`
+	Attempt      int                           `json:"attempt"`
+	ReviewType   string                        `json:"review_type"` // ai, manual, trust
+	Action       string                        `json:"action"`      // approve, reject, flag, escalate, delete, restore
+	Stage        string                        `json:"stage"`
+	ReviewerID   *int64                        `json:"reviewer_id,omitempty"`
+	ReviewerName string                        `json:"reviewer_name,omitempty"`
+	Reasons      []*model.RejectionReasonBrief `json:"reasons,omitempty"`
+	Note         *string                       `json:"note,omitempty"`
+	AIResult     json.RawMessage               `json:"ai_result,omitempty"` // AI result or quality screening report
+	TicketID     *int64                        `json:"ticket_id,omitempty"` // Appeal ticket of a decision made on appeal
+	CreatedAt    string                        `json:"created_at"`
+}
+
+// ReviewHistoryResponse represents the full moderation history of a photo
+type ReviewHistoryResponse struct {
+	Photo   ReviewHistoryPhoto  `json:"photo"`
+	Reviews []ReviewHistoryItem `json:"reviews"`
+}
+
+// GetReviewHistory retrieves every review record of a photo within the
+// reviewer's scope, including records of deleted photos
+func (s *Service) GetReviewHistory(ctx context.Context, photoID, reviewerID int64, role model.UserRole) (*ReviewHistoryResponse, error) {
+	scope, err := s.loadReviewScope(ctx, reviewerID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	photo, scoped, err := s.historyPhoto(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+	if !scope.Allows(scoped) {
+		return nil, ErrPhotoOutOfScope
+	}
+
+	reviews, err := s.photoRepo.ListReviewHistory(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get reasons
+	reviewIDs := make([]int64, len(reviews))
+	for i, r := range reviews {
+		reviewIDs[i] = r.ID
+	}
+	reasons, err := s.photoRepo.GetReviewReasonMap(ctx, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get user IDs
+	userIDs := make([]int64, 0, len(reviews)+1)
+	userIDMap := make(map[int64]bool)
+	if photo.UserID != nil {
+		userIDs = append(userIDs, *photo.UserID)
+		userIDMap[*photo.UserID] = true
+	}
+	for _, r := range reviews {
+		if r.ReviewerID.Valid && !userIDMap[r.ReviewerID.Int64] {
+			userIDs = append(userIDs, r.ReviewerID.Int64)
+			userIDMap[r.ReviewerID.Int64] = true
+		}
+	}
+
+	// Get users
+	users, err := s.photoRepo.GetUserMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if photo.UserID != nil {
+		if u, ok := users[*photo.UserID]; ok {
+			photo.Username = u.Username
+		}
+	}
+
+	list := make([]ReviewHistoryItem, len(reviews))
+	for i, r := range reviews {
+		item := ReviewHistoryItem{
+			ID:         r.ID,
+			Attempt:    r.Attempt,
+			ReviewType: r.ReviewType,
+			Action:     r.Action,
+			Stage:      string(r.Stage),
+			Reasons:    reasons[r.ID],
+			AIResult:   r.AIResult,
+			CreatedAt:  r.CreatedAt.Format(time.RFC3339),
+		}
+		if r.ReviewerID.Valid {
+			item.ReviewerID = &r.ReviewerID.Int64
+			if u, ok := users[r.ReviewerID.Int64]; ok {
+				item.ReviewerName = u.Username
+			}
+		}
+		if r.Reason.Valid {
+			item.Note = &r.Reason.String
+		}
+		if r.TicketID.Valid {
+			item.TicketID = &r.TicketID.Int64
+		}
+		list[i] = item
+	}
+
+	return &ReviewHistoryResponse{
+		Photo:   *photo,
+		Reviews: list,
+	}, nil
+}
+
+// historyPhoto describes a live or deleted photo. The returned model only
+// carries what is needed for scope checks on deleted photos.
+func (s *Service) historyPhoto(ctx context.Context, photoID int64) (*ReviewHistoryPhoto, *model.Photo, error) {
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err == nil {
+		photo := &ReviewHistoryPhoto{
+			ID:      p.ID,
+			Title:   p.Title,
+			UserID:  &p.UserID,
+			Status:  string(p.Status),
+			Attempt: p.Attempt,
+			Stage:   string(p.ReviewStage),
+		}
+		if p.CategoryID.Valid {
+			photo.CategoryID = &p.CategoryID.Int32
+		}
+		return photo, p, nil
+	}
+	if !errors.Is(err, postgresql.ErrNotFound) {
+		return nil, nil, err
+	}
+
+	d, err := s.photoRepo.GetDeletedPhoto(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, nil, ErrPhotoNotFound
+		}
+		return nil, nil, err
+	}
+
+	deletedAt := d.DeletedAt.Format(time.RFC3339)
+	photo := &ReviewHistoryPhoto{
+		ID:        d.PhotoID,
+		Title:     d.Title,
+		Status:    string(d.Status),
+		Attempt:   d.Attempt,
+		Deleted:   true,
+		DeletedAt: &deletedAt,
+	}
+	if d.UserID.Valid {
+		photo.UserID = &d.UserID.Int64
+	}
+	if d.CategoryID.Valid {
+		photo.CategoryID = &d.CategoryID.Int32
+	}
+	if d.DeletedBy.Valid {
+		photo.DeletedBy = &d.DeletedBy.Int64
+	}
+	if d.Reason.Valid {
+		photo.DeleteReason = &d.Reason.String
+	}
+	return photo, &model.Photo{ID: d.PhotoID, CategoryID: d.CategoryID}, nil
+}
@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// defaultReviewPolicy is the policy of uncategorized photos and categories
+// without a configured policy
+func (s *Service) defaultReviewPolicy() *model.ReviewPolicy {
+	return &model.ReviewPolicy{
+		RequiredDecisions: max(s.reviewCfg.RequiredDecisions, 1),
+		AllowEscalation:   true,
+	}
+}
+
+// reviewPolicy retrieves the review policy that applies to a photo
+func (s *Service) reviewPolicy(ctx context.Context, p *model.Photo) (*model.ReviewPolicy, error) {
+	if !p.CategoryID.Valid {
+		return s.defaultReviewPolicy(), nil
+	}
+
+	policy, err := s.superadminRepo.GetReviewPolicy(ctx, int(p.CategoryID.Int32))
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return s.defaultReviewPolicy(), nil
+	}
+	return policy, err
+}
+
+// reviewPolicyMap retrieves the review policies of all configured categories
+func (s *Service) reviewPolicyMap(ctx context.Context) (map[int32]*model.ReviewPolicy, error) {
+	policies, err := s.superadminRepo.ListReviewPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policyMap := make(map[int32]*model.ReviewPolicy, len(policies))
+	for _, p := range policies {
+		policyMap[p.CategoryID] = p
+	}
+	return policyMap, nil
+}
+
+// policyFor picks the policy of a photo from a policy map
+func (s *Service) policyFor(p *model.Photo, policies map[int32]*model.ReviewPolicy) *model.ReviewPolicy {
+	if p.CategoryID.Valid {
+		if policy, ok := policies[p.CategoryID.Int32]; ok {
+			return policy
+		}
+	}
+	return s.defaultReviewPolicy()
+}
+
+// resolveReview applies a review policy to the actions taken on the current
+// attempt of a photo in its review stage, oldest first.
+//
+// In the standard stage an escalation or any disagreement between approvals
+// and rejections moves the photo to the senior queue, otherwise the photo is
+// decided once RequiredDecisions reviewers concur. In the senior stage the
+// first decision is final.
+func resolveReview(policy *model.ReviewPolicy, stage model.ReviewStage, actions []string) photo.ReviewOutcome {
+	if stage == model.ReviewStageSenior {
+		for _, action := range actions {
+			if status := decisionStatus(action); status != "" {
+				return photo.ReviewOutcome{Status: status}
+			}
+		}
+		return photo.ReviewOutcome{}
+	}
+
+	var approvals, rejections int
+	for _, action := range actions {
+		switch action {
+		case "escalate":
+			return photo.ReviewOutcome{Escalate: true}
+		case "approve":
+			approvals++
+		case "reject":
+			rejections++
+		}
+	}
+
+	switch {
+	case approvals > 0 && rejections > 0:
+		return photo.ReviewOutcome{Escalate: true}
+	case approvals >= policy.RequiredDecisions:
+		return photo.ReviewOutcome{Status: model.PhotoStatusApproved}
+	case rejections >= policy.RequiredDecisions:
+		return photo.ReviewOutcome{Status: model.PhotoStatusRejected}
+	}
+	return photo.ReviewOutcome{}
+}
+
+// decisionStatus maps a decision to the photo status it leads to
+func decisionStatus(action string) model.PhotoStatus {
+	switch action {
+	case "approve":
+		return model.PhotoStatusApproved
+	case "reject":
+		return model.PhotoStatusRejected
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+func TestResolveReview(t *testing.T) {
+	single := &model.ReviewPolicy{RequiredDecisions: 1, AllowEscalation: true}
+	double := &model.ReviewPolicy{RequiredDecisions: 2, AllowEscalation: true}
+
+	tests := []struct {
+		name    string
+		policy  *model.ReviewPolicy
+		stage   model.ReviewStage
+		actions []string
+		expect  photo.ReviewOutcome
+	}{
+		{
+			name:    "Single approval decides",
+			policy:  single,
+			stage:   model.ReviewStageStandard,
+			actions: []string{"approve"},
+			expect:  photo.ReviewOutcome{Status: model.PhotoStatusApproved},
+		},
+		{
+			name:    "Single rejection decides",
+			policy:  single,
+			stage:   model.ReviewStageStandard,
+			actions: []string{"reject"},
+			expect:  photo.ReviewOutcome{Status: model.PhotoStatusRejected},
+		},
+		{
+			name:    "First of two decisions waits for a second opinion",
+			policy:  double,
+			stage:   model.ReviewStageStandard,
+			actions: []string{"approve"},
+			expect:  photo.ReviewOutcome{},
+		},
+		{
+			name:    "Two concurring approvals decide",
+			policy:  double,
+			stage:   model.ReviewStageStandard,
+			actions: []string{"approve", "approve"},
+			expect:  photo.ReviewOutcome{Status: model.PhotoStatusApproved},
+		},
+		{
+			name:    "Two concurring rejections decide",
+			policy:  double,
+			stage:   model.ReviewStageStandard,
+			actions: []string{"reject", "reject"},
+			expect:  photo.ReviewOutcome{Status: model.PhotoStatusRejected},
+		},
+		{
+			name:    "Disagreement escalates",
+			policy:  double,
+			stage:   model.ReviewStageStandard,
+			actions: []string{"approve", "reject"},
+			expect:  photo.ReviewOutcome{Escalate: true},
+		},
+		{
+			name:    "Escalation escalates",
+			policy:  double,
+			stage:   model.ReviewStageStandard,
+			actions: []string{"approve", "escalate"},
+			expect:  photo.ReviewOutcome{Escalate: true},
+		},
+		{
+			name:    "Senior decision is final",
+			policy:  double,
+			stage:   model.ReviewStageSenior,
+			actions: []string{"reject"},
+			expect:  photo.ReviewOutcome{Status: model.PhotoStatusRejected},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveReview(tt.policy, tt.stage, tt.actions)
+			if got != tt.expect {
+				t.Errorf("expected %+v, got %+v", tt.expect, got)
+			}
+		})
+	}
+}
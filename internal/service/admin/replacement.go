@@ -0,0 +1,250 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// FileRemover deletes stored image files that are no longer served
+type FileRemover interface {
+	RemoveStoredFiles(ctx context.Context, p *model.Photo)
+}
+
+// SetFileRemover enables removal of replaced and rejected image files
+func (s *Service) SetFileRemover(remover FileRemover) {
+	s.fileRemover = remover
+}
+
+// removeFiles deletes stored files if a remover is configured
+func (s *Service) removeFiles(ctx context.Context, p *model.Photo) {
+	if s.fileRemover != nil {
+		s.fileRemover.RemoveStoredFiles(ctx, p)
+	}
+}
+
+// ListReplacementsRequest represents request for listing replacement images
+type ListReplacementsRequest struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	Status   string `form:"status"`   // pending (default), approved, rejected, superseded, all
+	PhotoID  int64  `form:"photo_id"` // Replacement history of one photo
+}
+
+// ReplacementItem represents a replacement image with its photo
+type ReplacementItem struct {
+	ID                  int64                   `json:"id"`
+	PhotoID             int64                   `json:"photo_id"`
+	PhotoTitle          string                  `json:"photo_title"`
+	UserID              *int64                  `json:"user_id,omitempty"`
+	Username            string                  `json:"username,omitempty"`
+	Status              string                  `json:"status"`
+	ImageURL            string                  `json:"image_url"`
+	ThumbnailURL        string                  `json:"thumbnail_url,omitempty"`
+	CurrentThumbnailURL string                  `json:"current_thumbnail_url,omitempty"` // Image the photo serves now
+	Screening           *model.QualityScreening `json:"screening,omitempty"`
+	ReviewType          *string                 `json:"review_type,omitempty"` // manual, trust, screening
+	ReviewerID          *int64                  `json:"reviewer_id,omitempty"`
+	Reason              *string                 `json:"reason,omitempty"`
+	PreviousFilePath    *string                 `json:"previous_file_path,omitempty"`
+	CreatedAt           string                  `json:"created_at"`
+	DecidedAt           *string                 `json:"decided_at,omitempty"`
+}
+
+// ListReplacementsResponse represents response for listing replacement images
+type ListReplacementsResponse struct {
+	List       []ReplacementItem `json:"list"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// ListReplacements retrieves replacement images within the reviewer's scope,
+// by default those awaiting review
+func (s *Service) ListReplacements(ctx context.Context, reviewerID int64, role model.UserRole, req *ListReplacementsRequest) (*ListReplacementsResponse, error) {
+	scope, err := s.loadReviewScope(ctx, reviewerID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	params := photo.ReplacementListParams{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		PhotoID:  req.PhotoID,
+		Status:   req.Status,
+	}
+	switch req.Status {
+	case "":
+		params.Status = string(model.ReplacementStatusPending)
+	case "all":
+		params.Status = ""
+	}
+	if !scope.All {
+		params.CategoryReviewerID = scope.ReviewerID
+	}
+
+	result, err := s.photoRepo.ListReplacements(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get photos and uploaders
+	photoIDs := make([]int64, 0, len(result.Replacements))
+	userIDs := make([]int64, 0, len(result.Replacements))
+	for _, r := range result.Replacements {
+		if !slices.Contains(photoIDs, r.PhotoID) {
+			photoIDs = append(photoIDs, r.PhotoID)
+		}
+		if r.UserID.Valid && !slices.Contains(userIDs, r.UserID.Int64) {
+			userIDs = append(userIDs, r.UserID.Int64)
+		}
+	}
+	photos, err := s.photoRepo.GetPhotoMap(ctx, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.photoRepo.GetUserMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]ReplacementItem, len(result.Replacements))
+	for i, r := range result.Replacements {
+		item := s.toReplacementItem(r, photos[r.PhotoID])
+		if r.UserID.Valid {
+			if u, ok := users[r.UserID.Int64]; ok {
+				item.Username = u.Username
+			}
+		}
+		list[i] = *item
+	}
+
+	return &ListReplacementsResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// ReviewReplacementRequest represents request for a replacement decision.
+// Rejections need a reason.
+type ReviewReplacementRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve reject"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// ReviewReplacement approves or rejects a replacement image within the
+// reviewer's scope. Approval makes the photo serve the new image and removes
+// the replaced files, rejection removes the new files. The photo's review
+// status is not affected either way.
+func (s *Service) ReviewReplacement(ctx context.Context, replacementID, reviewerID int64, role model.UserRole, req *ReviewReplacementRequest) (*ReplacementItem, error) {
+	scope, err := s.loadReviewScope(ctx, reviewerID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	replacement, err := s.photoRepo.GetReplacement(ctx, replacementID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrReplacementNotFound
+		}
+		return nil, err
+	}
+	p, err := s.photoRepo.GetByID(ctx, replacement.PhotoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrReplacementNotFound
+		}
+		return nil, err
+	}
+	if !scope.Allows(p) {
+		return nil, ErrPhotoOutOfScope
+	}
+
+	approve := req.Action == "approve"
+	reason := strings.TrimSpace(req.Reason)
+	if !approve && reason == "" {
+		return nil, ErrReasonRequired
+	}
+
+	params := &photo.DecideReplacementParams{
+		ReplacementID: replacementID,
+		Approve:       approve,
+		ReviewType:    "manual",
+		ReviewerID:    &reviewerID,
+	}
+	if reason != "" {
+		params.Reason = &reason
+	}
+
+	decided, err := s.photoRepo.DecideReplacement(ctx, params)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrNotPendingReplacement
+		}
+		return nil, err
+	}
+
+	if approve {
+		s.removeFiles(ctx, decided.PreviousFiles())
+	} else {
+		s.removeFiles(ctx, decided.StoredFiles())
+	}
+
+	return s.toReplacementItem(decided, p), nil
+}
+
+// toReplacementItem converts a replacement of a photo
+func (s *Service) toReplacementItem(r *model.PhotoReplacement, p *model.Photo) *ReplacementItem {
+	item := &ReplacementItem{
+		ID:        r.ID,
+		PhotoID:   r.PhotoID,
+		Status:    string(r.Status),
+		ImageURL:  s.baseURL + r.FilePath,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+	if p != nil {
+		item.PhotoTitle = p.Title
+		if p.ThumbnailPath.Valid {
+			item.CurrentThumbnailURL = s.baseURL + p.ThumbnailPath.String
+		}
+	}
+	if r.UserID.Valid {
+		item.UserID = &r.UserID.Int64
+	}
+	if r.ThumbnailPath.Valid {
+		item.ThumbnailURL = s.baseURL + r.ThumbnailPath.String
+	}
+	if len(r.Screening) > 0 {
+		var screening model.QualityScreening
+		if json.Unmarshal(r.Screening, &screening) == nil {
+			item.Screening = &screening
+		}
+	}
+	if r.ReviewType.Valid {
+		item.ReviewType = &r.ReviewType.String
+	}
+	if r.ReviewerID.Valid {
+		item.ReviewerID = &r.ReviewerID.Int64
+	}
+	if r.Reason.Valid {
+		item.Reason = &r.Reason.String
+	}
+	if r.PreviousFilePath.Valid {
+		item.PreviousFilePath = &r.PreviousFilePath.String
+	}
+	if r.DecidedAt.Valid {
+		decidedAt := r.DecidedAt.Time.Format(time.RFC3339)
+		item.DecidedAt = &decidedAt
+	}
+	return item
+}
@@ -0,0 +1,119 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// RevisionItem represents a metadata revision of a photo with its editor
+type RevisionItem struct {
+	ID         int64                        `json:"id"`
+	Source     model.RevisionSource         `json:"source"` // edit, resubmit, rollback
+	EditorID   *int64                       `json:"editor_id,omitempty"`
+	EditorName string                       `json:"editor_name,omitempty"`
+	Changes    map[string]model.FieldChange `json:"changes"`
+	ReReview   bool                         `json:"rereview"`
+	RollbackOf *int64                       `json:"rollback_of,omitempty"`
+	CreatedAt  string                       `json:"created_at"`
+}
+
+// ListPhotoRevisions retrieves every metadata revision of a photo, newest first
+func (s *Service) ListPhotoRevisions(ctx context.Context, photoID int64) ([]RevisionItem, error) {
+	exists, err := s.photoRepo.Exists(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrPhotoNotFound
+	}
+
+	revisions, err := s.photoRepo.ListRevisions(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get editors
+	editorIDs := make([]int64, 0, len(revisions))
+	editorIDMap := make(map[int64]bool)
+	for _, r := range revisions {
+		if r.EditorID.Valid && !editorIDMap[r.EditorID.Int64] {
+			editorIDs = append(editorIDs, r.EditorID.Int64)
+			editorIDMap[r.EditorID.Int64] = true
+		}
+	}
+	users, err := s.photoRepo.GetUserMap(ctx, editorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]RevisionItem, len(revisions))
+	for i, r := range revisions {
+		item, err := toRevisionItem(r, users)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = *item
+	}
+
+	return list, nil
+}
+
+// RollbackPhotoRevision restores the metadata a photo had before the given
+// revision. The rollback is recorded as a revision of its own and does not
+// send the photo back to review.
+func (s *Service) RollbackPhotoRevision(ctx context.Context, photoID, revisionID, adminID int64) (*RevisionItem, error) {
+	exists, err := s.photoRepo.Exists(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrPhotoNotFound
+	}
+
+	revision, err := s.photoRepo.RollbackRevision(ctx, photoID, revisionID, adminID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	if revision == nil {
+		return nil, ErrNothingToRollback
+	}
+
+	users, err := s.photoRepo.GetUserMap(ctx, []int64{adminID})
+	if err != nil {
+		return nil, err
+	}
+	return toRevisionItem(revision, users)
+}
+
+// toRevisionItem converts a stored revision, resolving the editor's name
+func toRevisionItem(r *model.PhotoRevision, users map[int64]*model.User) (*RevisionItem, error) {
+	changes, err := r.FieldChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	item := &RevisionItem{
+		ID:        r.ID,
+		Source:    r.Source,
+		Changes:   changes,
+		ReReview:  r.ReReview,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.EditorID.Valid {
+		item.EditorID = &r.EditorID.Int64
+		if u, ok := users[r.EditorID.Int64]; ok {
+			item.EditorName = u.Username
+		}
+	}
+	if r.RollbackOf.Valid {
+		item.RollbackOf = &r.RollbackOf.Int64
+	}
+	return item, nil
+}
@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"context"
+	"slices"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql/photo"
+	"QuanPhotos/internal/repository/postgresql/superadmin"
+)
+
+// ReviewScope describes which photos a user may review
+type ReviewScope struct {
+	// All is set for superadmins and admins granted review_photos, who
+	// also decide the senior queue
+	All bool
+	// ReviewerID and CategoryIDs restrict reviewers to their assigned categories
+	ReviewerID  int64
+	CategoryIDs []int
+}
+
+// Allows reports whether a photo is within the scope.
+// Uncategorized photos are only visible to unrestricted reviewers.
+func (s *ReviewScope) Allows(p *model.Photo) bool {
+	if s.All {
+		return true
+	}
+	if !p.CategoryID.Valid {
+		return false
+	}
+	return slices.Contains(s.CategoryIDs, int(p.CategoryID.Int32))
+}
+
+// Senior reports whether the scope includes the senior review queue
+func (s *ReviewScope) Senior() bool {
+	return s.All
+}
+
+// apply restricts review list parameters to the scope
+func (s *ReviewScope) apply(params *photo.ReviewListParams) {
+	if !s.All {
+		params.CategoryReviewerID = s.ReviewerID
+	}
+	if !s.Senior() {
+		params.Stage = model.ReviewStageStandard
+	}
+}
+
+// resolveReviewScope determines the review scope of a user from their role,
+// admin permissions and assigned reviewer categories
+func resolveReviewScope(userID int64, role model.UserRole, permissions []string, categoryIDs []int) (*ReviewScope, error) {
+	switch role {
+	case model.RoleSuperAdmin:
+		return &ReviewScope{All: true}, nil
+	case model.RoleAdmin:
+		if slices.Contains(permissions, superadmin.PermReviewPhotos) {
+			return &ReviewScope{All: true}, nil
+		}
+		return nil, ErrInsufficientPerm
+	case model.RoleReviewer:
+		return &ReviewScope{ReviewerID: userID, CategoryIDs: categoryIDs}, nil
+	default:
+		return nil, ErrInsufficientPerm
+	}
+}
+
+// loadReviewScope loads the data needed to resolve a user's review scope
+func (s *Service) loadReviewScope(ctx context.Context, userID int64, role model.UserRole) (*ReviewScope, error) {
+	var permissions []string
+	var categoryIDs []int
+	var err error
+
+	switch role {
+	case model.RoleAdmin:
+		permissions, err = s.permissions.Permissions(ctx, userID)
+	case model.RoleReviewer:
+		categoryIDs, err = s.superadminRepo.GetReviewerCategories(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveReviewScope(userID, role, permissions, categoryIDs)
+}
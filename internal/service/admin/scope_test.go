@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql/photo"
+	"QuanPhotos/internal/repository/postgresql/superadmin"
+)
+
+func TestResolveReviewScope(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        model.UserRole
+		permissions []string
+		categoryIDs []int
+		expectAll   bool
+		expectError error
+	}{
+		{
+			name:      "SuperAdmin reviews everything",
+			role:      model.RoleSuperAdmin,
+			expectAll: true,
+		},
+		{
+			name:        "Admin with review_photos reviews everything",
+			role:        model.RoleAdmin,
+			permissions: []string{superadmin.PermManageTickets, superadmin.PermReviewPhotos},
+			expectAll:   true,
+		},
+		{
+			name:        "Admin without review_photos is denied",
+			role:        model.RoleAdmin,
+			permissions: []string{superadmin.PermManageTickets},
+			expectError: ErrInsufficientPerm,
+		},
+		{
+			name:        "Reviewer is scoped to assigned categories",
+			role:        model.RoleReviewer,
+			categoryIDs: []int{1, 3},
+		},
+		{
+			name: "Reviewer without categories gets an empty scope",
+			role: model.RoleReviewer,
+		},
+		{
+			name:        "Regular user is denied",
+			role:        model.RoleUser,
+			categoryIDs: []int{1},
+			expectError: ErrInsufficientPerm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, err := resolveReviewScope(42, tt.role, tt.permissions, tt.categoryIDs)
+			if !errors.Is(err, tt.expectError) {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if scope.All != tt.expectAll {
+				t.Errorf("expected All=%v, got %v", tt.expectAll, scope.All)
+			}
+			if !scope.All && scope.ReviewerID != 42 {
+				t.Errorf("expected reviewer ID 42, got %d", scope.ReviewerID)
+			}
+		})
+	}
+}
+
+func TestReviewScopeAllows(t *testing.T) {
+	inCategory := func(id int32) *model.Photo {
+		return &model.Photo{CategoryID: sql.NullInt32{Int32: id, Valid: true}}
+	}
+	uncategorized := &model.Photo{}
+
+	reviewer := &ReviewScope{ReviewerID: 42, CategoryIDs: []int{1, 3}}
+	unassigned := &ReviewScope{ReviewerID: 42}
+	all := &ReviewScope{All: true}
+
+	tests := []struct {
+		name   string
+		scope  *ReviewScope
+		photo  *model.Photo
+		expect bool
+	}{
+		{"Reviewer allowed in assigned category", reviewer, inCategory(3), true},
+		{"Reviewer denied in other category", reviewer, inCategory(2), false},
+		{"Reviewer denied on uncategorized photo", reviewer, uncategorized, false},
+		{"Reviewer without categories denied", unassigned, inCategory(1), false},
+		{"Unrestricted scope allows any category", all, inCategory(2), true},
+		{"Unrestricted scope allows uncategorized photo", all, uncategorized, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.Allows(tt.photo); got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestReviewScopeApply(t *testing.T) {
+	var params photo.ReviewListParams
+	(&ReviewScope{ReviewerID: 42, CategoryIDs: []int{1}}).apply(&params)
+	if params.CategoryReviewerID != 42 {
+		t.Errorf("reviewer scope: expected CategoryReviewerID=42, got %d", params.CategoryReviewerID)
+	}
+	if params.Stage != model.ReviewStageStandard {
+		t.Errorf("reviewer scope: expected standard stage, got %q", params.Stage)
+	}
+
+	params = photo.ReviewListParams{}
+	(&ReviewScope{All: true}).apply(&params)
+	if params.CategoryReviewerID != 0 {
+		t.Errorf("unrestricted scope: expected no category restriction, got %d", params.CategoryReviewerID)
+	}
+	if params.Stage != "" {
+		t.Errorf("unrestricted scope: expected no stage restriction, got %q", params.Stage)
+	}
+}
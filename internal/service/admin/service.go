@@ -3,11 +3,18 @@ package admin
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"QuanPhotos/internal/config"
 	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/permission"
 	"QuanPhotos/internal/repository/postgresql"
 	"QuanPhotos/internal/repository/postgresql/photo"
+	"QuanPhotos/internal/repository/postgresql/rejection"
+	"QuanPhotos/internal/repository/postgresql/superadmin"
 	"QuanPhotos/internal/repository/postgresql/ticket"
 	"QuanPhotos/internal/repository/postgresql/user"
 )
@@ -22,14 +29,37 @@ var (
 	ErrAnnouncementNotFound = errors.New("announcement not found")
 	ErrAlreadyFeatured    = errors.New("photo is already featured")
 	ErrNotFeatured        = errors.New("photo is not featured")
+	ErrClaimNotHeld       = errors.New("photo is not claimed by reviewer")
+	ErrPhotoOutOfScope    = errors.New("photo is outside reviewer's categories")
+	ErrReasonRequired     = errors.New("rejection requires a reason code or note")
+	ErrInvalidReasonCode  = errors.New("unknown or inactive rejection reason code")
+	ErrAlreadyDecided     = errors.New("reviewer already decided on this photo")
+	ErrNotReviewable      = errors.New("photo is not awaiting review")
+	ErrSeniorReviewRequired = errors.New("photo is in the senior review queue")
+	ErrEscalationDisabled = errors.New("escalation is disabled for this category")
+	ErrAlreadyEscalated   = errors.New("photo is already in the senior review queue")
+	ErrNotPhotoAppeal     = errors.New("only appeal tickets about a photo can overturn a review")
+	ErrNotOverturnable    = errors.New("photo is not rejected")
+	ErrNoSpotCheck        = errors.New("photo is not awaiting a spot check")
+	ErrRevisionNotFound   = errors.New("photo revision not found")
+	ErrNothingToRollback  = errors.New("photo already matches the revision")
+	ErrReplacementNotFound = errors.New("replacement not found")
+	ErrNotPendingReplacement = errors.New("replacement is not awaiting review")
 )
 
 // Service handles admin business logic
 type Service struct {
-	userRepo   *user.UserRepository
-	photoRepo  *photo.PhotoRepository
-	ticketRepo *ticket.TicketRepository
-	baseURL    string
+	userRepo       *user.UserRepository
+	photoRepo      *photo.PhotoRepository
+	reviews        ReviewStore
+	ticketRepo     *ticket.TicketRepository
+	superadminRepo *superadmin.SuperadminRepository
+	rejectionRepo  *rejection.RejectionRepository
+	permissions    *permission.Cache
+	demoter        Demoter
+	fileRemover    FileRemover
+	baseURL        string
+	reviewCfg      config.ReviewConfig
 }
 
 // New creates a new admin service
@@ -40,12 +70,17 @@ func New(userRepo *user.UserRepository) *Service {
 }
 
 // NewFull creates a new admin service with all dependencies
-func NewFull(userRepo *user.UserRepository, photoRepo *photo.PhotoRepository, ticketRepo *ticket.TicketRepository, baseURL string) *Service {
+func NewFull(userRepo *user.UserRepository, photoRepo *photo.PhotoRepository, ticketRepo *ticket.TicketRepository, superadminRepo *superadmin.SuperadminRepository, rejectionRepo *rejection.RejectionRepository, permissions *permission.Cache, cfg *config.Config) *Service {
 	return &Service{
-		userRepo:   userRepo,
-		photoRepo:  photoRepo,
-		ticketRepo: ticketRepo,
-		baseURL:    baseURL,
+		userRepo:       userRepo,
+		photoRepo:      photoRepo,
+		reviews:        photoRepo,
+		ticketRepo:     ticketRepo,
+		superadminRepo: superadminRepo,
+		rejectionRepo:  rejectionRepo,
+		permissions:    permissions,
+		baseURL:        cfg.Storage.BaseURL,
+		reviewCfg:      cfg.Review,
 	}
 }
 
@@ -168,7 +203,13 @@ func (s *Service) UpdateUserRole(ctx context.Context, operatorID, targetUserID i
 		return ErrInvalidRole
 	}
 
-	return s.userRepo.UpdateRole(ctx, targetUserID, newRole)
+	if err := s.userRepo.UpdateRole(ctx, targetUserID, newRole); err != nil {
+		return err
+	}
+
+	// Permissions only apply to admins, drop any cached set
+	s.permissions.Invalidate(targetUserID)
+	return nil
 }
 
 // UpdateStatusRequest represents request for updating user status
@@ -231,6 +272,8 @@ type ListReviewsRequest struct {
 	Page     int    `form:"page"`
 	PageSize int    `form:"page_size"`
 	Status   string `form:"status"` // ai_passed, ai_rejected, pending, all
+	Claimed  string `form:"claimed" binding:"omitempty,oneof=mine unclaimed"`
+	Stage    string `form:"stage" binding:"omitempty,oneof=standard senior"` // Reviewers only see the standard queue
 }
 
 // ReviewListItem represents a photo in review list
@@ -242,6 +285,29 @@ type ReviewListItem struct {
 	UserID       int64   `json:"user_id"`
 	Username     string  `json:"username"`
 	CreatedAt    string  `json:"created_at"`
+
+	// Screening is the heuristic quality report produced at upload
+	Screening *model.QualityScreening `json:"screening,omitempty"`
+	// Claim is the active review claim, if any
+	Claim *ReviewClaimInfo `json:"claim,omitempty"`
+
+	// Stage is the review queue the photo is in, standard or senior
+	Stage string `json:"stage"`
+	// RequiredDecisions is the number of concurring decisions the category's
+	// review policy requires in the standard queue
+	RequiredDecisions int `json:"required_decisions"`
+	// Decisions are the manual decisions made on the current attempt
+	Decisions []ReviewDecisionInfo `json:"decisions"`
+}
+
+// ReviewDecisionInfo represents a manual decision on a photo in the review queue
+type ReviewDecisionInfo struct {
+	ReviewerID   int64   `json:"reviewer_id"`
+	ReviewerName string  `json:"reviewer_name"`
+	Action       string  `json:"action"` // approve, reject, escalate
+	Stage        string  `json:"stage"`
+	Note         *string `json:"note,omitempty"`
+	CreatedAt    string  `json:"created_at"`
 }
 
 // ListReviewsResponse represents response for listing reviews
@@ -250,13 +316,53 @@ type ListReviewsResponse struct {
 	Pagination Pagination       `json:"pagination"`
 }
 
-// ListReviews retrieves photos pending review
-func (s *Service) ListReviews(ctx context.Context, req *ListReviewsRequest) (*ListReviewsResponse, error) {
-	result, err := s.photoRepo.ListPendingReviews(ctx, photo.ReviewListParams{
+// ListReviews retrieves photos pending review within the reviewer's scope
+func (s *Service) ListReviews(ctx context.Context, reviewerID int64, role model.UserRole, req *ListReviewsRequest) (*ListReviewsResponse, error) {
+	scope, err := s.loadReviewScope(ctx, reviewerID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listReviews(ctx, reviewerID, scope, req)
+}
+
+// listReviews retrieves photos pending review within a resolved scope
+func (s *Service) listReviews(ctx context.Context, reviewerID int64, scope *ReviewScope, req *ListReviewsRequest) (*ListReviewsResponse, error) {
+	params := photo.ReviewListParams{
 		Page:     req.Page,
 		PageSize: req.PageSize,
 		Status:   req.Status,
-	})
+	}
+	switch req.Claimed {
+	case "mine":
+		params.ClaimedBy = reviewerID
+	case "unclaimed":
+		params.Unclaimed = true
+	}
+	params.Stage = model.ReviewStage(req.Stage)
+	scope.apply(&params)
+
+	result, err := s.photoRepo.ListPendingReviews(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get active claims
+	photoIDs := make([]int64, len(result.Photos))
+	for i, p := range result.Photos {
+		photoIDs[i] = p.ID
+	}
+	claims, err := s.photoRepo.GetActiveClaimMap(ctx, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get decisions and the policies deciding them
+	decisions, err := s.photoRepo.GetDecisionMap(ctx, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := s.reviewPolicyMap(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -270,6 +376,20 @@ func (s *Service) ListReviews(ctx context.Context, req *ListReviewsRequest) (*Li
 			userIDMap[p.UserID] = true
 		}
 	}
+	for _, c := range claims {
+		if !userIDMap[c.ReviewerID] {
+			userIDs = append(userIDs, c.ReviewerID)
+			userIDMap[c.ReviewerID] = true
+		}
+	}
+	for _, photoDecisions := range decisions {
+		for _, d := range photoDecisions {
+			if !userIDMap[d.ReviewerID] {
+				userIDs = append(userIDs, d.ReviewerID)
+				userIDMap[d.ReviewerID] = true
+			}
+		}
+	}
 
 	// Get users
 	users, err := s.photoRepo.GetUserMap(ctx, userIDs)
@@ -277,6 +397,12 @@ func (s *Service) ListReviews(ctx context.Context, req *ListReviewsRequest) (*Li
 		return nil, err
 	}
 
+	// Get quality screening results
+	screenings, err := s.photoRepo.GetScreeningMap(ctx, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	list := make([]ReviewListItem, len(result.Photos))
 	for i, p := range result.Photos {
 		item := ReviewListItem{
@@ -292,6 +418,16 @@ func (s *Service) ListReviews(ctx context.Context, req *ListReviewsRequest) (*Li
 		if u, ok := users[p.UserID]; ok {
 			item.Username = u.Username
 		}
+		item.Screening = screenings[p.ID]
+		if c, ok := claims[p.ID]; ok {
+			item.Claim = newReviewClaimInfo(c, users)
+		}
+		item.Stage = string(p.ReviewStage)
+		item.RequiredDecisions = s.policyFor(p, policies).RequiredDecisions
+		item.Decisions = make([]ReviewDecisionInfo, len(decisions[p.ID]))
+		for j, d := range decisions[p.ID] {
+			item.Decisions[j] = newReviewDecisionInfo(d, users)
+		}
 		list[i] = item
 	}
 
@@ -306,24 +442,169 @@ func (s *Service) ListReviews(ctx context.Context, req *ListReviewsRequest) (*Li
 	}, nil
 }
 
-// ReviewRequest represents request for reviewing a photo
+// newReviewDecisionInfo builds decision info from a decision and a user map
+func newReviewDecisionInfo(d *model.ReviewDecision, users map[int64]*model.User) ReviewDecisionInfo {
+	info := ReviewDecisionInfo{
+		ReviewerID: d.ReviewerID,
+		Action:     d.Action,
+		Stage:      string(d.Stage),
+		CreatedAt:  d.CreatedAt.Format(time.RFC3339),
+	}
+	if u, ok := users[d.ReviewerID]; ok {
+		info.ReviewerName = u.Username
+	}
+	if d.Reason.Valid {
+		info.Note = &d.Reason.String
+	}
+	return info
+}
+
+// ReviewRequest represents request for reviewing a photo.
+// Rejections need at least one reason code or a note. Escalation sends the
+// photo to the senior review queue when the category's policy allows it.
 type ReviewRequest struct {
-	Action string `json:"action" binding:"required,oneof=approve reject"`
-	Reason string `json:"reason"`
+	Action      string   `json:"action" binding:"required,oneof=approve reject escalate"`
+	ReasonCodes []string `json:"reason_codes" binding:"omitempty,max=10"` // Rejection reason codes, ignored on approval
+	Reason      string   `json:"reason"`                                  // Free-text note
 }
 
-// ReviewPhoto performs a manual review on a photo
-func (s *Service) ReviewPhoto(ctx context.Context, photoID, reviewerID int64, req *ReviewRequest) error {
-	// Check if photo exists
-	exists, err := s.photoRepo.Exists(ctx, photoID)
+// ReviewResult represents the state of a photo after a review decision
+type ReviewResult struct {
+	PhotoID           int64  `json:"photo_id"`
+	Status            string `json:"status"`
+	Stage             string `json:"stage"`
+	Final             bool   `json:"final"`              // The decision settled the photo's status
+	Escalated         bool   `json:"escalated"`          // The photo moved to the senior queue
+	Decisions         int    `json:"decisions"`          // Decisions made on the current attempt in the stage
+	RequiredDecisions int    `json:"required_decisions"` // Concurring decisions the policy requires
+}
+
+// ReviewPhoto records a manual decision on a photo within the reviewer's scope.
+// The photo's status follows from the category's review policy rather than
+// from the decision alone.
+func (s *Service) ReviewPhoto(ctx context.Context, photoID, reviewerID int64, role model.UserRole, req *ReviewRequest) (*ReviewResult, error) {
+	scope, err := s.loadReviewScope(ctx, reviewerID, role)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if !exists {
-		return ErrPhotoNotFound
+
+	p, err := s.reviews.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if !scope.Allows(p) {
+		return nil, ErrPhotoOutOfScope
+	}
+	if !p.Status.AwaitsReview() {
+		return nil, ErrNotReviewable
+	}
+	if p.ReviewStage == model.ReviewStageSenior && !scope.Senior() {
+		return nil, ErrSeniorReviewRequired
+	}
+
+	policy, err := s.reviewPolicy(ctx, p)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.photoRepo.ReviewPhoto(ctx, photoID, reviewerID, req.Action, req.Reason)
+	var reasonIDs []int32
+	switch req.Action {
+	case "reject":
+		reasonIDs, err = s.resolveReasonCodes(ctx, req.ReasonCodes)
+		if err != nil {
+			return nil, err
+		}
+		if len(reasonIDs) == 0 && strings.TrimSpace(req.Reason) == "" {
+			return nil, ErrReasonRequired
+		}
+	case "escalate":
+		if p.ReviewStage == model.ReviewStageSenior {
+			return nil, ErrAlreadyEscalated
+		}
+		if !policy.AllowEscalation {
+			return nil, ErrEscalationDisabled
+		}
+	}
+
+	result, err := s.reviews.ReviewPhoto(ctx, photo.ReviewParams{
+		PhotoID:    photoID,
+		ReviewerID: reviewerID,
+		Action:     req.Action,
+		Reason:     req.Reason,
+		ReasonIDs:  reasonIDs,
+		Resolve: func(stage model.ReviewStage, actions []string) photo.ReviewOutcome {
+			return resolveReview(policy, stage, actions)
+		},
+	})
+	if err != nil {
+		if errors.Is(err, postgresql.ErrClaimNotHeld) {
+			return nil, ErrClaimNotHeld
+		}
+		if errors.Is(err, postgresql.ErrAlreadyDecided) {
+			return nil, ErrAlreadyDecided
+		}
+		if errors.Is(err, postgresql.ErrNotReviewable) {
+			return nil, ErrNotReviewable
+		}
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+
+	resp := &ReviewResult{
+		PhotoID:           photoID,
+		Status:            string(p.Status),
+		Stage:             string(result.Stage),
+		Final:             result.Status != "",
+		Escalated:         result.Escalate,
+		Decisions:         result.Decisions,
+		RequiredDecisions: policy.RequiredDecisions,
+	}
+	if resp.Final {
+		resp.Status = string(result.Status)
+	}
+	if result.Status == model.PhotoStatusRejected {
+		s.demote(ctx, p.UserID, fmt.Sprintf("photo %d rejected", photoID))
+	}
+	if resp.Escalated {
+		resp.Stage = string(model.ReviewStageSenior)
+	}
+	return resp, nil
+}
+
+// resolveReasonCodes maps rejection reason codes to the IDs of active reasons
+func (s *Service) resolveReasonCodes(ctx context.Context, codes []string) ([]int32, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	reasons, err := s.rejectionRepo.GetByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[string]int32, len(reasons))
+	for _, r := range reasons {
+		if r.IsActive {
+			active[r.Code] = r.ID
+		}
+	}
+
+	ids := make([]int32, 0, len(codes))
+	for _, code := range codes {
+		id, ok := active[code]
+		if !ok {
+			return nil, ErrInvalidReasonCode
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
 }
 
 // ============================================
@@ -335,13 +616,27 @@ type DeletePhotoRequest struct {
 	Reason string `json:"reason" binding:"required"`
 }
 
-// AdminDeletePhoto deletes a photo with reason
+// AdminDeletePhoto moves a photo to the trash with reason and demotes the
+// uploader
 func (s *Service) AdminDeletePhoto(ctx context.Context, photoID, adminID int64, req *DeletePhotoRequest) error {
-	err := s.photoRepo.AdminDeletePhoto(ctx, photoID, adminID, req.Reason)
-	if errors.Is(err, postgresql.ErrNotFound) {
-		return ErrPhotoNotFound
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return ErrPhotoNotFound
+		}
+		return err
 	}
-	return err
+
+	err = s.photoRepo.AdminDeletePhoto(ctx, photoID, adminID, req.Reason)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return ErrPhotoNotFound
+		}
+		return err
+	}
+
+	s.demote(ctx, p.UserID, fmt.Sprintf("photo %d taken down", photoID))
+	return nil
 }
 
 // ============================================
@@ -440,16 +735,21 @@ func (s *Service) AdminListTickets(ctx context.Context, req *AdminListTicketsReq
 type ProcessTicketRequest struct {
 	Status  string `json:"status" binding:"required,oneof=processing resolved closed"`
 	Reply   string `json:"reply"`
+	// Overturn approves the rejected photo of an appeal ticket, the reply is
+	// recorded as the review note
+	Overturn bool `json:"overturn"`
 }
 
 // ProcessTicketResponse represents response for processing a ticket
 type ProcessTicketResponse struct {
-	ReplyID *int64 `json:"reply_id,omitempty"`
-	Status  string `json:"status"`
+	ReplyID    *int64 `json:"reply_id,omitempty"`
+	Status     string `json:"status"`
+	Overturned bool   `json:"overturned,omitempty"`
 }
 
-// ProcessTicket processes a ticket (update status and optionally reply)
-func (s *Service) ProcessTicket(ctx context.Context, ticketID, adminID int64, req *ProcessTicketRequest) (*ProcessTicketResponse, error) {
+// ProcessTicket processes a ticket (update status and optionally reply).
+// Overturning the rejection of an appealed photo is a senior review decision.
+func (s *Service) ProcessTicket(ctx context.Context, ticketID, adminID int64, role model.UserRole, req *ProcessTicketRequest) (*ProcessTicketResponse, error) {
 	// Check if ticket exists
 	exists, err := s.ticketRepo.Exists(ctx, ticketID)
 	if err != nil {
@@ -464,6 +764,13 @@ func (s *Service) ProcessTicket(ctx context.Context, ticketID, adminID int64, re
 		Status: req.Status,
 	}
 
+	if req.Overturn {
+		if err := s.overturnOnAppeal(ctx, ticketID, adminID, role, req.Reply); err != nil {
+			return nil, err
+		}
+		resp.Overturned = true
+	}
+
 	if req.Reply != "" {
 		// Reply and update status
 		replyID, err := s.ticketRepo.AdminReply(ctx, ticketID, adminID, req.Reply, &newStatus)
@@ -482,6 +789,34 @@ func (s *Service) ProcessTicket(ctx context.Context, ticketID, adminID int64, re
 	return resp, nil
 }
 
+// overturnOnAppeal approves the rejected photo of an appeal ticket
+func (s *Service) overturnOnAppeal(ctx context.Context, ticketID, adminID int64, role model.UserRole, note string) error {
+	t, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return ErrTicketNotFound
+		}
+		return err
+	}
+	if t.Type != model.TicketTypeAppeal || !t.PhotoID.Valid {
+		return ErrNotPhotoAppeal
+	}
+
+	scope, err := s.loadReviewScope(ctx, adminID, role)
+	if err != nil {
+		return err
+	}
+	if !scope.Senior() {
+		return ErrInsufficientPerm
+	}
+
+	err = s.photoRepo.OverturnRejection(ctx, t.PhotoID.Int64, adminID, ticketID, note)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrNotOverturnable
+	}
+	return err
+}
+
 // ============================================
 // Featured Photos Methods
 // ============================================
@@ -0,0 +1,117 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// ListTrashRequest represents request for listing photos in the trash
+type ListTrashRequest struct {
+	Page     int   `form:"page"`
+	PageSize int   `form:"page_size"`
+	UserID   int64 `form:"user_id"` // Only photos of this user
+}
+
+// TrashItem represents a photo in the trash with its owner
+type TrashItem struct {
+	ID           int64  `json:"id"`
+	Title        string `json:"title"`
+	UserID       int64  `json:"user_id"`
+	Username     string `json:"username,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Status       string `json:"status"`
+	DeletedAt    string `json:"deleted_at"`
+	DeletedBy    *int64 `json:"deleted_by,omitempty"`
+	TakenDown    bool   `json:"taken_down"` // Deleted by an admin rather than the owner
+}
+
+// ListTrashResponse represents response for listing photos in the trash
+type ListTrashResponse struct {
+	List       []TrashItem `json:"list"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+// RestorePhotoResponse represents a photo taken out of the trash
+type RestorePhotoResponse struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
+
+// ListTrash retrieves deleted photos that have not been purged yet, most
+// recently deleted first
+func (s *Service) ListTrash(ctx context.Context, req *ListTrashRequest) (*ListTrashResponse, error) {
+	result, err := s.photoRepo.ListTrash(ctx, photo.TrashListParams{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		UserID:   req.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]int64, 0, len(result.Photos))
+	for _, p := range result.Photos {
+		if !slices.Contains(userIDs, p.UserID) {
+			userIDs = append(userIDs, p.UserID)
+		}
+	}
+	users, err := s.photoRepo.GetUserMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]TrashItem, len(result.Photos))
+	for i, p := range result.Photos {
+		item := TrashItem{
+			ID:        p.ID,
+			Title:     p.Title,
+			UserID:    p.UserID,
+			Status:    string(p.Status),
+			DeletedAt: p.DeletedAt.Time.Format(time.RFC3339),
+			TakenDown: p.DeletedBy.Valid && p.DeletedBy.Int64 != p.UserID,
+		}
+		if u, ok := users[p.UserID]; ok {
+			item.Username = u.Username
+		}
+		if p.ThumbnailPath.Valid {
+			item.ThumbnailURL = s.baseURL + p.ThumbnailPath.String
+		}
+		if p.DeletedBy.Valid {
+			item.DeletedBy = &p.DeletedBy.Int64
+		}
+		list[i] = item
+	}
+
+	return &ListTrashResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// RestorePhoto takes a photo out of the trash before it is purged. The photo
+// comes back with its previous status, comments, likes and favorites. A
+// demotion of the uploader for a take down is not reverted.
+func (s *Service) RestorePhoto(ctx context.Context, photoID, adminID int64) (*RestorePhotoResponse, error) {
+	p, err := s.photoRepo.Restore(ctx, photoID, adminID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+
+	return &RestorePhotoResponse{
+		ID:     p.ID,
+		Status: string(p.Status),
+	}, nil
+}
@@ -0,0 +1,203 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/logger"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// Demoter lowers the trust tier of users whose photos are rejected or taken down
+type Demoter interface {
+	Demote(ctx context.Context, userID int64, reason string) error
+}
+
+// SetDemoter enables trust demotion on rejections and takedowns
+func (s *Service) SetDemoter(demoter Demoter) {
+	s.demoter = demoter
+}
+
+// demote lowers the uploader's trust tier. Failures are logged, the
+// decision that caused the demotion stands.
+func (s *Service) demote(ctx context.Context, userID int64, reason string) {
+	if s.demoter == nil {
+		return
+	}
+	if err := s.demoter.Demote(ctx, userID, reason); err != nil {
+		logger.Warn("Failed to demote user trust",
+			zap.Int64("user_id", userID),
+			zap.String("reason", reason),
+			zap.Error(err),
+		)
+	}
+}
+
+// ListSpotChecksRequest represents request for listing photos awaiting a spot check
+type ListSpotChecksRequest struct {
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
+}
+
+// SpotCheckItem represents an auto-approved photo sampled for a spot check
+type SpotCheckItem struct {
+	ID           int64                   `json:"id"`
+	Title        string                  `json:"title"`
+	ThumbnailURL string                  `json:"thumbnail_url"`
+	UserID       int64                   `json:"user_id"`
+	Username     string                  `json:"username"`
+	Screening    *model.QualityScreening `json:"screening,omitempty"`
+	CreatedAt    string                  `json:"created_at"`
+	ApprovedAt   string                  `json:"approved_at"`
+}
+
+// ListSpotChecksResponse represents response for listing spot checks
+type ListSpotChecksResponse struct {
+	List       []SpotCheckItem `json:"list"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// ListSpotChecks retrieves published photos awaiting a spot check within the
+// reviewer's scope
+func (s *Service) ListSpotChecks(ctx context.Context, reviewerID int64, role model.UserRole, req *ListSpotChecksRequest) (*ListSpotChecksResponse, error) {
+	scope, err := s.loadReviewScope(ctx, reviewerID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	params := photo.SpotCheckListParams{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	if !scope.All {
+		params.CategoryReviewerID = scope.ReviewerID
+	}
+
+	result, err := s.photoRepo.ListSpotChecks(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	photoIDs := make([]int64, len(result.Photos))
+	userIDs := make([]int64, 0, len(result.Photos))
+	for i, p := range result.Photos {
+		photoIDs[i] = p.ID
+		if !slices.Contains(userIDs, p.UserID) {
+			userIDs = append(userIDs, p.UserID)
+		}
+	}
+
+	users, err := s.photoRepo.GetUserMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	screenings, err := s.photoRepo.GetScreeningMap(ctx, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]SpotCheckItem, len(result.Photos))
+	for i, p := range result.Photos {
+		item := SpotCheckItem{
+			ID:        p.ID,
+			Title:     p.Title,
+			UserID:    p.UserID,
+			Screening: screenings[p.ID],
+			CreatedAt: p.CreatedAt.Format(time.RFC3339),
+		}
+		if p.ThumbnailPath.Valid {
+			item.ThumbnailURL = s.baseURL + p.ThumbnailPath.String
+		}
+		if u, ok := users[p.UserID]; ok {
+			item.Username = u.Username
+		}
+		if p.ApprovedAt.Valid {
+			item.ApprovedAt = p.ApprovedAt.Time.Format(time.RFC3339)
+		}
+		list[i] = item
+	}
+
+	return &ListSpotChecksResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// SpotCheckRequest represents request for a spot check decision.
+// Rejections need at least one reason code or a note.
+type SpotCheckRequest struct {
+	Action      string   `json:"action" binding:"required,oneof=approve reject"`
+	ReasonCodes []string `json:"reason_codes" binding:"omitempty,max=10"`
+	Reason      string   `json:"reason"`
+}
+
+// SpotCheckResult represents the state of a photo after a spot check
+type SpotCheckResult struct {
+	PhotoID int64  `json:"photo_id"`
+	Status  string `json:"status"`
+}
+
+// SpotCheck decides on an auto-approved photo sampled for a spot check.
+// Rejection unpublishes the photo and demotes the uploader.
+func (s *Service) SpotCheck(ctx context.Context, photoID, reviewerID int64, role model.UserRole, req *SpotCheckRequest) (*SpotCheckResult, error) {
+	scope, err := s.loadReviewScope(ctx, reviewerID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if !scope.Allows(p) {
+		return nil, ErrPhotoOutOfScope
+	}
+
+	var reasonIDs []int32
+	if req.Action == "reject" {
+		reasonIDs, err = s.resolveReasonCodes(ctx, req.ReasonCodes)
+		if err != nil {
+			return nil, err
+		}
+		if len(reasonIDs) == 0 && strings.TrimSpace(req.Reason) == "" {
+			return nil, ErrReasonRequired
+		}
+	}
+
+	err = s.photoRepo.DecideSpotCheck(ctx, photo.SpotCheckParams{
+		PhotoID:    photoID,
+		ReviewerID: reviewerID,
+		Action:     req.Action,
+		Reason:     req.Reason,
+		ReasonIDs:  reasonIDs,
+	})
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrNoSpotCheck
+		}
+		return nil, err
+	}
+
+	result := &SpotCheckResult{PhotoID: photoID, Status: string(model.PhotoStatusApproved)}
+	if req.Action == "reject" {
+		result.Status = string(model.PhotoStatusRejected)
+		s.demote(ctx, p.UserID, fmt.Sprintf("photo %d failed spot check", photoID))
+	}
+	return result, nil
+}
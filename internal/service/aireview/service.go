@@ -0,0 +1,126 @@
+package aireview
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"QuanPhotos/internal/config"
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/ai"
+	"QuanPhotos/internal/pkg/logger"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+var (
+	ErrCallbackDisabled = errors.New("ai callback is not configured")
+)
+
+// Service submits uploaded photos to the AI review service and applies its verdicts.
+// Photos stay pending, and therefore in the manual review queue, whenever the
+// AI service cannot be reached.
+type Service struct {
+	client         *ai.Client
+	photoRepo      *photo.PhotoRepository
+	baseURL        string
+	callbackURL    string
+	callbackSecret string
+	timeout        time.Duration
+}
+
+// New creates a new AI review service
+func New(client *ai.Client, photoRepo *photo.PhotoRepository, cfg *config.Config) *Service {
+	return &Service{
+		client:         client,
+		photoRepo:      photoRepo,
+		baseURL:        cfg.Storage.BaseURL,
+		callbackURL:    cfg.AI.CallbackURL,
+		callbackSecret: cfg.AI.CallbackSecret,
+		// Covers every retry attempt plus backoff
+		timeout: cfg.AI.Timeout*time.Duration(cfg.AI.Retry+1) + time.Minute,
+	}
+}
+
+// Submit reviews a photo in the background
+func (s *Service) Submit(photoID int64) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+
+		if err := s.Review(ctx, photoID); err != nil {
+			logger.Warn("AI review failed, photo left for manual review",
+				zap.Int64("photo_id", photoID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+// Review sends a pending photo to the AI service and applies a synchronous verdict.
+// Asynchronous verdicts arrive later through HandleCallback.
+func (s *Service) Review(ctx context.Context, photoID int64) error {
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		return err
+	}
+	if p.Status != model.PhotoStatusPending {
+		return nil
+	}
+
+	req := &ai.ReviewRequest{
+		PhotoID:  photoID,
+		ImageURL: s.baseURL + p.FilePath,
+	}
+	if s.callbackURL != "" && s.callbackSecret != "" {
+		req.CallbackURL = s.callbackURL
+	}
+
+	result, err := s.client.Review(ctx, req)
+	if err != nil {
+		if errors.Is(err, ai.ErrQueued) {
+			return nil
+		}
+		return err
+	}
+
+	return s.apply(ctx, result)
+}
+
+// HandleCallback verifies and applies an asynchronous verdict
+func (s *Service) HandleCallback(ctx context.Context, signature string, body []byte) error {
+	if s.callbackSecret == "" {
+		return ErrCallbackDisabled
+	}
+
+	if err := ai.VerifySignature(s.callbackSecret, signature, body, time.Now()); err != nil {
+		return err
+	}
+
+	result, err := ai.ParseResult(body)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(ctx, result)
+}
+
+// apply stores the verdict. Photos already handled (e.g. reviewed manually
+// or a repeated callback) are left unchanged.
+func (s *Service) apply(ctx context.Context, result *ai.Result) error {
+	var reason *string
+	if len(result.Reasons) > 0 {
+		r := strings.Join(result.Reasons, "; ")
+		reason = &r
+	}
+
+	err := s.photoRepo.ApplyAIReview(ctx, result.PhotoID, result.Decision == ai.DecisionPass, reason, result.Raw)
+	if err != nil && !errors.Is(err, postgresql.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,447 @@
+package album
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/album"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+var (
+	ErrAlbumNotFound     = errors.New("album not found")
+	ErrNotOwner          = errors.New("you are not the owner of this album")
+	ErrPhotoNotFound     = errors.New("photo not found")
+	ErrPhotoNotInAlbum   = errors.New("photo is not in this album")
+	ErrInvalidVisibility = errors.New("invalid visibility")
+	ErrTitleRequired     = errors.New("album title is required")
+)
+
+// Service handles album business logic
+type Service struct {
+	albumRepo *album.AlbumRepository
+	photoRepo *photo.PhotoRepository
+	baseURL   string
+}
+
+// New creates a new album service
+func New(albumRepo *album.AlbumRepository, photoRepo *photo.PhotoRepository, baseURL string) *Service {
+	return &Service{
+		albumRepo: albumRepo,
+		photoRepo: photoRepo,
+		baseURL:   baseURL,
+	}
+}
+
+// Pagination represents pagination info
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// CreateRequest represents request for creating an album
+type CreateRequest struct {
+	Title       string `json:"title" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=2000"`
+	Visibility  string `json:"visibility"` // public (default), unlisted, private
+}
+
+// UpdateRequest represents request for updating an album, omitted fields are
+// left unchanged
+type UpdateRequest struct {
+	Title        *string `json:"title" binding:"omitempty,max=100"`
+	Description  *string `json:"description" binding:"omitempty,max=2000"`
+	CoverPhotoID *int64  `json:"cover_photo_id"` // 0 uses the first photo as cover
+	Visibility   *string `json:"visibility"`
+}
+
+// PhotosRequest represents request for adding or removing photos in bulk
+type PhotosRequest struct {
+	PhotoIDs []int64 `json:"photo_ids" binding:"required,min=1,max=100,dive,gt=0"`
+}
+
+// MovePhotoRequest represents request for moving a photo within an album
+type MovePhotoRequest struct {
+	Position int `json:"position" binding:"required,min=1"` // 1 is the first photo
+}
+
+// PhotosResponse represents the result of adding or removing photos
+type PhotosResponse struct {
+	Changed    int `json:"changed"` // Photos added or removed, photos already in or not in the album are skipped
+	PhotoCount int `json:"photo_count"`
+}
+
+// ListResponse represents response for listing albums
+type ListResponse struct {
+	List       []*model.AlbumListItem `json:"list"`
+	Pagination Pagination             `json:"pagination"`
+}
+
+// PhotoListResponse represents response for listing the photos of an album
+type PhotoListResponse struct {
+	List       []*model.PhotoListItem `json:"list"`
+	Pagination Pagination             `json:"pagination"`
+}
+
+// Create creates a new empty album
+func (s *Service) Create(ctx context.Context, userID int64, req *CreateRequest) (*model.AlbumDetail, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, ErrTitleRequired
+	}
+
+	visibility := model.PhotoVisibility(req.Visibility)
+	if visibility == "" {
+		visibility = model.PhotoVisibilityPublic
+	}
+	if !visibility.IsValid() {
+		return nil, ErrInvalidVisibility
+	}
+
+	id, err := s.albumRepo.Create(ctx, &album.CreateParams{
+		UserID:      userID,
+		Title:       title,
+		Description: optionalString(req.Description),
+		Visibility:  visibility,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetDetail(ctx, id, &userID)
+}
+
+// ListMine retrieves all of the user's albums
+func (s *Service) ListMine(ctx context.Context, userID int64, page, pageSize int) (*ListResponse, error) {
+	return s.list(ctx, album.ListParams{
+		Page:     page,
+		PageSize: pageSize,
+		UserID:   userID,
+	})
+}
+
+// ListUserAlbums retrieves a user's public albums shown on their profile
+func (s *Service) ListUserAlbums(ctx context.Context, userID int64, page, pageSize int) (*ListResponse, error) {
+	return s.list(ctx, album.ListParams{
+		Page:       page,
+		PageSize:   pageSize,
+		UserID:     userID,
+		PublicOnly: true,
+	})
+}
+
+func (s *Service) list(ctx context.Context, params album.ListParams) (*ListResponse, error) {
+	result, err := s.albumRepo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.AlbumListItem, len(result.Albums))
+	for i, a := range result.Albums {
+		list[i] = a.ToListItem(s.baseURL)
+	}
+
+	return &ListResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// GetDetail retrieves album detail. Private albums are only visible to their
+// owner, who also sees photos that are not published.
+func (s *Service) GetDetail(ctx context.Context, albumID int64, currentUserID *int64) (*model.AlbumDetail, error) {
+	a, isOwner, err := s.getVisible(ctx, albumID, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.photoRepo.GetUserMap(ctx, []int64{a.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	var userBrief *model.UserBrief
+	if u, ok := users[a.UserID]; ok {
+		userBrief = &model.UserBrief{
+			ID:       u.ID,
+			Username: u.Username,
+		}
+		if u.Avatar.Valid {
+			userBrief.Avatar = &u.Avatar.String
+		}
+	}
+
+	return a.ToDetail(userBrief, s.baseURL, isOwner), nil
+}
+
+// Update updates the title, description, cover or visibility of an album.
+// The cover must be one of the album's photos.
+func (s *Service) Update(ctx context.Context, albumID, userID int64, req *UpdateRequest) (*model.AlbumDetail, error) {
+	a, err := s.getOwned(ctx, albumID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &album.UpdateParams{
+		Title:      a.Title,
+		Visibility: a.Visibility,
+	}
+	if a.Description.Valid {
+		params.Description = &a.Description.String
+	}
+	if a.CoverPhotoID.Valid {
+		params.CoverPhotoID = &a.CoverPhotoID.Int64
+	}
+
+	if req.Title != nil {
+		params.Title = strings.TrimSpace(*req.Title)
+		if params.Title == "" {
+			return nil, ErrTitleRequired
+		}
+	}
+	if req.Description != nil {
+		params.Description = optionalString(*req.Description)
+	}
+	if req.Visibility != nil {
+		params.Visibility = model.PhotoVisibility(*req.Visibility)
+		if !params.Visibility.IsValid() {
+			return nil, ErrInvalidVisibility
+		}
+	}
+	if req.CoverPhotoID != nil {
+		params.CoverPhotoID = nil
+		if *req.CoverPhotoID > 0 {
+			inAlbum, err := s.albumRepo.HasPhoto(ctx, albumID, *req.CoverPhotoID)
+			if err != nil {
+				return nil, err
+			}
+			if !inAlbum {
+				return nil, ErrPhotoNotInAlbum
+			}
+			params.CoverPhotoID = req.CoverPhotoID
+		}
+	}
+
+	if err := s.albumRepo.Update(ctx, albumID, params); err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrAlbumNotFound
+		}
+		return nil, err
+	}
+
+	return s.GetDetail(ctx, albumID, &userID)
+}
+
+// Delete deletes one of the user's albums, its photos are kept
+func (s *Service) Delete(ctx context.Context, albumID, userID int64) error {
+	if _, err := s.getOwned(ctx, albumID, userID); err != nil {
+		return err
+	}
+
+	err := s.albumRepo.Delete(ctx, albumID)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrAlbumNotFound
+	}
+	return err
+}
+
+// ListPhotos retrieves the photos of an album in their manual order. Other
+// users only see approved photos that are not private or scheduled.
+func (s *Service) ListPhotos(ctx context.Context, albumID int64, currentUserID *int64, page, pageSize int) (*PhotoListResponse, error) {
+	a, isOwner, err := s.getVisible(ctx, albumID, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.albumRepo.ListPhotos(ctx, albumID, isOwner, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Album photos all belong to the album owner
+	users, err := s.photoRepo.GetUserMap(ctx, []int64{a.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	var userBrief *model.UserBrief
+	if u, ok := users[a.UserID]; ok {
+		userBrief = &model.UserBrief{
+			ID:       u.ID,
+			Username: u.Username,
+		}
+		if u.Avatar.Valid {
+			userBrief.Avatar = &u.Avatar.String
+		}
+	}
+
+	list := make([]*model.PhotoListItem, len(result.Photos))
+	for i, p := range result.Photos {
+		list[i] = p.ToListItem(userBrief, s.baseURL)
+		if isOwner {
+			list[i].Status = p.Status
+			list[i].Visibility = p.Visibility
+		}
+	}
+
+	return &PhotoListResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// AddPhotos appends the user's photos to the end of an album in the given
+// order. Every photo must belong to the album owner.
+func (s *Service) AddPhotos(ctx context.Context, albumID, userID int64, req *PhotosRequest) (*PhotosResponse, error) {
+	if _, err := s.getOwned(ctx, albumID, userID); err != nil {
+		return nil, err
+	}
+
+	photoIDs := uniqueIDs(req.PhotoIDs)
+	owned, err := s.albumRepo.CountOwnedPhotos(ctx, userID, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+	if owned != len(photoIDs) {
+		return nil, ErrPhotoNotFound
+	}
+
+	added, err := s.albumRepo.AddPhotos(ctx, albumID, photoIDs)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrAlbumNotFound
+		}
+		return nil, err
+	}
+
+	return s.photosResponse(ctx, albumID, added)
+}
+
+// RemovePhotos removes photos from one of the user's albums, the photos
+// themselves are kept
+func (s *Service) RemovePhotos(ctx context.Context, albumID, userID int64, req *PhotosRequest) (*PhotosResponse, error) {
+	if _, err := s.getOwned(ctx, albumID, userID); err != nil {
+		return nil, err
+	}
+
+	removed, err := s.albumRepo.RemovePhotos(ctx, albumID, uniqueIDs(req.PhotoIDs))
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrAlbumNotFound
+		}
+		return nil, err
+	}
+
+	return s.photosResponse(ctx, albumID, removed)
+}
+
+// MovePhoto moves a photo to a new position in one of the user's albums
+func (s *Service) MovePhoto(ctx context.Context, albumID, photoID, userID int64, req *MovePhotoRequest) error {
+	if _, err := s.getOwned(ctx, albumID, userID); err != nil {
+		return err
+	}
+
+	err := s.albumRepo.MovePhoto(ctx, albumID, photoID, req.Position)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrPhotoNotInAlbum
+	}
+	return err
+}
+
+// getVisible retrieves an album the current user may see and whether they
+// own it
+func (s *Service) getVisible(ctx context.Context, albumID int64, currentUserID *int64) (*model.Album, bool, error) {
+	a, err := s.albumRepo.GetByID(ctx, albumID, false)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, false, ErrAlbumNotFound
+		}
+		return nil, false, err
+	}
+
+	isOwner := currentUserID != nil && *currentUserID == a.UserID
+	if !isOwner {
+		if a.Visibility == model.PhotoVisibilityPrivate {
+			return nil, false, ErrAlbumNotFound
+		}
+		return a, false, nil
+	}
+
+	// Owners see every photo that is not in the trash
+	a, err = s.albumRepo.GetByID(ctx, albumID, true)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, false, ErrAlbumNotFound
+		}
+		return nil, false, err
+	}
+	return a, true, nil
+}
+
+// getOwned retrieves one of the user's albums
+func (s *Service) getOwned(ctx context.Context, albumID, userID int64) (*model.Album, error) {
+	a, err := s.albumRepo.GetByID(ctx, albumID, true)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrAlbumNotFound
+		}
+		return nil, err
+	}
+	if a.UserID != userID {
+		return nil, ErrNotOwner
+	}
+	return a, nil
+}
+
+// photosResponse reports the number of changed photos with the new photo
+// count of an album
+func (s *Service) photosResponse(ctx context.Context, albumID int64, changed int) (*PhotosResponse, error) {
+	a, err := s.albumRepo.GetByID(ctx, albumID, true)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrAlbumNotFound
+		}
+		return nil, err
+	}
+
+	return &PhotosResponse{
+		Changed:    changed,
+		PhotoCount: a.PhotoCount,
+	}, nil
+}
+
+// uniqueIDs removes duplicate IDs, keeping the first occurrence
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func optionalString(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
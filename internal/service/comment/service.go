@@ -12,6 +12,7 @@ import (
 var (
 	ErrCommentNotFound = errors.New("comment not found")
 	ErrPhotoNotFound   = errors.New("photo not found")
+	ErrSeriesNotFound  = errors.New("series not found")
 	ErrNotOwner        = errors.New("you are not the owner of this comment")
 	ErrNotLiked        = errors.New("comment is not liked")
 	ErrParentNotFound  = errors.New("parent comment not found")
@@ -34,6 +35,7 @@ func New(commentRepo *comment.CommentRepository, baseURL string) *Service {
 // ListRequest represents request for listing comments
 type ListRequest struct {
 	PhotoID  int64  `form:"-"`
+	SeriesID int64  `form:"-"` // Set instead of PhotoID for comments on a series
 	ParentID *int64 `form:"parent_id"`
 	Page     int    `form:"page"`
 	PageSize int    `form:"page_size"`
@@ -50,7 +52,8 @@ type UserBrief struct {
 // CommentItem represents a comment in response
 type CommentItem struct {
 	ID         int64         `json:"id"`
-	PhotoID    int64         `json:"photo_id"`
+	PhotoID    int64         `json:"photo_id,omitempty"`
+	SeriesID   int64         `json:"series_id,omitempty"`
 	ParentID   *int64        `json:"parent_id,omitempty"`
 	Content    string        `json:"content"`
 	LikeCount  int           `json:"like_count"`
@@ -75,10 +78,10 @@ type ListResponse struct {
 	Pagination Pagination    `json:"pagination"`
 }
 
-// List retrieves comments for a photo
+// List retrieves comments for a photo or a series
 func (s *Service) List(ctx context.Context, req *ListRequest, currentUserID *int64) (*ListResponse, error) {
 	result, err := s.commentRepo.List(ctx, comment.ListParams{
-		PhotoID:  req.PhotoID,
+		Target:   comment.Target{PhotoID: req.PhotoID, SeriesID: req.SeriesID},
 		ParentID: req.ParentID,
 		Page:     req.Page,
 		PageSize: req.PageSize,
@@ -183,24 +186,37 @@ func (s *Service) List(ctx context.Context, req *ListRequest, currentUserID *int
 // CreateRequest represents request for creating a comment
 type CreateRequest struct {
 	PhotoID  int64  `json:"-"`
+	SeriesID int64  `json:"-"` // Set instead of PhotoID for comments on a series
 	ParentID *int64 `json:"parent_id"`
 	Content  string `json:"content" binding:"required,min=1,max=1000"`
 }
 
-// Create creates a new comment
+// Create creates a new comment on a photo or a series
 func (s *Service) Create(ctx context.Context, userID int64, req *CreateRequest) (*CommentItem, error) {
-	// Check if photo exists
-	exists, err := s.commentRepo.PhotoExists(ctx, req.PhotoID)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, ErrPhotoNotFound
+	target := comment.Target{PhotoID: req.PhotoID, SeriesID: req.SeriesID}
+
+	// Check if the photo or series exists and is visible to the user
+	if target.SeriesID > 0 {
+		exists, err := s.commentRepo.SeriesExists(ctx, target.SeriesID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrSeriesNotFound
+		}
+	} else {
+		exists, err := s.commentRepo.PhotoExists(ctx, target.PhotoID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrPhotoNotFound
+		}
 	}
 
-	// Check if parent comment exists (if provided)
+	// Check if parent comment exists on the same photo or series (if provided)
 	if req.ParentID != nil {
-		parentExists, err := s.commentRepo.Exists(ctx, *req.ParentID)
+		parentExists, err := s.commentRepo.ExistsOn(ctx, *req.ParentID, target)
 		if err != nil {
 			return nil, err
 		}
@@ -210,7 +226,7 @@ func (s *Service) Create(ctx context.Context, userID int64, req *CreateRequest)
 	}
 
 	// Create comment
-	c, err := s.commentRepo.Create(ctx, req.PhotoID, userID, req.ParentID, req.Content)
+	c, err := s.commentRepo.Create(ctx, target, userID, req.ParentID, req.Content)
 	if err != nil {
 		return nil, err
 	}
@@ -289,7 +305,8 @@ func (s *Service) RemoveLike(ctx context.Context, userID, commentID int64) error
 func (s *Service) toCommentItem(c *comment.Comment, usersMap map[int64]*UserBrief) CommentItem {
 	item := CommentItem{
 		ID:         c.ID,
-		PhotoID:    c.PhotoID,
+		PhotoID:    c.PhotoID.Int64,
+		SeriesID:   c.SeriesID.Int64,
 		Content:    c.Content,
 		LikeCount:  c.LikeCount,
 		ReplyCount: c.ReplyCount,
@@ -0,0 +1,281 @@
+package gear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/exif"
+	"QuanPhotos/internal/pkg/logger"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/gear"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+var (
+	ErrGearNotFound = errors.New("gear not found")
+)
+
+// topPhotographers is the number of photographers shown on a gear page
+const topPhotographers = 10
+
+// normalizeBatchSize is the maximum number of photos normalized per query
+const normalizeBatchSize = 100
+
+// Service handles camera and lens page business logic
+type Service struct {
+	gearRepo  *gear.GearRepository
+	photoRepo *photo.PhotoRepository
+	baseURL   string
+}
+
+// New creates a new gear service
+func New(gearRepo *gear.GearRepository, photoRepo *photo.PhotoRepository, baseURL string) *Service {
+	return &Service{
+		gearRepo:  gearRepo,
+		photoRepo: photoRepo,
+		baseURL:   baseURL,
+	}
+}
+
+// ListRequest represents request for listing cameras or lenses
+type ListRequest struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	Keyword  string `form:"keyword"`
+}
+
+// ListPhotosRequest represents request for listing photos taken with a gear
+type ListPhotosRequest struct {
+	Page      int    `form:"page"`
+	PageSize  int    `form:"page_size"`
+	SortBy    string `form:"sort_by"`
+	SortOrder string `form:"sort_order"`
+}
+
+// Pagination represents pagination info
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// ListResponse represents response for listing gear
+type ListResponse struct {
+	List       []*model.GearItem `json:"list"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// PhotoListResponse represents response for listing photos taken with a gear
+type PhotoListResponse struct {
+	List       []*model.PhotoListItem `json:"list"`
+	Pagination Pagination             `json:"pagination"`
+}
+
+// List retrieves cameras or lenses with photo counts
+func (s *Service) List(ctx context.Context, gearType model.GearType, req *ListRequest) (*ListResponse, error) {
+	result, err := s.gearRepo.List(ctx, gear.ListParams{
+		Type:     gearType,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Keyword:  strings.TrimSpace(req.Keyword),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResponse{
+		List: result.Items,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// GetStats retrieves focal length distribution, median ISO and top photographers of a gear
+func (s *Service) GetStats(ctx context.Context, gearType model.GearType, key string) (*model.GearStats, error) {
+	summary, err := s.gearRepo.GetSummary(ctx, gearType, key)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrGearNotFound
+		}
+		return nil, err
+	}
+
+	counts, err := s.gearRepo.GetFocalLengthDistribution(ctx, gearType, key)
+	if err != nil {
+		return nil, err
+	}
+
+	photographers, err := s.gearRepo.ListTopPhotographers(ctx, gearType, key, topPhotographers)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &model.GearStats{
+		Type:              gearType,
+		Key:               key,
+		Name:              summary.Name,
+		PhotoCount:        summary.PhotoCount,
+		PhotographerCount: summary.PhotographerCount,
+		FocalLengths:      buildFocalLengthBuckets(counts),
+		TopPhotographers:  make([]*model.GearPhotographer, len(photographers)),
+	}
+	if summary.MedianISO.Valid {
+		stats.MedianISO = &summary.MedianISO.Float64
+	}
+
+	for i, p := range photographers {
+		user := &model.UserBrief{
+			ID:       p.UserID,
+			Username: p.Username,
+		}
+		if p.Avatar.Valid {
+			user.Avatar = &p.Avatar.String
+		}
+		stats.TopPhotographers[i] = &model.GearPhotographer{
+			User:       user,
+			PhotoCount: p.PhotoCount,
+		}
+	}
+
+	return stats, nil
+}
+
+// ListPhotos retrieves approved photos taken with a gear
+func (s *Service) ListPhotos(ctx context.Context, gearType model.GearType, key string, req *ListPhotosRequest) (*PhotoListResponse, error) {
+	params := photo.ListParams{
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+		SortBy:    req.SortBy,
+		SortOrder: req.SortOrder,
+	}
+	if gearType == model.GearTypeLens {
+		params.LensKey = key
+	} else {
+		params.CameraKey = key
+	}
+
+	result, err := s.photoRepo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get unique user IDs
+	userIDs := make([]int64, 0, len(result.Photos))
+	userIDMap := make(map[int64]bool)
+	for _, p := range result.Photos {
+		if !userIDMap[p.UserID] {
+			userIDs = append(userIDs, p.UserID)
+			userIDMap[p.UserID] = true
+		}
+	}
+
+	users, err := s.photoRepo.GetUserMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.PhotoListItem, len(result.Photos))
+	for i, p := range result.Photos {
+		var userBrief *model.UserBrief
+		if u, ok := users[p.UserID]; ok {
+			userBrief = &model.UserBrief{
+				ID:       u.ID,
+				Username: u.Username,
+			}
+			if u.Avatar.Valid {
+				userBrief.Avatar = &u.Avatar.String
+			}
+		}
+		list[i] = p.ToListItem(userBrief, s.baseURL)
+	}
+
+	return &PhotoListResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// buildFocalLengthBuckets labels bucket counts returned by the repository
+func buildFocalLengthBuckets(counts []int) []*model.FocalLengthBucket {
+	bounds := gear.FocalLengthBounds
+	buckets := make([]*model.FocalLengthBucket, 0, len(counts))
+
+	for i, count := range counts {
+		bucket := &model.FocalLengthBucket{Count: count}
+		switch {
+		case i == 0:
+			upper := bounds[0]
+			bucket.Max = &upper
+			bucket.Label = fmt.Sprintf("<%gmm", upper)
+		case i == len(bounds):
+			bucket.Min = bounds[i-1]
+			bucket.Label = fmt.Sprintf("%gmm+", bucket.Min)
+		default:
+			upper := bounds[i]
+			bucket.Min = bounds[i-1]
+			bucket.Max = &upper
+			bucket.Label = fmt.Sprintf("%g-%gmm", bucket.Min, upper)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets
+}
+
+// StartNormalizer normalizes the gear of photos stored before uploads wrote
+// it, once in the background
+func (s *Service) StartNormalizer() {
+	go func() {
+		normalized, err := s.NormalizeStored(context.Background())
+		if err != nil {
+			logger.Warn("Failed to normalize stored gear", zap.Error(err))
+		}
+		if normalized > 0 {
+			logger.Info("Normalized stored gear", zap.Int("photos", normalized))
+		}
+	}()
+}
+
+// NormalizeStored derives the gear columns of photos without gear from their
+// EXIF fields and returns the number of photos normalized
+func (s *Service) NormalizeStored(ctx context.Context) (int, error) {
+	normalized := 0
+	var afterID int64
+	for {
+		photos, err := s.gearRepo.ListUnnormalized(ctx, afterID, normalizeBatchSize)
+		if err != nil {
+			return normalized, err
+		}
+
+		for _, p := range photos {
+			afterID = p.ID
+			g := exif.NormalizeGear(p.ExifCameraMake.String, p.ExifCameraModel.String, p.ExifLensModel.String)
+			if g.CameraKey == "" && g.LensKey == "" {
+				continue
+			}
+			if err := s.gearRepo.SetGear(ctx, p.ID, g.CameraKey, g.CameraName, g.LensKey, g.LensName); err != nil {
+				return normalized, err
+			}
+			normalized++
+		}
+
+		if len(photos) < normalizeBatchSize {
+			return normalized, nil
+		}
+	}
+}
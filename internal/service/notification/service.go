@@ -247,6 +247,18 @@ func (s *Service) CreateFeaturedNotification(ctx context.Context, photoOwnerID,
 	return err
 }
 
+// CreatePublishedNotification creates a notification for a scheduled photo going live
+func (s *Service) CreatePublishedNotification(ctx context.Context, photoOwnerID, photoID int64, photoTitle string) error {
+	_, err := s.notifRepo.Create(ctx, &notification.Notification{
+		UserID:         photoOwnerID,
+		Type:           string(notification.TypeSystem),
+		Title:          "你的照片已按计划发布",
+		Content:        sql.NullString{String: photoTitle, Valid: true},
+		RelatedPhotoID: sql.NullInt64{Int64: photoID, Valid: true},
+	})
+	return err
+}
+
 // CreateReviewNotification creates a notification for a photo review result
 func (s *Service) CreateReviewNotification(ctx context.Context, photoOwnerID, photoID int64, approved bool, reason string) error {
 	var title string
@@ -0,0 +1,206 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"QuanPhotos/internal/model"
+)
+
+var (
+	ErrBatchEmpty       = errors.New("no files provided")
+	ErrBatchTooLarge    = errors.New("too many files in one batch")
+	ErrManifestMismatch = errors.New("manifest has more items than files")
+	ErrRawFileNotFound  = errors.New("raw file not found in the batch")
+)
+
+// BatchUploadFields are metadata of a batch upload, either the defaults
+// shared by all files or the overrides of one file
+type BatchUploadFields struct {
+	Title        string                `json:"title" binding:"max=100"`
+	Description  string                `json:"description" binding:"max=500"`
+	AircraftType string                `json:"aircraft_type" binding:"max=100"`
+	Airline      string                `json:"airline" binding:"max=100"`
+	Registration string                `json:"registration" binding:"max=20"`
+	Airport      string                `json:"airport" binding:"max=10"`
+	CategoryID   int32                 `json:"category_id" binding:"min=0"`
+	SpotID       int64                 `json:"spot_id" binding:"min=0"`
+	FlightNumber string                `json:"flight_number"`
+	Origin       string                `json:"origin"`
+	Destination  string                `json:"destination"`
+	FlightPhase  string                `json:"flight_phase"`
+	Tags         string                `json:"tags"` // Comma-separated, per-file tags are added to the shared ones
+	Visibility   model.PhotoVisibility `json:"visibility"`
+	PublishAt    *time.Time            `json:"publish_at"`
+}
+
+// BatchUploadItem is the metadata of one file in a batch upload
+type BatchUploadItem struct {
+	BatchUploadFields
+	RawFile string `json:"raw_file"` // File name of the matching RAW file, optional
+}
+
+// BatchManifest describes the files of a batch upload. Items apply to the
+// files in upload order, files without an item only use the defaults.
+type BatchManifest struct {
+	Defaults BatchUploadFields  `json:"defaults"`
+	Items    []*BatchUploadItem `json:"items" binding:"dive"`
+}
+
+// BatchUploadRequest represents a batch upload
+type BatchUploadRequest struct {
+	UserID   int64
+	Files    []*multipart.FileHeader
+	RawFiles []*multipart.FileHeader
+	Manifest BatchManifest
+}
+
+// BatchUploadResult is the outcome of one file of a batch upload
+type BatchUploadResult struct {
+	Index    int
+	Filename string
+	Photo    *UploadResponse // Set on success
+	Err      error           // Set on failure
+}
+
+// BatchUploadResponse represents the outcome of a batch upload
+type BatchUploadResponse struct {
+	Succeeded int
+	Failed    int
+	Results   []*BatchUploadResult
+}
+
+// MaxBatchFiles returns the number of files one batch upload can carry
+func (s *Service) MaxBatchFiles() int {
+	if s.uploader == nil {
+		return 0
+	}
+	return s.uploader.config.Storage.MaxBatchFiles
+}
+
+// BatchUpload uploads several photos sharing default metadata. Every file
+// goes through the same checks as a single upload, a failed file does not
+// stop the others.
+func (s *Service) BatchUpload(ctx context.Context, req *BatchUploadRequest) (*BatchUploadResponse, error) {
+	if s.uploader == nil {
+		return nil, errors.New("uploader not initialized")
+	}
+
+	if len(req.Files) == 0 {
+		return nil, ErrBatchEmpty
+	}
+	if len(req.Files) > s.MaxBatchFiles() {
+		return nil, ErrBatchTooLarge
+	}
+	if len(req.Manifest.Items) > len(req.Files) {
+		return nil, ErrManifestMismatch
+	}
+
+	rawFiles := make(map[string]*multipart.FileHeader, len(req.RawFiles))
+	for _, raw := range req.RawFiles {
+		rawFiles[raw.Filename] = raw
+	}
+
+	resp := &BatchUploadResponse{Results: make([]*BatchUploadResult, 0, len(req.Files))}
+	for i, file := range req.Files {
+		result := &BatchUploadResult{Index: i, Filename: file.Filename}
+		resp.Results = append(resp.Results, result)
+
+		fields := req.Manifest.Defaults
+		var rawFile *multipart.FileHeader
+		if i < len(req.Manifest.Items) && req.Manifest.Items[i] != nil {
+			item := req.Manifest.Items[i]
+			fields = fields.merge(item.BatchUploadFields)
+			if item.RawFile != "" {
+				if rawFile = rawFiles[item.RawFile]; rawFile == nil {
+					result.Err = ErrRawFileNotFound
+					resp.Failed++
+					continue
+				}
+			}
+		}
+
+		if strings.TrimSpace(fields.Title) == "" {
+			result.Err = ErrEmptyTitle
+			resp.Failed++
+			continue
+		}
+
+		result.Photo, result.Err = s.Upload(ctx, fields.uploadRequest(req.UserID, file, rawFile))
+		if result.Err != nil {
+			resp.Failed++
+			continue
+		}
+		resp.Succeeded++
+	}
+
+	return resp, nil
+}
+
+// merge returns the fields with the non-empty values of override applied.
+// Tags are combined instead of replaced.
+func (f BatchUploadFields) merge(override BatchUploadFields) BatchUploadFields {
+	set := func(dst *string, value string) {
+		if value != "" {
+			*dst = value
+		}
+	}
+	set(&f.Title, override.Title)
+	set(&f.Description, override.Description)
+	set(&f.AircraftType, override.AircraftType)
+	set(&f.Airline, override.Airline)
+	set(&f.Registration, override.Registration)
+	set(&f.Airport, override.Airport)
+	set(&f.FlightNumber, override.FlightNumber)
+	set(&f.Origin, override.Origin)
+	set(&f.Destination, override.Destination)
+	set(&f.FlightPhase, override.FlightPhase)
+
+	if override.CategoryID > 0 {
+		f.CategoryID = override.CategoryID
+	}
+	if override.SpotID > 0 {
+		f.SpotID = override.SpotID
+	}
+	if override.Visibility != "" {
+		f.Visibility = override.Visibility
+	}
+	if override.PublishAt != nil {
+		f.PublishAt = override.PublishAt
+	}
+	if override.Tags != "" {
+		if f.Tags != "" {
+			f.Tags += "," + override.Tags
+		} else {
+			f.Tags = override.Tags
+		}
+	}
+	return f
+}
+
+// uploadRequest builds the single upload request of one file
+func (f BatchUploadFields) uploadRequest(userID int64, file, rawFile *multipart.FileHeader) *UploadRequest {
+	return &UploadRequest{
+		UserID:       userID,
+		File:         file,
+		RawFile:      rawFile,
+		Title:        f.Title,
+		Description:  f.Description,
+		AircraftType: f.AircraftType,
+		Airline:      f.Airline,
+		Registration: f.Registration,
+		Airport:      f.Airport,
+		CategoryID:   f.CategoryID,
+		SpotID:       f.SpotID,
+		FlightNumber: f.FlightNumber,
+		Origin:       f.Origin,
+		Destination:  f.Destination,
+		FlightPhase:  f.FlightPhase,
+		Tags:         f.Tags,
+		Visibility:   f.Visibility,
+		PublishAt:    f.PublishAt,
+	}
+}
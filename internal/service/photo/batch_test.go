@@ -0,0 +1,64 @@
+package photo
+
+import (
+	"testing"
+
+	"QuanPhotos/internal/model"
+)
+
+func TestBatchUploadFieldsMerge(t *testing.T) {
+	defaults := BatchUploadFields{
+		Title:      "Morning at PEK",
+		Airport:    "ZBAA",
+		CategoryID: 3,
+		Tags:       "pek,sunrise",
+	}
+
+	tests := []struct {
+		name     string
+		override BatchUploadFields
+		expect   BatchUploadFields
+	}{
+		{
+			name:     "Empty override keeps defaults",
+			override: BatchUploadFields{},
+			expect:   defaults,
+		},
+		{
+			name: "Non-empty values replace defaults",
+			override: BatchUploadFields{
+				Title:        "B-2447 departing",
+				Registration: "B-2447",
+				CategoryID:   5,
+				Visibility:   model.PhotoVisibilityUnlisted,
+			},
+			expect: BatchUploadFields{
+				Title:        "B-2447 departing",
+				Registration: "B-2447",
+				Airport:      "ZBAA",
+				CategoryID:   5,
+				Tags:         "pek,sunrise",
+				Visibility:   model.PhotoVisibilityUnlisted,
+			},
+		},
+		{
+			name:     "Tags are added to the shared tags",
+			override: BatchUploadFields{Tags: "b747"},
+			expect: BatchUploadFields{
+				Title:      "Morning at PEK",
+				Airport:    "ZBAA",
+				CategoryID: 3,
+				Tags:       "pek,sunrise,b747",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := defaults.merge(tt.override)
+			if got != tt.expect {
+				t.Errorf("merge() = %+v, expected %+v", got, tt.expect)
+			}
+		})
+	}
+}
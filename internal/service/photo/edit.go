@@ -0,0 +1,260 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// UpdateRequest represents edited metadata of a photo.
+// Omitted fields are left unchanged, empty values clear optional fields.
+type UpdateRequest struct {
+	Title        *string   `json:"title" binding:"omitempty,min=1,max=100"`
+	Description  *string   `json:"description" binding:"omitempty,max=500"`
+	CategoryID   *int32    `json:"category_id" binding:"omitempty,min=0"` // 0 clears the category
+	AircraftType *string   `json:"aircraft_type" binding:"omitempty,max=100"`
+	Airline      *string   `json:"airline" binding:"omitempty,max=100"`
+	Registration *string   `json:"registration" binding:"omitempty,max=20"`
+	Airport      *string   `json:"airport" binding:"omitempty,max=10"`
+	FlightNumber *string   `json:"flight_number"`
+	Origin       *string   `json:"origin"`
+	Destination  *string   `json:"destination"`
+	FlightPhase  *string   `json:"flight_phase"`
+	Tags         *[]string `json:"tags" binding:"omitempty,max=20,dive,max=50"` // Replaces all tags
+
+	// Reschedules a photo that is not published yet, see Schedule for
+	// publishing it on approval instead
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// hasMetadata reports whether the request edits any metadata field
+func (r *UpdateRequest) hasMetadata() bool {
+	return r.Title != nil || r.Description != nil || r.CategoryID != nil ||
+		r.AircraftType != nil || r.Airline != nil || r.Registration != nil || r.Airport != nil ||
+		r.FlightNumber != nil || r.Origin != nil || r.Destination != nil || r.FlightPhase != nil ||
+		r.Tags != nil
+}
+
+// UpdateResponse represents the result of editing a photo
+type UpdateResponse struct {
+	ID        int64         `json:"id"`
+	Status    string        `json:"status"`
+	Revision  *RevisionItem `json:"revision"` // Null when only the schedule changed
+	PublishAt *string       `json:"publish_at,omitempty"`
+}
+
+// RevisionItem represents a metadata revision of a photo
+type RevisionItem struct {
+	ID         int64                        `json:"id"`
+	Source     model.RevisionSource         `json:"source"` // edit, resubmit, rollback
+	Changes    map[string]model.FieldChange `json:"changes"`
+	ReReview   bool                         `json:"rereview"`
+	RollbackOf *int64                       `json:"rollback_of,omitempty"`
+	CreatedAt  string                       `json:"created_at"`
+}
+
+// NewRevisionItem converts a stored revision to its response form
+func NewRevisionItem(r *model.PhotoRevision) (*RevisionItem, error) {
+	changes, err := r.FieldChanges()
+	if err != nil {
+		return nil, err
+	}
+	item := &RevisionItem{
+		ID:        r.ID,
+		Source:    r.Source,
+		Changes:   changes,
+		ReReview:  r.ReReview,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.RollbackOf.Valid {
+		item.RollbackOf = &r.RollbackOf.Int64
+	}
+	return item, nil
+}
+
+// SetReReviewFields enables re-review of approved photos whose owner edits one
+// of the given fields
+func (s *Service) SetReReviewFields(fields []string) {
+	s.reReviewFields = make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			s.reReviewFields = append(s.reReviewFields, field)
+		}
+	}
+}
+
+// Update edits the metadata of one of the user's photos and records the change
+// as a revision. Editing a review relevant field of an approved photo sends it
+// back to review as a new attempt. Rejected photos are corrected by Resubmit.
+// Photos that are not published yet can be rescheduled along with the edit.
+func (s *Service) Update(ctx context.Context, photoID, userID int64, req *UpdateRequest) (*UpdateResponse, error) {
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if p.UserID != userID {
+		return nil, ErrNotOwner
+	}
+	if p.Status == model.PhotoStatusRejected || p.Status == model.PhotoStatusAIRejected {
+		return nil, ErrEditRejected
+	}
+	if req.PublishAt != nil {
+		if !req.PublishAt.After(time.Now()) {
+			return nil, ErrPublishAtPast
+		}
+		if p.Status == model.PhotoStatusApproved && !p.IsScheduled() {
+			return nil, ErrAlreadyPublished
+		}
+	}
+
+	params := &photo.MetadataParams{
+		Title:        trimmed(req.Title),
+		Description:  req.Description,
+		CategoryID:   req.CategoryID,
+		AircraftType: trimmed(req.AircraftType),
+		Airline:      trimmed(req.Airline),
+		Registration: trimmed(req.Registration),
+		Airport:      trimmed(req.Airport),
+		ReviewFields: s.reReviewFields,
+	}
+	if params.Title != nil && *params.Title == "" {
+		return nil, ErrEmptyTitle
+	}
+
+	// Validate and normalize edited flight info
+	flight, err := normalizeFlightInfo(
+		derefString(req.FlightNumber), derefString(req.Origin),
+		derefString(req.Destination), derefString(req.FlightPhase),
+	)
+	if err != nil {
+		return nil, err
+	}
+	params.FlightNumber = correctedField(req.FlightNumber, flight.FlightNumber)
+	params.Origin = correctedField(req.Origin, flight.Origin)
+	params.Destination = correctedField(req.Destination, flight.Destination)
+	params.FlightPhase = correctedField(req.FlightPhase, flight.FlightPhase)
+
+	if req.Tags != nil {
+		tags := normalizeTags(*req.Tags)
+		params.Tags = &tags
+	}
+
+	if req.PublishAt == nil {
+		return s.updateMetadata(ctx, p, params)
+	}
+
+	resp := &UpdateResponse{ID: photoID, Status: string(p.Status)}
+	if req.hasMetadata() {
+		// Unchanged metadata does not stop the photo from being rescheduled
+		edited, err := s.updateMetadata(ctx, p, params)
+		if err != nil && !errors.Is(err, ErrNoChanges) {
+			return nil, err
+		}
+		if edited != nil {
+			resp = edited
+		}
+	}
+
+	scheduled, err := s.photoRepo.UpdatePublishAt(ctx, photoID, userID, req.PublishAt)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrAlreadyPublished // Published in the meantime
+		}
+		return nil, err
+	}
+	resp.PublishAt = formatPublishAt(scheduled)
+
+	return resp, nil
+}
+
+// updateMetadata applies validated metadata to the owner's photo and resubmits
+// it for review when the change requires a re-review
+func (s *Service) updateMetadata(ctx context.Context, p *model.Photo, params *photo.MetadataParams) (*UpdateResponse, error) {
+	photoID := p.ID
+	revision, err := s.photoRepo.UpdateMetadata(ctx, photoID, p.UserID, params)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if revision == nil {
+		return nil, ErrNoChanges
+	}
+
+	resp := &UpdateResponse{ID: photoID, Status: string(p.Status)}
+	if resp.Revision, err = NewRevisionItem(revision); err != nil {
+		return nil, err
+	}
+
+	if revision.ReReview {
+		// Re-reviewed edits follow the upload path: trusted owners skip the queue
+		upload := &UploadResponse{ID: photoID, Status: string(model.PhotoStatusPending)}
+		if !s.autoApprove(ctx, p.UserID, upload) && s.aiReviewer != nil {
+			s.aiReviewer.Submit(photoID)
+		}
+		resp.Status = upload.Status
+	}
+
+	return resp, nil
+}
+
+// ListRevisions retrieves the metadata revisions of one of the user's photos,
+// newest first
+func (s *Service) ListRevisions(ctx context.Context, photoID, userID int64) ([]*RevisionItem, error) {
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if p.UserID != userID {
+		return nil, ErrNotOwner
+	}
+
+	revisions, err := s.photoRepo.ListRevisions(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*RevisionItem, len(revisions))
+	for i, r := range revisions {
+		if items[i], err = NewRevisionItem(r); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// normalizeTags trims tags and removes empty and duplicate names
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// trimmed returns a submitted value without surrounding whitespace
+func trimmed(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	value := strings.TrimSpace(*s)
+	return &value
+}
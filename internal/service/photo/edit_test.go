@@ -0,0 +1,67 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+func TestUpdateWithPublishAt(t *testing.T) {
+	db := pgtest.Open(t)
+	s := New(photo.NewPhotoRepository(db), "")
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+
+	unchanged := "Test photo"
+	edited := "Edited title"
+	publishAt := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		req            UpdateRequest
+		expectErr      error
+		expectRevision bool
+		expectSchedule bool
+	}{
+		{
+			name:      "Unchanged metadata",
+			req:       UpdateRequest{Title: &unchanged},
+			expectErr: ErrNoChanges,
+		},
+		{
+			name:           "Unchanged metadata with a new schedule",
+			req:            UpdateRequest{Title: &unchanged, PublishAt: &publishAt},
+			expectSchedule: true,
+		},
+		{
+			name:           "Edited metadata with a new schedule",
+			req:            UpdateRequest{Title: &edited, PublishAt: &publishAt},
+			expectRevision: true,
+			expectSchedule: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			photoID := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusPending))
+
+			resp, err := s.Update(context.Background(), photoID, ownerID, &tt.req)
+			if !errors.Is(err, tt.expectErr) || (tt.expectErr == nil && err != nil) {
+				t.Fatalf("Update() error = %v, expected %v", err, tt.expectErr)
+			}
+			if err != nil {
+				return
+			}
+			if hasRevision := resp.Revision != nil; hasRevision != tt.expectRevision {
+				t.Errorf("Update() revision = %v, expected %v", hasRevision, tt.expectRevision)
+			}
+			if scheduled := resp.PublishAt != nil; scheduled != tt.expectSchedule {
+				t.Errorf("Update() scheduled = %v, expected %v", scheduled, tt.expectSchedule)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package photo
+
+import (
+	"errors"
+	"strings"
+
+	exifPkg "QuanPhotos/internal/pkg/exif"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+var (
+	ErrInvalidExifFilter = errors.New("invalid EXIF filter")
+)
+
+// ExifFilter contains EXIF range and equality filters for photo search.
+// Range bounds are inclusive.
+type ExifFilter struct {
+	FocalMin    *float64 `form:"focal_min"`    // 35mm-equivalent focal length in mm
+	FocalMax    *float64 `form:"focal_max"`    // 35mm-equivalent focal length in mm
+	ApertureMin *float64 `form:"aperture_min"` // f-number, e.g. 2.8
+	ApertureMax *float64 `form:"aperture_max"` // f-number, e.g. 8
+	ShutterMin  string   `form:"shutter_min"`  // Exposure time, e.g. "1/2000" or "0.5"
+	ShutterMax  string   `form:"shutter_max"`  // Exposure time, e.g. "1/60" or "2"
+	ISOMin      *int32   `form:"iso_min"`
+	ISOMax      *int32   `form:"iso_max"`
+	CameraModel string   `form:"camera_model"` // Exact EXIF camera model, case-insensitive
+	LensModel   string   `form:"lens_model"`   // Exact EXIF lens model, case-insensitive
+}
+
+// apply validates the filter and copies it into repository list params
+func (f *ExifFilter) apply(params *photo.ListParams) error {
+	if !validFloatRange(f.FocalMin, f.FocalMax) || !validFloatRange(f.ApertureMin, f.ApertureMax) {
+		return ErrInvalidExifFilter
+	}
+	if (f.ISOMin != nil && *f.ISOMin < 0) || (f.ISOMax != nil && *f.ISOMax < 0) ||
+		(f.ISOMin != nil && f.ISOMax != nil && *f.ISOMin > *f.ISOMax) {
+		return ErrInvalidExifFilter
+	}
+
+	shutterMin, err := parseShutterBound(f.ShutterMin)
+	if err != nil {
+		return err
+	}
+	shutterMax, err := parseShutterBound(f.ShutterMax)
+	if err != nil {
+		return err
+	}
+	if !validFloatRange(shutterMin, shutterMax) {
+		return ErrInvalidExifFilter
+	}
+
+	params.FocalLengthMin = f.FocalMin
+	params.FocalLengthMax = f.FocalMax
+	params.ApertureMin = f.ApertureMin
+	params.ApertureMax = f.ApertureMax
+	params.ShutterMin = shutterMin
+	params.ShutterMax = shutterMax
+	params.ISOMin = f.ISOMin
+	params.ISOMax = f.ISOMax
+	params.CameraModel = strings.TrimSpace(f.CameraModel)
+	params.LensModel = strings.TrimSpace(f.LensModel)
+
+	return nil
+}
+
+// parseShutterBound parses an optional exposure time bound to seconds
+func parseShutterBound(s string) (*float64, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	v, ok := exifPkg.ParseShutterSpeed(s)
+	if !ok {
+		return nil, ErrInvalidExifFilter
+	}
+	return &v, nil
+}
+
+// validFloatRange reports whether optional bounds are non-negative and ordered
+func validFloatRange(lower, upper *float64) bool {
+	if (lower != nil && *lower < 0) || (upper != nil && *upper < 0) {
+		return false
+	}
+	return lower == nil || upper == nil || *lower <= *upper
+}
@@ -0,0 +1,32 @@
+package photo
+
+import "testing"
+
+func TestValidFloatRange(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		lower    *float64
+		upper    *float64
+		expected bool
+	}{
+		{name: "No bounds", expected: true},
+		{name: "Lower bound only", lower: f(2.8), expected: true},
+		{name: "Upper bound only", upper: f(400), expected: true},
+		{name: "Ordered bounds", lower: f(100), upper: f(400), expected: true},
+		{name: "Equal bounds", lower: f(5.6), upper: f(5.6), expected: true},
+		{name: "Zero lower bound", lower: f(0), upper: f(1), expected: true},
+		{name: "Inverted bounds", lower: f(400), upper: f(100), expected: false},
+		{name: "Negative lower bound", lower: f(-1), expected: false},
+		{name: "Negative upper bound", upper: f(-1), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validFloatRange(tt.lower, tt.upper); got != tt.expected {
+				t.Errorf("validFloatRange() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// MaxFavoriteFolders is the number of favorite folders a user may have
+const MaxFavoriteFolders = 100
+
+var (
+	ErrFolderNotFound  = errors.New("favorite folder not found")
+	ErrFolderNameEmpty = errors.New("favorite folder name cannot be empty")
+	ErrDefaultFolder   = errors.New("the default favorite folder cannot be deleted")
+	ErrFolderLimit     = errors.New("favorite folder limit reached")
+	ErrFolderNameTaken = errors.New("a favorite folder with this name already exists")
+	ErrSameFolder      = errors.New("source and target folder are the same")
+)
+
+// FolderRequest represents request for creating or renaming a favorite folder
+type FolderRequest struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+// FolderPhotosRequest represents request for adding photos to or removing
+// them from a favorite folder
+type FolderPhotosRequest struct {
+	PhotoIDs []int64 `json:"photo_ids" binding:"required,min=1,max=100,dive,gt=0"`
+}
+
+// MoveFavoritesRequest represents request for moving photos to another folder
+type MoveFavoritesRequest struct {
+	PhotoIDs       []int64 `json:"photo_ids" binding:"required,min=1,max=100,dive,gt=0"`
+	TargetFolderID int64   `json:"target_folder_id" binding:"required,gt=0"`
+}
+
+// FolderPhotosResponse represents the result of changing the photos of a folder
+type FolderPhotosResponse struct {
+	Changed int `json:"changed"` // Photos actually added, removed or moved
+}
+
+// ListFavoriteFolders retrieves the user's favorite folders. A positive
+// photoID reports for each folder whether it holds that photo.
+func (s *Service) ListFavoriteFolders(ctx context.Context, userID, photoID int64) ([]*model.FavoriteFolderItem, error) {
+	folders, err := s.photoRepo.ListFavoriteFolders(ctx, userID, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.FavoriteFolderItem, len(folders))
+	for i, f := range folders {
+		list[i] = f.ToItem(s.baseURL)
+		if photoID > 0 {
+			contains := f.Contains
+			list[i].Contains = &contains
+		}
+	}
+	return list, nil
+}
+
+// CreateFavoriteFolder creates a favorite folder for the user
+func (s *Service) CreateFavoriteFolder(ctx context.Context, userID int64, req *FolderRequest) (*model.FavoriteFolderItem, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrFolderNameEmpty
+	}
+
+	count, err := s.photoRepo.CountFavoriteFolders(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxFavoriteFolders {
+		return nil, ErrFolderLimit
+	}
+
+	taken, err := s.photoRepo.FavoriteFolderNameExists(ctx, userID, name, 0)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, ErrFolderNameTaken
+	}
+
+	id, err := s.photoRepo.CreateFavoriteFolder(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getFavoriteFolder(ctx, id, userID)
+}
+
+// RenameFavoriteFolder renames one of the user's favorite folders, the
+// default one included
+func (s *Service) RenameFavoriteFolder(ctx context.Context, folderID, userID int64, req *FolderRequest) (*model.FavoriteFolderItem, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrFolderNameEmpty
+	}
+
+	taken, err := s.photoRepo.FavoriteFolderNameExists(ctx, userID, name, folderID)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, ErrFolderNameTaken
+	}
+
+	if err := s.photoRepo.RenameFavoriteFolder(ctx, folderID, userID, name); err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrFolderNotFound
+		}
+		return nil, err
+	}
+
+	return s.getFavoriteFolder(ctx, folderID, userID)
+}
+
+// DeleteFavoriteFolder deletes one of the user's favorite folders. Photos
+// only filed in it stay favorites and move to the default folder.
+func (s *Service) DeleteFavoriteFolder(ctx context.Context, folderID, userID int64) error {
+	folder, err := s.photoRepo.GetFavoriteFolder(ctx, folderID, userID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return ErrFolderNotFound
+		}
+		return err
+	}
+	if folder.IsDefault {
+		return ErrDefaultFolder
+	}
+
+	err = s.photoRepo.DeleteFavoriteFolder(ctx, folderID, userID)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrFolderNotFound
+	}
+	return err
+}
+
+// ListFolderFavorites retrieves the photos of one of the user's favorite folders
+func (s *Service) ListFolderFavorites(ctx context.Context, folderID, userID int64, page, pageSize int) (*ListResponse, error) {
+	if _, err := s.getFavoriteFolder(ctx, folderID, userID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.photoRepo.ListFavoriteFolderPhotos(ctx, userID, folderID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildListResponse(ctx, result)
+}
+
+// AddToFavoriteFolder files photos in one of the user's favorite folders,
+// favoriting those that are not favorites yet
+func (s *Service) AddToFavoriteFolder(ctx context.Context, folderID, userID int64, req *FolderPhotosRequest) (*FolderPhotosResponse, error) {
+	if _, err := s.getFavoriteFolder(ctx, folderID, userID); err != nil {
+		return nil, err
+	}
+
+	photoIDs := uniquePhotoIDs(req.PhotoIDs)
+	visible, err := s.photoRepo.CountVisibleTo(ctx, photoIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	if visible != len(photoIDs) {
+		return nil, ErrPhotoNotFound
+	}
+
+	added, err := s.photoRepo.AddToFavoriteFolder(ctx, userID, folderID, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &FolderPhotosResponse{Changed: added}, nil
+}
+
+// RemoveFromFavoriteFolder takes photos out of one of the user's favorite
+// folders. Photos left in no folder are unfavorited.
+func (s *Service) RemoveFromFavoriteFolder(ctx context.Context, folderID, userID int64, req *FolderPhotosRequest) (*FolderPhotosResponse, error) {
+	if _, err := s.getFavoriteFolder(ctx, folderID, userID); err != nil {
+		return nil, err
+	}
+
+	removed, err := s.photoRepo.RemoveFromFavoriteFolder(ctx, userID, folderID, uniquePhotoIDs(req.PhotoIDs))
+	if err != nil {
+		return nil, err
+	}
+	return &FolderPhotosResponse{Changed: removed}, nil
+}
+
+// MoveFavorites moves photos from one of the user's favorite folders to
+// another. Photos not in the source folder are skipped.
+func (s *Service) MoveFavorites(ctx context.Context, folderID, userID int64, req *MoveFavoritesRequest) (*FolderPhotosResponse, error) {
+	if folderID == req.TargetFolderID {
+		return nil, ErrSameFolder
+	}
+	if _, err := s.getFavoriteFolder(ctx, folderID, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getFavoriteFolder(ctx, req.TargetFolderID, userID); err != nil {
+		return nil, err
+	}
+
+	moved, err := s.photoRepo.MoveFavorites(ctx, userID, folderID, req.TargetFolderID, uniquePhotoIDs(req.PhotoIDs))
+	if err != nil {
+		return nil, err
+	}
+	return &FolderPhotosResponse{Changed: moved}, nil
+}
+
+// getFavoriteFolder retrieves one of the user's favorite folders as list item
+func (s *Service) getFavoriteFolder(ctx context.Context, folderID, userID int64) (*model.FavoriteFolderItem, error) {
+	folder, err := s.photoRepo.GetFavoriteFolder(ctx, folderID, userID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrFolderNotFound
+		}
+		return nil, err
+	}
+	return folder.ToItem(s.baseURL), nil
+}
+
+// uniquePhotoIDs drops repeated photo IDs, keeping the first occurrence
+func uniquePhotoIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,114 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/aviation"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+var (
+	ErrInvalidFlightNumber = errors.New("invalid flight number")
+	ErrInvalidRouteAirport = errors.New("origin or destination is not the IATA or ICAO code of a known airport")
+	ErrInvalidFlightPhase  = errors.New("invalid flight phase")
+)
+
+// FlightInfoRequest represents request for updating flight info of a photo.
+// Empty fields are cleared.
+type FlightInfoRequest struct {
+	FlightNumber string `json:"flight_number"`
+	Origin       string `json:"origin"`
+	Destination  string `json:"destination"`
+	FlightPhase  string `json:"flight_phase"`
+}
+
+// UpdateFlightInfo updates flight number, route and phase of flight of the
+// user's photo. The change is recorded as a revision like any other edit.
+func (s *Service) UpdateFlightInfo(ctx context.Context, photoID, userID int64, req *FlightInfoRequest) error {
+	params, err := normalizeFlightInfo(req.FlightNumber, req.Origin, req.Destination, req.FlightPhase)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return ErrPhotoNotFound
+		}
+		return err
+	}
+	if p.UserID != userID {
+		return ErrNotOwner
+	}
+
+	// Empty fields clear the flight info
+	empty := ""
+	orEmpty := func(value *string) *string {
+		if value == nil {
+			return &empty
+		}
+		return value
+	}
+
+	_, err = s.updateMetadata(ctx, p, &photo.MetadataParams{
+		FlightNumber: orEmpty(params.FlightNumber),
+		Origin:       orEmpty(params.Origin),
+		Destination:  orEmpty(params.Destination),
+		FlightPhase:  orEmpty(params.FlightPhase),
+		ReviewFields: s.reReviewFields,
+	})
+	if errors.Is(err, ErrNoChanges) {
+		return nil
+	}
+	return err
+}
+
+// normalizeFlightInfo validates and normalizes flight fields, empty values become nil
+func normalizeFlightInfo(flightNumber, origin, destination, phase string) (*photo.UpdateFlightInfoParams, error) {
+	params := &photo.UpdateFlightInfoParams{}
+
+	if strings.TrimSpace(flightNumber) != "" {
+		normalized, ok := aviation.NormalizeFlightNumber(flightNumber)
+		if !ok {
+			return nil, ErrInvalidFlightNumber
+		}
+		params.FlightNumber = &normalized
+	}
+
+	if strings.TrimSpace(origin) != "" {
+		normalized, ok := aviation.NormalizeAirportCode(origin)
+		if !ok {
+			return nil, ErrInvalidRouteAirport
+		}
+		params.Origin = &normalized
+	}
+
+	if strings.TrimSpace(destination) != "" {
+		normalized, ok := aviation.NormalizeAirportCode(destination)
+		if !ok {
+			return nil, ErrInvalidRouteAirport
+		}
+		params.Destination = &normalized
+	}
+
+	if strings.TrimSpace(phase) != "" {
+		normalized := strings.ToLower(strings.TrimSpace(phase))
+		if !model.FlightPhase(normalized).IsValid() {
+			return nil, ErrInvalidFlightPhase
+		}
+		params.FlightPhase = &normalized
+	}
+
+	return params, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
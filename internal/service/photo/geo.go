@@ -0,0 +1,190 @@
+package photo
+
+import (
+	"context"
+	"errors"
+
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+var (
+	ErrInvalidBoundingBox = errors.New("invalid bounding box")
+	ErrInvalidRadius      = errors.New("invalid radius search")
+)
+
+// maxSearchRadiusKm limits radius searches to a sensible area
+const maxSearchRadiusKm = 500
+
+// GeoSearchRequest represents request for searching photos by location.
+// Either a full bounding box (min_lat, max_lat, min_lng, max_lng) or a
+// center point with radius (lat, lng, radius_km) must be supplied.
+type GeoSearchRequest struct {
+	Page     int      `form:"page"`
+	PageSize int      `form:"page_size"`
+	MinLat   *float64 `form:"min_lat"`
+	MaxLat   *float64 `form:"max_lat"`
+	MinLng   *float64 `form:"min_lng"`
+	MaxLng   *float64 `form:"max_lng"`
+	Lat      *float64 `form:"lat"`
+	Lng      *float64 `form:"lng"`
+	RadiusKm float64  `form:"radius_km"`
+}
+
+// MapClustersRequest represents request for map cluster markers
+type MapClustersRequest struct {
+	Zoom   int      `form:"zoom"`
+	MinLat *float64 `form:"min_lat"`
+	MaxLat *float64 `form:"max_lat"`
+	MinLng *float64 `form:"min_lng"`
+	MaxLng *float64 `form:"max_lng"`
+	Limit  int      `form:"limit"`
+}
+
+// MapClusterItem represents a clustered marker on the map
+type MapClusterItem struct {
+	Geohash      string  `json:"geohash"`
+	Count        int64   `json:"count"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	PhotoID      int64   `json:"photo_id"`
+	ThumbnailURL string  `json:"thumbnail_url,omitempty"`
+}
+
+// MapClustersResponse represents response for map clusters
+type MapClustersResponse struct {
+	Zoom      int              `json:"zoom"`
+	Precision int              `json:"precision"`
+	Clusters  []MapClusterItem `json:"clusters"`
+}
+
+// SearchByLocation searches approved photos by bounding box or radius
+func (s *Service) SearchByLocation(ctx context.Context, req *GeoSearchRequest) (*ListResponse, error) {
+	box, err := parseBoundingBox(req.MinLat, req.MaxLat, req.MinLng, req.MaxLng)
+	if err != nil {
+		return nil, err
+	}
+
+	params := photo.GeoSearchParams{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Box:      box,
+	}
+
+	if req.Lat != nil || req.Lng != nil {
+		if req.Lat == nil || req.Lng == nil || !validLatitude(*req.Lat) || !validLongitude(*req.Lng) {
+			return nil, ErrInvalidRadius
+		}
+		if req.RadiusKm <= 0 || req.RadiusKm > maxSearchRadiusKm {
+			return nil, ErrInvalidRadius
+		}
+		params.CenterLat = req.Lat
+		params.CenterLng = req.Lng
+		params.RadiusKm = req.RadiusKm
+	}
+
+	if params.Box == nil && params.CenterLat == nil {
+		return nil, ErrInvalidBoundingBox
+	}
+
+	result, err := s.photoRepo.SearchByLocation(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildListResponse(ctx, result)
+}
+
+// ListMapClusters returns clustered markers for the given zoom level
+func (s *Service) ListMapClusters(ctx context.Context, req *MapClustersRequest) (*MapClustersResponse, error) {
+	box, err := parseBoundingBox(req.MinLat, req.MaxLat, req.MinLng, req.MaxLng)
+	if err != nil {
+		return nil, err
+	}
+
+	precision := GeohashPrecisionForZoom(req.Zoom)
+	clusters, err := s.photoRepo.ListMapClusters(ctx, photo.MapClusterParams{
+		Precision: precision,
+		Box:       box,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]MapClusterItem, len(clusters))
+	for i, c := range clusters {
+		item := MapClusterItem{
+			Geohash:   c.Geohash,
+			Count:     c.Count,
+			Latitude:  c.Latitude,
+			Longitude: c.Longitude,
+			PhotoID:   c.PhotoID,
+		}
+		if c.ThumbnailPath.Valid {
+			item.ThumbnailURL = s.baseURL + c.ThumbnailPath.String
+		}
+		items[i] = item
+	}
+
+	return &MapClustersResponse{
+		Zoom:      req.Zoom,
+		Precision: precision,
+		Clusters:  items,
+	}, nil
+}
+
+// GeohashPrecisionForZoom maps a web map zoom level (0-20) to the geohash
+// prefix length used as cluster bucket, so a cluster roughly matches a few
+// screen tiles at that zoom.
+func GeohashPrecisionForZoom(zoom int) int {
+	switch {
+	case zoom <= 2:
+		return 1
+	case zoom <= 4:
+		return 2
+	case zoom <= 7:
+		return 3
+	case zoom <= 9:
+		return 4
+	case zoom <= 12:
+		return 5
+	case zoom <= 14:
+		return 6
+	case zoom <= 16:
+		return 7
+	default:
+		return 8
+	}
+}
+
+// parseBoundingBox validates an optional bounding box.
+// A nil box is returned when no coordinate is provided at all.
+func parseBoundingBox(minLat, maxLat, minLng, maxLng *float64) (*photo.BoundingBox, error) {
+	if minLat == nil && maxLat == nil && minLng == nil && maxLng == nil {
+		return nil, nil
+	}
+	if minLat == nil || maxLat == nil || minLng == nil || maxLng == nil {
+		return nil, ErrInvalidBoundingBox
+	}
+	if !validLatitude(*minLat) || !validLatitude(*maxLat) || *minLat > *maxLat {
+		return nil, ErrInvalidBoundingBox
+	}
+	if !validLongitude(*minLng) || !validLongitude(*maxLng) {
+		return nil, ErrInvalidBoundingBox
+	}
+
+	return &photo.BoundingBox{
+		MinLat: *minLat,
+		MaxLat: *maxLat,
+		MinLng: *minLng,
+		MaxLng: *maxLng,
+	}, nil
+}
+
+func validLatitude(lat float64) bool {
+	return lat >= -90 && lat <= 90
+}
+
+func validLongitude(lng float64) bool {
+	return lng >= -180 && lng <= 180
+}
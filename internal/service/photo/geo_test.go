@@ -0,0 +1,149 @@
+package photo
+
+import (
+	"errors"
+	"testing"
+
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+func TestGeohashPrecisionForZoom(t *testing.T) {
+	tests := []struct {
+		zoom     int
+		expected int
+	}{
+		{-1, 1},
+		{0, 1},
+		{2, 1},
+		{3, 2},
+		{4, 2},
+		{5, 3},
+		{7, 3},
+		{8, 4},
+		{9, 4},
+		{10, 5},
+		{12, 5},
+		{13, 6},
+		{14, 6},
+		{15, 7},
+		{16, 7},
+		{17, 8},
+		{20, 8},
+		{25, 8},
+	}
+
+	for _, tt := range tests {
+		if got := GeohashPrecisionForZoom(tt.zoom); got != tt.expected {
+			t.Errorf("GeohashPrecisionForZoom(%d) = %d, expected %d", tt.zoom, got, tt.expected)
+		}
+	}
+
+	// Zooming in never coarsens the clusters
+	for zoom := 1; zoom <= 20; zoom++ {
+		if GeohashPrecisionForZoom(zoom) < GeohashPrecisionForZoom(zoom-1) {
+			t.Errorf("precision decreases from zoom %d to %d", zoom-1, zoom)
+		}
+	}
+}
+
+func TestParseBoundingBox(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name      string
+		minLat    *float64
+		maxLat    *float64
+		minLng    *float64
+		maxLng    *float64
+		expectBox *photo.BoundingBox
+		expectErr error
+	}{
+		{
+			name: "No box",
+		},
+		{
+			name:      "Full box",
+			minLat:    f(39.7),
+			maxLat:    f(40.2),
+			minLng:    f(116.1),
+			maxLng:    f(116.8),
+			expectBox: &photo.BoundingBox{MinLat: 39.7, MaxLat: 40.2, MinLng: 116.1, MaxLng: 116.8},
+		},
+		{
+			name:      "Box crossing the antimeridian keeps its longitudes",
+			minLat:    f(-20),
+			maxLat:    f(-10),
+			minLng:    f(170),
+			maxLng:    f(-170),
+			expectBox: &photo.BoundingBox{MinLat: -20, MaxLat: -10, MinLng: 170, MaxLng: -170},
+		},
+		{
+			name:      "Whole world",
+			minLat:    f(-90),
+			maxLat:    f(90),
+			minLng:    f(-180),
+			maxLng:    f(180),
+			expectBox: &photo.BoundingBox{MinLat: -90, MaxLat: 90, MinLng: -180, MaxLng: 180},
+		},
+		{
+			name:      "Single point",
+			minLat:    f(31.14),
+			maxLat:    f(31.14),
+			minLng:    f(121.8),
+			maxLng:    f(121.8),
+			expectBox: &photo.BoundingBox{MinLat: 31.14, MaxLat: 31.14, MinLng: 121.8, MaxLng: 121.8},
+		},
+		{
+			name:      "Missing longitude bound",
+			minLat:    f(39.7),
+			maxLat:    f(40.2),
+			minLng:    f(116.1),
+			expectErr: ErrInvalidBoundingBox,
+		},
+		{
+			name:      "Latitudes only",
+			minLat:    f(39.7),
+			maxLat:    f(40.2),
+			expectErr: ErrInvalidBoundingBox,
+		},
+		{
+			name:      "Inverted latitudes",
+			minLat:    f(40.2),
+			maxLat:    f(39.7),
+			minLng:    f(116.1),
+			maxLng:    f(116.8),
+			expectErr: ErrInvalidBoundingBox,
+		},
+		{
+			name:      "Latitude out of range",
+			minLat:    f(-91),
+			maxLat:    f(10),
+			minLng:    f(0),
+			maxLng:    f(10),
+			expectErr: ErrInvalidBoundingBox,
+		},
+		{
+			name:      "Longitude out of range",
+			minLat:    f(0),
+			maxLat:    f(10),
+			minLng:    f(170),
+			maxLng:    f(190),
+			expectErr: ErrInvalidBoundingBox,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box, err := parseBoundingBox(tt.minLat, tt.maxLat, tt.minLng, tt.maxLng)
+			if !errors.Is(err, tt.expectErr) || (tt.expectErr == nil && err != nil) {
+				t.Fatalf("parseBoundingBox() error = %v, expected %v", err, tt.expectErr)
+			}
+			switch {
+			case tt.expectBox == nil && box != nil:
+				t.Errorf("parseBoundingBox() = %+v, expected nil", box)
+			case tt.expectBox != nil && (box == nil || *box != *tt.expectBox):
+				t.Errorf("parseBoundingBox() = %+v, expected %+v", box, tt.expectBox)
+			}
+		})
+	}
+}
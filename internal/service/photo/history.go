@@ -0,0 +1,122 @@
+package photo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// ReviewHistoryItem represents a review record as shown to the uploader.
+// Reviewer identities, review stages and raw AI results are not included.
+type ReviewHistoryItem struct {
+	Attempt   int                           `json:"attempt"`
+	Source    string                        `json:"source"` // ai, screening, reviewer, trust
+	Action    string                        `json:"action"` // approve, reject, delete
+	Reasons   []*model.RejectionReasonBrief `json:"reasons,omitempty"`
+	Note      *string                       `json:"note,omitempty"`
+	CreatedAt string                        `json:"created_at"`
+}
+
+// ReviewHistoryResponse represents the review history of the uploader's photo
+type ReviewHistoryResponse struct {
+	PhotoID   int64               `json:"photo_id"`
+	Title     string              `json:"title"`
+	Status    string              `json:"status"`
+	Attempt   int                 `json:"attempt"`
+	Deleted   bool                `json:"deleted"`
+	DeletedAt *string             `json:"deleted_at,omitempty"`
+	Reviews   []ReviewHistoryItem `json:"reviews"`
+}
+
+// GetReviewHistory retrieves the redacted review history of one of the user's
+// photos. The history stays available after the photo is deleted.
+func (s *Service) GetReviewHistory(ctx context.Context, photoID, userID int64) (*ReviewHistoryResponse, error) {
+	resp := &ReviewHistoryResponse{PhotoID: photoID}
+
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	switch {
+	case err == nil:
+		if p.UserID != userID {
+			return nil, ErrNotOwner
+		}
+		resp.Title = p.Title
+		resp.Status = string(p.Status)
+		resp.Attempt = p.Attempt
+	case errors.Is(err, postgresql.ErrNotFound):
+		d, err := s.photoRepo.GetDeletedPhoto(ctx, photoID)
+		if err != nil {
+			if errors.Is(err, postgresql.ErrNotFound) {
+				return nil, ErrPhotoNotFound
+			}
+			return nil, err
+		}
+		if !d.UserID.Valid || d.UserID.Int64 != userID {
+			return nil, ErrNotOwner
+		}
+		deletedAt := d.DeletedAt.Format(time.RFC3339)
+		resp.Title = d.Title
+		resp.Status = string(d.Status)
+		resp.Attempt = d.Attempt
+		resp.Deleted = true
+		resp.DeletedAt = &deletedAt
+	default:
+		return nil, err
+	}
+
+	reviews, err := s.photoRepo.ListReviewHistory(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewIDs := make([]int64, len(reviews))
+	for i, r := range reviews {
+		reviewIDs[i] = r.ID
+	}
+	reasons, err := s.photoRepo.GetReviewReasonMap(ctx, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Reviews = make([]ReviewHistoryItem, 0, len(reviews))
+	for _, r := range reviews {
+		// Escalations and screening flags only route the review queue
+		if r.Action == "escalate" || r.Action == "flag" {
+			continue
+		}
+		item := ReviewHistoryItem{
+			Attempt:   r.Attempt,
+			Source:    reviewSource(r),
+			Action:    r.Action,
+			Reasons:   reasons[r.ID],
+			CreatedAt: r.CreatedAt.Format(time.RFC3339),
+		}
+		if r.Reason.Valid {
+			item.Note = &r.Reason.String
+		}
+		resp.Reviews = append(resp.Reviews, item)
+	}
+
+	return resp, nil
+}
+
+// reviewSource tells the uploader who made a review
+func reviewSource(r *model.PhotoReview) string {
+	switch r.ReviewType {
+	case "manual":
+		return "reviewer"
+	case "trust":
+		return "trust"
+	}
+
+	var result struct {
+		Source string `json:"source"`
+	}
+	if json.Unmarshal(r.AIResult, &result) == nil && result.Source == model.ScreeningSource {
+		return "screening"
+	}
+	return "ai"
+}
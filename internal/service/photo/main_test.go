@@ -0,0 +1,15 @@
+package photo
+
+import (
+	"os"
+	"testing"
+
+	"QuanPhotos/internal/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	if err := logger.Init(logger.Config{Level: "error"}); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
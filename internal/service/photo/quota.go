@@ -0,0 +1,102 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"os"
+
+	"go.uber.org/zap"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/logger"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+var (
+	// ErrQuotaExceeded is returned when an upload does not fit the uploader's storage quota
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+	// ErrQuotaUnavailable is returned when the uploader's storage quota cannot be checked
+	ErrQuotaUnavailable = errors.New("storage quota check unavailable")
+)
+
+// QuotaPolicy decides whether new files fit a user's storage quota. It
+// returns the quota in bytes, 0 when unlimited, which is enforced again when
+// the files are saved.
+type QuotaPolicy interface {
+	AllowsUpload(ctx context.Context, userID, size int64) (quotaBytes int64, allowed bool, err error)
+}
+
+// SetQuotaPolicy enables storage quota checks of uploads
+func (s *Service) SetQuotaPolicy(policy QuotaPolicy) {
+	s.quotaPolicy = policy
+}
+
+// checkQuota checks that the uploaded files fit the user's storage quota,
+// freed is the size of stored files the upload replaces. The uploaded sizes
+// stand in for the processed image and its thumbnails, the repository checks
+// the stored sizes again under a lock when saving. Returns the quota in
+// bytes to enforce there, 0 for unlimited. Uploads are refused when the quota
+// cannot be checked.
+func (s *Service) checkQuota(ctx context.Context, userID, freed int64, files ...*multipart.FileHeader) (int64, error) {
+	if s.quotaPolicy == nil {
+		return 0, nil
+	}
+
+	size := -freed
+	for _, file := range files {
+		if file != nil {
+			size += file.Size
+		}
+	}
+
+	quotaBytes, allowed, err := s.quotaPolicy.AllowsUpload(ctx, userID, size)
+	if err != nil {
+		logger.Warn("Failed to check storage quota, upload refused",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+		return 0, fmt.Errorf("%w: %v", ErrQuotaUnavailable, err)
+	}
+	if !allowed {
+		return 0, ErrQuotaExceeded
+	}
+	return quotaBytes, nil
+}
+
+// quotaError maps a repository quota refusal to ErrQuotaExceeded
+func quotaError(err error) error {
+	if errors.Is(err, postgresql.ErrQuotaExceeded) {
+		return ErrQuotaExceeded
+	}
+	return err
+}
+
+// MeasureStoredFiles returns the sizes of a photo's stored image, RAW file and
+// thumbnails on disk. Missing files count as empty.
+func (s *Service) MeasureStoredFiles(_ context.Context, p *model.Photo) (fileSize, rawSize, derivativeSize int64) {
+	if s.uploader == nil {
+		return 0, 0, 0
+	}
+
+	fileSize = s.uploader.storedSize(p.FilePath)
+	if p.RawFilePath.Valid {
+		rawSize = s.uploader.storedSize(p.RawFilePath.String)
+	}
+	if p.ThumbnailPath.Valid {
+		for _, size := range s.uploader.thumbnails {
+			derivativeSize += s.uploader.storedSize(fmt.Sprintf("%s_%s.jpg", p.ThumbnailPath.String, size.Name))
+		}
+	}
+	return fileSize, rawSize, derivativeSize
+}
+
+// storedSize returns the size of a stored file, 0 when it does not exist
+func (u *Uploader) storedSize(path string) int64 {
+	info, err := os.Stat(u.storage.GetAbsolutePath(path))
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
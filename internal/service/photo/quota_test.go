@@ -0,0 +1,88 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"testing"
+)
+
+type fakeQuotaPolicy struct {
+	quotaBytes int64
+	allowed    bool
+	err        error
+	size       int64
+}
+
+func (p *fakeQuotaPolicy) AllowsUpload(_ context.Context, _, size int64) (int64, bool, error) {
+	p.size = size
+	return p.quotaBytes, p.allowed, p.err
+}
+
+func TestCheckQuota(t *testing.T) {
+	files := []*multipart.FileHeader{{Size: 300}, nil, {Size: 200}}
+
+	tests := []struct {
+		name        string
+		policy      *fakeQuotaPolicy
+		freed       int64
+		expectSize  int64
+		expectQuota int64
+		expectErr   error
+	}{
+		{
+			name:        "Upload within quota",
+			policy:      &fakeQuotaPolicy{quotaBytes: 1000, allowed: true},
+			expectSize:  500,
+			expectQuota: 1000,
+		},
+		{
+			name:       "Unlimited quota",
+			policy:     &fakeQuotaPolicy{allowed: true},
+			expectSize: 500,
+		},
+		{
+			name:       "Freed size is subtracted",
+			policy:     &fakeQuotaPolicy{allowed: true},
+			freed:      450,
+			expectSize: 50,
+		},
+		{
+			name:       "Upload over quota",
+			policy:     &fakeQuotaPolicy{allowed: false},
+			expectSize: 500,
+			expectErr:  ErrQuotaExceeded,
+		},
+		{
+			name:       "Failed check refuses the upload",
+			policy:     &fakeQuotaPolicy{allowed: true, err: errors.New("connection refused")},
+			expectSize: 500,
+			expectErr:  ErrQuotaUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			s.SetQuotaPolicy(tt.policy)
+
+			quotaBytes, err := s.checkQuota(context.Background(), 1, tt.freed, files...)
+			if !errors.Is(err, tt.expectErr) || (tt.expectErr == nil && err != nil) {
+				t.Errorf("checkQuota() error = %v, expected %v", err, tt.expectErr)
+			}
+			if quotaBytes != tt.expectQuota {
+				t.Errorf("checkQuota() = %d, expected %d", quotaBytes, tt.expectQuota)
+			}
+			if tt.policy.size != tt.expectSize {
+				t.Errorf("checked size = %d, expected %d", tt.policy.size, tt.expectSize)
+			}
+		})
+	}
+}
+
+func TestCheckQuotaWithoutPolicy(t *testing.T) {
+	s := &Service{}
+	if quotaBytes, err := s.checkQuota(context.Background(), 1, 0, &multipart.FileHeader{Size: 1 << 40}); quotaBytes != 0 || err != nil {
+		t.Errorf("checkQuota() = %d, %v, expected unlimited", quotaBytes, err)
+	}
+}
@@ -0,0 +1,241 @@
+package photo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"time"
+
+	"go.uber.org/zap"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/logger"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// ReplacementItem represents a replacement image of a photo
+type ReplacementItem struct {
+	ID           int64                   `json:"id"`
+	PhotoID      int64                   `json:"photo_id"`
+	Status       string                  `json:"status"` // pending, approved, rejected, superseded
+	ImageURL     string                  `json:"image_url,omitempty"`
+	ThumbnailURL string                  `json:"thumbnail_url,omitempty"`
+	Source       string                  `json:"source,omitempty"` // reviewer, trust, screening
+	Reason       *string                 `json:"reason,omitempty"`
+	Screening    *model.QualityScreening `json:"screening,omitempty"`
+	CreatedAt    string                  `json:"created_at"`
+	DecidedAt    *string                 `json:"decided_at,omitempty"`
+}
+
+// RemoveStoredFiles removes the stored image, thumbnails and RAW file of a
+// photo or of a replacement that is no longer served
+func (s *Service) RemoveStoredFiles(ctx context.Context, p *model.Photo) {
+	if s.uploader != nil {
+		s.uploader.removeStoredFiles(ctx, p)
+	}
+}
+
+// ReplaceFile uploads a new image for one of the user's approved photos. The
+// image goes through the full upload pipeline and is screened, but the photo
+// keeps serving its current files until the replacement is approved. Trusted
+// users' replacements are approved right away.
+func (s *Service) ReplaceFile(ctx context.Context, photoID, userID int64, file, rawFile *multipart.FileHeader) (*ReplacementItem, error) {
+	if s.uploader == nil {
+		return nil, errors.New("uploader not initialized")
+	}
+
+	p, err := s.submissions.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if p.UserID != userID {
+		return nil, ErrNotOwner
+	}
+	if p.Status != model.PhotoStatusApproved {
+		return nil, ErrNotApproved
+	}
+
+	// The current files stay stored until the replacement is approved, a
+	// pending replacement is superseded and its files removed
+	pending, err := s.submissions.PendingReplacementSize(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+	quotaBytes, err := s.checkQuota(ctx, userID, pending, file, rawFile)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, err := s.uploader.processFile(ctx, file, rawFile)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &photo.CreateReplacementParams{
+		PhotoID:    photoID,
+		UserID:     userID,
+		File:       processed.params,
+		QuotaBytes: quotaBytes,
+	}
+	if processed.result.Quality != nil {
+		params.Screening = screenQuality(processed.result.Quality, int(processed.exif.ISO), s.uploader.config.Quality)
+		params.Rejected = params.Screening.Verdict == model.ScreeningReject
+		if params.Rejected {
+			params.Reason = screeningReason(params.Screening)
+		}
+	}
+
+	replacement, superseded, err := s.submissions.CreateReplacement(ctx, params)
+	if err != nil {
+		s.uploader.cleanupProcessedFiles(processed.result)
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrNotApproved
+		}
+		return nil, quotaError(err)
+	}
+
+	// Superseded replacements were never served
+	for _, r := range superseded {
+		s.uploader.removeStoredFiles(ctx, r.StoredFiles())
+	}
+	if params.Rejected {
+		s.uploader.removeStoredFiles(ctx, replacement.StoredFiles())
+		return s.toReplacementItem(replacement), nil
+	}
+
+	flagged := params.Screening != nil && params.Screening.Verdict == model.ScreeningFlag
+	if approved := s.autoApproveReplacement(ctx, userID, replacement, flagged); approved != nil {
+		replacement = approved
+	}
+
+	return s.toReplacementItem(replacement), nil
+}
+
+// autoApproveReplacement approves a replacement when the uploader's trust tier
+// auto approves uploads, with the same spot check sampling. Returns nil and
+// leaves the replacement pending otherwise, including on failure.
+func (s *Service) autoApproveReplacement(ctx context.Context, userID int64, replacement *model.PhotoReplacement, flagged bool) *model.PhotoReplacement {
+	if s.trustPolicy == nil {
+		return nil
+	}
+
+	decision, err := s.trustPolicy.UploadDecision(ctx, userID)
+	if err != nil {
+		logger.Warn("Failed to evaluate uploader trust, replacement left for review",
+			zap.Int64("replacement_id", replacement.ID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if !decision.AutoApprove {
+		return nil
+	}
+
+	reason := "trust tier: " + decision.Tier
+	approved, err := s.submissions.DecideReplacement(ctx, &photo.DecideReplacementParams{
+		ReplacementID: replacement.ID,
+		Approve:       true,
+		ReviewType:    "trust",
+		Reason:        &reason,
+		SpotCheck:     decision.SpotCheck || flagged,
+	})
+	if err != nil {
+		if !errors.Is(err, postgresql.ErrNotFound) {
+			logger.Warn("Failed to auto approve replacement, replacement left for review",
+				zap.Int64("replacement_id", replacement.ID),
+				zap.Error(err),
+			)
+		}
+		return nil
+	}
+
+	s.uploader.removeStoredFiles(ctx, approved.PreviousFiles())
+	return approved
+}
+
+// ListReplacements retrieves the replacement history of one of the user's
+// photos, newest first
+func (s *Service) ListReplacements(ctx context.Context, photoID, userID int64, page, pageSize int) (*ListReplacementsResponse, error) {
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if p.UserID != userID {
+		return nil, ErrNotOwner
+	}
+
+	result, err := s.photoRepo.ListReplacements(ctx, photo.ReplacementListParams{
+		Page:     page,
+		PageSize: pageSize,
+		PhotoID:  photoID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*ReplacementItem, len(result.Replacements))
+	for i, r := range result.Replacements {
+		list[i] = s.toReplacementItem(r)
+	}
+
+	return &ListReplacementsResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// ListReplacementsResponse represents response for listing replacements
+type ListReplacementsResponse struct {
+	List       []*ReplacementItem `json:"list"`
+	Pagination Pagination         `json:"pagination"`
+}
+
+// toReplacementItem converts a replacement for its uploader. Reviewer
+// identities are not included, images are only linked while awaiting review.
+func (s *Service) toReplacementItem(r *model.PhotoReplacement) *ReplacementItem {
+	item := &ReplacementItem{
+		ID:        r.ID,
+		PhotoID:   r.PhotoID,
+		Status:    string(r.Status),
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.Status == model.ReplacementStatusPending {
+		item.ImageURL = s.baseURL + r.FilePath
+		if r.ThumbnailPath.Valid {
+			item.ThumbnailURL = s.baseURL + r.ThumbnailPath.String
+		}
+	}
+	switch r.ReviewType.String {
+	case "manual":
+		item.Source = "reviewer"
+	case "trust", "screening":
+		item.Source = r.ReviewType.String
+	}
+	if r.Reason.Valid && r.ReviewType.String != "trust" {
+		item.Reason = &r.Reason.String
+	}
+	if len(r.Screening) > 0 {
+		var screening model.QualityScreening
+		if json.Unmarshal(r.Screening, &screening) == nil {
+			item.Screening = &screening
+		}
+	}
+	if r.DecidedAt.Valid {
+		decidedAt := r.DecidedAt.Time.Format(time.RFC3339)
+		item.DecidedAt = &decidedAt
+	}
+	return item
+}
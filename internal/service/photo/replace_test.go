@@ -0,0 +1,281 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+	"QuanPhotos/internal/service/trust"
+)
+
+func (f *fakeSubmissionStore) CreateReplacement(_ context.Context, params *photo.CreateReplacementParams) (*model.PhotoReplacement, []*model.PhotoReplacement, error) {
+	f.replacements = append(f.replacements, params)
+	if f.replaceErr != nil {
+		return nil, nil, f.replaceErr
+	}
+
+	status := model.ReplacementStatusPending
+	if params.Rejected {
+		status = model.ReplacementStatusRejected
+	}
+	return &model.PhotoReplacement{
+		ID:            int64(len(f.replacements)),
+		PhotoID:       params.PhotoID,
+		UserID:        sql.NullInt64{Int64: params.UserID, Valid: true},
+		Status:        status,
+		FilePath:      params.File.FilePath,
+		ThumbnailPath: sql.NullString{String: *params.File.ThumbnailPath, Valid: true},
+	}, f.superseded, nil
+}
+
+func (f *fakeSubmissionStore) PendingReplacementSize(_ context.Context, _ int64) (int64, error) {
+	return f.pendingSize, nil
+}
+
+func (f *fakeSubmissionStore) DecideReplacement(_ context.Context, params *photo.DecideReplacementParams) (*model.PhotoReplacement, error) {
+	f.decisions = append(f.decisions, params)
+
+	created := f.replacements[params.ReplacementID-1]
+	current := f.photos[created.PhotoID]
+	return &model.PhotoReplacement{
+		ID:                    params.ReplacementID,
+		PhotoID:               created.PhotoID,
+		Status:                model.ReplacementStatusApproved,
+		FilePath:              created.File.FilePath,
+		PreviousFilePath:      sql.NullString{String: current.FilePath, Valid: true},
+		PreviousThumbnailPath: current.ThumbnailPath,
+		ReviewType:            sql.NullString{String: params.ReviewType, Valid: true},
+	}, nil
+}
+
+type fakeTrustPolicy struct {
+	decision *trust.UploadDecision
+	err      error
+}
+
+func (p *fakeTrustPolicy) UploadDecision(_ context.Context, _ int64) (*trust.UploadDecision, error) {
+	return p.decision, p.err
+}
+
+func TestReplaceFileRefused(t *testing.T) {
+	const ownerID = 1
+
+	tests := []struct {
+		name       string
+		status     model.PhotoStatus
+		photoID    int64
+		userID     int64
+		replaceErr error
+		expectErr  error
+	}{
+		{"Other users may not replace", model.PhotoStatusApproved, 1, 2, nil, ErrNotOwner},
+		{"Pending photo", model.PhotoStatusPending, 1, ownerID, nil, ErrNotApproved},
+		{"Rejected photo", model.PhotoStatusRejected, 1, ownerID, nil, ErrNotApproved},
+		{"Missing photo", model.PhotoStatusApproved, 99, ownerID, nil, ErrPhotoNotFound},
+		{"Photo no longer approved when saved", model.PhotoStatusApproved, 1, ownerID, postgresql.ErrNotFound, ErrNotApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestUploader(t)
+			stored := storeTestPhoto(t, u, 1, ownerID, tt.status)
+			store := &fakeSubmissionStore{
+				photos:     map[int64]*model.Photo{1: stored},
+				replaceErr: tt.replaceErr,
+			}
+			s := &Service{submissions: store, uploader: u}
+
+			_, err := s.ReplaceFile(context.Background(), tt.photoID, tt.userID, testJPEG(t), nil)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("ReplaceFile() error = %v, expected %v", err, tt.expectErr)
+			}
+			if !fileExists(u, stored.FilePath) {
+				t.Error("current image removed")
+			}
+
+			// Uploaded files are removed when the replacement is not recorded
+			for _, params := range store.replacements {
+				if fileExists(u, params.File.FilePath) {
+					t.Error("uploaded image kept")
+				}
+			}
+			if tt.replaceErr == nil && len(store.replacements) > 0 {
+				t.Errorf("replacements recorded = %d, expected none", len(store.replacements))
+			}
+		})
+	}
+}
+
+func TestReplaceFile(t *testing.T) {
+	const ownerID = 1
+
+	tests := []struct {
+		name          string
+		trust         *fakeTrustPolicy
+		expectStatus  model.ReplacementStatus
+		expectCurrent bool // Files of the current image are kept
+	}{
+		{
+			name:          "Replacement waits for review",
+			expectStatus:  model.ReplacementStatusPending,
+			expectCurrent: true,
+		},
+		{
+			name:          "Untrusted uploader",
+			trust:         &fakeTrustPolicy{decision: &trust.UploadDecision{Tier: "new"}},
+			expectStatus:  model.ReplacementStatusPending,
+			expectCurrent: true,
+		},
+		{
+			name:          "Failed trust evaluation leaves the replacement for review",
+			trust:         &fakeTrustPolicy{err: errors.New("connection refused")},
+			expectStatus:  model.ReplacementStatusPending,
+			expectCurrent: true,
+		},
+		{
+			name:         "Trusted uploader's replacement frees the current files",
+			trust:        &fakeTrustPolicy{decision: &trust.UploadDecision{Tier: "trusted", AutoApprove: true}},
+			expectStatus: model.ReplacementStatusApproved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestUploader(t)
+			stored := storeTestPhoto(t, u, 1, ownerID, model.PhotoStatusApproved)
+			store := &fakeSubmissionStore{photos: map[int64]*model.Photo{1: stored}}
+			policy := &fakeQuotaPolicy{allowed: true}
+			s := &Service{submissions: store, uploader: u}
+			s.SetQuotaPolicy(policy)
+			if tt.trust != nil {
+				s.SetTrustPolicy(tt.trust)
+			}
+
+			file := testJPEG(t)
+			item, err := s.ReplaceFile(context.Background(), 1, ownerID, file, nil)
+			if err != nil {
+				t.Fatalf("ReplaceFile() error = %v", err)
+			}
+			if item.Status != string(tt.expectStatus) {
+				t.Errorf("ReplaceFile() status = %s, expected %s", item.Status, tt.expectStatus)
+			}
+
+			// The current files stay stored until approval, so nothing is freed
+			if policy.size != file.Size {
+				t.Errorf("checked size = %d, expected %d", policy.size, file.Size)
+			}
+
+			// The replacement is recorded in the photo's history
+			if len(store.replacements) != 1 {
+				t.Fatalf("replacements recorded = %d, expected 1", len(store.replacements))
+			}
+			recorded := store.replacements[0]
+			if recorded.PhotoID != 1 || recorded.UserID != ownerID {
+				t.Errorf("replacement recorded for photo %d by user %d, expected photo 1 by user %d", recorded.PhotoID, recorded.UserID, ownerID)
+			}
+			if !fileExists(u, recorded.File.FilePath) {
+				t.Error("uploaded image removed")
+			}
+
+			if kept := fileExists(u, stored.FilePath); kept != tt.expectCurrent {
+				t.Errorf("current image kept = %v, expected %v", kept, tt.expectCurrent)
+			}
+			if kept := fileExists(u, stored.ThumbnailPath.String+"_md.jpg"); kept != tt.expectCurrent {
+				t.Errorf("current thumbnail kept = %v, expected %v", kept, tt.expectCurrent)
+			}
+			if !tt.expectCurrent && (len(store.decisions) != 1 || store.decisions[0].ReviewType != "trust") {
+				t.Errorf("decisions = %+v, expected one trust approval", store.decisions)
+			}
+		})
+	}
+}
+
+func TestReplaceFileQuotaExceeded(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowed        bool
+		replaceErr     error
+		expectRecorded int
+	}{
+		{
+			name:    "Refused before processing",
+			allowed: false,
+		},
+		{
+			name:           "Refused when saving",
+			allowed:        true,
+			replaceErr:     postgresql.ErrQuotaExceeded,
+			expectRecorded: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestUploader(t)
+			stored := storeTestPhoto(t, u, 1, 1, model.PhotoStatusApproved)
+			store := &fakeSubmissionStore{photos: map[int64]*model.Photo{1: stored}, replaceErr: tt.replaceErr}
+			s := &Service{submissions: store, uploader: u}
+			s.SetQuotaPolicy(&fakeQuotaPolicy{allowed: tt.allowed})
+
+			if _, err := s.ReplaceFile(context.Background(), 1, 1, testJPEG(t), nil); !errors.Is(err, ErrQuotaExceeded) {
+				t.Fatalf("ReplaceFile() error = %v, expected ErrQuotaExceeded", err)
+			}
+			if len(store.replacements) != tt.expectRecorded {
+				t.Fatalf("replacements recorded = %d, expected %d", len(store.replacements), tt.expectRecorded)
+			}
+			for _, r := range store.replacements {
+				if fileExists(u, r.File.FilePath) {
+					t.Error("uploaded image kept")
+				}
+			}
+		})
+	}
+}
+
+func TestReplaceFileSupersedesPending(t *testing.T) {
+	u := newTestUploader(t)
+	stored := storeTestPhoto(t, u, 1, 1, model.PhotoStatusApproved)
+	pending := storeTestPhoto(t, u, 2, 1, model.PhotoStatusApproved)
+	store := &fakeSubmissionStore{
+		photos: map[int64]*model.Photo{1: stored},
+		superseded: []*model.PhotoReplacement{{
+			ID:            1,
+			PhotoID:       1,
+			Status:        model.ReplacementStatusSuperseded,
+			FilePath:      pending.FilePath,
+			ThumbnailPath: pending.ThumbnailPath,
+		}},
+		pendingSize: 300,
+	}
+	policy := &fakeQuotaPolicy{quotaBytes: 1 << 30, allowed: true}
+	s := &Service{submissions: store, uploader: u}
+	s.SetQuotaPolicy(policy)
+
+	file := testJPEG(t)
+	if _, err := s.ReplaceFile(context.Background(), 1, 1, file, nil); err != nil {
+		t.Fatalf("ReplaceFile() error = %v", err)
+	}
+
+	// The superseded files are not counted twice
+	if expected := file.Size - store.pendingSize; policy.size != expected {
+		t.Errorf("checked size = %d, expected %d", policy.size, expected)
+	}
+	if quota := store.replacements[0].QuotaBytes; quota != policy.quotaBytes {
+		t.Errorf("saved with quota %d, expected %d", quota, policy.quotaBytes)
+	}
+
+	// Superseded replacements were never served, their files are freed
+	if fileExists(u, pending.FilePath) {
+		t.Error("superseded image kept")
+	}
+	if !fileExists(u, stored.FilePath) {
+		t.Error("current image removed")
+	}
+	if !fileExists(u, store.replacements[0].File.FilePath) {
+		t.Error("uploaded image removed")
+	}
+}
@@ -0,0 +1,135 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// ResubmitRequest represents the corrections made to a rejected photo.
+// Nil fields are left unchanged, empty values clear optional fields.
+type ResubmitRequest struct {
+	UserID       int64
+	File         *multipart.FileHeader // Optional replacement image
+	RawFile      *multipart.FileHeader // Optional, only used with File
+	Title        *string
+	Description  *string
+	AircraftType *string
+	Airline      *string
+	Registration *string
+	Airport      *string
+	CategoryID   *int32 // 0 clears the category
+	FlightNumber *string
+	Origin       *string
+	Destination  *string
+	FlightPhase  *string
+}
+
+// Resubmit applies corrections to a rejected photo and sends it back to review
+// as a new attempt. A replacement image is screened again like a new upload.
+func (s *Service) Resubmit(ctx context.Context, photoID int64, req *ResubmitRequest) (*UploadResponse, error) {
+	p, err := s.submissions.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if p.UserID != req.UserID {
+		return nil, ErrNotOwner
+	}
+	if p.Status != model.PhotoStatusRejected && p.Status != model.PhotoStatusAIRejected {
+		return nil, ErrNotRejected
+	}
+
+	params := &photo.ResubmitParams{
+		Title:        req.Title,
+		Description:  req.Description,
+		AircraftType: req.AircraftType,
+		Airline:      req.Airline,
+		Registration: req.Registration,
+		Airport:      req.Airport,
+		CategoryID:   req.CategoryID,
+	}
+
+	// Validate and normalize corrected flight info
+	flight, err := normalizeFlightInfo(
+		derefString(req.FlightNumber), derefString(req.Origin),
+		derefString(req.Destination), derefString(req.FlightPhase),
+	)
+	if err != nil {
+		return nil, err
+	}
+	params.FlightNumber = correctedField(req.FlightNumber, flight.FlightNumber)
+	params.Origin = correctedField(req.Origin, flight.Origin)
+	params.Destination = correctedField(req.Destination, flight.Destination)
+	params.FlightPhase = correctedField(req.FlightPhase, flight.FlightPhase)
+
+	// Store the replacement image
+	var file *processedFile
+	if req.File != nil {
+		if s.uploader == nil {
+			return nil, errors.New("uploader not initialized")
+		}
+		// The replaced files are removed once the resubmission is saved
+		params.QuotaBytes, err = s.checkQuota(ctx, req.UserID, p.StoredBytes(), req.File, req.RawFile)
+		if err != nil {
+			return nil, err
+		}
+		file, err = s.uploader.processFile(ctx, req.File, req.RawFile)
+		if err != nil {
+			return nil, err
+		}
+		params.File = &file.params
+	}
+
+	previous, err := s.submissions.Resubmit(ctx, photoID, req.UserID, params)
+	if err != nil {
+		if file != nil {
+			s.uploader.cleanupProcessedFiles(file.result)
+		}
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrNotRejected
+		}
+		return nil, quotaError(err)
+	}
+
+	status := model.PhotoStatusPending
+	if file != nil {
+		s.uploader.removeStoredFiles(ctx, previous)
+		status = s.uploader.recordScreening(ctx, photoID, file)
+	}
+
+	// Photos rejected by quality screening are not worth an AI review
+	if s.aiReviewer != nil && status == model.PhotoStatusPending {
+		s.aiReviewer.Submit(photoID)
+	}
+
+	title := previous.Title
+	if req.Title != nil {
+		title = *req.Title
+	}
+
+	return &UploadResponse{
+		ID:     photoID,
+		Status: string(status),
+		Title:  title,
+	}, nil
+}
+
+// correctedField returns the normalized value of a submitted field, an empty
+// value for a cleared field, or nil when the field was not submitted
+func correctedField(submitted, normalized *string) *string {
+	if submitted == nil {
+		return nil
+	}
+	if normalized == nil {
+		empty := ""
+		return &empty
+	}
+	return normalized
+}
@@ -0,0 +1,353 @@
+package photo
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"QuanPhotos/internal/config"
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/storage"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+type fakeSubmissionStore struct {
+	photos      map[int64]*model.Photo
+	resubmitErr error
+	replaceErr  error
+	superseded  []*model.PhotoReplacement // Pending replacements superseded by a new one
+	pendingSize int64                     // Stored size of the superseded replacements
+
+	resubmits    []*photo.ResubmitParams
+	replacements []*photo.CreateReplacementParams
+	decisions    []*photo.DecideReplacementParams
+}
+
+func (f *fakeSubmissionStore) GetByID(_ context.Context, id int64) (*model.Photo, error) {
+	p, ok := f.photos[id]
+	if !ok {
+		return nil, postgresql.ErrNotFound
+	}
+	stored := *p
+	return &stored, nil
+}
+
+func (f *fakeSubmissionStore) Resubmit(_ context.Context, photoID, _ int64, params *photo.ResubmitParams) (*model.Photo, error) {
+	f.resubmits = append(f.resubmits, params)
+	if f.resubmitErr != nil {
+		return nil, f.resubmitErr
+	}
+	previous := *f.photos[photoID]
+	return &previous, nil
+}
+
+type fakeAIReviewer struct {
+	submitted []int64
+}
+
+func (r *fakeAIReviewer) Submit(photoID int64) {
+	r.submitted = append(r.submitted, photoID)
+}
+
+// newTestUploader returns an uploader storing files under a temporary
+// directory, without quality screening
+func newTestUploader(t *testing.T) *Uploader {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Storage.Path = t.TempDir()
+	cfg.Storage.MaxSize = 10 << 20
+	cfg.Storage.AllowedTypes = []string{"jpg", "jpeg"}
+	cfg.Image = config.ImageConfig{
+		MaxDimension: 256, Quality: 85,
+		ThumbSmWidth: 16, ThumbSmHeight: 16, ThumbSmQuality: 80,
+		ThumbMdWidth: 32, ThumbMdHeight: 32, ThumbMdQuality: 80,
+		ThumbLgWidth: 48, ThumbLgHeight: 48, ThumbLgQuality: 80,
+	}
+
+	localStorage, err := storage.NewLocalStorage(cfg.Storage.Path, "")
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	return NewUploader(localStorage, nil, cfg)
+}
+
+// storeTestPhoto writes a photo's image and thumbnails to the uploader's
+// storage and returns the photo
+func storeTestPhoto(t *testing.T, u *Uploader, id, userID int64, status model.PhotoStatus) *model.Photo {
+	t.Helper()
+
+	p := &model.Photo{
+		ID:             id,
+		UserID:         userID,
+		Title:          "Stored photo",
+		Status:         status,
+		FilePath:       fmt.Sprintf("photos/stored-%d.jpg", id),
+		ThumbnailPath:  sql.NullString{String: fmt.Sprintf("thumbnails/stored-%d", id), Valid: true},
+		FileSize:       sql.NullInt64{Int64: 400, Valid: true},
+		DerivativeSize: sql.NullInt64{Int64: 100, Valid: true},
+	}
+	paths := []string{p.FilePath}
+	for _, size := range u.thumbnails {
+		paths = append(paths, p.ThumbnailPath.String+"_"+size.Name+".jpg")
+	}
+	for _, path := range paths {
+		full := u.storage.GetAbsolutePath(path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte("stored"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return p
+}
+
+// testJPEG returns an uploaded JPEG image
+func testJPEG(t *testing.T) *multipart.FileHeader {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 48; y++ {
+			img.Set(x, y, color.RGBA{uint8(x * 4), uint8(y * 5), 128, 255})
+		}
+	}
+	var data bytes.Buffer
+	if err := jpeg.Encode(&data, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", "upload.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data.Bytes())
+	w.Close()
+
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+	return form.File["file"][0]
+}
+
+// fileExists reports whether a stored file exists
+func fileExists(u *Uploader, path string) bool {
+	_, err := os.Stat(u.storage.GetAbsolutePath(path))
+	return err == nil
+}
+
+func TestResubmit(t *testing.T) {
+	const ownerID = 1
+	title := "Corrected title"
+	flightNumber := "not a flight"
+
+	tests := []struct {
+		name          string
+		status        model.PhotoStatus
+		photoID       int64
+		req           ResubmitRequest
+		resubmitErr   error
+		expectErr     error
+		expectTitle   string
+		expectStored  bool
+		expectAIQueue bool
+	}{
+		{
+			name:          "Rejected photo goes back to review",
+			status:        model.PhotoStatusRejected,
+			req:           ResubmitRequest{UserID: ownerID},
+			expectTitle:   "Stored photo",
+			expectStored:  true,
+			expectAIQueue: true,
+		},
+		{
+			name:          "Photo rejected by AI review goes back to review",
+			status:        model.PhotoStatusAIRejected,
+			req:           ResubmitRequest{UserID: ownerID, Title: &title},
+			expectTitle:   title,
+			expectStored:  true,
+			expectAIQueue: true,
+		},
+		{
+			name:      "Other users may not resubmit",
+			status:    model.PhotoStatusRejected,
+			req:       ResubmitRequest{UserID: 2},
+			expectErr: ErrNotOwner,
+		},
+		{
+			name:      "Pending photo",
+			status:    model.PhotoStatusPending,
+			req:       ResubmitRequest{UserID: ownerID},
+			expectErr: ErrNotRejected,
+		},
+		{
+			name:      "Photo passed by AI review",
+			status:    model.PhotoStatusAIPassed,
+			req:       ResubmitRequest{UserID: ownerID},
+			expectErr: ErrNotRejected,
+		},
+		{
+			name:      "Approved photo",
+			status:    model.PhotoStatusApproved,
+			req:       ResubmitRequest{UserID: ownerID},
+			expectErr: ErrNotRejected,
+		},
+		{
+			name:      "Missing photo",
+			status:    model.PhotoStatusRejected,
+			photoID:   99,
+			req:       ResubmitRequest{UserID: ownerID},
+			expectErr: ErrPhotoNotFound,
+		},
+		{
+			name:      "Invalid correction",
+			status:    model.PhotoStatusRejected,
+			req:       ResubmitRequest{UserID: ownerID, FlightNumber: &flightNumber},
+			expectErr: ErrInvalidFlightNumber,
+		},
+		{
+			name:         "Photo no longer rejected when saved",
+			status:       model.PhotoStatusRejected,
+			req:          ResubmitRequest{UserID: ownerID},
+			resubmitErr:  postgresql.ErrNotFound,
+			expectErr:    ErrNotRejected,
+			expectStored: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeSubmissionStore{
+				photos: map[int64]*model.Photo{
+					1: {ID: 1, UserID: ownerID, Title: "Stored photo", Status: tt.status},
+				},
+				resubmitErr: tt.resubmitErr,
+			}
+			reviewer := &fakeAIReviewer{}
+			s := &Service{submissions: store}
+			s.SetAIReviewer(reviewer)
+
+			photoID := tt.photoID
+			if photoID == 0 {
+				photoID = 1
+			}
+
+			resp, err := s.Resubmit(context.Background(), photoID, &tt.req)
+			if !errors.Is(err, tt.expectErr) || (tt.expectErr == nil && err != nil) {
+				t.Fatalf("Resubmit() error = %v, expected %v", err, tt.expectErr)
+			}
+			if stored := len(store.resubmits) > 0; stored != tt.expectStored {
+				t.Errorf("resubmission stored = %v, expected %v", stored, tt.expectStored)
+			}
+			if queued := len(reviewer.submitted) > 0; queued != tt.expectAIQueue {
+				t.Errorf("AI review submitted = %v, expected %v", queued, tt.expectAIQueue)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Status != string(model.PhotoStatusPending) {
+				t.Errorf("Resubmit() status = %s, expected %s", resp.Status, model.PhotoStatusPending)
+			}
+			if resp.Title != tt.expectTitle {
+				t.Errorf("Resubmit() title = %q, expected %q", resp.Title, tt.expectTitle)
+			}
+		})
+	}
+}
+
+func TestResubmitWithFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		allowed     bool
+		resubmitErr error
+		expectErr   error
+		expectOld   bool // Files of the rejected image are kept
+		expectNew   bool // Files of the uploaded image are kept
+	}{
+		{
+			name:      "Replaced files are freed",
+			allowed:   true,
+			expectNew: true,
+		},
+		{
+			name:      "Upload over quota",
+			allowed:   false,
+			expectErr: ErrQuotaExceeded,
+			expectOld: true,
+		},
+		{
+			name:        "Uploaded files are removed when saving fails",
+			allowed:     true,
+			resubmitErr: postgresql.ErrNotFound,
+			expectErr:   ErrNotRejected,
+			expectOld:   true,
+		},
+		{
+			name:        "Quota exceeded when saving",
+			allowed:     true,
+			resubmitErr: postgresql.ErrQuotaExceeded,
+			expectErr:   ErrQuotaExceeded,
+			expectOld:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestUploader(t)
+			stored := storeTestPhoto(t, u, 1, 1, model.PhotoStatusRejected)
+			store := &fakeSubmissionStore{
+				photos:      map[int64]*model.Photo{1: stored},
+				resubmitErr: tt.resubmitErr,
+			}
+			policy := &fakeQuotaPolicy{quotaBytes: 1 << 30, allowed: tt.allowed}
+			s := &Service{submissions: store, uploader: u}
+			s.SetQuotaPolicy(policy)
+
+			file := testJPEG(t)
+			_, err := s.Resubmit(context.Background(), 1, &ResubmitRequest{UserID: 1, File: file})
+			if !errors.Is(err, tt.expectErr) || (tt.expectErr == nil && err != nil) {
+				t.Fatalf("Resubmit() error = %v, expected %v", err, tt.expectErr)
+			}
+
+			// The quota is checked for the size the upload adds to the stored files
+			if expected := file.Size - stored.StoredBytes(); policy.size != expected {
+				t.Errorf("checked size = %d, expected %d", policy.size, expected)
+			}
+			if kept := fileExists(u, stored.FilePath); kept != tt.expectOld {
+				t.Errorf("rejected image kept = %v, expected %v", kept, tt.expectOld)
+			}
+			if kept := fileExists(u, stored.ThumbnailPath.String+"_sm.jpg"); kept != tt.expectOld {
+				t.Errorf("rejected thumbnail kept = %v, expected %v", kept, tt.expectOld)
+			}
+
+			if len(store.resubmits) == 0 {
+				return
+			}
+			uploaded := store.resubmits[0].File
+			if uploaded == nil {
+				t.Fatal("resubmission has no file")
+			}
+			if quota := store.resubmits[0].QuotaBytes; quota != policy.quotaBytes {
+				t.Errorf("saved with quota %d, expected %d", quota, policy.quotaBytes)
+			}
+			if kept := fileExists(u, uploaded.FilePath); kept != tt.expectNew {
+				t.Errorf("uploaded image kept = %v, expected %v", kept, tt.expectNew)
+			}
+		})
+	}
+}
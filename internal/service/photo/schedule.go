@@ -0,0 +1,129 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/logger"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// publishBatchSize is the maximum number of photos published per run
+const publishBatchSize = 100
+
+// PublishNotifier notifies owners that their scheduled photo went live
+type PublishNotifier interface {
+	CreatePublishedNotification(ctx context.Context, photoOwnerID, photoID int64, photoTitle string) error
+}
+
+// ScheduleRequest represents request for rescheduling the publication of a photo
+type ScheduleRequest struct {
+	PublishAt *time.Time `json:"publish_at"` // Null publishes the photo once approved
+}
+
+// ScheduleResponse represents the publication schedule of a photo
+type ScheduleResponse struct {
+	ID        int64   `json:"id"`
+	Status    string  `json:"status"`
+	PublishAt *string `json:"publish_at"` // Null once published or when published on approval
+}
+
+// SetPublishNotifier enables notifications when scheduled photos go live
+func (s *Service) SetPublishNotifier(notifier PublishNotifier) {
+	s.notifier = notifier
+}
+
+// Schedule reschedules the publication of one of the user's photos that is
+// not published yet. A nil time publishes the photo once approved, right away
+// if it already is.
+func (s *Service) Schedule(ctx context.Context, photoID, userID int64, req *ScheduleRequest) (*ScheduleResponse, error) {
+	if req.PublishAt != nil && !req.PublishAt.After(time.Now()) {
+		return nil, ErrPublishAtPast
+	}
+
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if p.UserID != userID {
+		return nil, ErrNotOwner
+	}
+	if p.Status == model.PhotoStatusApproved && !p.IsScheduled() {
+		return nil, ErrAlreadyPublished
+	}
+
+	updated, err := s.photoRepo.UpdatePublishAt(ctx, photoID, userID, req.PublishAt)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrAlreadyPublished // Published in the meantime
+		}
+		return nil, err
+	}
+
+	return &ScheduleResponse{
+		ID:        updated.ID,
+		Status:    string(updated.Status),
+		PublishAt: formatPublishAt(updated),
+	}, nil
+}
+
+// StartPublisher publishes due scheduled photos every interval in the
+// background
+func (s *Service) StartPublisher(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := s.PublishDue(context.Background()); err != nil {
+				logger.Warn("Failed to publish scheduled photos", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// PublishDue publishes approved photos whose publication time has come and
+// notifies their owners. Returns the number of photos published.
+func (s *Service) PublishDue(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		photos, err := s.photoRepo.PublishDue(ctx, publishBatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += len(photos)
+
+		for _, p := range photos {
+			if s.notifier == nil {
+				break
+			}
+			if err := s.notifier.CreatePublishedNotification(ctx, p.UserID, p.ID, p.Title); err != nil {
+				logger.Warn("Failed to notify owner of published photo",
+					zap.Int64("photo_id", p.ID),
+					zap.Error(err),
+				)
+			}
+		}
+
+		if len(photos) < publishBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// formatPublishAt returns the scheduled publication time of a photo, nil once
+// published
+func formatPublishAt(p *model.Photo) *string {
+	if !p.IsScheduled() {
+		return nil
+	}
+	publishAt := p.PublishAt.Time.Format(time.RFC3339)
+	return &publishAt
+}
@@ -0,0 +1,72 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql/pgtest"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+type publishedNotification struct {
+	ownerID int64
+	photoID int64
+}
+
+type fakePublishNotifier struct {
+	notified []publishedNotification
+	err      error
+}
+
+func (n *fakePublishNotifier) CreatePublishedNotification(_ context.Context, photoOwnerID, photoID int64, _ string) error {
+	n.notified = append(n.notified, publishedNotification{photoOwnerID, photoID})
+	return n.err
+}
+
+func TestPublishDueNotifiesOwners(t *testing.T) {
+	db := pgtest.Open(t)
+	ownerID := pgtest.CreateUser(t, db, "owner", "user")
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"Owner notified", nil},
+		{"Failed notification still publishes", errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+			pgtest.Exec(t, db, `UPDATE photos SET publish_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, due)
+			future := pgtest.CreatePhoto(t, db, ownerID, string(model.PhotoStatusApproved))
+			pgtest.Exec(t, db, `UPDATE photos SET publish_at = NOW() + INTERVAL '1 day' WHERE id = $1`, future)
+
+			notifier := &fakePublishNotifier{err: tt.err}
+			s := New(photo.NewPhotoRepository(db), "")
+			s.SetPublishNotifier(notifier)
+
+			published, err := s.PublishDue(context.Background())
+			if err != nil {
+				t.Fatalf("PublishDue() error = %v", err)
+			}
+			if published != 1 {
+				t.Errorf("PublishDue() = %d, expected 1", published)
+			}
+			if len(notifier.notified) != 1 || notifier.notified[0] != (publishedNotification{ownerID, due}) {
+				t.Errorf("notified %v, expected owner %d of photo %d", notifier.notified, ownerID, due)
+			}
+
+			// Published photos are not notified again
+			notifier.notified = nil
+			if published, err := s.PublishDue(context.Background()); err != nil || published != 0 {
+				t.Errorf("second PublishDue() = %d, %v, expected nothing to publish", published, err)
+			}
+			if len(notifier.notified) != 0 {
+				t.Errorf("notified %v again", notifier.notified)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package photo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"QuanPhotos/internal/config"
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/imaging"
+)
+
+// minTiltConfidence is the share of horizontal edge energy needed before a tilt is reported.
+// Below it the scene has no clear horizon and the estimate is meaningless.
+const minTiltConfidence = 0.2
+
+// screenQuality evaluates quality metrics against the configured thresholds.
+// A zero threshold disables the corresponding check.
+func screenQuality(metrics *imaging.QualityMetrics, iso int, cfg config.QualityConfig) *model.QualityScreening {
+	screening := &model.QualityScreening{
+		Source:            model.ScreeningSource,
+		Verdict:           model.ScreeningPass,
+		Width:             metrics.Width,
+		Height:            metrics.Height,
+		Sharpness:         round(metrics.Sharpness, 2),
+		ShadowClipping:    round(metrics.ShadowClipping, 4),
+		HighlightClipping: round(metrics.HighlightClipping, 4),
+		TiltDegrees:       round(metrics.TiltDegrees, 2),
+		TiltConfidence:    round(metrics.TiltConfidence, 3),
+		NoiseSigma:        round(metrics.NoiseSigma, 3),
+		ISO:               iso,
+	}
+
+	// Noise grows roughly with the square root of the gain, so compare the
+	// measured sigma with what the ISO explains rather than an absolute value
+	expected := math.Sqrt(math.Max(float64(iso), 100) / 100)
+	screening.NoiseRatio = round(metrics.NoiseSigma/expected, 3)
+
+	addIssue := func(check string, severity model.ScreeningVerdict, value, threshold float64) {
+		screening.Issues = append(screening.Issues, &model.ScreeningIssue{
+			Check:     check,
+			Severity:  severity,
+			Value:     value,
+			Threshold: threshold,
+		})
+		if severity == model.ScreeningReject || screening.Verdict == model.ScreeningPass {
+			screening.Verdict = severity
+		}
+	}
+
+	// Resolution
+	if cfg.MinWidth > 0 && metrics.Width < cfg.MinWidth {
+		addIssue("resolution", model.ScreeningReject, float64(metrics.Width), float64(cfg.MinWidth))
+	} else if cfg.MinHeight > 0 && metrics.Height < cfg.MinHeight {
+		addIssue("resolution", model.ScreeningReject, float64(metrics.Height), float64(cfg.MinHeight))
+	}
+
+	// Sharpness
+	if cfg.RejectSharpness > 0 && screening.Sharpness < cfg.RejectSharpness {
+		addIssue("sharpness", model.ScreeningReject, screening.Sharpness, cfg.RejectSharpness)
+	} else if cfg.FlagSharpness > 0 && screening.Sharpness < cfg.FlagSharpness {
+		addIssue("sharpness", model.ScreeningFlag, screening.Sharpness, cfg.FlagSharpness)
+	}
+
+	// Exposure clipping
+	clipped := math.Max(screening.ShadowClipping, screening.HighlightClipping)
+	if cfg.RejectClipping > 0 && clipped > cfg.RejectClipping {
+		addIssue("clipping", model.ScreeningReject, clipped, cfg.RejectClipping)
+	} else if cfg.FlagClipping > 0 && clipped > cfg.FlagClipping {
+		addIssue("clipping", model.ScreeningFlag, clipped, cfg.FlagClipping)
+	}
+
+	// Horizon tilt is only ever flagged, intentional dutch angles are legitimate
+	tilt := math.Abs(screening.TiltDegrees)
+	if cfg.FlagTilt > 0 && screening.TiltConfidence >= minTiltConfidence && tilt > cfg.FlagTilt {
+		addIssue("tilt", model.ScreeningFlag, tilt, cfg.FlagTilt)
+	}
+
+	// Noise
+	if cfg.RejectNoiseRatio > 0 && screening.NoiseRatio > cfg.RejectNoiseRatio {
+		addIssue("noise", model.ScreeningReject, screening.NoiseRatio, cfg.RejectNoiseRatio)
+	} else if cfg.FlagNoiseRatio > 0 && screening.NoiseRatio > cfg.FlagNoiseRatio {
+		addIssue("noise", model.ScreeningFlag, screening.NoiseRatio, cfg.FlagNoiseRatio)
+	}
+
+	return screening
+}
+
+// screeningReason summarizes rejecting issues for the review record
+func screeningReason(screening *model.QualityScreening) *string {
+	if screening.Verdict != model.ScreeningReject {
+		return nil
+	}
+
+	var parts []string
+	for _, issue := range screening.Issues {
+		if issue.Severity == model.ScreeningReject {
+			parts = append(parts, fmt.Sprintf("%s %g (threshold %g)", issue.Check, issue.Value, issue.Threshold))
+		}
+	}
+	reason := "quality screening: " + strings.Join(parts, "; ")
+	return &reason
+}
+
+// screeningReasonCodes maps rejecting issues to rejection reason codes
+func screeningReasonCodes(screening *model.QualityScreening) []string {
+	var codes []string
+	for _, issue := range screening.Issues {
+		if issue.Severity != model.ScreeningReject {
+			continue
+		}
+		if code, ok := screeningReasonCode[issue.Check]; ok {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// screeningReasonCode maps screening checks to rejection reason codes
+var screeningReasonCode = map[string]string{
+	"resolution": "low_resolution",
+	"sharpness":  "soft_focus",
+	"clipping":   "exposure",
+	"tilt":       "tilted",
+	"noise":      "noise",
+}
+
+// round rounds a value to the given number of decimals
+func round(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
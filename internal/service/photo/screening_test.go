@@ -0,0 +1,324 @@
+package photo
+
+import (
+	"reflect"
+	"testing"
+
+	"QuanPhotos/internal/config"
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/imaging"
+)
+
+func TestScreenQuality(t *testing.T) {
+	cfg := config.QualityConfig{
+		MinWidth:         1600,
+		MinHeight:        1000,
+		RejectSharpness:  20,
+		FlagSharpness:    60,
+		RejectClipping:   0.3,
+		FlagClipping:     0.1,
+		FlagTilt:         1.5,
+		RejectNoiseRatio: 6,
+		FlagNoiseRatio:   3,
+	}
+
+	// good passes every check, cases change one metric at a time
+	good := imaging.QualityMetrics{
+		Width:          4000,
+		Height:         2667,
+		Sharpness:      250,
+		ShadowClipping: 0.01,
+		TiltDegrees:    0.4,
+		TiltConfidence: 0.8,
+		NoiseSigma:     1.2,
+	}
+
+	tests := []struct {
+		name          string
+		change        func(m *imaging.QualityMetrics)
+		iso           int
+		cfg           *config.QualityConfig
+		expectVerdict model.ScreeningVerdict
+		expectChecks  []string
+	}{
+		{
+			name:          "Good photo passes",
+			change:        func(m *imaging.QualityMetrics) {},
+			iso:           100,
+			expectVerdict: model.ScreeningPass,
+		},
+		{
+			name:          "Narrow photo is rejected",
+			change:        func(m *imaging.QualityMetrics) { m.Width = 1200 },
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"resolution"},
+		},
+		{
+			name:          "Short photo is rejected",
+			change:        func(m *imaging.QualityMetrics) { m.Height = 800 },
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"resolution"},
+		},
+		{
+			name:          "Slightly soft photo is flagged",
+			change:        func(m *imaging.QualityMetrics) { m.Sharpness = 40 },
+			iso:           100,
+			expectVerdict: model.ScreeningFlag,
+			expectChecks:  []string{"sharpness"},
+		},
+		{
+			name:          "Blurred photo is rejected",
+			change:        func(m *imaging.QualityMetrics) { m.Sharpness = 5 },
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"sharpness"},
+		},
+		{
+			name:          "Sharpness at the threshold passes",
+			change:        func(m *imaging.QualityMetrics) { m.Sharpness = 60 },
+			iso:           100,
+			expectVerdict: model.ScreeningPass,
+		},
+		{
+			name: "Minimum resolution passes",
+			change: func(m *imaging.QualityMetrics) {
+				m.Width = 1600
+				m.Height = 1000
+			},
+			iso:           100,
+			expectVerdict: model.ScreeningPass,
+		},
+		{
+			name:          "One pixel below the minimum width is rejected",
+			change:        func(m *imaging.QualityMetrics) { m.Width = 1599 },
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"resolution"},
+		},
+		{
+			name:          "Sharpness just below the flag threshold is flagged",
+			change:        func(m *imaging.QualityMetrics) { m.Sharpness = 59.99 },
+			iso:           100,
+			expectVerdict: model.ScreeningFlag,
+			expectChecks:  []string{"sharpness"},
+		},
+		{
+			name:          "Sharpness at the reject threshold is flagged",
+			change:        func(m *imaging.QualityMetrics) { m.Sharpness = 20 },
+			iso:           100,
+			expectVerdict: model.ScreeningFlag,
+			expectChecks:  []string{"sharpness"},
+		},
+		{
+			name:          "Sharpness just below the reject threshold is rejected",
+			change:        func(m *imaging.QualityMetrics) { m.Sharpness = 19.99 },
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"sharpness"},
+		},
+		{
+			name:          "Clipping at the flag threshold passes",
+			change:        func(m *imaging.QualityMetrics) { m.HighlightClipping = 0.1 },
+			iso:           100,
+			expectVerdict: model.ScreeningPass,
+		},
+		{
+			name:          "Clipping at the reject threshold is flagged",
+			change:        func(m *imaging.QualityMetrics) { m.HighlightClipping = 0.3 },
+			iso:           100,
+			expectVerdict: model.ScreeningFlag,
+			expectChecks:  []string{"clipping"},
+		},
+		{
+			name:          "Clipping just above the reject threshold is rejected",
+			change:        func(m *imaging.QualityMetrics) { m.HighlightClipping = 0.3001 },
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"clipping"},
+		},
+		{
+			name:          "Tilt at the flag threshold passes",
+			change:        func(m *imaging.QualityMetrics) { m.TiltDegrees = 1.5 },
+			iso:           100,
+			expectVerdict: model.ScreeningPass,
+		},
+		{
+			name:          "Noise at the flag threshold passes",
+			change:        func(m *imaging.QualityMetrics) { m.NoiseSigma = 3 },
+			iso:           100,
+			expectVerdict: model.ScreeningPass,
+		},
+		{
+			name:          "Noise at the reject threshold is flagged",
+			change:        func(m *imaging.QualityMetrics) { m.NoiseSigma = 6 },
+			iso:           100,
+			expectVerdict: model.ScreeningFlag,
+			expectChecks:  []string{"noise"},
+		},
+		{
+			name:          "Noise just above the reject threshold is rejected",
+			change:        func(m *imaging.QualityMetrics) { m.NoiseSigma = 6.001 },
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"noise"},
+		},
+		{
+			name:          "Blown highlights are rejected",
+			change:        func(m *imaging.QualityMetrics) { m.HighlightClipping = 0.5 },
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"clipping"},
+		},
+		{
+			name:          "Some crushed shadows are flagged",
+			change:        func(m *imaging.QualityMetrics) { m.ShadowClipping = 0.2 },
+			iso:           100,
+			expectVerdict: model.ScreeningFlag,
+			expectChecks:  []string{"clipping"},
+		},
+		{
+			name:          "Tilted horizon is flagged",
+			change:        func(m *imaging.QualityMetrics) { m.TiltDegrees = -3 },
+			iso:           100,
+			expectVerdict: model.ScreeningFlag,
+			expectChecks:  []string{"tilt"},
+		},
+		{
+			name: "Tilt without a clear horizon is ignored",
+			change: func(m *imaging.QualityMetrics) {
+				m.TiltDegrees = 8
+				m.TiltConfidence = 0.1
+			},
+			iso:           100,
+			expectVerdict: model.ScreeningPass,
+		},
+		{
+			name:          "Noise explained by high ISO passes",
+			change:        func(m *imaging.QualityMetrics) { m.NoiseSigma = 12 },
+			iso:           6400,
+			expectVerdict: model.ScreeningPass,
+		},
+		{
+			name:          "Same noise at base ISO is rejected",
+			change:        func(m *imaging.QualityMetrics) { m.NoiseSigma = 12 },
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"noise"},
+		},
+		{
+			name:          "Missing ISO counts as base ISO",
+			change:        func(m *imaging.QualityMetrics) { m.NoiseSigma = 4 },
+			iso:           0,
+			expectVerdict: model.ScreeningFlag,
+			expectChecks:  []string{"noise"},
+		},
+		{
+			name: "Reject wins over an earlier flag",
+			change: func(m *imaging.QualityMetrics) {
+				m.Sharpness = 40
+				m.HighlightClipping = 0.5
+			},
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"sharpness", "clipping"},
+		},
+		{
+			name: "Flag does not downgrade a reject",
+			change: func(m *imaging.QualityMetrics) {
+				m.Width = 1200
+				m.TiltDegrees = 5
+			},
+			iso:           100,
+			expectVerdict: model.ScreeningReject,
+			expectChecks:  []string{"resolution", "tilt"},
+		},
+		{
+			name: "Zero thresholds disable checks",
+			change: func(m *imaging.QualityMetrics) {
+				m.Width = 100
+				m.Sharpness = 1
+				m.HighlightClipping = 1
+				m.TiltDegrees = 10
+				m.NoiseSigma = 50
+			},
+			iso:           100,
+			cfg:           &config.QualityConfig{},
+			expectVerdict: model.ScreeningPass,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := good
+			tt.change(&metrics)
+			c := cfg
+			if tt.cfg != nil {
+				c = *tt.cfg
+			}
+
+			screening := screenQuality(&metrics, tt.iso, c)
+			if screening.Verdict != tt.expectVerdict {
+				t.Errorf("Verdict = %s, expected %s", screening.Verdict, tt.expectVerdict)
+			}
+
+			var checks []string
+			for _, issue := range screening.Issues {
+				checks = append(checks, issue.Check)
+			}
+			if !reflect.DeepEqual(checks, tt.expectChecks) {
+				t.Errorf("checks = %v, expected %v", checks, tt.expectChecks)
+			}
+		})
+	}
+}
+
+func TestScreeningReason(t *testing.T) {
+	tests := []struct {
+		name         string
+		screening    *model.QualityScreening
+		expectReason string
+		expectCodes  []string
+	}{
+		{
+			name: "Flags give no reason",
+			screening: &model.QualityScreening{
+				Verdict: model.ScreeningFlag,
+				Issues: []*model.ScreeningIssue{
+					{Check: "tilt", Severity: model.ScreeningFlag, Value: 3, Threshold: 1.5},
+				},
+			},
+		},
+		{
+			name: "Only rejecting issues are listed",
+			screening: &model.QualityScreening{
+				Verdict: model.ScreeningReject,
+				Issues: []*model.ScreeningIssue{
+					{Check: "sharpness", Severity: model.ScreeningReject, Value: 5, Threshold: 20},
+					{Check: "tilt", Severity: model.ScreeningFlag, Value: 3, Threshold: 1.5},
+					{Check: "noise", Severity: model.ScreeningReject, Value: 12, Threshold: 6},
+				},
+			},
+			expectReason: "quality screening: sharpness 5 (threshold 20); noise 12 (threshold 6)",
+			expectCodes:  []string{"soft_focus", "noise"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := screeningReason(tt.screening)
+			if tt.expectReason == "" {
+				if reason != nil {
+					t.Errorf("screeningReason() = %q, expected nil", *reason)
+				}
+			} else if reason == nil || *reason != tt.expectReason {
+				t.Errorf("screeningReason() = %v, expected %q", reason, tt.expectReason)
+			}
+
+			if codes := screeningReasonCodes(tt.screening); !reflect.DeepEqual(codes, tt.expectCodes) {
+				t.Errorf("screeningReasonCodes() = %v, expected %v", codes, tt.expectCodes)
+			}
+		})
+	}
+}
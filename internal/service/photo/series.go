@@ -0,0 +1,26 @@
+package photo
+
+import (
+	"context"
+
+	"QuanPhotos/internal/model"
+)
+
+// attachSeries marks the photos of a list that are frames of a visible
+// series, so clients can show each series as one card
+func (s *Service) attachSeries(ctx context.Context, list []*model.PhotoListItem) error {
+	photoIDs := make([]int64, len(list))
+	for i, item := range list {
+		photoIDs[i] = item.ID
+	}
+
+	series, err := s.photoRepo.GetSeriesBriefs(ctx, photoIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list {
+		item.Series = series[item.ID]
+	}
+	return nil
+}
@@ -3,51 +3,159 @@ package photo
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"QuanPhotos/internal/config"
 	"QuanPhotos/internal/model"
 	"QuanPhotos/internal/pkg/storage"
 	"QuanPhotos/internal/repository/postgresql"
 	"QuanPhotos/internal/repository/postgresql/photo"
+	"QuanPhotos/internal/service/trust"
 )
 
 var (
-	ErrPhotoNotFound = errors.New("photo not found")
-	ErrNotOwner      = errors.New("you are not the owner of this photo")
-	ErrNotFavorited  = errors.New("photo is not in favorites")
-	ErrNotLiked      = errors.New("photo is not liked")
+	ErrPhotoNotFound     = errors.New("photo not found")
+	ErrNotOwner          = errors.New("you are not the owner of this photo")
+	ErrNotFavorited      = errors.New("photo is not in favorites")
+	ErrNotLiked          = errors.New("photo is not liked")
+	ErrSpotNotFound      = errors.New("spotting spot not found")
+	ErrNotRejected       = errors.New("only rejected photos can be resubmitted")
+	ErrEditRejected      = errors.New("rejected photos are corrected by resubmitting them")
+	ErrEmptyTitle        = errors.New("title cannot be empty")
+	ErrNoChanges         = errors.New("no changes to photo")
+	ErrNotApproved       = errors.New("only approved photos can have their file replaced")
+	ErrInvalidVisibility = errors.New("visibility must be public, unlisted or private")
+	ErrPublishAtPast     = errors.New("publish time must be in the future")
+	ErrAlreadyPublished  = errors.New("photo is already published")
 )
 
+// AIReviewer submits uploaded photos for AI review
+type AIReviewer interface {
+	Submit(photoID int64)
+}
+
+// TrustPolicy decides whether uploads of a user skip the manual review queue
+type TrustPolicy interface {
+	UploadDecision(ctx context.Context, userID int64) (*trust.UploadDecision, error)
+}
+
+// SubmissionStore is the part of the photo repository that sends rejected
+// photos back to review and records replacement images
+type SubmissionStore interface {
+	GetByID(ctx context.Context, id int64) (*model.Photo, error)
+	Resubmit(ctx context.Context, photoID, userID int64, params *photo.ResubmitParams) (*model.Photo, error)
+	CreateReplacement(ctx context.Context, params *photo.CreateReplacementParams) (*model.PhotoReplacement, []*model.PhotoReplacement, error)
+	PendingReplacementSize(ctx context.Context, photoID int64) (int64, error)
+	DecideReplacement(ctx context.Context, params *photo.DecideReplacementParams) (*model.PhotoReplacement, error)
+}
+
 // Service handles photo business logic
 type Service struct {
-	photoRepo *photo.PhotoRepository
-	uploader  *Uploader
-	baseURL   string
+	photoRepo   *photo.PhotoRepository
+	submissions SubmissionStore
+	uploader    *Uploader
+	aiReviewer  AIReviewer
+	trustPolicy TrustPolicy
+	quotaPolicy QuotaPolicy
+	notifier    PublishNotifier
+	baseURL     string
+
+	// trashRetention is how long deleted photos stay in the trash
+	trashRetention time.Duration
+
+	// reReviewFields are the fields whose edit sends an approved photo back to review
+	reReviewFields []string
 }
 
 // New creates a new photo service
 func New(photoRepo *photo.PhotoRepository, baseURL string) *Service {
 	return &Service{
-		photoRepo: photoRepo,
-		baseURL:   baseURL,
+		photoRepo:   photoRepo,
+		submissions: photoRepo,
+		baseURL:     baseURL,
 	}
 }
 
 // NewWithUploader creates a new photo service with uploader support
 func NewWithUploader(photoRepo *photo.PhotoRepository, localStorage *storage.LocalStorage, cfg *config.Config) *Service {
 	return &Service{
-		photoRepo: photoRepo,
-		uploader:  NewUploader(localStorage, photoRepo, cfg),
-		baseURL:   cfg.Storage.BaseURL,
+		photoRepo:   photoRepo,
+		submissions: photoRepo,
+		uploader:    NewUploader(localStorage, photoRepo, cfg),
+		baseURL:     cfg.Storage.BaseURL,
 	}
 }
 
+// SetAIReviewer enables AI review of uploaded photos
+func (s *Service) SetAIReviewer(reviewer AIReviewer) {
+	s.aiReviewer = reviewer
+}
+
+// SetTrustPolicy enables auto approval of uploads from trusted users
+func (s *Service) SetTrustPolicy(policy TrustPolicy) {
+	s.trustPolicy = policy
+}
+
 // Upload uploads a new photo
 func (s *Service) Upload(ctx context.Context, req *UploadRequest) (*UploadResponse, error) {
 	if s.uploader == nil {
 		return nil, errors.New("uploader not initialized")
 	}
-	return s.uploader.Upload(ctx, req)
+
+	if req.Visibility != "" && !req.Visibility.IsValid() {
+		return nil, ErrInvalidVisibility
+	}
+	if req.PublishAt != nil && !req.PublishAt.After(time.Now()) {
+		return nil, ErrPublishAtPast
+	}
+
+	// Validate and normalize flight info
+	flight, err := normalizeFlightInfo(req.FlightNumber, req.Origin, req.Destination, req.FlightPhase)
+	if err != nil {
+		return nil, err
+	}
+	req.FlightNumber = derefString(flight.FlightNumber)
+	req.Origin = derefString(flight.Origin)
+	req.Destination = derefString(flight.Destination)
+	req.FlightPhase = derefString(flight.FlightPhase)
+
+	// Explicitly chosen spot must exist and be approved
+	if req.SpotID > 0 {
+		spot, err := s.photoRepo.GetSpotByID(ctx, req.SpotID)
+		if err != nil {
+			if errors.Is(err, postgresql.ErrNotFound) {
+				return nil, ErrSpotNotFound
+			}
+			return nil, err
+		}
+		if spot.Status != model.SpotStatusApproved {
+			return nil, ErrSpotNotFound
+		}
+	}
+
+	quotaBytes, err := s.checkQuota(ctx, req.UserID, 0, req.File, req.RawFile)
+	if err != nil {
+		return nil, err
+	}
+	req.quotaBytes = quotaBytes
+
+	resp, err := s.uploader.Upload(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Trusted uploads skip the review queue, including the AI review
+	if s.autoApprove(ctx, req.UserID, resp) {
+		return resp, nil
+	}
+
+	// Photos rejected by quality screening are not worth an AI review
+	if s.aiReviewer != nil && resp.Status == string(model.PhotoStatusPending) {
+		s.aiReviewer.Submit(resp.ID)
+	}
+
+	return resp, nil
 }
 
 // ListRequest represents request for listing photos
@@ -59,11 +167,18 @@ type ListRequest struct {
 	Airline      string `form:"airline"`
 	Airport      string `form:"airport"`
 	Registration string `form:"registration"`
+	FlightNumber string `form:"flight_number"`
+	Origin       string `form:"origin"`
+	Destination  string `form:"destination"`
+	FlightPhase  string `form:"flight_phase"`
+	Camera       string `form:"camera"` // Normalized camera key, e.g. "nikon-z9"
+	Lens         string `form:"lens"`   // Normalized lens key
 	Keyword      string `form:"keyword"`
 	TakenFrom    string `form:"taken_from"` // Date in format "2006-01-02"
 	TakenTo      string `form:"taken_to"`   // Date in format "2006-01-02"
 	SortBy       string `form:"sort_by"`
 	SortOrder    string `form:"sort_order"`
+	ExifFilter
 }
 
 // ListResponse represents response for listing photos
@@ -82,7 +197,7 @@ type Pagination struct {
 
 // List retrieves a paginated list of photos
 func (s *Service) List(ctx context.Context, req *ListRequest) (*ListResponse, error) {
-	result, err := s.photoRepo.List(ctx, photo.ListParams{
+	params := photo.ListParams{
 		Page:         req.Page,
 		PageSize:     req.PageSize,
 		CategoryID:   req.CategoryID,
@@ -90,16 +205,33 @@ func (s *Service) List(ctx context.Context, req *ListRequest) (*ListResponse, er
 		Airline:      req.Airline,
 		Airport:      req.Airport,
 		Registration: req.Registration,
+		FlightNumber: strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(req.FlightNumber)),
+		Origin:       strings.ToUpper(strings.TrimSpace(req.Origin)),
+		Destination:  strings.ToUpper(strings.TrimSpace(req.Destination)),
+		FlightPhase:  strings.ToLower(strings.TrimSpace(req.FlightPhase)),
+		CameraKey:    strings.ToLower(strings.TrimSpace(req.Camera)),
+		LensKey:      strings.ToLower(strings.TrimSpace(req.Lens)),
 		Keyword:      req.Keyword,
 		TakenFrom:    req.TakenFrom,
 		TakenTo:      req.TakenTo,
 		SortBy:       req.SortBy,
 		SortOrder:    req.SortOrder,
-	})
+	}
+	if err := req.ExifFilter.apply(&params); err != nil {
+		return nil, err
+	}
+
+	result, err := s.photoRepo.List(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
+	return s.buildListResponse(ctx, result)
+}
+
+// buildListResponse converts a repository list result into a list response,
+// marking the photos that are frames of a series
+func (s *Service) buildListResponse(ctx context.Context, result *photo.ListResult) (*ListResponse, error) {
 	// Get unique user IDs
 	userIDs := make([]int64, 0, len(result.Photos))
 	userIDMap := make(map[int64]bool)
@@ -131,6 +263,9 @@ func (s *Service) List(ctx context.Context, req *ListRequest) (*ListResponse, er
 		}
 		list[i] = p.ToListItem(userBrief, s.baseURL)
 	}
+	if err := s.attachSeries(ctx, list); err != nil {
+		return nil, err
+	}
 
 	return &ListResponse{
 		List: list,
@@ -143,8 +278,9 @@ func (s *Service) List(ctx context.Context, req *ListRequest) (*ListResponse, er
 	}, nil
 }
 
-// GetDetail retrieves photo detail
-func (s *Service) GetDetail(ctx context.Context, photoID int64, currentUserID *int64) (*model.PhotoDetail, error) {
+// GetDetail retrieves photo detail. Private and scheduled photos are only
+// visible to their owner and admins.
+func (s *Service) GetDetail(ctx context.Context, photoID int64, currentUserID *int64, isAdmin bool) (*model.PhotoDetail, error) {
 	// Get photo
 	p, err := s.photoRepo.GetByID(ctx, photoID)
 	if err != nil {
@@ -162,6 +298,18 @@ func (s *Service) GetDetail(ctx context.Context, photoID int64, currentUserID *i
 			return nil, ErrPhotoNotFound // Hide non-approved photos from non-owners
 		}
 	}
+	if (p.Visibility == model.PhotoVisibilityPrivate || p.IsScheduled()) && !isAdmin {
+		if currentUserID == nil || *currentUserID != p.UserID {
+			return nil, ErrPhotoNotFound
+		}
+	}
+
+	return s.buildDetail(ctx, p, currentUserID)
+}
+
+// buildDetail counts a view of a photo the user may see and builds its detail
+func (s *Service) buildDetail(ctx context.Context, p *model.Photo, currentUserID *int64) (*model.PhotoDetail, error) {
+	photoID := p.ID
 
 	// Increment view count
 	_ = s.photoRepo.IncrementViewCount(ctx, photoID)
@@ -192,6 +340,15 @@ func (s *Service) GetDetail(ctx context.Context, photoID int64, currentUserID *i
 		}
 	}
 
+	// Get spotting spot
+	var spotBrief *model.SpotBrief
+	if p.SpotID.Valid {
+		spot, err := s.photoRepo.GetSpotByID(ctx, p.SpotID.Int64)
+		if err == nil && spot.Status == model.SpotStatusApproved {
+			spotBrief = spot.ToBrief()
+		}
+	}
+
 	// Get tags
 	tags, err := s.photoRepo.GetTagsByPhotoID(ctx, photoID)
 	if err != nil {
@@ -205,20 +362,52 @@ func (s *Service) GetDetail(ctx context.Context, photoID int64, currentUserID *i
 		isLiked, _ = s.photoRepo.IsLiked(ctx, *currentUserID, photoID)
 	}
 
-	return p.ToDetail(userBrief, categoryBrief, tags, s.baseURL, isFavorited, isLiked), nil
+	detail := p.ToDetail(userBrief, categoryBrief, tags, s.baseURL, isFavorited, isLiked)
+	detail.Spot = spotBrief
+
+	// Get series
+	if series, err := s.photoRepo.GetSeriesBriefs(ctx, []int64{photoID}); err == nil {
+		detail.Series = series[photoID]
+	}
+
+	// Hide GPS coordinates from others if the owner opted out of sharing locations
+	if user.HidePhotoLocation && detail.EXIF != nil {
+		if currentUserID == nil || *currentUserID != p.UserID {
+			detail.EXIF.GPSLatitude = nil
+			detail.EXIF.GPSLongitude = nil
+		}
+	}
+
+	// Only the owner gets the share link and publication schedule
+	if currentUserID != nil && *currentUserID == p.UserID {
+		if p.ShareToken.Valid {
+			detail.ShareToken = &p.ShareToken.String
+		}
+		detail.PublishAt = formatPublishAt(p)
+	}
+
+	return detail, nil
 }
 
 // ListMyPhotos lists current user's photos
-func (s *Service) ListMyPhotos(ctx context.Context, userID int64, page, pageSize int, status string) (*ListResponse, error) {
+func (s *Service) ListMyPhotos(ctx context.Context, userID int64, page, pageSize int, status, visibility string, scheduled bool) (*ListResponse, error) {
 	params := photo.ListParams{
-		Page:     page,
-		PageSize: pageSize,
-		UserID:   userID,
-		Status:   status,
+		Page:       page,
+		PageSize:   pageSize,
+		UserID:     userID,
+		Status:     status,
+		Visibility: visibility,
+		Schedule:   "all",
 	}
 	if status == "" {
 		params.Status = "all" // Show all statuses for own photos
 	}
+	if visibility == "" {
+		params.Visibility = "all"
+	}
+	if scheduled {
+		params.Schedule = "scheduled"
+	}
 
 	result, err := s.photoRepo.List(ctx, params)
 	if err != nil {
@@ -242,10 +431,26 @@ func (s *Service) ListMyPhotos(ctx context.Context, userID int64, page, pageSize
 		}
 	}
 
+	// Get why rejected photos were rejected
+	rejectedIDs := make([]int64, 0)
+	for _, p := range result.Photos {
+		if p.Status == model.PhotoStatusRejected || p.Status == model.PhotoStatusAIRejected {
+			rejectedIDs = append(rejectedIDs, p.ID)
+		}
+	}
+	rejections, err := s.photoRepo.GetRejectionMap(ctx, rejectedIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build response
 	list := make([]*model.PhotoListItem, len(result.Photos))
 	for i, p := range result.Photos {
 		list[i] = p.ToListItem(userBrief, s.baseURL)
+		list[i].Status = p.Status
+		list[i].Rejection = rejections[p.ID]
+		list[i].Visibility = p.Visibility
+		list[i].PublishAt = formatPublishAt(p)
 	}
 
 	return &ListResponse{
@@ -293,6 +498,9 @@ func (s *Service) ListUserPhotos(ctx context.Context, userID int64, page, pageSi
 	for i, p := range result.Photos {
 		list[i] = p.ToListItem(userBrief, s.baseURL)
 	}
+	if err := s.attachSeries(ctx, list); err != nil {
+		return nil, err
+	}
 
 	return &ListResponse{
 		List: list,
@@ -305,60 +513,20 @@ func (s *Service) ListUserPhotos(ctx context.Context, userID int64, page, pageSi
 	}, nil
 }
 
-// ListFavorites lists user's favorite photos
+// ListFavorites lists user's favorite photos across all folders
 func (s *Service) ListFavorites(ctx context.Context, userID int64, page, pageSize int) (*ListResponse, error) {
 	result, err := s.photoRepo.ListUserFavorites(ctx, userID, page, pageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get unique user IDs
-	userIDs := make([]int64, 0, len(result.Photos))
-	userIDMap := make(map[int64]bool)
-	for _, p := range result.Photos {
-		if !userIDMap[p.UserID] {
-			userIDs = append(userIDs, p.UserID)
-			userIDMap[p.UserID] = true
-		}
-	}
-
-	// Get users
-	users, err := s.photoRepo.GetUserMap(ctx, userIDs)
-	if err != nil {
-		return nil, err
-	}
-
-	// Build response
-	list := make([]*model.PhotoListItem, len(result.Photos))
-	for i, p := range result.Photos {
-		var userBrief *model.UserBrief
-		if u, ok := users[p.UserID]; ok {
-			userBrief = &model.UserBrief{
-				ID:       u.ID,
-				Username: u.Username,
-			}
-			if u.Avatar.Valid {
-				userBrief.Avatar = &u.Avatar.String
-			}
-		}
-		list[i] = p.ToListItem(userBrief, s.baseURL)
-	}
-
-	return &ListResponse{
-		List: list,
-		Pagination: Pagination{
-			Page:       result.Page,
-			PageSize:   result.PageSize,
-			Total:      result.Total,
-			TotalPages: result.TotalPages,
-		},
-	}, nil
+	return s.buildListResponse(ctx, result)
 }
 
 // AddFavorite adds a photo to user's favorites
 func (s *Service) AddFavorite(ctx context.Context, userID, photoID int64) error {
-	// Check if photo exists
-	exists, err := s.photoRepo.Exists(ctx, photoID)
+	// Check if photo exists and is visible to the user
+	exists, err := s.photoRepo.IsVisibleTo(ctx, photoID, userID)
 	if err != nil {
 		return err
 	}
@@ -380,8 +548,8 @@ func (s *Service) RemoveFavorite(ctx context.Context, userID, photoID int64) err
 
 // AddLike adds a like to a photo
 func (s *Service) AddLike(ctx context.Context, userID, photoID int64) error {
-	// Check if photo exists
-	exists, err := s.photoRepo.Exists(ctx, photoID)
+	// Check if photo exists and is visible to the user
+	exists, err := s.photoRepo.IsVisibleTo(ctx, photoID, userID)
 	if err != nil {
 		return err
 	}
@@ -401,7 +569,8 @@ func (s *Service) RemoveLike(ctx context.Context, userID, photoID int64) error {
 	return err
 }
 
-// Delete deletes a photo
+// Delete moves a photo to the trash. Its files are removed when it is
+// purged after the retention period.
 func (s *Service) Delete(ctx context.Context, photoID, userID int64, isAdmin bool) error {
 	// Check if photo exists
 	exists, err := s.photoRepo.Exists(ctx, photoID)
@@ -423,7 +592,5 @@ func (s *Service) Delete(ctx context.Context, photoID, userID int64, isAdmin boo
 		}
 	}
 
-	// TODO: Delete actual files from storage
-
-	return s.photoRepo.Delete(ctx, photoID)
+	return s.photoRepo.Delete(ctx, photoID, userID)
 }
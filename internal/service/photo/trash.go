@@ -0,0 +1,131 @@
+package photo
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/logger"
+	"QuanPhotos/internal/repository/postgresql/photo"
+)
+
+// purgeBatchSize is the maximum number of photos purged per transaction
+const purgeBatchSize = 100
+
+// TrashItem represents a photo in the trash
+type TrashItem struct {
+	ID           int64   `json:"id"`
+	Title        string  `json:"title"`
+	ThumbnailURL string  `json:"thumbnail_url,omitempty"`
+	Status       string  `json:"status"`
+	Visibility   string  `json:"visibility"`
+	DeletedAt    string  `json:"deleted_at"`
+	PurgeAt      *string `json:"purge_at"`   // Null when the trash is never purged
+	TakenDown    bool    `json:"taken_down"` // Deleted by an admin rather than the owner
+}
+
+// TrashResponse represents a page of photos in the trash
+type TrashResponse struct {
+	List       []*TrashItem `json:"list"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// SetTrashRetention sets how long deleted photos stay in the trash before
+// they are purged
+func (s *Service) SetTrashRetention(retention time.Duration) {
+	s.trashRetention = retention
+}
+
+// ListTrash lists the user's deleted photos that have not been purged yet
+func (s *Service) ListTrash(ctx context.Context, userID int64, page, pageSize int) (*TrashResponse, error) {
+	result, err := s.photoRepo.ListTrash(ctx, photo.TrashListParams{
+		Page:     page,
+		PageSize: pageSize,
+		UserID:   userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*TrashItem, len(result.Photos))
+	for i, p := range result.Photos {
+		list[i] = s.toTrashItem(p)
+	}
+
+	return &TrashResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// StartPurger purges photos whose retention period has expired every
+// interval in the background
+func (s *Service) StartPurger(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := s.PurgeTrash(context.Background()); err != nil {
+				logger.Warn("Failed to purge trash", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// PurgeTrash permanently deletes photos that have been in the trash longer
+// than the retention period and removes their files from storage. Returns
+// the number of photos purged.
+func (s *Service) PurgeTrash(ctx context.Context) (int, error) {
+	if s.trashRetention <= 0 {
+		return 0, nil
+	}
+
+	before := time.Now().Add(-s.trashRetention)
+	total := 0
+	for {
+		result, err := s.photoRepo.PurgeDeleted(ctx, before, purgeBatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += len(result.Photos)
+
+		for _, p := range result.Photos {
+			s.RemoveStoredFiles(ctx, p)
+		}
+		for _, r := range result.Replacements {
+			s.RemoveStoredFiles(ctx, r.StoredFiles())
+		}
+
+		if len(result.Photos) < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// toTrashItem converts a deleted photo to a trash item
+func (s *Service) toTrashItem(p *model.Photo) *TrashItem {
+	item := &TrashItem{
+		ID:         p.ID,
+		Title:      p.Title,
+		Status:     string(p.Status),
+		Visibility: string(p.Visibility),
+		DeletedAt:  p.DeletedAt.Time.Format(time.RFC3339),
+		TakenDown:  p.DeletedBy.Valid && p.DeletedBy.Int64 != p.UserID,
+	}
+	if p.ThumbnailPath.Valid {
+		item.ThumbnailURL = s.baseURL + p.ThumbnailPath.String
+	}
+	if s.trashRetention > 0 {
+		purgeAt := p.DeletedAt.Time.Add(s.trashRetention).Format(time.RFC3339)
+		item.PurgeAt = &purgeAt
+	}
+	return item
+}
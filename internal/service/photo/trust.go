@@ -0,0 +1,47 @@
+package photo
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/logger"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// autoApprove publishes a pending upload when the uploader's trust tier
+// allows it. Images flagged by quality screening are always spot checked.
+// Returns false and leaves the photo pending otherwise, including on failure.
+func (s *Service) autoApprove(ctx context.Context, userID int64, resp *UploadResponse) bool {
+	if s.trustPolicy == nil || resp.Status != string(model.PhotoStatusPending) {
+		return false
+	}
+
+	decision, err := s.trustPolicy.UploadDecision(ctx, userID)
+	if err != nil {
+		logger.Warn("Failed to evaluate uploader trust, photo left for review",
+			zap.Int64("photo_id", resp.ID),
+			zap.Error(err),
+		)
+		return false
+	}
+	if !decision.AutoApprove {
+		return false
+	}
+
+	err = s.photoRepo.AutoApprove(ctx, resp.ID, decision.Tier, decision.SpotCheck || resp.flagged)
+	if err != nil {
+		if !errors.Is(err, postgresql.ErrNotFound) {
+			logger.Warn("Failed to auto approve photo, photo left for review",
+				zap.Int64("photo_id", resp.ID),
+				zap.Error(err),
+			)
+		}
+		return false
+	}
+
+	resp.Status = string(model.PhotoStatusApproved)
+	return true
+}
@@ -3,6 +3,7 @@ package photo
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -12,10 +13,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 
 	"QuanPhotos/internal/config"
+	"QuanPhotos/internal/model"
 	exifPkg "QuanPhotos/internal/pkg/exif"
 	"QuanPhotos/internal/pkg/imaging"
+	"QuanPhotos/internal/pkg/logger"
 	"QuanPhotos/internal/pkg/storage"
 	"QuanPhotos/internal/repository/postgresql/photo"
 )
@@ -32,7 +36,19 @@ type UploadRequest struct {
 	Registration string
 	Airport      string
 	CategoryID   int32
+	SpotID       int64
+	FlightNumber string
+	Origin       string // Airport code (ICAO/IATA)
+	Destination  string // Airport code (ICAO/IATA)
+	FlightPhase  string // taxi, takeoff, landing, cruise, ground
 	Tags         string // Comma-separated
+
+	// Visibility, empty means public
+	Visibility model.PhotoVisibility
+	// Scheduled publication, nil publishes the photo once approved
+	PublishAt *time.Time
+
+	quotaBytes int64 // Storage quota enforced when saving, 0 for unlimited
 }
 
 // UploadResponse represents the upload response
@@ -40,6 +56,8 @@ type UploadResponse struct {
 	ID     int64  `json:"id"`
 	Status string `json:"status"`
 	Title  string `json:"title"`
+
+	flagged bool // Quality screening flagged the image
 }
 
 // Uploader handles photo upload logic
@@ -52,6 +70,7 @@ type Uploader struct {
 	config        *config.Config
 	allowedTypes  []string
 	maxUploadSize int64
+	thumbnails    []imaging.ThumbnailSize
 }
 
 // NewUploader creates a new photo uploader
@@ -62,8 +81,9 @@ func NewUploader(
 ) *Uploader {
 	// Create image processor with config
 	procConfig := imaging.ProcessorConfig{
-		MaxDimension: cfg.Image.MaxDimension,
-		Quality:      cfg.Image.Quality,
+		MaxDimension:   cfg.Image.MaxDimension,
+		Quality:        cfg.Image.Quality,
+		AnalyzeQuality: cfg.Quality.Enabled,
 		ThumbnailSizes: []imaging.ThumbnailSize{
 			{Name: "sm", Width: cfg.Image.ThumbSmWidth, Height: cfg.Image.ThumbSmHeight, Quality: cfg.Image.ThumbSmQuality},
 			{Name: "md", Width: cfg.Image.ThumbMdWidth, Height: cfg.Image.ThumbMdHeight, Quality: cfg.Image.ThumbMdQuality},
@@ -80,13 +100,51 @@ func NewUploader(
 		config:        cfg,
 		allowedTypes:  cfg.Storage.AllowedTypes,
 		maxUploadSize: cfg.Storage.MaxSize,
+		thumbnails:    procConfig.ThumbnailSizes,
 	}
 }
 
 // Upload handles the photo upload process
 func (u *Uploader) Upload(ctx context.Context, req *UploadRequest) (*UploadResponse, error) {
+	// 1. Validate, process and store the image
+	file, err := u.processFile(ctx, req.File, req.RawFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Save to database
+	createParams := u.buildCreateParams(req, file.params)
+	photoID, err := u.photoRepo.CreateWithTags(ctx, createParams)
+	if err != nil {
+		// Cleanup files on database error
+		u.cleanupProcessedFiles(file.result)
+		return nil, fmt.Errorf("failed to save photo: %w", quotaError(err))
+	}
+
+	// 3. Record quality screening
+	status := u.recordScreening(ctx, photoID, file)
+
+	return &UploadResponse{
+		ID:      photoID,
+		Status:  string(status),
+		Title:   req.Title,
+		flagged: file.verdict == model.ScreeningFlag,
+	}, nil
+}
+
+// processedFile is an uploaded image stored with its renditions
+type processedFile struct {
+	result  *imaging.ProcessResult
+	exif    *exifPkg.Data
+	params  photo.FileParams
+	verdict model.ScreeningVerdict // Set by recordScreening, empty when not screened
+}
+
+// processFile validates an uploaded image, stores the processed image,
+// its thumbnails and the optional RAW file, and reads its EXIF data
+func (u *Uploader) processFile(ctx context.Context, file, rawFile *multipart.FileHeader) (*processedFile, error) {
 	// 1. Validate file
-	if err := u.validateFile(req.File); err != nil {
+	if err := u.validateFile(file); err != nil {
 		return nil, err
 	}
 
@@ -94,15 +152,14 @@ func (u *Uploader) Upload(ctx context.Context, req *UploadRequest) (*UploadRespo
 	fileUUID := uuid.New().String()
 
 	// 3. Save to temp directory
-	tempPath, err := u.saveToTemp(req.File, fileUUID)
+	tempPath, err := u.saveToTemp(file, fileUUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save temp file: %w", err)
 	}
 	defer u.cleanupTemp(tempPath)
 
 	// 4. Validate file type by magic bytes
-	fileType, err := u.validateFileType(tempPath)
-	if err != nil {
+	if _, err := u.validateFileType(tempPath); err != nil {
 		return nil, err
 	}
 
@@ -136,30 +193,48 @@ func (u *Uploader) Upload(ctx context.Context, req *UploadRequest) (*UploadRespo
 		fileSize = fileInfo.Size()
 	}
 
-	// 8. Prepare database record
-	createParams := u.buildCreateParams(req, fileUUID, now, result, exifData, fileSize, fileType)
+	// 8. Prepare file record
+	params := u.buildFileParams(fileUUID, now, result, exifData, fileSize)
+	derivativeSize := thumbnailsSize(result)
+	params.DerivativeSize = &derivativeSize
 
 	// 9. Handle RAW file if present
-	if req.RawFile != nil {
-		rawPath, err := u.handleRawFile(ctx, req.RawFile, fileUUID, now)
+	if rawFile != nil {
+		rawPath, rawSize, err := u.handleRawFile(ctx, rawFile, fileUUID, now)
 		if err == nil {
-			createParams.RawFilePath = &rawPath
+			params.RawFilePath = &rawPath
+			params.RawFileSize = &rawSize
 		}
 	}
 
-	// 10. Save to database
-	photoID, err := u.photoRepo.CreateWithTags(ctx, createParams)
-	if err != nil {
-		// Cleanup files on database error
-		u.cleanupProcessedFiles(result)
-		return nil, fmt.Errorf("failed to save photo: %w", err)
+	return &processedFile{
+		result: result,
+		exif:   exifData,
+		params: params,
+	}, nil
+}
+
+// recordScreening screens a processed image and records the result.
+// Returns the resulting photo status, failures leave the photo pending.
+func (u *Uploader) recordScreening(ctx context.Context, photoID int64, file *processedFile) model.PhotoStatus {
+	if file.result.Quality == nil {
+		return model.PhotoStatusPending
 	}
 
-	return &UploadResponse{
-		ID:     photoID,
-		Status: "pending",
-		Title:  req.Title,
-	}, nil
+	screening := screenQuality(file.result.Quality, int(file.exif.ISO), u.config.Quality)
+	err := u.photoRepo.RecordScreening(ctx, photoID, screening, screeningReason(screening), screeningReasonCodes(screening))
+	if err != nil {
+		logger.Warn("Failed to record quality screening",
+			zap.Int64("photo_id", photoID),
+			zap.Error(err),
+		)
+		return model.PhotoStatusPending
+	}
+	file.verdict = screening.Verdict
+	if screening.Verdict == model.ScreeningReject {
+		return model.PhotoStatusAIRejected
+	}
+	return model.PhotoStatusPending
 }
 
 // validateFile validates the uploaded file
@@ -257,25 +332,18 @@ func (u *Uploader) parseEXIF(path string) (*exifPkg.Data, error) {
 }
 
 // buildCreateParams builds the photo creation parameters
-func (u *Uploader) buildCreateParams(
-	req *UploadRequest,
-	fileUUID string,
-	now time.Time,
-	result *imaging.ProcessResult,
-	exifData *exifPkg.Data,
-	fileSize int64,
-	_ storage.FileType, // fileType reserved for future use
-) *photo.CreatePhotoParams {
-	// Build relative paths for database storage
-	filePath := u.pathGen.RelativePhotoPath(now, fileUUID+".jpg")
-	thumbnailPath := u.pathGen.RelativeThumbnailPath(now, fileUUID)
-
+func (u *Uploader) buildCreateParams(req *UploadRequest, file photo.FileParams) *photo.CreatePhotoParams {
 	params := &photo.CreatePhotoParams{
-		UserID:        req.UserID,
-		Title:         req.Title,
-		FilePath:      filePath,
-		ThumbnailPath: &thumbnailPath,
-		FileSize:      &fileSize,
+		UserID:     req.UserID,
+		Title:      req.Title,
+		Visibility: req.Visibility,
+		PublishAt:  req.PublishAt,
+		FileParams: file,
+		QuotaBytes: req.quotaBytes,
+	}
+	if req.Visibility == model.PhotoVisibilityUnlisted {
+		shareToken := rand.Text()
+		params.ShareToken = &shareToken
 	}
 
 	// Optional fields
@@ -297,6 +365,21 @@ func (u *Uploader) buildCreateParams(
 	if req.CategoryID > 0 {
 		params.CategoryID = &req.CategoryID
 	}
+	if req.SpotID > 0 {
+		params.SpotID = &req.SpotID
+	}
+	if req.FlightNumber != "" {
+		params.FlightNumber = &req.FlightNumber
+	}
+	if req.Origin != "" {
+		params.Origin = &req.Origin
+	}
+	if req.Destination != "" {
+		params.Destination = &req.Destination
+	}
+	if req.FlightPhase != "" {
+		params.FlightPhase = &req.FlightPhase
+	}
 
 	// Parse tags
 	if req.Tags != "" {
@@ -307,6 +390,27 @@ func (u *Uploader) buildCreateParams(
 		params.Tags = tags
 	}
 
+	return params
+}
+
+// buildFileParams builds the stored file and EXIF parameters of a processed image
+func (u *Uploader) buildFileParams(
+	fileUUID string,
+	now time.Time,
+	result *imaging.ProcessResult,
+	exifData *exifPkg.Data,
+	fileSize int64,
+) photo.FileParams {
+	// Build relative paths for database storage
+	filePath := u.pathGen.RelativePhotoPath(now, fileUUID+".jpg")
+	thumbnailPath := u.pathGen.RelativeThumbnailPath(now, fileUUID)
+
+	params := photo.FileParams{
+		FilePath:      filePath,
+		ThumbnailPath: &thumbnailPath,
+		FileSize:      &fileSize,
+	}
+
 	// EXIF data
 	if exifData.CameraMake != "" {
 		params.ExifCameraMake = &exifData.CameraMake
@@ -328,12 +432,21 @@ func (u *Uploader) buildCreateParams(
 	}
 	if exifData.FocalLength35mm != "" {
 		params.ExifFocalLength35mm = &exifData.FocalLength35mm
+		if v, ok := exifPkg.ParseFocalLength(exifData.FocalLength35mm); ok {
+			params.ExifFocalLength35mmNum = &v
+		}
 	}
 	if exifData.Aperture != "" {
 		params.ExifAperture = &exifData.Aperture
+		if v, ok := exifPkg.ParseAperture(exifData.Aperture); ok {
+			params.ExifApertureNum = &v
+		}
 	}
 	if exifData.ShutterSpeed != "" {
 		params.ExifShutterSpeed = &exifData.ShutterSpeed
+		if v, ok := exifPkg.ParseShutterSpeed(exifData.ShutterSpeed); ok {
+			params.ExifShutterSpeedNum = &v
+		}
 	}
 	if exifData.ISO > 0 {
 		iso := int32(exifData.ISO)
@@ -389,37 +502,60 @@ func (u *Uploader) buildCreateParams(
 		params.ExifSoftware = &exifData.Software
 	}
 
+	// Normalized gear for gear pages
+	gear := exifPkg.NormalizeGear(exifData.CameraMake, exifData.CameraModel, exifData.LensModel)
+	if gear.CameraKey != "" {
+		params.GearCameraKey = &gear.CameraKey
+		params.GearCameraName = &gear.CameraName
+	}
+	if gear.LensKey != "" {
+		params.GearLensKey = &gear.LensKey
+		params.GearLensName = &gear.LensName
+	}
+
 	return params
 }
 
-// handleRawFile handles RAW file upload
-func (u *Uploader) handleRawFile(_ context.Context, rawFile *multipart.FileHeader, fileUUID string, now time.Time) (string, error) {
+// handleRawFile handles RAW file upload, returns its relative path and size
+func (u *Uploader) handleRawFile(_ context.Context, rawFile *multipart.FileHeader, fileUUID string, now time.Time) (string, int64, error) {
 	ext := strings.ToLower(filepath.Ext(rawFile.Filename))
 
 	// Save RAW file
 	src, err := rawFile.Open()
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	defer src.Close()
 
 	rawPath := u.pathGen.RawPath(now, fileUUID+ext)
 	if err := os.MkdirAll(filepath.Dir(rawPath), 0755); err != nil {
-		return "", err
+		return "", 0, err
 	}
 
 	dst, err := os.Create(rawPath)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	defer dst.Close()
 
-	if _, err := io.Copy(dst, src); err != nil {
-		return "", err
+	size, err := io.Copy(dst, src)
+	if err != nil {
+		return "", 0, err
 	}
 
 	// Return relative path for database
-	return u.pathGen.RelativeRawPath(now, fileUUID+ext), nil
+	return u.pathGen.RelativeRawPath(now, fileUUID+ext), size, nil
+}
+
+// thumbnailsSize returns the total size of the generated thumbnails
+func thumbnailsSize(result *imaging.ProcessResult) int64 {
+	var total int64
+	for _, path := range result.ThumbnailPaths {
+		if info, err := os.Stat(path); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
 }
 
 // cleanupTemp removes temporary file
@@ -439,3 +575,16 @@ func (u *Uploader) cleanupProcessedFiles(result *imaging.ProcessResult) {
 		os.Remove(path)
 	}
 }
+
+// removeStoredFiles removes the stored image, thumbnails and RAW file of a photo
+func (u *Uploader) removeStoredFiles(ctx context.Context, p *model.Photo) {
+	u.storage.Delete(ctx, p.FilePath)
+	if p.ThumbnailPath.Valid {
+		for _, size := range u.thumbnails {
+			u.storage.Delete(ctx, fmt.Sprintf("%s_%s.jpg", p.ThumbnailPath.String, size.Name))
+		}
+	}
+	if p.RawFilePath.Valid {
+		u.storage.Delete(ctx, p.RawFilePath.String)
+	}
+}
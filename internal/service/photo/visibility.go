@@ -0,0 +1,128 @@
+package photo
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"path"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+)
+
+// VisibilityRequest represents request for changing who can see a photo
+type VisibilityRequest struct {
+	Visibility  model.PhotoVisibility `json:"visibility" binding:"required,oneof=public unlisted private"`
+	RotateToken bool                  `json:"rotate_token"` // Issue a new share link for an unlisted photo, the old one stops working
+}
+
+// VisibilityResponse represents the visibility of a photo
+type VisibilityResponse struct {
+	ID         int64                 `json:"id"`
+	Visibility model.PhotoVisibility `json:"visibility"`
+	ShareToken *string               `json:"share_token,omitempty"` // Unlisted only
+}
+
+// UpdateVisibility changes who can see one of the user's photos. Unlisted
+// photos get a share token, which is kept until rotated or the photo stops
+// being unlisted.
+func (s *Service) UpdateVisibility(ctx context.Context, photoID, userID int64, req *VisibilityRequest) (*VisibilityResponse, error) {
+	if !req.Visibility.IsValid() {
+		return nil, ErrInvalidVisibility
+	}
+
+	p, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if p.UserID != userID {
+		return nil, ErrNotOwner
+	}
+
+	var shareToken *string
+	if req.Visibility == model.PhotoVisibilityUnlisted {
+		token := p.ShareToken.String
+		if !p.ShareToken.Valid || req.RotateToken {
+			token = rand.Text()
+		}
+		shareToken = &token
+	}
+
+	updated, err := s.photoRepo.UpdateVisibility(ctx, photoID, userID, req.Visibility, shareToken)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+
+	resp := &VisibilityResponse{ID: updated.ID, Visibility: updated.Visibility}
+	if updated.ShareToken.Valid {
+		resp.ShareToken = &updated.ShareToken.String
+	}
+	return resp, nil
+}
+
+// GetShared retrieves the photo a share link points to. Share links stop
+// working once the photo is made private and only work once it is published.
+func (s *Service) GetShared(ctx context.Context, token string, currentUserID *int64) (*model.PhotoDetail, error) {
+	p, err := s.photoRepo.GetByShareToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if p.Status != model.PhotoStatusApproved || p.Visibility == model.PhotoVisibilityPrivate || p.IsScheduled() {
+		return nil, ErrPhotoNotFound
+	}
+
+	return s.buildDetail(ctx, p, currentUserID)
+}
+
+// CanAccessFile checks if a user may download a stored file. Files of private
+// and scheduled photos are only served to their owner and to staff, who
+// review them; other files are public.
+func (s *Service) CanAccessFile(ctx context.Context, filePath string, userID *int64, isStaff bool) (bool, error) {
+	photoPath, ok := photoFilePath(filePath)
+	if !ok || isStaff {
+		return true, nil
+	}
+
+	ownerID, err := s.photoRepo.GetHiddenFileOwner(ctx, photoPath)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	return userID != nil && *userID == ownerID, nil
+}
+
+// photoFilePath maps a stored image, thumbnail or RAW file to the file path
+// of its photo. Every file of a photo is named after the same UUID in the
+// same date directory, thumbnails carry a size suffix.
+func photoFilePath(filePath string) (string, bool) {
+	dir, name := path.Split(path.Clean("/" + filePath))
+	kind, date, ok := strings.Cut(strings.TrimPrefix(dir, "/"), "/")
+	if !ok || name == "" {
+		return "", false
+	}
+
+	base := strings.TrimSuffix(name, path.Ext(name))
+	switch kind {
+	case "photos", "raw":
+	case "thumbnails":
+		if i := strings.LastIndex(base, "_"); i > 0 {
+			base = base[:i]
+		}
+	default:
+		return "", false
+	}
+
+	return "/photos/" + date + base + ".jpg", true
+}
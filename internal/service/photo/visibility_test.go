@@ -0,0 +1,54 @@
+package photo
+
+import "testing"
+
+func TestPhotoFilePath(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		expect string
+		ok     bool
+	}{
+		{
+			name:   "Main image",
+			path:   "/photos/2026/10/01/4f1c.jpg",
+			expect: "/photos/2026/10/01/4f1c.jpg",
+			ok:     true,
+		},
+		{
+			name:   "Thumbnail size suffix is dropped",
+			path:   "/thumbnails/2026/10/01/4f1c_md.jpg",
+			expect: "/photos/2026/10/01/4f1c.jpg",
+			ok:     true,
+		},
+		{
+			name:   "RAW file extension is replaced",
+			path:   "/raw/2026/10/01/4f1c.cr3",
+			expect: "/photos/2026/10/01/4f1c.jpg",
+			ok:     true,
+		},
+		{
+			name:   "Path is cleaned before mapping",
+			path:   "/photos/../thumbnails/2026/10/01/4f1c_lg.jpg",
+			expect: "/photos/2026/10/01/4f1c.jpg",
+			ok:     true,
+		},
+		{
+			name: "Files outside photo directories",
+			path: "/temp/upload.jpg",
+		},
+		{
+			name: "Directory listing",
+			path: "/photos/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := photoFilePath(tt.path)
+			if ok != tt.ok || got != tt.expect {
+				t.Errorf("photoFilePath(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.expect, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"QuanPhotos/internal/config"
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/logger"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/quota"
+)
+
+// measureBatchSize is the maximum number of photos measured per query
+const measureBatchSize = 100
+
+// bytesPerMB converts quota limits to bytes
+const bytesPerMB = 1024 * 1024
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// Sources of a user's quota limit
+const (
+	SourceOverride = "override"
+	SourceTier     = "tier"
+	SourceRole     = "role"
+)
+
+// FileMeasurer measures the stored files of photos on disk
+type FileMeasurer interface {
+	MeasureStoredFiles(ctx context.Context, p *model.Photo) (fileSize, rawSize, derivativeSize int64)
+}
+
+// Service tracks storage usage and enforces per-user storage quotas
+type Service struct {
+	quotaRepo *quota.QuotaRepository
+	measurer  FileMeasurer
+	enabled   bool
+	defaultMB int64
+	roleMB    map[string]int64
+}
+
+// New creates a new quota service
+func New(quotaRepo *quota.QuotaRepository, cfg *config.Config) *Service {
+	return &Service{
+		quotaRepo: quotaRepo,
+		enabled:   cfg.Quota.Enabled,
+		defaultMB: cfg.Quota.DefaultMB,
+		roleMB:    cfg.Quota.RoleMB,
+	}
+}
+
+// SetFileMeasurer enables measuring stored files whose sizes were not recorded
+func (s *Service) SetFileMeasurer(measurer FileMeasurer) {
+	s.measurer = measurer
+}
+
+// StorageUsage represents the storage usage and quota of a user
+type StorageUsage struct {
+	UserID         int64   `json:"user_id"`
+	UsedBytes      int64   `json:"used_bytes"`
+	QuotaBytes     *int64  `json:"quota_bytes"`     // Null when unlimited
+	RemainingBytes *int64  `json:"remaining_bytes"` // Null when unlimited
+	Source         string  `json:"source"`          // override, tier, role
+	Role           string  `json:"role"`
+	Tier           string  `json:"tier"`
+	Enforced       bool    `json:"enforced"`              // Uploads over the quota are refused
+	OverrideMB     *int64  `json:"override_mb,omitempty"` // Admin view only
+	OverrideNote   *string `json:"override_note,omitempty"`
+	ReconciledAt   *string `json:"reconciled_at"`
+}
+
+// SetOverrideRequest represents request for overriding a user's quota
+type SetOverrideRequest struct {
+	QuotaMB *int64 `json:"quota_mb" binding:"omitempty,min=0"` // null clears the override, 0 is unlimited
+	Note    string `json:"note" binding:"max=500"`
+}
+
+// ReconcileResult represents the outcome of a reconciliation run
+type ReconcileResult struct {
+	Measured  int `json:"measured"`  // Photos whose stored files were measured
+	Corrected int `json:"corrected"` // Users whose recorded usage was corrected
+}
+
+// AllowsUpload reports whether size more bytes fit the user's quota and
+// returns the quota in bytes, 0 when unlimited or not enforced
+func (s *Service) AllowsUpload(ctx context.Context, userID, size int64) (int64, bool, error) {
+	if !s.enabled {
+		return 0, true, nil
+	}
+
+	u, err := s.quotaRepo.GetUserStorage(ctx, userID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return 0, false, ErrUserNotFound
+		}
+		return 0, false, err
+	}
+
+	limitMB, _ := s.resolveLimit(u)
+	if limitMB == 0 {
+		return 0, true, nil
+	}
+	quotaBytes := limitMB * bytesPerMB
+	return quotaBytes, u.UsedBytes+size <= quotaBytes, nil
+}
+
+// GetUsage returns the storage usage and quota of a user
+func (s *Service) GetUsage(ctx context.Context, userID int64) (*StorageUsage, error) {
+	u, err := s.quotaRepo.GetUserStorage(ctx, userID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return s.toStorageUsage(u), nil
+}
+
+// GetUserUsage returns the storage usage of a user with the admin override
+func (s *Service) GetUserUsage(ctx context.Context, userID int64) (*StorageUsage, error) {
+	u, err := s.quotaRepo.GetUserStorage(ctx, userID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	usage := s.toStorageUsage(u)
+	if u.QuotaOverrideMB.Valid {
+		usage.OverrideMB = &u.QuotaOverrideMB.Int64
+	}
+	if u.OverrideNote.Valid {
+		usage.OverrideNote = &u.OverrideNote.String
+	}
+	return usage, nil
+}
+
+// SetOverride sets or clears the quota override of a user
+func (s *Service) SetOverride(ctx context.Context, userID, operatorID int64, req *SetOverrideRequest) (*StorageUsage, error) {
+	if _, err := s.quotaRepo.GetUserStorage(ctx, userID); err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.quotaRepo.SetOverride(ctx, userID, req.QuotaMB, operatorID, req.Note); err != nil {
+		return nil, err
+	}
+	return s.GetUserUsage(ctx, userID)
+}
+
+// StartReconciler corrects recorded usage every interval in the background
+func (s *Service) StartReconciler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := s.Reconcile(context.Background()); err != nil {
+				logger.Warn("Failed to reconcile storage usage", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// Reconcile measures stored files whose sizes were not recorded, then sets
+// the recorded usage of users that drifted from their stored photos
+func (s *Service) Reconcile(ctx context.Context) (*ReconcileResult, error) {
+	started := time.Now()
+	result := &ReconcileResult{}
+
+	if s.measurer != nil {
+		for {
+			photos, err := s.quotaRepo.ListUnmeasured(ctx, measureBatchSize)
+			if err != nil {
+				return result, err
+			}
+
+			for _, p := range photos {
+				fileSize, rawSize, derivativeSize := s.measurer.MeasureStoredFiles(ctx, p)
+				if err := s.quotaRepo.SetMeasuredSizes(ctx, p.ID, fileSize, rawSize, derivativeSize); err != nil {
+					return result, err
+				}
+				result.Measured++
+			}
+
+			if len(photos) < measureBatchSize {
+				break
+			}
+		}
+	}
+
+	userIDs, err := s.quotaRepo.ListDrifted(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, userID := range userIDs {
+		drift, err := s.quotaRepo.Recompute(ctx, userID)
+		if err != nil {
+			// The user may have been deleted meanwhile
+			logger.Warn("Failed to recompute storage usage",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if drift.Recorded != drift.Actual {
+			logger.Info("Corrected storage usage",
+				zap.Int64("user_id", drift.UserID),
+				zap.Int64("recorded_bytes", drift.Recorded),
+				zap.Int64("actual_bytes", drift.Actual),
+			)
+			result.Corrected++
+		}
+	}
+
+	if err := s.quotaRepo.MarkReconciled(ctx, started); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// resolveLimit returns the quota of a user in megabytes and where it comes
+// from, 0 is unlimited. An admin override wins, otherwise the more generous
+// of the role and trust tier limits applies.
+func (s *Service) resolveLimit(u *model.UserStorage) (int64, string) {
+	if u.QuotaOverrideMB.Valid {
+		return u.QuotaOverrideMB.Int64, SourceOverride
+	}
+
+	roleMB, ok := s.roleMB[string(u.Role)]
+	if !ok {
+		roleMB = s.defaultMB
+	}
+	if u.TierQuotaMB.Valid && moreGenerous(u.TierQuotaMB.Int64, roleMB) {
+		return u.TierQuotaMB.Int64, SourceTier
+	}
+	return roleMB, SourceRole
+}
+
+// moreGenerous reports whether limit a allows more than limit b, 0 is unlimited
+func moreGenerous(a, b int64) bool {
+	if b == 0 {
+		return false
+	}
+	return a == 0 || a > b
+}
+
+// toStorageUsage converts a storage record to the usage shown to its user
+func (s *Service) toStorageUsage(u *model.UserStorage) *StorageUsage {
+	limitMB, source := s.resolveLimit(u)
+	usage := &StorageUsage{
+		UserID:       u.UserID,
+		UsedBytes:    u.UsedBytes,
+		Source:       source,
+		Role:         string(u.Role),
+		Tier:         u.Tier,
+		Enforced:     s.enabled,
+		ReconciledAt: u.ReconciledAtString(),
+	}
+	if limitMB > 0 {
+		quotaBytes := limitMB * bytesPerMB
+		remaining := max(quotaBytes-u.UsedBytes, 0)
+		usage.QuotaBytes = &quotaBytes
+		usage.RemainingBytes = &remaining
+	}
+	return usage
+}
@@ -0,0 +1,77 @@
+package quota
+
+import (
+	"database/sql"
+	"testing"
+
+	"QuanPhotos/internal/model"
+)
+
+func TestResolveLimit(t *testing.T) {
+	s := &Service{
+		defaultMB: 10240,
+		roleMB:    map[string]int64{"reviewer": 51200, "admin": 0},
+	}
+	limit := func(mb int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: mb, Valid: true}
+	}
+
+	tests := []struct {
+		name         string
+		storage      model.UserStorage
+		expectMB     int64
+		expectSource string
+	}{
+		{
+			name:         "Roles without their own limit use the default",
+			storage:      model.UserStorage{Role: model.RoleUser},
+			expectMB:     10240,
+			expectSource: SourceRole,
+		},
+		{
+			name:         "Role limit",
+			storage:      model.UserStorage{Role: model.RoleReviewer},
+			expectMB:     51200,
+			expectSource: SourceRole,
+		},
+		{
+			name:         "Larger tier limit raises the role limit",
+			storage:      model.UserStorage{Role: model.RoleUser, TierQuotaMB: limit(20480)},
+			expectMB:     20480,
+			expectSource: SourceTier,
+		},
+		{
+			name:         "Smaller tier limit leaves the role limit",
+			storage:      model.UserStorage{Role: model.RoleReviewer, TierQuotaMB: limit(20480)},
+			expectMB:     51200,
+			expectSource: SourceRole,
+		},
+		{
+			name:         "Unlimited tier",
+			storage:      model.UserStorage{Role: model.RoleUser, TierQuotaMB: limit(0)},
+			expectMB:     0,
+			expectSource: SourceTier,
+		},
+		{
+			name:         "Unlimited role ignores the tier",
+			storage:      model.UserStorage{Role: model.RoleAdmin, TierQuotaMB: limit(20480)},
+			expectMB:     0,
+			expectSource: SourceRole,
+		},
+		{
+			name:         "Override wins even when lower",
+			storage:      model.UserStorage{Role: model.RoleReviewer, TierQuotaMB: limit(0), QuotaOverrideMB: limit(1024)},
+			expectMB:     1024,
+			expectSource: SourceOverride,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mb, source := s.resolveLimit(&tt.storage)
+			if mb != tt.expectMB || source != tt.expectSource {
+				t.Errorf("resolveLimit() = %d, %s, expected %d, %s", mb, source, tt.expectMB, tt.expectSource)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package rejection
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/rejection"
+)
+
+var (
+	ErrReasonNotFound = errors.New("rejection reason not found")
+	ErrDuplicateCode  = errors.New("rejection reason code already exists")
+	ErrReasonInUse    = errors.New("rejection reason has been used, deactivate it instead")
+	ErrInvalidCode    = errors.New("code must be lowercase letters, digits and underscores")
+)
+
+// codePattern matches stable, i18n-friendly reason codes such as "soft_focus"
+var codePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Service handles rejection reason catalogue business logic
+type Service struct {
+	rejectionRepo *rejection.RejectionRepository
+}
+
+// New creates a new rejection reason service
+func New(rejectionRepo *rejection.RejectionRepository) *Service {
+	return &Service{
+		rejectionRepo: rejectionRepo,
+	}
+}
+
+// ReasonItem represents a rejection reason in response
+type ReasonItem struct {
+	ID          int32   `json:"id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	NameEN      string  `json:"name_en"`
+	Description *string `json:"description,omitempty"`
+	SortOrder   int     `json:"sort_order"`
+	IsActive    bool    `json:"is_active"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// List retrieves the rejection reason catalogue, inactive reasons are only
+// included for catalogue management
+func (s *Service) List(ctx context.Context, includeInactive bool) ([]ReasonItem, error) {
+	reasons, err := s.rejectionRepo.List(ctx, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]ReasonItem, len(reasons))
+	for i, r := range reasons {
+		list[i] = toReasonItem(r)
+	}
+	return list, nil
+}
+
+// ReasonRequest represents request for creating or updating a rejection reason
+type ReasonRequest struct {
+	Code        string `json:"code" binding:"required,max=50"`
+	Name        string `json:"name" binding:"required,max=100"`
+	NameEN      string `json:"name_en" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+	SortOrder   int    `json:"sort_order"`
+	IsActive    *bool  `json:"is_active"` // Defaults to true
+}
+
+// Create creates a new rejection reason
+func (s *Service) Create(ctx context.Context, req *ReasonRequest) (*ReasonItem, error) {
+	if !codePattern.MatchString(req.Code) {
+		return nil, ErrInvalidCode
+	}
+
+	reason, err := s.rejectionRepo.Create(ctx, toReasonParams(req))
+	if err != nil {
+		if errors.Is(err, postgresql.ErrDuplicateKey) {
+			return nil, ErrDuplicateCode
+		}
+		return nil, err
+	}
+
+	item := toReasonItem(reason)
+	return &item, nil
+}
+
+// Update updates a rejection reason
+func (s *Service) Update(ctx context.Context, id int32, req *ReasonRequest) (*ReasonItem, error) {
+	if !codePattern.MatchString(req.Code) {
+		return nil, ErrInvalidCode
+	}
+
+	reason, err := s.rejectionRepo.Update(ctx, id, toReasonParams(req))
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrReasonNotFound
+		}
+		if errors.Is(err, postgresql.ErrDuplicateKey) {
+			return nil, ErrDuplicateCode
+		}
+		return nil, err
+	}
+
+	item := toReasonItem(reason)
+	return &item, nil
+}
+
+// Delete deletes a rejection reason that has never been used
+func (s *Service) Delete(ctx context.Context, id int32) error {
+	used, err := s.rejectionRepo.IsUsed(ctx, id)
+	if err != nil {
+		return err
+	}
+	if used {
+		return ErrReasonInUse
+	}
+
+	err = s.rejectionRepo.Delete(ctx, id)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrReasonNotFound
+	}
+	return err
+}
+
+func toReasonParams(req *ReasonRequest) rejection.ReasonParams {
+	params := rejection.ReasonParams{
+		Code:        req.Code,
+		Name:        req.Name,
+		NameEN:      req.NameEN,
+		Description: req.Description,
+		SortOrder:   req.SortOrder,
+		IsActive:    true,
+	}
+	if req.IsActive != nil {
+		params.IsActive = *req.IsActive
+	}
+	return params
+}
+
+func toReasonItem(r *model.RejectionReason) ReasonItem {
+	item := ReasonItem{
+		ID:        r.ID,
+		Code:      r.Code,
+		Name:      r.Name,
+		NameEN:    r.NameEN,
+		SortOrder: r.SortOrder,
+		IsActive:  r.IsActive,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.Description.Valid {
+		item.Description = &r.Description.String
+	}
+	return item
+}
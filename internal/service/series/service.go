@@ -0,0 +1,473 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+	"QuanPhotos/internal/repository/postgresql/series"
+)
+
+// MaxFrames is the number of frames a series may have
+const MaxFrames = 50
+
+var (
+	ErrSeriesNotFound     = errors.New("series not found")
+	ErrNotOwner           = errors.New("you are not the owner of this series")
+	ErrPhotoNotFound      = errors.New("photo not found")
+	ErrPhotoInOtherSeries = errors.New("photo is already a frame of another series")
+	ErrPhotoNotInSeries   = errors.New("photo is not a frame of this series")
+	ErrTitleRequired      = errors.New("series title is required")
+	ErrTooManyFrames      = errors.New("series frame limit reached")
+	ErrLastFrame          = errors.New("a series must keep at least one frame")
+	ErrNotLiked           = errors.New("series is not liked")
+)
+
+// Service handles photo series business logic
+type Service struct {
+	seriesRepo *series.SeriesRepository
+	photoRepo  *photo.PhotoRepository
+	baseURL    string
+}
+
+// New creates a new series service
+func New(seriesRepo *series.SeriesRepository, photoRepo *photo.PhotoRepository, baseURL string) *Service {
+	return &Service{
+		seriesRepo: seriesRepo,
+		photoRepo:  photoRepo,
+		baseURL:    baseURL,
+	}
+}
+
+// Pagination represents pagination info
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// CreateRequest represents request for creating a series
+type CreateRequest struct {
+	Title       string  `json:"title" binding:"required,max=200"`
+	Description string  `json:"description" binding:"max=2000"`
+	PhotoIDs    []int64 `json:"photo_ids" binding:"required,min=1,max=50,dive,gt=0"` // Frames in order
+}
+
+// UpdateRequest represents request for updating a series, omitted fields are
+// left unchanged
+type UpdateRequest struct {
+	Title       *string `json:"title" binding:"omitempty,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+}
+
+// FramesRequest represents request for adding or removing frames in bulk
+type FramesRequest struct {
+	PhotoIDs []int64 `json:"photo_ids" binding:"required,min=1,max=50,dive,gt=0"`
+}
+
+// MoveFrameRequest represents request for moving a frame within a series
+type MoveFrameRequest struct {
+	Position int `json:"position" binding:"required,min=1"` // 1 is the first frame
+}
+
+// FramesResponse represents the result of adding or removing frames
+type FramesResponse struct {
+	Changed    int `json:"changed"` // Frames added or removed, photos already in or not in the series are skipped
+	FrameCount int `json:"frame_count"`
+}
+
+// ListResponse represents response for listing series
+type ListResponse struct {
+	List       []*model.SeriesListItem `json:"list"`
+	Pagination Pagination              `json:"pagination"`
+}
+
+// Create creates a series from the user's photos. Each frame keeps its own
+// review status, the series is shown to others once one frame is visible.
+func (s *Service) Create(ctx context.Context, userID int64, req *CreateRequest) (*model.SeriesDetail, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, ErrTitleRequired
+	}
+
+	photoIDs := uniqueIDs(req.PhotoIDs)
+	if err := s.checkPhotos(ctx, userID, 0, photoIDs); err != nil {
+		return nil, err
+	}
+
+	id, err := s.seriesRepo.Create(ctx, &series.CreateParams{
+		UserID:      userID,
+		Title:       title,
+		Description: optionalString(req.Description),
+		PhotoIDs:    photoIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetDetail(ctx, id, &userID)
+}
+
+// List retrieves the series of all users that have visible frames, newest first
+func (s *Service) List(ctx context.Context, page, pageSize int) (*ListResponse, error) {
+	return s.list(ctx, series.ListParams{
+		Page:       page,
+		PageSize:   pageSize,
+		PublicOnly: true,
+	})
+}
+
+// ListMine retrieves all of the user's series, including those without
+// visible frames yet
+func (s *Service) ListMine(ctx context.Context, userID int64, page, pageSize int) (*ListResponse, error) {
+	return s.list(ctx, series.ListParams{
+		Page:     page,
+		PageSize: pageSize,
+		UserID:   userID,
+	})
+}
+
+// ListUserSeries retrieves a user's series shown on their profile
+func (s *Service) ListUserSeries(ctx context.Context, userID int64, page, pageSize int) (*ListResponse, error) {
+	return s.list(ctx, series.ListParams{
+		Page:       page,
+		PageSize:   pageSize,
+		UserID:     userID,
+		PublicOnly: true,
+	})
+}
+
+func (s *Service) list(ctx context.Context, params series.ListParams) (*ListResponse, error) {
+	result, err := s.seriesRepo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get unique user IDs
+	userIDs := make([]int64, 0, len(result.Series))
+	userIDMap := make(map[int64]bool)
+	for _, sr := range result.Series {
+		if !userIDMap[sr.UserID] {
+			userIDs = append(userIDs, sr.UserID)
+			userIDMap[sr.UserID] = true
+		}
+	}
+
+	users, err := s.photoRepo.GetUserMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.SeriesListItem, len(result.Series))
+	for i, sr := range result.Series {
+		list[i] = sr.ToListItem(userBrief(users[sr.UserID]), s.baseURL)
+	}
+
+	return &ListResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// GetDetail retrieves a series with its frames in order. Other users only
+// see approved frames that are not private or scheduled, and no series
+// without such frames.
+func (s *Service) GetDetail(ctx context.Context, seriesID int64, currentUserID *int64) (*model.SeriesDetail, error) {
+	sr, isOwner, err := s.getVisible(ctx, seriesID, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	photos, err := s.seriesRepo.ListFrames(ctx, seriesID, isOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.photoRepo.GetUserMap(ctx, []int64{sr.UserID})
+	if err != nil {
+		return nil, err
+	}
+	user := userBrief(users[sr.UserID])
+
+	frames := make([]*model.PhotoListItem, len(photos))
+	for i, p := range photos {
+		frames[i] = p.ToListItem(user, s.baseURL)
+		if isOwner {
+			frames[i].Status = p.Status
+			frames[i].Visibility = p.Visibility
+		}
+	}
+
+	var isLiked bool
+	if currentUserID != nil {
+		isLiked, _ = s.seriesRepo.IsLiked(ctx, *currentUserID, seriesID)
+	}
+
+	return sr.ToDetail(user, frames, isLiked, isOwner), nil
+}
+
+// Update updates the title or description of one of the user's series
+func (s *Service) Update(ctx context.Context, seriesID, userID int64, req *UpdateRequest) (*model.SeriesDetail, error) {
+	sr, err := s.getOwned(ctx, seriesID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	title := sr.Title
+	var description *string
+	if sr.Description.Valid {
+		description = &sr.Description.String
+	}
+
+	if req.Title != nil {
+		title = strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, ErrTitleRequired
+		}
+	}
+	if req.Description != nil {
+		description = optionalString(*req.Description)
+	}
+
+	if err := s.seriesRepo.Update(ctx, seriesID, title, description); err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+
+	return s.GetDetail(ctx, seriesID, &userID)
+}
+
+// Delete deletes one of the user's series with its likes and comments, the
+// frames are kept as single photos
+func (s *Service) Delete(ctx context.Context, seriesID, userID int64) error {
+	if _, err := s.getOwned(ctx, seriesID, userID); err != nil {
+		return err
+	}
+
+	err := s.seriesRepo.Delete(ctx, seriesID)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrSeriesNotFound
+	}
+	return err
+}
+
+// AddFrames appends the user's photos to the end of a series in the given
+// order. Photos must not be frames of another series.
+func (s *Service) AddFrames(ctx context.Context, seriesID, userID int64, req *FramesRequest) (*FramesResponse, error) {
+	if _, err := s.getOwned(ctx, seriesID, userID); err != nil {
+		return nil, err
+	}
+
+	photoIDs := uniqueIDs(req.PhotoIDs)
+	if err := s.checkPhotos(ctx, userID, seriesID, photoIDs); err != nil {
+		return nil, err
+	}
+
+	count, err := s.seriesRepo.CountFrames(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if count+len(photoIDs) > MaxFrames {
+		return nil, ErrTooManyFrames
+	}
+
+	added, err := s.seriesRepo.AddFrames(ctx, seriesID, photoIDs)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+
+	return s.framesResponse(ctx, seriesID, added)
+}
+
+// RemoveFrames removes frames from one of the user's series, the photos
+// themselves are kept. A series keeps at least one frame, delete the series
+// to ungroup all of them.
+func (s *Service) RemoveFrames(ctx context.Context, seriesID, userID int64, req *FramesRequest) (*FramesResponse, error) {
+	if _, err := s.getOwned(ctx, seriesID, userID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.seriesRepo.CountFrames(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	photoIDs := uniqueIDs(req.PhotoIDs)
+	if len(photoIDs) >= count {
+		return nil, ErrLastFrame
+	}
+
+	removed, err := s.seriesRepo.RemoveFrames(ctx, seriesID, photoIDs)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+
+	return s.framesResponse(ctx, seriesID, removed)
+}
+
+// MoveFrame moves a frame to a new position in one of the user's series
+func (s *Service) MoveFrame(ctx context.Context, seriesID, photoID, userID int64, req *MoveFrameRequest) error {
+	if _, err := s.getOwned(ctx, seriesID, userID); err != nil {
+		return err
+	}
+
+	err := s.seriesRepo.MoveFrame(ctx, seriesID, photoID, req.Position)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrPhotoNotInSeries
+	}
+	return err
+}
+
+// AddLike adds a like to a series the user can see
+func (s *Service) AddLike(ctx context.Context, userID, seriesID int64) error {
+	if _, _, err := s.getVisible(ctx, seriesID, &userID); err != nil {
+		return err
+	}
+
+	return s.seriesRepo.AddLike(ctx, userID, seriesID)
+}
+
+// RemoveLike removes a like from a series
+func (s *Service) RemoveLike(ctx context.Context, userID, seriesID int64) error {
+	err := s.seriesRepo.RemoveLike(ctx, userID, seriesID)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrNotLiked
+	}
+	return err
+}
+
+// checkPhotos checks that every photo belongs to the user, is not in the
+// trash and is not a frame of another series than the given one
+func (s *Service) checkPhotos(ctx context.Context, userID, seriesID int64, photoIDs []int64) error {
+	owned, err := s.seriesRepo.CountOwnedPhotos(ctx, userID, photoIDs)
+	if err != nil {
+		return err
+	}
+	if owned != len(photoIDs) {
+		return ErrPhotoNotFound
+	}
+
+	taken, err := s.seriesRepo.CountTakenPhotos(ctx, seriesID, photoIDs)
+	if err != nil {
+		return err
+	}
+	if taken > 0 {
+		return ErrPhotoInOtherSeries
+	}
+	return nil
+}
+
+// getVisible retrieves a series the current user may see and whether they
+// own it
+func (s *Service) getVisible(ctx context.Context, seriesID int64, currentUserID *int64) (*model.Series, bool, error) {
+	sr, err := s.seriesRepo.GetByID(ctx, seriesID, false)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, false, ErrSeriesNotFound
+		}
+		return nil, false, err
+	}
+
+	isOwner := currentUserID != nil && *currentUserID == sr.UserID
+	if !isOwner {
+		// Hidden until a frame is approved and visible
+		if sr.FrameCount == 0 {
+			return nil, false, ErrSeriesNotFound
+		}
+		return sr, false, nil
+	}
+
+	// Owners see every frame that is not in the trash
+	sr, err = s.seriesRepo.GetByID(ctx, seriesID, true)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, false, ErrSeriesNotFound
+		}
+		return nil, false, err
+	}
+	return sr, true, nil
+}
+
+// getOwned retrieves one of the user's series
+func (s *Service) getOwned(ctx context.Context, seriesID, userID int64) (*model.Series, error) {
+	sr, err := s.seriesRepo.GetByID(ctx, seriesID, true)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	if sr.UserID != userID {
+		return nil, ErrNotOwner
+	}
+	return sr, nil
+}
+
+// framesResponse reports the number of changed frames with the new frame
+// count of a series
+func (s *Service) framesResponse(ctx context.Context, seriesID int64, changed int) (*FramesResponse, error) {
+	sr, err := s.seriesRepo.GetByID(ctx, seriesID, true)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+
+	return &FramesResponse{
+		Changed:    changed,
+		FrameCount: sr.FrameCount,
+	}, nil
+}
+
+// userBrief converts a user to the brief shown with series
+func userBrief(u *model.User) *model.UserBrief {
+	if u == nil {
+		return nil
+	}
+	brief := &model.UserBrief{
+		ID:       u.ID,
+		Username: u.Username,
+	}
+	if u.Avatar.Valid {
+		brief.Avatar = &u.Avatar.String
+	}
+	return brief
+}
+
+// uniqueIDs removes duplicate IDs, keeping the first occurrence
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func optionalString(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,410 @@
+package spot
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/aviation"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/photo"
+	"QuanPhotos/internal/repository/postgresql/spot"
+)
+
+var (
+	ErrSpotNotFound       = errors.New("spotting spot not found")
+	ErrPhotoNotFound      = errors.New("photo not found")
+	ErrNotOwner           = errors.New("you are not the owner of this photo")
+	ErrInvalidAirport     = errors.New("airport is not the IATA or ICAO code of a known airport")
+	ErrInvalidCoordinates = errors.New("invalid coordinates")
+	ErrInvalidAction      = errors.New("invalid review action")
+	ErrAlreadyReviewed    = errors.New("spot has already been reviewed")
+	ErrNoPhotoLocation    = errors.New("photo has no GPS location")
+)
+
+const (
+	// suggestRadiusKm is the radius used to suggest spots from photo GPS
+	suggestRadiusKm = 3.0
+	// maxNearbyRadiusKm limits nearby spot searches
+	maxNearbyRadiusKm = 50.0
+	// commonFocalLengths is the number of most used focal lengths shown per spot
+	commonFocalLengths = 5
+)
+
+// Service handles spotting spot business logic
+type Service struct {
+	spotRepo  *spot.SpotRepository
+	photoRepo *photo.PhotoRepository
+	baseURL   string
+}
+
+// New creates a new spot service
+func New(spotRepo *spot.SpotRepository, photoRepo *photo.PhotoRepository, baseURL string) *Service {
+	return &Service{
+		spotRepo:  spotRepo,
+		photoRepo: photoRepo,
+		baseURL:   baseURL,
+	}
+}
+
+// Pagination represents pagination info
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// CreateRequest represents request for submitting a spot
+type CreateRequest struct {
+	Airport        string   `json:"airport" binding:"required"`
+	Name           string   `json:"name" binding:"required,max=100"`
+	Latitude       *float64 `json:"latitude" binding:"required"`
+	Longitude      *float64 `json:"longitude" binding:"required"`
+	Description    string   `json:"description" binding:"max=2000"`
+	AccessNotes    string   `json:"access_notes" binding:"max=2000"`
+	BestLightTimes string   `json:"best_light_times" binding:"max=200"`
+}
+
+// ListRequest represents request for listing spots
+type ListRequest struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	Airport  string `form:"airport"`
+}
+
+// AdminListRequest represents request for admin listing spots
+type AdminListRequest struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	Airport  string `form:"airport"`
+	Status   string `form:"status"` // pending, approved, rejected, all
+}
+
+// NearbyRequest represents request for finding spots near a point
+type NearbyRequest struct {
+	Lat      *float64 `form:"lat" binding:"required"`
+	Lng      *float64 `form:"lng" binding:"required"`
+	RadiusKm float64  `form:"radius_km"`
+	Limit    int      `form:"limit"`
+}
+
+// ReviewRequest represents request for moderating a spot
+type ReviewRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve reject"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// LinkPhotoRequest represents request for linking a photo to a spot.
+// A nil spot ID removes the link.
+type LinkPhotoRequest struct {
+	SpotID *int64 `json:"spot_id"`
+}
+
+// ListResponse represents response for listing spots
+type ListResponse struct {
+	List       []*model.SpotListItem `json:"list"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+// PhotoListResponse represents response for listing photos at a spot
+type PhotoListResponse struct {
+	List       []*model.PhotoListItem `json:"list"`
+	Pagination Pagination             `json:"pagination"`
+}
+
+// Create submits a new spot for moderation
+func (s *Service) Create(ctx context.Context, userID int64, req *CreateRequest) (*model.SpotDetail, error) {
+	airport, ok := aviation.NormalizeAirportCode(req.Airport)
+	if !ok {
+		return nil, ErrInvalidAirport
+	}
+	if *req.Latitude < -90 || *req.Latitude > 90 || *req.Longitude < -180 || *req.Longitude > 180 {
+		return nil, ErrInvalidCoordinates
+	}
+
+	id, err := s.spotRepo.Create(ctx, &spot.CreateSpotParams{
+		UserID:         userID,
+		Airport:        airport,
+		Name:           strings.TrimSpace(req.Name),
+		Latitude:       *req.Latitude,
+		Longitude:      *req.Longitude,
+		Description:    optionalString(req.Description),
+		AccessNotes:    optionalString(req.AccessNotes),
+		BestLightTimes: optionalString(req.BestLightTimes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sp, err := s.spotRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return sp.ToDetail(nil), nil
+}
+
+// List retrieves approved spots
+func (s *Service) List(ctx context.Context, req *ListRequest) (*ListResponse, error) {
+	return s.list(ctx, spot.ListParams{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Airport:  req.Airport,
+	})
+}
+
+// ListMine retrieves spots submitted by a user in any status
+func (s *Service) ListMine(ctx context.Context, userID int64, page, pageSize int) (*ListResponse, error) {
+	return s.list(ctx, spot.ListParams{
+		Page:     page,
+		PageSize: pageSize,
+		Status:   "all",
+		UserID:   userID,
+	})
+}
+
+// AdminList retrieves spots for moderation
+func (s *Service) AdminList(ctx context.Context, req *AdminListRequest) (*ListResponse, error) {
+	status := req.Status
+	if status == "" {
+		status = string(model.SpotStatusPending)
+	}
+
+	return s.list(ctx, spot.ListParams{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Airport:  req.Airport,
+		Status:   status,
+	})
+}
+
+func (s *Service) list(ctx context.Context, params spot.ListParams) (*ListResponse, error) {
+	result, err := s.spotRepo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.SpotListItem, len(result.Spots))
+	for i, sp := range result.Spots {
+		list[i] = sp.ToListItem()
+	}
+
+	return &ListResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// GetDetail retrieves spot detail with focal length statistics.
+// Spots not yet approved are only visible to their submitter and admins.
+func (s *Service) GetDetail(ctx context.Context, spotID int64, currentUserID *int64, isAdmin bool) (*model.SpotDetail, error) {
+	sp, err := s.spotRepo.GetByID(ctx, spotID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrSpotNotFound
+		}
+		return nil, err
+	}
+
+	if sp.Status != model.SpotStatusApproved && !isAdmin {
+		if currentUserID == nil || !sp.UserID.Valid || sp.UserID.Int64 != *currentUserID {
+			return nil, ErrSpotNotFound
+		}
+	}
+
+	stats, err := s.spotRepo.GetFocalLengthStats(ctx, spotID, commonFocalLengths)
+	if err != nil {
+		return nil, err
+	}
+
+	return sp.ToDetail(stats), nil
+}
+
+// ListPhotos retrieves approved photos taken from an approved spot
+func (s *Service) ListPhotos(ctx context.Context, spotID int64, page, pageSize int) (*PhotoListResponse, error) {
+	sp, err := s.spotRepo.GetByID(ctx, spotID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrSpotNotFound
+		}
+		return nil, err
+	}
+	if sp.Status != model.SpotStatusApproved {
+		return nil, ErrSpotNotFound
+	}
+
+	result, err := s.spotRepo.ListPhotos(ctx, spotID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get unique user IDs
+	userIDs := make([]int64, 0, len(result.Photos))
+	userIDMap := make(map[int64]bool)
+	for _, p := range result.Photos {
+		if !userIDMap[p.UserID] {
+			userIDs = append(userIDs, p.UserID)
+			userIDMap[p.UserID] = true
+		}
+	}
+
+	users, err := s.photoRepo.GetUserMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.PhotoListItem, len(result.Photos))
+	for i, p := range result.Photos {
+		var userBrief *model.UserBrief
+		if u, ok := users[p.UserID]; ok {
+			userBrief = &model.UserBrief{
+				ID:       u.ID,
+				Username: u.Username,
+			}
+			if u.Avatar.Valid {
+				userBrief.Avatar = &u.Avatar.String
+			}
+		}
+		list[i] = p.ToListItem(userBrief, s.baseURL)
+	}
+
+	return &PhotoListResponse{
+		List: list,
+		Pagination: Pagination{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
+
+// Nearby finds approved spots near a point
+func (s *Service) Nearby(ctx context.Context, req *NearbyRequest) ([]*model.SpotListItem, error) {
+	if *req.Lat < -90 || *req.Lat > 90 || *req.Lng < -180 || *req.Lng > 180 {
+		return nil, ErrInvalidCoordinates
+	}
+
+	radius := req.RadiusKm
+	if radius <= 0 {
+		radius = suggestRadiusKm
+	}
+	if radius > maxNearbyRadiusKm {
+		radius = maxNearbyRadiusKm
+	}
+
+	return s.nearby(ctx, *req.Lat, *req.Lng, radius, req.Limit)
+}
+
+// SuggestForPhoto suggests spots near where the photo was taken, based on its GPS data
+func (s *Service) SuggestForPhoto(ctx context.Context, userID, photoID int64) ([]*model.SpotListItem, error) {
+	ownerID, lat, lng, err := s.spotRepo.GetPhotoLocation(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	if ownerID != userID {
+		return nil, ErrNotOwner
+	}
+	if !lat.Valid || !lng.Valid {
+		return nil, ErrNoPhotoLocation
+	}
+
+	return s.nearby(ctx, lat.Float64, lng.Float64, suggestRadiusKm, 5)
+}
+
+func (s *Service) nearby(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]*model.SpotListItem, error) {
+	spots, err := s.spotRepo.FindNearby(ctx, lat, lng, radiusKm, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.SpotListItem, len(spots))
+	for i, sp := range spots {
+		list[i] = sp.ToListItem()
+	}
+	return list, nil
+}
+
+// LinkPhoto links one of the user's photos to an approved spot
+func (s *Service) LinkPhoto(ctx context.Context, userID, photoID int64, req *LinkPhotoRequest) error {
+	ownerID, _, _, err := s.spotRepo.GetPhotoLocation(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return ErrPhotoNotFound
+		}
+		return err
+	}
+	if ownerID != userID {
+		return ErrNotOwner
+	}
+
+	if req.SpotID != nil {
+		sp, err := s.spotRepo.GetByID(ctx, *req.SpotID)
+		if err != nil {
+			if errors.Is(err, postgresql.ErrNotFound) {
+				return ErrSpotNotFound
+			}
+			return err
+		}
+		if sp.Status != model.SpotStatusApproved {
+			return ErrSpotNotFound
+		}
+	}
+
+	return s.spotRepo.SetPhotoSpot(ctx, photoID, req.SpotID)
+}
+
+// Review approves or rejects a pending spot
+func (s *Service) Review(ctx context.Context, spotID, reviewerID int64, req *ReviewRequest) error {
+	sp, err := s.spotRepo.GetByID(ctx, spotID)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return ErrSpotNotFound
+		}
+		return err
+	}
+	if sp.Status != model.SpotStatusPending {
+		return ErrAlreadyReviewed
+	}
+
+	var status model.SpotStatus
+	var reason *string
+	switch req.Action {
+	case "approve":
+		status = model.SpotStatusApproved
+	case "reject":
+		status = model.SpotStatusRejected
+		reason = optionalString(req.Reason)
+	default:
+		return ErrInvalidAction
+	}
+
+	return s.spotRepo.Review(ctx, spotID, reviewerID, status, reason)
+}
+
+// Delete deletes a spot (admin only)
+func (s *Service) Delete(ctx context.Context, spotID int64) error {
+	err := s.spotRepo.Delete(ctx, spotID)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrSpotNotFound
+	}
+	return err
+}
+
+func optionalString(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,303 @@
+package superadmin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql/superadmin"
+)
+
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+const (
+	statsDateLayout  = "2006-01-02"
+	defaultStatsDays = 30
+	maxStatsDays     = 366
+)
+
+// StatsRange is a date range filter, both dates are inclusive (YYYY-MM-DD)
+type StatsRange struct {
+	From string `form:"from"` // Defaults to 29 days before To
+	To   string `form:"to"`   // Defaults to today
+}
+
+// resolve converts the range to the half-open interval [from, to)
+func (r StatsRange) resolve(now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	lastDay := today
+	if r.To != "" {
+		d, err := time.Parse(statsDateLayout, r.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDateRange
+		}
+		lastDay = d
+	}
+
+	firstDay := lastDay.AddDate(0, 0, -(defaultStatsDays - 1))
+	if r.From != "" {
+		d, err := time.Parse(statsDateLayout, r.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDateRange
+		}
+		firstDay = d
+	}
+
+	to := lastDay.AddDate(0, 0, 1)
+	if firstDay.After(lastDay) || to.Sub(firstDay) > maxStatsDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	return firstDay, to, nil
+}
+
+// ReviewerDay is the decisions of a reviewer on one day
+type ReviewerDay struct {
+	Date        string `json:"date"`
+	Decisions   int64  `json:"decisions"`
+	Approvals   int64  `json:"approvals"`
+	Rejections  int64  `json:"rejections"`
+	Escalations int64  `json:"escalations"`
+}
+
+// ReviewerStats is the performance of a reviewer within the date range
+type ReviewerStats struct {
+	ReviewerID   int64          `json:"reviewer_id"`
+	Username     string         `json:"username"`
+	Role         string         `json:"role"`
+	Decisions    int64          `json:"decisions"`
+	Approvals    int64          `json:"approvals"`
+	Rejections   int64          `json:"rejections"`
+	Escalations  int64          `json:"escalations"`
+	Appealed     int64          `json:"appealed"`
+	Overturned   int64          `json:"overturned"`
+	OverturnRate *float64       `json:"overturn_rate"` // Overturned / appealed, null without appeals
+	Daily        []*ReviewerDay `json:"daily"`
+}
+
+// ReviewerStatsResult is the result of reviewer statistics
+type ReviewerStatsResult struct {
+	From      string           `json:"from"`
+	To        string           `json:"to"`
+	Reviewers []*ReviewerStats `json:"reviewers"`
+}
+
+// CategoryStats is the decisions made in a category within the date range
+type CategoryStats struct {
+	CategoryID            *int32   `json:"category_id"` // Null for uncategorized photos
+	Name                  string   `json:"name"`
+	NameEN                string   `json:"name_en"`
+	Approvals             int64    `json:"approvals"`
+	Rejections            int64    `json:"rejections"`
+	ApproveRatio          *float64 `json:"approve_ratio"`
+	DecidedPhotos         int64    `json:"decided_photos"`
+	MedianDecisionSeconds *int64   `json:"median_decision_seconds"`
+}
+
+// CategoryStatsResult is the result of category statistics
+type CategoryStatsResult struct {
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	Overall    *CategoryStats   `json:"overall"`
+	Categories []*CategoryStats `json:"categories"`
+}
+
+// QueueCategoryStats is the current review queue of a category
+type QueueCategoryStats struct {
+	CategoryID       *int32    `json:"category_id"`
+	Name             string    `json:"name"`
+	NameEN           string    `json:"name_en"`
+	Pending          int64     `json:"pending"`
+	Senior           int64     `json:"senior"`
+	OldestUploadedAt time.Time `json:"oldest_uploaded_at"`
+	MaxAgeSeconds    int64     `json:"max_age_seconds"`
+	AvgAgeSeconds    int64     `json:"avg_age_seconds"`
+}
+
+// QueueStatsResult is the result of review queue statistics
+type QueueStatsResult struct {
+	Pending    int64                 `json:"pending"`
+	Categories []*QueueCategoryStats `json:"categories"`
+}
+
+// GetReviewerStats retrieves decisions and appeal outcomes per reviewer
+func (s *Service) GetReviewerStats(ctx context.Context, rng StatsRange) (*ReviewerStatsResult, error) {
+	from, to, err := rng.resolve(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := s.repo.ReviewerDailyStats(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	appeals, err := s.repo.ReviewerAppealStats(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewers []*ReviewerStats
+	byID := make(map[int64]*ReviewerStats)
+	reviewer := func(id int64) *ReviewerStats {
+		if st, ok := byID[id]; ok {
+			return st
+		}
+		st := &ReviewerStats{ReviewerID: id, Daily: []*ReviewerDay{}}
+		byID[id] = st
+		reviewers = append(reviewers, st)
+		return st
+	}
+
+	for _, d := range daily {
+		st := reviewer(d.ReviewerID)
+		day := &ReviewerDay{
+			Date:        d.Day.Format(statsDateLayout),
+			Decisions:   d.Approvals + d.Rejections + d.Escalations,
+			Approvals:   d.Approvals,
+			Rejections:  d.Rejections,
+			Escalations: d.Escalations,
+		}
+		st.Daily = append(st.Daily, day)
+		st.Decisions += day.Decisions
+		st.Approvals += day.Approvals
+		st.Rejections += day.Rejections
+		st.Escalations += day.Escalations
+	}
+	for _, a := range appeals {
+		st := reviewer(a.ReviewerID)
+		st.Appealed = a.Appealed
+		st.Overturned = a.Overturned
+		st.OverturnRate = ratio(a.Overturned, a.Appealed)
+	}
+
+	userIDs := make([]int64, 0, len(reviewers))
+	for _, st := range reviewers {
+		userIDs = append(userIDs, st.ReviewerID)
+	}
+	users, err := s.repo.GetUserMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range reviewers {
+		if u, ok := users[st.ReviewerID]; ok {
+			st.Username = u.Username
+			st.Role = string(u.Role)
+		}
+	}
+
+	if reviewers == nil {
+		reviewers = []*ReviewerStats{}
+	}
+	return &ReviewerStatsResult{
+		From:      from.Format(statsDateLayout),
+		To:        to.AddDate(0, 0, -1).Format(statsDateLayout),
+		Reviewers: reviewers,
+	}, nil
+}
+
+// GetCategoryStats retrieves approve/reject ratios and decision times per category
+func (s *Service) GetCategoryStats(ctx context.Context, rng StatsRange) (*CategoryStatsResult, error) {
+	from, to, err := rng.resolve(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.CategoryDecisionStats(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	overall, err := s.repo.OverallDecisionStats(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.GetCategoryMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CategoryStatsResult{
+		From:       from.Format(statsDateLayout),
+		To:         to.AddDate(0, 0, -1).Format(statsDateLayout),
+		Overall:    categoryStats(overall, nil),
+		Categories: make([]*CategoryStats, 0, len(rows)),
+	}
+	for _, row := range rows {
+		result.Categories = append(result.Categories, categoryStats(row, categories))
+	}
+	return result, nil
+}
+
+// GetQueueStats retrieves the current review queue depth and age per category
+func (s *Service) GetQueueStats(ctx context.Context) (*QueueStatsResult, error) {
+	rows, err := s.repo.ReviewQueueStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.GetCategoryMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := &QueueStatsResult{Categories: make([]*QueueCategoryStats, 0, len(rows))}
+	for _, row := range rows {
+		item := &QueueCategoryStats{
+			CategoryID:       nullInt32Ptr(row.CategoryID),
+			Pending:          row.Pending,
+			Senior:           row.Senior,
+			OldestUploadedAt: row.OldestAt,
+			MaxAgeSeconds:    int64(now.Sub(row.OldestAt).Seconds()),
+			AvgAgeSeconds:    int64(math.Round(row.AvgAgeSeconds)),
+		}
+		if row.CategoryID.Valid {
+			if c, ok := categories[row.CategoryID.Int32]; ok {
+				item.Name = c.Name
+				item.NameEN = c.NameEN
+			}
+		}
+		result.Pending += row.Pending
+		result.Categories = append(result.Categories, item)
+	}
+	return result, nil
+}
+
+// categoryStats converts repository stats, categories may be nil for the overall row
+func categoryStats(row *superadmin.CategoryDecisionStats, categories map[int32]*model.Category) *CategoryStats {
+	item := &CategoryStats{
+		CategoryID:    nullInt32Ptr(row.CategoryID),
+		Approvals:     row.Approvals,
+		Rejections:    row.Rejections,
+		ApproveRatio:  ratio(row.Approvals, row.Approvals+row.Rejections),
+		DecidedPhotos: row.DecidedPhotos,
+	}
+	if row.MedianSeconds.Valid {
+		seconds := int64(math.Round(row.MedianSeconds.Float64))
+		item.MedianDecisionSeconds = &seconds
+	}
+	if row.CategoryID.Valid && categories != nil {
+		if c, ok := categories[row.CategoryID.Int32]; ok {
+			item.Name = c.Name
+			item.NameEN = c.NameEN
+		}
+	}
+	return item
+}
+
+// ratio returns part/total rounded to four decimals, nil when total is zero
+func ratio(part, total int64) *float64 {
+	if total == 0 {
+		return nil
+	}
+	r := math.Round(float64(part)/float64(total)*10000) / 10000
+	return &r
+}
+
+func nullInt32Ptr(v sql.NullInt32) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int32
+}
@@ -0,0 +1,74 @@
+package superadmin
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatsRangeResolve(t *testing.T) {
+	now := time.Date(2026, 3, 15, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rng      StatsRange
+		wantFrom string
+		wantTo   string
+		wantErr  bool
+	}{
+		{
+			name:     "Defaults to the last 30 days",
+			rng:      StatsRange{},
+			wantFrom: "2026-02-14",
+			wantTo:   "2026-03-16",
+		},
+		{
+			name:     "To is inclusive",
+			rng:      StatsRange{From: "2026-01-01", To: "2026-01-31"},
+			wantFrom: "2026-01-01",
+			wantTo:   "2026-02-01",
+		},
+		{
+			name:     "Single day",
+			rng:      StatsRange{From: "2026-01-01", To: "2026-01-01"},
+			wantFrom: "2026-01-01",
+			wantTo:   "2026-01-02",
+		},
+		{
+			name:    "From after to",
+			rng:     StatsRange{From: "2026-02-01", To: "2026-01-01"},
+			wantErr: true,
+		},
+		{
+			name:    "Range too long",
+			rng:     StatsRange{From: "2024-01-01", To: "2026-01-01"},
+			wantErr: true,
+		},
+		{
+			name:    "Malformed date",
+			rng:     StatsRange{From: "2026/01/01"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := tt.rng.resolve(now)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDateRange) {
+					t.Fatalf("expected ErrInvalidDateRange, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := from.Format(statsDateLayout); got != tt.wantFrom {
+				t.Errorf("from = %s, want %s", got, tt.wantFrom)
+			}
+			if got := to.Format(statsDateLayout); got != tt.wantTo {
+				t.Errorf("to = %s, want %s", got, tt.wantTo)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/pkg/permission"
 	"QuanPhotos/internal/repository/postgresql"
 	"QuanPhotos/internal/repository/postgresql/superadmin"
 )
@@ -16,16 +17,18 @@ var (
 	ErrCategoryNotFound  = errors.New("category not found")
 	ErrUserNotFound      = errors.New("user not found")
 	ErrSelfModify        = errors.New("cannot modify own permissions")
+	ErrPolicyNotFound    = errors.New("review policy not found")
 )
 
 // Service handles superadmin business logic
 type Service struct {
-	repo *superadmin.SuperadminRepository
+	repo        *superadmin.SuperadminRepository
+	permissions *permission.Cache
 }
 
 // New creates a new superadmin service
-func New(repo *superadmin.SuperadminRepository) *Service {
-	return &Service{repo: repo}
+func New(repo *superadmin.SuperadminRepository, permissions *permission.Cache) *Service {
+	return &Service{repo: repo, permissions: permissions}
 }
 
 // AdminBrief is a brief representation of an admin user
@@ -130,7 +133,8 @@ func (s *Service) GrantPermissions(ctx context.Context, adminID int64, permissio
 		}
 	}
 
-	// Grant each permission
+	// Grant each permission, effective on the admin's next request
+	defer s.permissions.Invalidate(adminID)
 	for _, perm := range permissions {
 		err := s.repo.GrantPermission(ctx, adminID, perm, grantedBy)
 		if err != nil {
@@ -157,7 +161,8 @@ func (s *Service) RevokePermissions(ctx context.Context, adminID int64, permissi
 		return err
 	}
 
-	// Revoke each permission
+	// Revoke each permission, effective on the admin's next request
+	defer s.permissions.Invalidate(adminID)
 	for _, perm := range permissions {
 		err := s.repo.RevokePermission(ctx, adminID, perm)
 		if err != nil && !errors.Is(err, postgresql.ErrNotFound) {
@@ -305,6 +310,48 @@ func (s *Service) UpdateUserRestrictions(ctx context.Context, userID int64, canC
 	return s.GetUserRestrictions(ctx, userID)
 }
 
+// ListReviewPoliciesResult is the result of listing review policies
+type ListReviewPoliciesResult struct {
+	Policies []*model.ReviewPolicy `json:"policies"`
+	Total    int                   `json:"total"`
+}
+
+// ListReviewPolicies retrieves all category review policies. Categories
+// without a policy use the configured default.
+func (s *Service) ListReviewPolicies(ctx context.Context) (*ListReviewPoliciesResult, error) {
+	policies, err := s.repo.ListReviewPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListReviewPoliciesResult{
+		Policies: policies,
+		Total:    len(policies),
+	}, nil
+}
+
+// SetReviewPolicy creates or replaces the review policy of a category
+func (s *Service) SetReviewPolicy(ctx context.Context, categoryID, requiredDecisions int, allowEscalation bool, operatorID int64) (*model.ReviewPolicy, error) {
+	exists, err := s.repo.CategoryExists(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrCategoryNotFound
+	}
+
+	return s.repo.SetReviewPolicy(ctx, categoryID, requiredDecisions, allowEscalation, operatorID)
+}
+
+// DeleteReviewPolicy reverts a category to the default review policy
+func (s *Service) DeleteReviewPolicy(ctx context.Context, categoryID int) error {
+	err := s.repo.DeleteReviewPolicy(ctx, categoryID)
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return ErrPolicyNotFound
+	}
+	return err
+}
+
 // GetAllPermissions returns all available permission names
 func (s *Service) GetAllPermissions() []string {
 	return superadmin.AllPermissions
@@ -0,0 +1,89 @@
+package trust
+
+import (
+	"math"
+	"time"
+
+	"QuanPhotos/internal/model"
+)
+
+// Score weights, a perfect record over a year of activity reaches 100
+const (
+	approvalWeight = 50.0 // Approval rate, scaled by confidence
+	volumeWeight   = 25.0 // Approved photos up to volumeTarget
+	ageWeight      = 15.0 // Account age up to ageTarget
+	appealWeight   = 10.0 // Share of appeals that were upheld
+
+	// confidenceTarget decided photos give the approval rate its full weight
+	confidenceTarget = 20.0
+	volumeTarget     = 100.0
+	ageTarget        = 365 * 24 * time.Hour
+
+	// takedownPenalty is deducted for every photo taken down by an admin
+	takedownPenalty = 15.0
+)
+
+// computeScore maps a user's record to a reputation score between 0 and 100
+func computeScore(stats *model.TrustStats, now time.Time) int {
+	var score float64
+
+	if decided := float64(stats.Approved + stats.Rejected); decided > 0 {
+		rate := float64(stats.Approved) / decided
+		confidence := math.Min(decided/confidenceTarget, 1)
+		score += approvalWeight * rate * confidence
+	}
+
+	score += volumeWeight * math.Min(float64(stats.Approved)/volumeTarget, 1)
+
+	if age := now.Sub(stats.JoinedAt); age > 0 {
+		score += ageWeight * math.Min(float64(age)/float64(ageTarget), 1)
+	}
+
+	// Users who never appealed keep the full weight, denied appeals count against
+	if appeals := float64(stats.AppealsUpheld + stats.AppealsDenied); appeals > 0 {
+		score += appealWeight * float64(stats.AppealsUpheld) / appeals
+	} else {
+		score += appealWeight
+	}
+
+	score -= takedownPenalty * float64(stats.TakenDown)
+
+	return int(math.Round(math.Max(0, math.Min(score, 100))))
+}
+
+// tierForScore returns the highest ranked tier the score qualifies for.
+// tiers must be ordered by rank.
+func tierForScore(tiers []*model.TrustTier, score int) *model.TrustTier {
+	var selected *model.TrustTier
+	for _, t := range tiers {
+		if score >= t.MinScore {
+			selected = t
+		}
+	}
+	if selected == nil && len(tiers) > 0 {
+		selected = tiers[0]
+	}
+	return selected
+}
+
+// findTier returns the tier with the given name, or nil
+func findTier(tiers []*model.TrustTier, name string) *model.TrustTier {
+	for _, t := range tiers {
+		if t.Tier == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// lowerTier returns the tier ranked directly below the given one, or the
+// lowest tier. tiers must be ordered by rank.
+func lowerTier(tiers []*model.TrustTier, current *model.TrustTier) *model.TrustTier {
+	lowered := tiers[0]
+	for _, t := range tiers {
+		if t.Rank < current.Rank {
+			lowered = t
+		}
+	}
+	return lowered
+}
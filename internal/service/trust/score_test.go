@@ -0,0 +1,96 @@
+package trust
+
+import (
+	"testing"
+	"time"
+
+	"QuanPhotos/internal/model"
+)
+
+func TestComputeScore(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	yearAgo := now.AddDate(-1, 0, 0)
+
+	tests := []struct {
+		name   string
+		stats  model.TrustStats
+		expect int
+	}{
+		{
+			name:   "New account without uploads keeps only the appeal weight",
+			stats:  model.TrustStats{JoinedAt: now},
+			expect: 10,
+		},
+		{
+			name:   "Perfect record over a year",
+			stats:  model.TrustStats{JoinedAt: yearAgo, Approved: 100},
+			expect: 100,
+		},
+		{
+			name:   "Few decisions carry little weight",
+			stats:  model.TrustStats{JoinedAt: now, Approved: 2},
+			expect: 16, // 50*1*0.1 + 25*0.02 + 10
+		},
+		{
+			name:   "Rejections lower the approval rate",
+			stats:  model.TrustStats{JoinedAt: yearAgo, Approved: 30, Rejected: 10},
+			expect: 70, // 50*0.75 + 25*0.3 + 15 + 10
+		},
+		{
+			name:   "Denied appeals count against",
+			stats:  model.TrustStats{JoinedAt: yearAgo, Approved: 100, AppealsUpheld: 1, AppealsDenied: 3},
+			expect: 93,
+		},
+		{
+			name:   "Takedowns are penalized",
+			stats:  model.TrustStats{JoinedAt: yearAgo, Approved: 100, TakenDown: 2},
+			expect: 70,
+		},
+		{
+			name:   "Score never goes below zero",
+			stats:  model.TrustStats{JoinedAt: now, Rejected: 5, TakenDown: 3},
+			expect: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeScore(&tt.stats, now); got != tt.expect {
+				t.Errorf("expected %d, got %d", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestTierForScore(t *testing.T) {
+	tiers := []*model.TrustTier{
+		{Tier: "newcomer", Rank: 0, MinScore: 0},
+		{Tier: "member", Rank: 1, MinScore: 30},
+		{Tier: "trusted", Rank: 2, MinScore: 60},
+		{Tier: "veteran", Rank: 3, MinScore: 85},
+	}
+
+	tests := []struct {
+		score  int
+		expect string
+	}{
+		{0, "newcomer"},
+		{29, "newcomer"},
+		{30, "member"},
+		{84, "trusted"},
+		{100, "veteran"},
+	}
+
+	for _, tt := range tests {
+		if got := tierForScore(tiers, tt.score); got.Tier != tt.expect {
+			t.Errorf("score %d: expected %s, got %s", tt.score, tt.expect, got.Tier)
+		}
+	}
+
+	if got := lowerTier(tiers, tiers[2]); got.Tier != "member" {
+		t.Errorf("lowerTier(trusted): expected member, got %s", got.Tier)
+	}
+	if got := lowerTier(tiers, tiers[0]); got.Tier != "newcomer" {
+		t.Errorf("lowerTier(newcomer): expected newcomer, got %s", got.Tier)
+	}
+}
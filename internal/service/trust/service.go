@@ -0,0 +1,278 @@
+package trust
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"QuanPhotos/internal/config"
+	"QuanPhotos/internal/model"
+	"QuanPhotos/internal/repository/postgresql"
+	"QuanPhotos/internal/repository/postgresql/trust"
+)
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrTierNotFound     = errors.New("trust tier not found")
+	ErrInvalidThreshold = errors.New("min_score must increase with tier rank and be 0 for the lowest tier")
+)
+
+// Service evaluates user reputation and the review policy of trust tiers
+type Service struct {
+	trustRepo         *trust.TrustRepository
+	autoApprove       bool
+	recoveryApprovals int64
+}
+
+// New creates a new trust service
+func New(trustRepo *trust.TrustRepository, cfg *config.Config) *Service {
+	return &Service{
+		trustRepo:         trustRepo,
+		autoApprove:       cfg.Review.TrustAutoApprove,
+		recoveryApprovals: int64(cfg.Review.TrustRecoveryApprovals),
+	}
+}
+
+// UserTrustInfo represents the reputation of a user
+type UserTrustInfo struct {
+	UserID         int64   `json:"user_id"`
+	Score          int     `json:"score"`
+	Tier           string  `json:"tier"`           // Effective tier
+	EvaluatedTier  string  `json:"evaluated_tier"` // Tier from the score and demotions
+	OverrideTier   *string `json:"override_tier"`  // Tier set by an admin
+	OverrideNote   *string `json:"override_note,omitempty"`
+	AutoApprove    bool    `json:"auto_approve"`
+	SpotCheckRate  int     `json:"spot_check_rate"`
+	DemotedAt      *string `json:"demoted_at,omitempty"`
+	DemotionReason *string `json:"demotion_reason,omitempty"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
+// UploadDecision is the review path of a new upload
+type UploadDecision struct {
+	Tier        string
+	AutoApprove bool // Publish without manual review
+	SpotCheck   bool // Sampled for review after publication
+}
+
+// evaluate recomputes the score and tier of a user. A demoted user cannot be
+// promoted above the demoted tier until enough photos are approved again.
+func (s *Service) evaluate(ctx context.Context, userID int64, tiers []*model.TrustTier) (*model.UserTrust, error) {
+	current, err := s.trustRepo.GetUserTrust(ctx, userID)
+	if err != nil && !errors.Is(err, postgresql.ErrNotFound) {
+		return nil, err
+	}
+
+	var demotedAt sql.NullTime
+	if current != nil {
+		demotedAt = current.DemotedAt
+	}
+
+	stats, err := s.trustRepo.GetStats(ctx, userID, demotedAt)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	score := computeScore(stats, time.Now())
+	tier := tierForScore(tiers, score)
+	if demotedAt.Valid && stats.ApprovedSince < s.recoveryApprovals {
+		if capped := findTier(tiers, current.Tier); capped != nil && capped.Rank < tier.Rank {
+			tier = capped
+		}
+	}
+
+	return s.trustRepo.SaveEvaluation(ctx, userID, score, tier.Tier)
+}
+
+// UploadDecision evaluates the uploader and decides whether a new upload
+// skips the manual review queue
+func (s *Service) UploadDecision(ctx context.Context, userID int64) (*UploadDecision, error) {
+	tiers, err := s.trustRepo.ListTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.evaluate(ctx, userID, tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &UploadDecision{Tier: t.EffectiveTier()}
+	tier := findTier(tiers, decision.Tier)
+	if s.autoApprove && tier != nil && tier.AutoApprove {
+		decision.AutoApprove = true
+		decision.SpotCheck = rand.IntN(100) < tier.SpotCheckRate
+	}
+	return decision, nil
+}
+
+// Demote lowers a user's effective tier by one rank after a rejection or a
+// takedown and clears any admin override
+func (s *Service) Demote(ctx context.Context, userID int64, reason string) error {
+	tiers, err := s.trustRepo.ListTiers(ctx)
+	if err != nil {
+		return err
+	}
+	if len(tiers) == 0 {
+		return nil
+	}
+
+	current := tiers[0]
+	t, err := s.trustRepo.GetUserTrust(ctx, userID)
+	if err != nil && !errors.Is(err, postgresql.ErrNotFound) {
+		return err
+	}
+	if t != nil {
+		if effective := findTier(tiers, t.EffectiveTier()); effective != nil {
+			current = effective
+		}
+	}
+
+	return s.trustRepo.Demote(ctx, userID, lowerTier(tiers, current).Tier, reason)
+}
+
+// GetUserTrust evaluates and returns the reputation of a user
+func (s *Service) GetUserTrust(ctx context.Context, userID int64) (*UserTrustInfo, error) {
+	tiers, err := s.trustRepo.ListTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.evaluate(ctx, userID, tiers)
+	if err != nil {
+		return nil, err
+	}
+	return s.toUserTrustInfo(t, tiers), nil
+}
+
+// SetOverrideRequest represents request for overriding a user's tier
+type SetOverrideRequest struct {
+	Tier *string `json:"tier"` // null clears the override
+	Note string  `json:"note" binding:"max=500"`
+}
+
+// SetOverride sets or clears the tier override of a user
+func (s *Service) SetOverride(ctx context.Context, userID, operatorID int64, req *SetOverrideRequest) (*UserTrustInfo, error) {
+	tiers, err := s.trustRepo.ListTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Tier != nil && findTier(tiers, *req.Tier) == nil {
+		return nil, ErrTierNotFound
+	}
+
+	// Evaluate first, which also ensures the user exists
+	if _, err := s.evaluate(ctx, userID, tiers); err != nil {
+		return nil, err
+	}
+
+	t, err := s.trustRepo.SetOverride(ctx, userID, req.Tier, operatorID, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return s.toUserTrustInfo(t, tiers), nil
+}
+
+// ListTiersResult is the result of listing trust tiers
+type ListTiersResult struct {
+	Tiers       []*model.TrustTier `json:"tiers"`
+	AutoApprove bool               `json:"auto_approve"` // Auto approval is enabled by configuration
+}
+
+// ListTiers retrieves all trust tiers
+func (s *Service) ListTiers(ctx context.Context) (*ListTiersResult, error) {
+	tiers, err := s.trustRepo.ListTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tiers == nil {
+		tiers = []*model.TrustTier{}
+	}
+	return &ListTiersResult{Tiers: tiers, AutoApprove: s.autoApprove}, nil
+}
+
+// UpdateTierRequest represents request for updating a trust tier
+type UpdateTierRequest struct {
+	MinScore      int  `json:"min_score" binding:"min=0,max=100"`
+	AutoApprove   bool `json:"auto_approve"`
+	SpotCheckRate int  `json:"spot_check_rate" binding:"min=0,max=100"`
+
+	// StorageQuotaMB raises the storage quota of the tier's users above their
+	// role limit, null leaves the role limit and 0 is unlimited
+	StorageQuotaMB *int64 `json:"storage_quota_mb" binding:"omitempty,min=0"`
+}
+
+// UpdateTier updates the score threshold and review policy of a tier.
+// Thresholds must stay strictly increasing with rank.
+func (s *Service) UpdateTier(ctx context.Context, tier string, operatorID int64, req *UpdateTierRequest) (*model.TrustTier, error) {
+	tiers, err := s.trustRepo.ListTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	target := findTier(tiers, tier)
+	if target == nil {
+		return nil, ErrTierNotFound
+	}
+	for i, t := range tiers {
+		if t.Tier != tier {
+			continue
+		}
+		if i == 0 && req.MinScore != 0 {
+			return nil, ErrInvalidThreshold
+		}
+		if i > 0 && req.MinScore <= tiers[i-1].MinScore {
+			return nil, ErrInvalidThreshold
+		}
+		if i < len(tiers)-1 && req.MinScore >= tiers[i+1].MinScore {
+			return nil, ErrInvalidThreshold
+		}
+	}
+
+	updated, err := s.trustRepo.UpdateTier(ctx, tier, trust.TierParams{
+		MinScore:      req.MinScore,
+		AutoApprove:   req.AutoApprove,
+		SpotCheckRate: req.SpotCheckRate,
+		StorageQuota:  req.StorageQuotaMB,
+		UpdatedBy:     operatorID,
+	})
+	if errors.Is(err, postgresql.ErrNotFound) {
+		return nil, ErrTierNotFound
+	}
+	return updated, err
+}
+
+// toUserTrustInfo converts a trust record, auto approval also requires it to
+// be enabled by configuration
+func (s *Service) toUserTrustInfo(t *model.UserTrust, tiers []*model.TrustTier) *UserTrustInfo {
+	info := &UserTrustInfo{
+		UserID:        t.UserID,
+		Score:         t.Score,
+		Tier:          t.EffectiveTier(),
+		EvaluatedTier: t.Tier,
+		UpdatedAt:     t.UpdatedAt.Format(time.RFC3339),
+	}
+	if t.OverrideTier.Valid {
+		info.OverrideTier = &t.OverrideTier.String
+	}
+	if t.OverrideNote.Valid {
+		info.OverrideNote = &t.OverrideNote.String
+	}
+	if t.DemotedAt.Valid {
+		demotedAt := t.DemotedAt.Time.Format(time.RFC3339)
+		info.DemotedAt = &demotedAt
+	}
+	if t.DemotionReason.Valid {
+		info.DemotionReason = &t.DemotionReason.String
+	}
+	if tier := findTier(tiers, info.Tier); tier != nil {
+		info.AutoApprove = s.autoApprove && tier.AutoApprove
+		info.SpotCheckRate = tier.SpotCheckRate
+	}
+	return info
+}
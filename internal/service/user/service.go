@@ -62,11 +62,14 @@ type UpdateProfileRequest struct {
 	Avatar   *string `json:"avatar"`
 	Bio      *string `json:"bio"`
 	Location *string `json:"location"`
+
+	// HidePhotoLocation hides GPS coordinates of the user's photos from others
+	HidePhotoLocation *bool `json:"hide_photo_location"`
 }
 
 // UpdateProfile updates user's profile
 func (s *Service) UpdateProfile(ctx context.Context, userID int64, req *UpdateProfileRequest) (*model.UserProfile, error) {
-	if err := s.userRepo.UpdateProfile(ctx, userID, req.Avatar, req.Bio, req.Location); err != nil {
+	if err := s.userRepo.UpdateProfile(ctx, userID, req.Avatar, req.Bio, req.Location, req.HidePhotoLocation); err != nil {
 		if errors.Is(err, postgresql.ErrUserNotFound) {
 			return nil, ErrUserNotFound
 		}
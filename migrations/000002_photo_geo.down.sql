@@ -0,0 +1,12 @@
+-- 000002_photo_geo.down.sql
+-- Rollback geospatial search and map clustering
+
+DROP TRIGGER IF EXISTS update_photos_geohash ON photos;
+DROP INDEX IF EXISTS idx_photos_exif_geohash;
+DROP INDEX IF EXISTS idx_photos_gps;
+ALTER TABLE photos DROP COLUMN IF EXISTS exif_geohash;
+
+ALTER TABLE users DROP COLUMN IF EXISTS hide_photo_location;
+
+DROP FUNCTION IF EXISTS update_photo_geohash() CASCADE;
+DROP FUNCTION IF EXISTS geohash_encode(DOUBLE PRECISION, DOUBLE PRECISION, INT) CASCADE;
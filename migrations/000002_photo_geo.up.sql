@@ -0,0 +1,95 @@
+-- 000002_photo_geo.up.sql
+-- Geospatial search and map clustering over photo GPS
+
+-- ============================================
+-- Functions
+-- ============================================
+
+-- Encode a latitude/longitude pair as a geohash string
+CREATE OR REPLACE FUNCTION geohash_encode(lat DOUBLE PRECISION, lng DOUBLE PRECISION, hash_len INT)
+RETURNS VARCHAR AS $$
+DECLARE
+    base32 CONSTANT TEXT := '0123456789bcdefghjkmnpqrstuvwxyz';
+    lat_min DOUBLE PRECISION := -90;
+    lat_max DOUBLE PRECISION := 90;
+    lng_min DOUBLE PRECISION := -180;
+    lng_max DOUBLE PRECISION := 180;
+    mid DOUBLE PRECISION;
+    result TEXT := '';
+    bits INT := 0;
+    ch INT := 0;
+    even BOOLEAN := TRUE;
+BEGIN
+    IF lat IS NULL OR lng IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    WHILE length(result) < hash_len LOOP
+        IF even THEN
+            mid := (lng_min + lng_max) / 2;
+            IF lng >= mid THEN
+                ch := ch * 2 + 1;
+                lng_min := mid;
+            ELSE
+                ch := ch * 2;
+                lng_max := mid;
+            END IF;
+        ELSE
+            mid := (lat_min + lat_max) / 2;
+            IF lat >= mid THEN
+                ch := ch * 2 + 1;
+                lat_min := mid;
+            ELSE
+                ch := ch * 2;
+                lat_max := mid;
+            END IF;
+        END IF;
+
+        even := NOT even;
+        bits := bits + 1;
+
+        IF bits = 5 THEN
+            result := result || substr(base32, ch + 1, 1);
+            bits := 0;
+            ch := 0;
+        END IF;
+    END LOOP;
+
+    RETURN result;
+END;
+$$ language 'plpgsql' IMMUTABLE;
+
+-- Keep photos.exif_geohash in sync with the GPS columns
+CREATE OR REPLACE FUNCTION update_photo_geohash()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.exif_geohash = geohash_encode(NEW.exif_gps_latitude, NEW.exif_gps_longitude, 12);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- ============================================
+-- Users: location privacy
+-- ============================================
+
+ALTER TABLE users ADD COLUMN hide_photo_location BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ============================================
+-- Photos: geohash and spatial indexes
+-- ============================================
+
+ALTER TABLE photos ADD COLUMN exif_geohash VARCHAR(12);
+
+UPDATE photos
+SET exif_geohash = geohash_encode(exif_gps_latitude, exif_gps_longitude, 12)
+WHERE exif_gps_latitude IS NOT NULL AND exif_gps_longitude IS NOT NULL;
+
+CREATE INDEX idx_photos_gps ON photos(exif_gps_latitude, exif_gps_longitude)
+    WHERE exif_gps_latitude IS NOT NULL AND exif_gps_longitude IS NOT NULL;
+CREATE INDEX idx_photos_exif_geohash ON photos(exif_geohash varchar_pattern_ops)
+    WHERE exif_geohash IS NOT NULL;
+
+CREATE TRIGGER update_photos_geohash
+    BEFORE INSERT OR UPDATE OF exif_gps_latitude, exif_gps_longitude ON photos
+    FOR EACH ROW
+    EXECUTE FUNCTION update_photo_geohash();
@@ -0,0 +1,7 @@
+-- 000003_spotting_spots.down.sql
+-- Rollback spotting locations directory
+
+DROP INDEX IF EXISTS idx_photos_spot_id;
+ALTER TABLE photos DROP COLUMN IF EXISTS spot_id;
+
+DROP TABLE IF EXISTS spotting_spots;
@@ -0,0 +1,46 @@
+-- 000003_spotting_spots.up.sql
+-- Spotting locations directory linked to photos
+
+-- ============================================
+-- Spotting Spots Table
+-- ============================================
+
+CREATE TABLE spotting_spots (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    airport VARCHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    latitude DECIMAL(10, 7) NOT NULL,
+    longitude DECIMAL(10, 7) NOT NULL,
+    description TEXT,
+    access_notes TEXT,
+    best_light_times VARCHAR(200),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewer_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    reject_reason VARCHAR(500),
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_spotting_spots_status CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT chk_spotting_spots_latitude CHECK (latitude BETWEEN -90 AND 90),
+    CONSTRAINT chk_spotting_spots_longitude CHECK (longitude BETWEEN -180 AND 180)
+);
+
+CREATE INDEX idx_spotting_spots_airport ON spotting_spots(airport);
+CREATE INDEX idx_spotting_spots_status ON spotting_spots(status);
+CREATE INDEX idx_spotting_spots_user_id ON spotting_spots(user_id);
+CREATE INDEX idx_spotting_spots_location ON spotting_spots(latitude, longitude);
+
+CREATE TRIGGER update_spotting_spots_updated_at
+    BEFORE UPDATE ON spotting_spots
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Photos: spot link
+-- ============================================
+
+ALTER TABLE photos ADD COLUMN spot_id BIGINT REFERENCES spotting_spots(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_photos_spot_id ON photos(spot_id);
@@ -0,0 +1,11 @@
+-- 000004_photo_flight.down.sql
+-- Rollback flight details and route fields
+
+DROP INDEX IF EXISTS idx_photos_route;
+DROP INDEX IF EXISTS idx_photos_flight_number;
+
+ALTER TABLE photos DROP CONSTRAINT IF EXISTS chk_photos_flight_phase;
+ALTER TABLE photos DROP COLUMN IF EXISTS flight_phase;
+ALTER TABLE photos DROP COLUMN IF EXISTS destination;
+ALTER TABLE photos DROP COLUMN IF EXISTS origin;
+ALTER TABLE photos DROP COLUMN IF EXISTS flight_number;
@@ -0,0 +1,13 @@
+-- 000004_photo_flight.up.sql
+-- Flight details and route fields on photos
+
+ALTER TABLE photos ADD COLUMN flight_number VARCHAR(10);
+ALTER TABLE photos ADD COLUMN origin VARCHAR(10);
+ALTER TABLE photos ADD COLUMN destination VARCHAR(10);
+ALTER TABLE photos ADD COLUMN flight_phase VARCHAR(20);
+
+ALTER TABLE photos ADD CONSTRAINT chk_photos_flight_phase
+    CHECK (flight_phase IS NULL OR flight_phase IN ('taxi', 'takeoff', 'landing', 'cruise', 'ground'));
+
+CREATE INDEX idx_photos_flight_number ON photos(flight_number);
+CREATE INDEX idx_photos_route ON photos(origin, destination);
@@ -0,0 +1,11 @@
+-- 000005_photo_gear.down.sql
+-- Rollback normalized camera body and lens keys
+
+DROP INDEX IF EXISTS idx_photos_gear_lens_key;
+DROP INDEX IF EXISTS idx_photos_gear_camera_key;
+
+ALTER TABLE photos DROP COLUMN IF EXISTS gear_lens_name;
+ALTER TABLE photos DROP COLUMN IF EXISTS gear_lens_key;
+ALTER TABLE photos DROP COLUMN IF EXISTS gear_camera_name;
+ALTER TABLE photos DROP COLUMN IF EXISTS gear_camera_key;
+
@@ -0,0 +1,15 @@
+-- 000005_photo_gear.up.sql
+-- Normalized camera body and lens keys for gear pages
+
+-- ============================================
+-- Photos: normalized gear columns
+-- ============================================
+
+-- Written on upload from the EXIF fields, see internal/pkg/exif/gear.go
+ALTER TABLE photos ADD COLUMN gear_camera_key VARCHAR(200);
+ALTER TABLE photos ADD COLUMN gear_camera_name VARCHAR(200);
+ALTER TABLE photos ADD COLUMN gear_lens_key VARCHAR(200);
+ALTER TABLE photos ADD COLUMN gear_lens_name VARCHAR(200);
+
+CREATE INDEX idx_photos_gear_camera_key ON photos(gear_camera_key) WHERE gear_camera_key IS NOT NULL;
+CREATE INDEX idx_photos_gear_lens_key ON photos(gear_lens_key) WHERE gear_lens_key IS NOT NULL;
@@ -0,0 +1,13 @@
+-- 000006_photo_exif_numeric.down.sql
+-- Rollback numeric companion columns for EXIF range filters
+
+DROP INDEX IF EXISTS idx_photos_exif_lens_model_lower;
+DROP INDEX IF EXISTS idx_photos_exif_camera_model_lower;
+DROP INDEX IF EXISTS idx_photos_exif_iso;
+DROP INDEX IF EXISTS idx_photos_exif_shutter_speed_num;
+DROP INDEX IF EXISTS idx_photos_exif_aperture_num;
+DROP INDEX IF EXISTS idx_photos_exif_focal_length_35mm_num;
+
+ALTER TABLE photos DROP COLUMN IF EXISTS exif_shutter_speed_num;
+ALTER TABLE photos DROP COLUMN IF EXISTS exif_aperture_num;
+ALTER TABLE photos DROP COLUMN IF EXISTS exif_focal_length_35mm_num;
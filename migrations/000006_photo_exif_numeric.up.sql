@@ -0,0 +1,46 @@
+-- 000006_photo_exif_numeric.up.sql
+-- Numeric companion columns for EXIF range filters
+
+-- ============================================
+-- Photos: numeric EXIF columns
+-- ============================================
+
+ALTER TABLE photos ADD COLUMN exif_focal_length_35mm_num DOUBLE PRECISION; -- Millimetres
+ALTER TABLE photos ADD COLUMN exif_aperture_num DOUBLE PRECISION;          -- f-number
+ALTER TABLE photos ADD COLUMN exif_shutter_speed_num DOUBLE PRECISION;     -- Seconds
+
+-- ============================================
+-- Backfill from the formatted strings, e.g. '500 mm', 'f/5.6', '1/1000 s', '2.0 s'
+-- ============================================
+
+ALTER TABLE photos DISABLE TRIGGER update_photos_updated_at;
+
+UPDATE photos SET exif_focal_length_35mm_num = NULLIF(
+    SUBSTRING(exif_focal_length_35mm FROM '[0-9]+(?:\.[0-9]+)?')::DOUBLE PRECISION, 0)
+WHERE exif_focal_length_35mm ~ '[0-9]';
+
+UPDATE photos SET exif_aperture_num = NULLIF(
+    SUBSTRING(exif_aperture FROM '[0-9]+(?:\.[0-9]+)?')::DOUBLE PRECISION, 0)
+WHERE exif_aperture ~ '[0-9]';
+
+UPDATE photos SET exif_shutter_speed_num = NULLIF(
+    SUBSTRING(exif_shutter_speed FROM '^\s*([0-9]+(?:\.[0-9]+)?)\s*/')::DOUBLE PRECISION
+    / NULLIF(SUBSTRING(exif_shutter_speed FROM '/\s*([0-9]+(?:\.[0-9]+)?)')::DOUBLE PRECISION, 0), 0)
+WHERE exif_shutter_speed ~ '^\s*[0-9]+(\.[0-9]+)?\s*/\s*[0-9]+(\.[0-9]+)?';
+
+UPDATE photos SET exif_shutter_speed_num = NULLIF(
+    SUBSTRING(exif_shutter_speed FROM '^\s*([0-9]+(?:\.[0-9]+)?)')::DOUBLE PRECISION, 0)
+WHERE exif_shutter_speed ~ '^\s*[0-9]+(\.[0-9]+)?\s*s?\s*$';
+
+ALTER TABLE photos ENABLE TRIGGER update_photos_updated_at;
+
+-- ============================================
+-- Indexes
+-- ============================================
+
+CREATE INDEX idx_photos_exif_focal_length_35mm_num ON photos(exif_focal_length_35mm_num);
+CREATE INDEX idx_photos_exif_aperture_num ON photos(exif_aperture_num);
+CREATE INDEX idx_photos_exif_shutter_speed_num ON photos(exif_shutter_speed_num);
+CREATE INDEX idx_photos_exif_iso ON photos(exif_iso);
+CREATE INDEX idx_photos_exif_camera_model_lower ON photos(LOWER(exif_camera_model));
+CREATE INDEX idx_photos_exif_lens_model_lower ON photos(LOWER(exif_lens_model));
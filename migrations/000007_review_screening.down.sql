@@ -0,0 +1,10 @@
+-- 000007_review_screening.down.sql
+-- Rollback quality screening review actions
+
+DROP INDEX IF EXISTS idx_photo_reviews_screening;
+
+DELETE FROM photo_reviews WHERE action = 'flag';
+
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_action;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_action
+    CHECK (action IN ('approve', 'reject'));
@@ -0,0 +1,10 @@
+-- 000007_review_screening.up.sql
+-- Allow quality screening to flag photos for reviewers
+
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_action;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_action
+    CHECK (action IN ('approve', 'reject', 'flag'));
+
+-- Latest screening result per photo
+CREATE INDEX idx_photo_reviews_screening ON photo_reviews(photo_id, created_at DESC)
+    WHERE review_type = 'ai' AND ai_result->>'source' = 'screening';
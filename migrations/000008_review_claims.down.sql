@@ -0,0 +1,4 @@
+-- 000008_review_claims.down.sql
+-- Rollback review queue claims
+
+DROP TABLE IF EXISTS review_claims;
@@ -0,0 +1,18 @@
+-- 000008_review_claims.up.sql
+-- Time-limited claims on photos in the manual review queue
+
+-- ============================================
+-- Review Claims Table
+-- ============================================
+
+-- A photo is held by at most one reviewer. Rows past expires_at are stale
+-- and are taken over by the next claim.
+CREATE TABLE review_claims (
+    photo_id BIGINT PRIMARY KEY REFERENCES photos(id) ON DELETE CASCADE,
+    reviewer_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    claimed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_review_claims_reviewer_id ON review_claims(reviewer_id, expires_at);
+CREATE INDEX idx_review_claims_expires_at ON review_claims(expires_at);
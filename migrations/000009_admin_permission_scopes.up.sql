@@ -0,0 +1,22 @@
+-- 000009_admin_permission_scopes.up.sql
+-- Permissions for admin routes not covered by the initial set
+
+ALTER TABLE admin_permissions DROP CONSTRAINT chk_admin_permissions_permission;
+ALTER TABLE admin_permissions ADD CONSTRAINT chk_admin_permissions_permission CHECK (permission IN (
+    'manage_announcements',
+    'manage_featured',
+    'ban_users',
+    'mute_comment',
+    'mute_message',
+    'mute_upload',
+    'review_photos',
+    'delete_photos',
+    'delete_comments',
+    'manage_tickets',
+    'manage_categories',
+    'manage_tags',
+    'view_statistics',
+    'view_user_details',
+    'manage_roles',
+    'manage_spots'
+));
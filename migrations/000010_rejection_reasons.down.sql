@@ -0,0 +1,9 @@
+-- 000010_rejection_reasons.down.sql
+-- Rollback structured rejection reasons and photo resubmission
+
+DROP INDEX IF EXISTS idx_photo_reviews_attempt;
+ALTER TABLE photo_reviews DROP COLUMN IF EXISTS attempt;
+ALTER TABLE photos DROP COLUMN IF EXISTS attempt;
+
+DROP TABLE IF EXISTS photo_review_reasons;
+DROP TABLE IF EXISTS rejection_reasons;
@@ -0,0 +1,64 @@
+-- 000010_rejection_reasons.up.sql
+-- Structured rejection reasons and photo resubmission
+
+-- ============================================
+-- Rejection Reasons Table
+-- ============================================
+
+-- Catalogue of reasons reviewers pick from. Clients translate by code;
+-- name and name_en are the default labels.
+CREATE TABLE rejection_reasons (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    name_en VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    sort_order INT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rejection_reasons_sort_order ON rejection_reasons(sort_order);
+
+CREATE TRIGGER update_rejection_reasons_updated_at
+    BEFORE UPDATE ON rejection_reasons
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO rejection_reasons (code, name, name_en, description, sort_order) VALUES
+('soft_focus', '对焦不实', 'Soft focus', '主体对焦不准或存在明显模糊', 1),
+('tilted', '画面倾斜', 'Tilted', '地平线或建筑明显倾斜', 2),
+('exposure', '曝光不当', 'Poor exposure', '过曝或欠曝导致细节丢失', 3),
+('noise', '噪点过多', 'Excessive noise', '高感光度噪点或压缩痕迹明显', 4),
+('low_resolution', '分辨率不足', 'Low resolution', '图片尺寸低于最低要求', 5),
+('composition', '构图不佳', 'Poor composition', '主体裁切不当或位置不佳', 6),
+('duplicate', '重复上传', 'Duplicate', '与已有作品重复或高度相似', 7),
+('watermark', '水印或边框', 'Watermark or border', '含有水印、边框或文字', 8),
+('not_aviation', '非航空题材', 'Not aviation related', '内容与航空无关', 9),
+('wrong_metadata', '信息有误', 'Incorrect metadata', '机型、注册号、航司或机场等信息有误', 10);
+
+-- ============================================
+-- Review Reasons Table
+-- ============================================
+
+-- Reasons selected for a rejection. Reasons in use cannot be deleted,
+-- deactivate them instead.
+CREATE TABLE photo_review_reasons (
+    review_id BIGINT NOT NULL REFERENCES photo_reviews(id) ON DELETE CASCADE,
+    reason_id INT NOT NULL REFERENCES rejection_reasons(id) ON DELETE RESTRICT,
+    PRIMARY KEY (review_id, reason_id)
+);
+
+CREATE INDEX idx_photo_review_reasons_reason_id ON photo_review_reasons(reason_id);
+
+-- ============================================
+-- Review Attempts
+-- ============================================
+
+-- A photo starts at attempt 1 and moves to the next attempt on every
+-- resubmission. Reviews record the attempt they decided on.
+ALTER TABLE photos ADD COLUMN attempt INT NOT NULL DEFAULT 1;
+ALTER TABLE photo_reviews ADD COLUMN attempt INT NOT NULL DEFAULT 1;
+
+CREATE INDEX idx_photo_reviews_attempt ON photo_reviews(photo_id, attempt, created_at DESC);
@@ -0,0 +1,18 @@
+-- 000011_review_policies.down.sql
+-- Rollback two-stage review policies
+
+DROP INDEX IF EXISTS idx_photo_reviews_decisions;
+DROP INDEX IF EXISTS idx_photos_review_stage;
+
+DELETE FROM photo_reviews WHERE action = 'escalate';
+
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_action;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_action
+    CHECK (action IN ('approve', 'reject', 'flag'));
+
+ALTER TABLE photo_reviews DROP CONSTRAINT IF EXISTS chk_photo_reviews_stage;
+ALTER TABLE photo_reviews DROP COLUMN IF EXISTS stage;
+ALTER TABLE photos DROP CONSTRAINT IF EXISTS chk_photos_review_stage;
+ALTER TABLE photos DROP COLUMN IF EXISTS review_stage;
+
+DROP TABLE IF EXISTS review_policies;
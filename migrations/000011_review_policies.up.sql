@@ -0,0 +1,50 @@
+-- 000011_review_policies.up.sql
+-- Two-stage review with per-category policies and senior escalation
+
+-- ============================================
+-- Review Policies Table
+-- ============================================
+
+-- Per-category review policy. Categories without a row, and uncategorized
+-- photos, use the default policy from configuration.
+CREATE TABLE review_policies (
+    category_id INT PRIMARY KEY REFERENCES categories(id) ON DELETE CASCADE,
+    required_decisions INT NOT NULL DEFAULT 1,
+    allow_escalation BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_review_policies_required_decisions CHECK (required_decisions BETWEEN 1 AND 5)
+);
+
+CREATE TRIGGER update_review_policies_updated_at
+    BEFORE UPDATE ON review_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Review Stages
+-- ============================================
+
+-- Photos start in the standard queue. Escalated photos and photos with
+-- disagreeing decisions move to the senior queue, where a single decision
+-- is final. Resubmission returns a photo to the standard queue.
+ALTER TABLE photos ADD COLUMN review_stage VARCHAR(20) NOT NULL DEFAULT 'standard';
+ALTER TABLE photos ADD CONSTRAINT chk_photos_review_stage
+    CHECK (review_stage IN ('standard', 'senior'));
+
+-- Reviews record the stage they were made in, decisions are counted per
+-- attempt and stage.
+ALTER TABLE photo_reviews ADD COLUMN stage VARCHAR(20) NOT NULL DEFAULT 'standard';
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_stage
+    CHECK (stage IN ('standard', 'senior'));
+
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_action;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_action
+    CHECK (action IN ('approve', 'reject', 'flag', 'escalate'));
+
+CREATE INDEX idx_photos_review_stage ON photos(review_stage, created_at)
+    WHERE status IN ('pending', 'ai_passed');
+CREATE INDEX idx_photo_reviews_decisions ON photo_reviews(photo_id, attempt, stage)
+    WHERE review_type = 'manual';
@@ -0,0 +1,14 @@
+-- 000012_review_history.down.sql
+-- Rollback review records surviving photo deletion
+
+DELETE FROM photo_reviews WHERE action = 'delete';
+DELETE FROM photo_reviews WHERE photo_id NOT IN (SELECT id FROM photos);
+
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_action;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_action
+    CHECK (action IN ('approve', 'reject', 'flag', 'escalate'));
+
+ALTER TABLE photo_reviews ADD CONSTRAINT photo_reviews_photo_id_fkey
+    FOREIGN KEY (photo_id) REFERENCES photos(id) ON DELETE CASCADE;
+
+DROP TABLE IF EXISTS deleted_photos;
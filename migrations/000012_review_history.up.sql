@@ -0,0 +1,41 @@
+-- 000012_review_history.up.sql
+-- Keep review records after photo deletion
+
+-- ============================================
+-- Deleted Photos Table
+-- ============================================
+
+-- Snapshot of a photo's metadata taken when it is deleted, so its review
+-- history stays readable. photo_id keeps the ID of the deleted photo.
+CREATE TABLE deleted_photos (
+    photo_id BIGINT PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    category_id INT REFERENCES categories(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    aircraft_type VARCHAR(100),
+    airline VARCHAR(100),
+    registration VARCHAR(20),
+    airport VARCHAR(10),
+    status VARCHAR(20) NOT NULL,
+    attempt INT NOT NULL,
+    uploaded_at TIMESTAMP NOT NULL,
+    deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    deleted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_deleted_photos_user_id ON deleted_photos(user_id);
+CREATE INDEX idx_deleted_photos_deleted_at ON deleted_photos(deleted_at DESC);
+
+-- ============================================
+-- Review Records
+-- ============================================
+
+-- Review records outlive their photo. Takedowns by admins are recorded
+-- with the delete action instead of a rejection.
+ALTER TABLE photo_reviews DROP CONSTRAINT photo_reviews_photo_id_fkey;
+
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_action;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_action
+    CHECK (action IN ('approve', 'reject', 'flag', 'escalate', 'delete'));
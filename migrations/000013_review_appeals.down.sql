@@ -0,0 +1,6 @@
+-- 000013_review_appeals.down.sql
+-- Rollback appeal decisions
+
+DROP INDEX IF EXISTS idx_tickets_appeal_photo_id;
+DROP INDEX IF EXISTS idx_photo_reviews_ticket_id;
+ALTER TABLE photo_reviews DROP COLUMN IF EXISTS ticket_id;
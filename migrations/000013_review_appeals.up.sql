@@ -0,0 +1,16 @@
+-- 000013_review_appeals.up.sql
+-- Link review decisions made on appeal to their ticket
+
+-- ============================================
+-- Appeal Decisions
+-- ============================================
+
+-- Set on decisions that overturn a rejection while processing an appeal
+-- ticket. Reviewer statistics count a decision as overturned when a later
+-- appeal decision on the same attempt reached the opposite result.
+ALTER TABLE photo_reviews ADD COLUMN ticket_id BIGINT REFERENCES tickets(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_photo_reviews_ticket_id ON photo_reviews(photo_id, attempt)
+    WHERE ticket_id IS NOT NULL;
+CREATE INDEX idx_tickets_appeal_photo_id ON tickets(photo_id, created_at)
+    WHERE type = 'appeal';
@@ -0,0 +1,14 @@
+-- 000014_trust_levels.down.sql
+-- Rollback trust tiers
+
+DROP INDEX IF EXISTS idx_photos_spot_check;
+ALTER TABLE photos DROP COLUMN IF EXISTS spot_check_pending;
+
+DELETE FROM photo_reviews WHERE review_type = 'trust';
+
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_type;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_type
+    CHECK (review_type IN ('ai', 'manual'));
+
+DROP TABLE IF EXISTS user_trust;
+DROP TABLE IF EXISTS trust_tiers;
@@ -0,0 +1,76 @@
+-- 000014_trust_levels.up.sql
+-- Trust tiers that let proven photographers skip the manual review queue
+
+-- ============================================
+-- Trust Tiers Table
+-- ============================================
+
+-- Fixed set of tiers ordered by rank. A user's reputation score selects the
+-- highest tier whose min_score it reaches. Tiers with auto_approve publish
+-- uploads without manual review; spot_check_rate percent of those are
+-- sampled for review after publication.
+CREATE TABLE trust_tiers (
+    tier VARCHAR(20) PRIMARY KEY,
+    rank INT UNIQUE NOT NULL,
+    min_score INT NOT NULL,
+    auto_approve BOOLEAN NOT NULL DEFAULT FALSE,
+    spot_check_rate INT NOT NULL DEFAULT 0,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_trust_tiers_min_score CHECK (min_score BETWEEN 0 AND 100),
+    CONSTRAINT chk_trust_tiers_spot_check_rate CHECK (spot_check_rate BETWEEN 0 AND 100)
+);
+
+CREATE TRIGGER update_trust_tiers_updated_at
+    BEFORE UPDATE ON trust_tiers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO trust_tiers (tier, rank, min_score, auto_approve, spot_check_rate) VALUES
+    ('newcomer', 0, 0, FALSE, 0),
+    ('member', 1, 30, FALSE, 0),
+    ('trusted', 2, 60, TRUE, 20),
+    ('veteran', 3, 85, TRUE, 5);
+
+-- ============================================
+-- User Trust Table
+-- ============================================
+
+-- Last evaluated score and tier of a user. Users without a row are
+-- newcomers. A demotion drops the tier by one rank and caps it until the
+-- user earns enough approvals after demoted_at. override_tier, set by an
+-- admin, takes precedence over the evaluated tier until the next demotion.
+CREATE TABLE user_trust (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    score INT NOT NULL DEFAULT 0,
+    tier VARCHAR(20) NOT NULL DEFAULT 'newcomer' REFERENCES trust_tiers(tier),
+    override_tier VARCHAR(20) REFERENCES trust_tiers(tier),
+    override_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    override_note TEXT,
+    demoted_at TIMESTAMP,
+    demotion_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_user_trust_updated_at
+    BEFORE UPDATE ON user_trust
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Auto Approval
+-- ============================================
+
+-- Auto approvals are recorded with the trust review type. Sampled photos
+-- wait for a spot check while published.
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_type;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_type
+    CHECK (review_type IN ('ai', 'manual', 'trust'));
+
+ALTER TABLE photos ADD COLUMN spot_check_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_photos_spot_check ON photos(approved_at)
+    WHERE spot_check_pending = TRUE;
@@ -0,0 +1,4 @@
+-- 000015_photo_revisions.down.sql
+-- Rollback photo revisions
+
+DROP TABLE IF EXISTS photo_revisions;
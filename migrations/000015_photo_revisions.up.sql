@@ -0,0 +1,25 @@
+-- 000015_photo_revisions.up.sql
+-- Metadata revisions of photos edited after upload
+
+-- ============================================
+-- Photo Revisions Table
+-- ============================================
+
+-- One row per change of a photo's editable metadata. changes holds a JSON
+-- object keyed by field with the old and new value of every changed field,
+-- e.g. {"registration": {"old": "B-1234", "new": "B-1243"}}. Rollbacks are
+-- stored as revisions of their own that point at the revision they undid.
+CREATE TABLE photo_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    photo_id BIGINT NOT NULL REFERENCES photos(id) ON DELETE CASCADE,
+    editor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    source VARCHAR(20) NOT NULL,
+    changes JSONB NOT NULL,
+    rereview BOOLEAN NOT NULL DEFAULT FALSE,
+    rollback_of BIGINT REFERENCES photo_revisions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_photo_revisions_source CHECK (source IN ('edit', 'resubmit', 'rollback'))
+);
+
+CREATE INDEX idx_photo_revisions_photo ON photo_revisions(photo_id, id DESC);
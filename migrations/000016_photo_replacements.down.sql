@@ -0,0 +1,4 @@
+-- 000016_photo_replacements.down.sql
+-- Rollback photo replacements
+
+DROP TABLE IF EXISTS photo_replacements;
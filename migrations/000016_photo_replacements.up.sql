@@ -0,0 +1,38 @@
+-- 000016_photo_replacements.up.sql
+-- Replacement image files of approved photos
+
+-- ============================================
+-- Photo Replacements Table
+-- ============================================
+
+-- A new image uploaded for an existing photo. The photo keeps serving its
+-- current files until the replacement is approved, then file_params (the
+-- stored file and EXIF columns) are written to the photo and the replaced
+-- paths are kept in previous_*. At most one replacement per photo is pending,
+-- a newer upload supersedes it.
+CREATE TABLE photo_replacements (
+    id BIGSERIAL PRIMARY KEY,
+    photo_id BIGINT NOT NULL REFERENCES photos(id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    file_path VARCHAR(500) NOT NULL,
+    thumbnail_path VARCHAR(500),
+    raw_file_path VARCHAR(500),
+    file_params JSONB NOT NULL,
+    screening JSONB,
+    previous_file_path VARCHAR(500),
+    previous_thumbnail_path VARCHAR(500),
+    previous_raw_file_path VARCHAR(500),
+    review_type VARCHAR(20),
+    reviewer_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    decided_at TIMESTAMP,
+
+    CONSTRAINT chk_photo_replacements_status CHECK (status IN ('pending', 'approved', 'rejected', 'superseded')),
+    CONSTRAINT chk_photo_replacements_review_type CHECK (review_type IN ('manual', 'trust', 'screening'))
+);
+
+CREATE UNIQUE INDEX idx_photo_replacements_pending ON photo_replacements(photo_id) WHERE status = 'pending';
+CREATE INDEX idx_photo_replacements_photo ON photo_replacements(photo_id, id DESC);
+CREATE INDEX idx_photo_replacements_status ON photo_replacements(status, created_at);
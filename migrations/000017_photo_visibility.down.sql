@@ -0,0 +1,8 @@
+-- 000017_photo_visibility.down.sql
+-- Rollback photo visibility
+
+DROP INDEX IF EXISTS idx_photos_private_file_path;
+DROP INDEX IF EXISTS idx_photos_share_token;
+ALTER TABLE photos DROP COLUMN IF EXISTS share_token;
+ALTER TABLE photos DROP CONSTRAINT IF EXISTS chk_photos_visibility;
+ALTER TABLE photos DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,23 @@
+-- 000017_photo_visibility.up.sql
+-- Public, unlisted and private photos
+
+-- ============================================
+-- Photo Visibility
+-- ============================================
+
+-- Public photos appear in lists, rankings, search and feeds. Unlisted photos
+-- are only reachable by direct link or share token, private photos only by
+-- their owner and admins.
+ALTER TABLE photos ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public';
+ALTER TABLE photos ADD CONSTRAINT chk_photos_visibility
+    CHECK (visibility IN ('public', 'unlisted', 'private'));
+
+-- Opaque token of the share link of an unlisted photo
+ALTER TABLE photos ADD COLUMN share_token VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_photos_share_token ON photos(share_token)
+    WHERE share_token IS NOT NULL;
+
+-- Static file requests look up whether a file belongs to a private photo
+CREATE INDEX idx_photos_private_file_path ON photos(file_path)
+    WHERE visibility = 'private';
@@ -0,0 +1,6 @@
+-- 000018_photo_publish_at.down.sql
+-- Rollback scheduled publication of photos
+
+DROP INDEX IF EXISTS idx_photos_scheduled_file_path;
+DROP INDEX IF EXISTS idx_photos_publish_at;
+ALTER TABLE photos DROP COLUMN IF EXISTS publish_at;
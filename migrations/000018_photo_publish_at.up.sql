@@ -0,0 +1,19 @@
+-- 000018_photo_publish_at.up.sql
+-- Scheduled publication of photos
+
+-- ============================================
+-- Scheduled Publication
+-- ============================================
+
+-- Time a photo is scheduled to go live. Photos with a publish time are hidden
+-- from everyone but their owner and admins, even once approved; the publisher
+-- clears it when the time has come and the photo is approved.
+ALTER TABLE photos ADD COLUMN publish_at TIMESTAMP;
+
+-- The publisher looks up approved photos that are due
+CREATE INDEX idx_photos_publish_at ON photos(publish_at)
+    WHERE publish_at IS NOT NULL;
+
+-- Static file requests look up whether a file belongs to a scheduled photo
+CREATE INDEX idx_photos_scheduled_file_path ON photos(file_path)
+    WHERE publish_at IS NOT NULL;
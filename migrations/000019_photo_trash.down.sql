@@ -0,0 +1,13 @@
+-- 000019_photo_trash.down.sql
+-- Rollback soft deletion of photos, photos still in the trash are deleted
+
+DELETE FROM photo_reviews WHERE action = 'restore';
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_action;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_action
+    CHECK (action IN ('approve', 'reject', 'flag', 'escalate', 'delete'));
+
+DELETE FROM photos WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_photos_deleted_file_path;
+DROP INDEX IF EXISTS idx_photos_deleted_at;
+ALTER TABLE photos DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE photos DROP COLUMN IF EXISTS deleted_at;
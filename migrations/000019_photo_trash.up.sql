@@ -0,0 +1,25 @@
+-- 000019_photo_trash.up.sql
+-- Soft deletion of photos with a trash bin
+
+-- ============================================
+-- Photo Trash
+-- ============================================
+
+-- Deleted photos stay in the trash with their comments, likes, favorites and
+-- shares until purged after the retention period. Queries skip rows with a
+-- deletion time.
+ALTER TABLE photos ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE photos ADD COLUMN deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+-- Trash listings and the purge job look up deleted photos by deletion time
+CREATE INDEX idx_photos_deleted_at ON photos(deleted_at)
+    WHERE deleted_at IS NOT NULL;
+
+-- Static file requests look up whether a file belongs to a deleted photo
+CREATE INDEX idx_photos_deleted_file_path ON photos(file_path)
+    WHERE deleted_at IS NOT NULL;
+
+-- Restoring a photo from the trash is recorded in its review history
+ALTER TABLE photo_reviews DROP CONSTRAINT chk_photo_reviews_action;
+ALTER TABLE photo_reviews ADD CONSTRAINT chk_photo_reviews_action
+    CHECK (action IN ('approve', 'reject', 'flag', 'escalate', 'delete', 'restore'));
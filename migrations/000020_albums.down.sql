@@ -0,0 +1,5 @@
+-- 000020_albums.down.sql
+-- Rollback user albums
+
+DROP TABLE IF EXISTS album_photos;
+DROP TABLE IF EXISTS albums;
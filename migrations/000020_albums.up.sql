@@ -0,0 +1,45 @@
+-- 000020_albums.up.sql
+-- User albums with manual photo ordering
+
+-- ============================================
+-- Albums Table
+-- ============================================
+
+-- Public albums appear on the owner's profile, unlisted albums are only
+-- reachable by direct link and private albums only by their owner.
+CREATE TABLE albums (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    description TEXT,
+    cover_photo_id BIGINT REFERENCES photos(id) ON DELETE SET NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_albums_visibility CHECK (visibility IN ('public', 'unlisted', 'private'))
+);
+
+CREATE INDEX idx_albums_user_id ON albums(user_id, created_at DESC);
+
+CREATE TRIGGER update_albums_updated_at
+    BEFORE UPDATE ON albums
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Album Photos Table
+-- ============================================
+
+-- Positions start at 1 and are kept contiguous within an album
+CREATE TABLE album_photos (
+    album_id BIGINT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    photo_id BIGINT NOT NULL REFERENCES photos(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (album_id, photo_id)
+);
+
+CREATE INDEX idx_album_photos_position ON album_photos(album_id, position);
+CREATE INDEX idx_album_photos_photo_id ON album_photos(photo_id);
@@ -0,0 +1,5 @@
+-- 000021_favorite_folders.down.sql
+-- Rollback favorite folders, favorites themselves are kept
+
+DROP TABLE IF EXISTS favorite_folder_photos;
+DROP TABLE IF EXISTS favorite_folders;
@@ -0,0 +1,60 @@
+-- 000021_favorite_folders.up.sql
+-- Named folders for organising favorite photos
+
+-- ============================================
+-- Favorite Folders Table
+-- ============================================
+
+-- Every user has one default folder, created with their first favorite
+CREATE TABLE favorite_folders (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_favorite_folders_owner UNIQUE (id, user_id)
+);
+
+CREATE INDEX idx_favorite_folders_user_id ON favorite_folders(user_id);
+CREATE UNIQUE INDEX idx_favorite_folders_default ON favorite_folders(user_id)
+    WHERE is_default = TRUE;
+
+CREATE TRIGGER update_favorite_folders_updated_at
+    BEFORE UPDATE ON favorite_folders
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Favorite Folder Photos Table
+-- ============================================
+
+-- A favorite may be filed in several folders of its owner. favorites stays
+-- the source of truth for favorite_count, unfavoriting a photo removes it
+-- from every folder.
+CREATE TABLE favorite_folder_photos (
+    folder_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    photo_id BIGINT NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (folder_id, photo_id),
+    FOREIGN KEY (folder_id, user_id) REFERENCES favorite_folders(id, user_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id, photo_id) REFERENCES favorites(user_id, photo_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_favorite_folder_photos_added_at ON favorite_folder_photos(folder_id, added_at DESC);
+CREATE INDEX idx_favorite_folder_photos_favorite ON favorite_folder_photos(user_id, photo_id);
+
+-- ============================================
+-- Existing Favorites
+-- ============================================
+
+INSERT INTO favorite_folders (user_id, name, is_default)
+SELECT DISTINCT user_id, '默认收藏夹', TRUE FROM favorites;
+
+INSERT INTO favorite_folder_photos (folder_id, user_id, photo_id, added_at)
+SELECT ff.id, f.user_id, f.photo_id, f.created_at
+FROM favorites f
+JOIN favorite_folders ff ON ff.user_id = f.user_id AND ff.is_default = TRUE;
@@ -0,0 +1,36 @@
+-- 000022_photo_series.down.sql
+-- Rollback photo series, comments on series are deleted
+
+CREATE OR REPLACE FUNCTION increment_photo_comment_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE photos SET comment_count = comment_count + 1 WHERE id = NEW.photo_id;
+    IF NEW.parent_id IS NOT NULL THEN
+        UPDATE photo_comments SET reply_count = reply_count + 1 WHERE id = NEW.parent_id;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION decrement_photo_comment_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE photos SET comment_count = comment_count - 1 WHERE id = OLD.photo_id;
+    IF OLD.parent_id IS NOT NULL THEN
+        UPDATE photo_comments SET reply_count = reply_count - 1 WHERE id = OLD.parent_id;
+    END IF;
+    RETURN OLD;
+END;
+$$ language 'plpgsql';
+
+DELETE FROM photo_comments WHERE series_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_photo_comments_series_id;
+ALTER TABLE photo_comments DROP CONSTRAINT IF EXISTS chk_photo_comments_target;
+ALTER TABLE photo_comments DROP COLUMN IF EXISTS series_id;
+ALTER TABLE photo_comments ALTER COLUMN photo_id SET NOT NULL;
+
+DROP TABLE IF EXISTS series_likes;
+DROP FUNCTION IF EXISTS increment_series_like_count();
+DROP FUNCTION IF EXISTS decrement_series_like_count();
+DROP TABLE IF EXISTS series_frames;
+DROP TABLE IF EXISTS photo_series;
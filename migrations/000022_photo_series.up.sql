@@ -0,0 +1,129 @@
+-- 000022_photo_series.up.sql
+-- Multi-photo posts grouping ordered frames under one title
+
+-- ============================================
+-- Photo Series Table
+-- ============================================
+
+-- A series is shown to other users once at least one of its frames is
+-- approved, public or unlisted and published. It is liked and commented on
+-- as a whole.
+CREATE TABLE photo_series (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    like_count INT NOT NULL DEFAULT 0,
+    comment_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_photo_series_user_id ON photo_series(user_id, created_at DESC);
+CREATE INDEX idx_photo_series_created_at ON photo_series(created_at DESC);
+
+CREATE TRIGGER update_photo_series_updated_at
+    BEFORE UPDATE ON photo_series
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Series Frames Table
+-- ============================================
+
+-- Frames keep their own review status. A photo is a frame of at most one
+-- series, positions start at 1 and are kept contiguous within a series.
+CREATE TABLE series_frames (
+    series_id BIGINT NOT NULL REFERENCES photo_series(id) ON DELETE CASCADE,
+    photo_id BIGINT NOT NULL REFERENCES photos(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (series_id, photo_id),
+    CONSTRAINT uq_series_frames_photo UNIQUE (photo_id)
+);
+
+CREATE INDEX idx_series_frames_position ON series_frames(series_id, position);
+
+-- ============================================
+-- Series Likes Table
+-- ============================================
+
+CREATE TABLE series_likes (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    series_id BIGINT NOT NULL REFERENCES photo_series(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, series_id)
+);
+
+CREATE INDEX idx_series_likes_series_id ON series_likes(series_id);
+
+-- Series like count triggers
+CREATE OR REPLACE FUNCTION increment_series_like_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE photo_series SET like_count = like_count + 1 WHERE id = NEW.series_id;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION decrement_series_like_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE photo_series SET like_count = like_count - 1 WHERE id = OLD.series_id;
+    RETURN OLD;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER trigger_increment_series_like_count
+    AFTER INSERT ON series_likes
+    FOR EACH ROW
+    EXECUTE FUNCTION increment_series_like_count();
+
+CREATE TRIGGER trigger_decrement_series_like_count
+    AFTER DELETE ON series_likes
+    FOR EACH ROW
+    EXECUTE FUNCTION decrement_series_like_count();
+
+-- ============================================
+-- Series Comments
+-- ============================================
+
+-- Comments target either a photo or a series
+ALTER TABLE photo_comments ALTER COLUMN photo_id DROP NOT NULL;
+ALTER TABLE photo_comments ADD COLUMN series_id BIGINT REFERENCES photo_series(id) ON DELETE CASCADE;
+ALTER TABLE photo_comments ADD CONSTRAINT chk_photo_comments_target
+    CHECK ((photo_id IS NULL) <> (series_id IS NULL));
+
+CREATE INDEX idx_photo_comments_series_id ON photo_comments(series_id) WHERE series_id IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION increment_photo_comment_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.series_id IS NOT NULL THEN
+        UPDATE photo_series SET comment_count = comment_count + 1 WHERE id = NEW.series_id;
+    ELSE
+        UPDATE photos SET comment_count = comment_count + 1 WHERE id = NEW.photo_id;
+    END IF;
+    IF NEW.parent_id IS NOT NULL THEN
+        UPDATE photo_comments SET reply_count = reply_count + 1 WHERE id = NEW.parent_id;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION decrement_photo_comment_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.series_id IS NOT NULL THEN
+        UPDATE photo_series SET comment_count = comment_count - 1 WHERE id = OLD.series_id;
+    ELSE
+        UPDATE photos SET comment_count = comment_count - 1 WHERE id = OLD.photo_id;
+    END IF;
+    IF OLD.parent_id IS NOT NULL THEN
+        UPDATE photo_comments SET reply_count = reply_count - 1 WHERE id = OLD.parent_id;
+    END IF;
+    RETURN OLD;
+END;
+$$ language 'plpgsql';
@@ -0,0 +1,19 @@
+-- 000023_storage_quotas.down.sql
+-- Rollback per-user storage usage and quotas
+
+DROP TRIGGER IF EXISTS trigger_photo_storage_delete ON photos;
+DROP TRIGGER IF EXISTS trigger_photo_storage_update ON photos;
+DROP TRIGGER IF EXISTS trigger_photo_storage_insert ON photos;
+DROP FUNCTION IF EXISTS update_user_storage_usage();
+DROP FUNCTION IF EXISTS add_user_storage(BIGINT, BIGINT);
+DROP FUNCTION IF EXISTS photo_stored_bytes(BIGINT, BIGINT, BIGINT);
+
+DROP TABLE IF EXISTS user_storage;
+
+ALTER TABLE trust_tiers DROP CONSTRAINT IF EXISTS chk_trust_tiers_storage_quota;
+ALTER TABLE trust_tiers DROP COLUMN IF EXISTS storage_quota_mb;
+
+DROP INDEX IF EXISTS idx_photos_unmeasured;
+ALTER TABLE photos
+    DROP COLUMN IF EXISTS derivative_size,
+    DROP COLUMN IF EXISTS raw_file_size;
@@ -0,0 +1,124 @@
+-- 000023_storage_quotas.up.sql
+-- Per-user storage usage and quotas
+
+-- ============================================
+-- Stored File Sizes
+-- ============================================
+
+-- file_size is the processed main image. raw_file_size and derivative_size
+-- (the thumbnails) are NULL for photos uploaded before sizes were recorded
+-- until the reconciliation job measures them on disk.
+ALTER TABLE photos
+    ADD COLUMN raw_file_size BIGINT,
+    ADD COLUMN derivative_size BIGINT;
+
+-- ============================================
+-- Quota Limits per Trust Tier
+-- ============================================
+
+-- NULL leaves the role limit in place, 0 is unlimited. A user gets the more
+-- generous of the role and tier limits.
+ALTER TABLE trust_tiers ADD COLUMN storage_quota_mb BIGINT;
+
+ALTER TABLE trust_tiers ADD CONSTRAINT chk_trust_tiers_storage_quota
+    CHECK (storage_quota_mb IS NULL OR storage_quota_mb >= 0);
+
+-- ============================================
+-- User Storage Table
+-- ============================================
+
+-- Bytes stored for a user's photos, including photos in the trash until
+-- they are purged. used_bytes is maintained by triggers on photos and
+-- corrected by the reconciliation job. quota_override_mb, set by an admin,
+-- takes precedence over the role and tier limits, 0 is unlimited.
+CREATE TABLE user_storage (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    used_bytes BIGINT NOT NULL DEFAULT 0,
+    quota_override_mb BIGINT,
+    override_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    override_note TEXT,
+    reconciled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_user_storage_quota_override CHECK (quota_override_mb IS NULL OR quota_override_mb >= 0)
+);
+
+CREATE TRIGGER update_user_storage_updated_at
+    BEFORE UPDATE ON user_storage
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Usage Triggers
+-- ============================================
+
+CREATE OR REPLACE FUNCTION photo_stored_bytes(file_size BIGINT, raw_file_size BIGINT, derivative_size BIGINT)
+RETURNS BIGINT AS $$
+    SELECT COALESCE(file_size, 0) + COALESCE(raw_file_size, 0) + COALESCE(derivative_size, 0);
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION add_user_storage(owner_id BIGINT, delta BIGINT)
+RETURNS VOID AS $$
+BEGIN
+    IF delta = 0 THEN
+        RETURN;
+    END IF;
+    INSERT INTO user_storage (user_id, used_bytes) VALUES (owner_id, GREATEST(delta, 0))
+    ON CONFLICT (user_id) DO UPDATE
+        SET used_bytes = GREATEST(user_storage.used_bytes + delta, 0);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION update_user_storage_usage()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM add_user_storage(NEW.user_id, photo_stored_bytes(NEW.file_size, NEW.raw_file_size, NEW.derivative_size));
+        RETURN NEW;
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        -- The owner may be deleted in the same cascade
+        UPDATE user_storage
+        SET used_bytes = GREATEST(used_bytes - photo_stored_bytes(OLD.file_size, OLD.raw_file_size, OLD.derivative_size), 0)
+        WHERE user_id = OLD.user_id;
+        RETURN OLD;
+    END IF;
+
+    IF NEW.user_id = OLD.user_id THEN
+        PERFORM add_user_storage(NEW.user_id,
+            photo_stored_bytes(NEW.file_size, NEW.raw_file_size, NEW.derivative_size)
+            - photo_stored_bytes(OLD.file_size, OLD.raw_file_size, OLD.derivative_size));
+    ELSE
+        PERFORM add_user_storage(OLD.user_id, -photo_stored_bytes(OLD.file_size, OLD.raw_file_size, OLD.derivative_size));
+        PERFORM add_user_storage(NEW.user_id, photo_stored_bytes(NEW.file_size, NEW.raw_file_size, NEW.derivative_size));
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_photo_storage_insert
+    AFTER INSERT ON photos
+    FOR EACH ROW
+    EXECUTE FUNCTION update_user_storage_usage();
+
+CREATE TRIGGER trigger_photo_storage_update
+    AFTER UPDATE OF user_id, file_size, raw_file_size, derivative_size ON photos
+    FOR EACH ROW
+    EXECUTE FUNCTION update_user_storage_usage();
+
+CREATE TRIGGER trigger_photo_storage_delete
+    AFTER DELETE ON photos
+    FOR EACH ROW
+    EXECUTE FUNCTION update_user_storage_usage();
+
+-- Existing usage, completed once the reconciliation job measures RAW files
+-- and thumbnails
+INSERT INTO user_storage (user_id, used_bytes)
+SELECT user_id, SUM(photo_stored_bytes(file_size, raw_file_size, derivative_size))
+FROM photos
+GROUP BY user_id;
+
+CREATE INDEX idx_photos_unmeasured ON photos(id)
+    WHERE derivative_size IS NULL OR (raw_file_path IS NOT NULL AND raw_file_size IS NULL);
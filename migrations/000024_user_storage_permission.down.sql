@@ -0,0 +1,24 @@
+-- 000024_user_storage_permission.down.sql
+-- Rollback user storage permission
+
+DELETE FROM admin_permissions WHERE permission = 'manage_user_storage';
+
+ALTER TABLE admin_permissions DROP CONSTRAINT chk_admin_permissions_permission;
+ALTER TABLE admin_permissions ADD CONSTRAINT chk_admin_permissions_permission CHECK (permission IN (
+    'manage_announcements',
+    'manage_featured',
+    'ban_users',
+    'mute_comment',
+    'mute_message',
+    'mute_upload',
+    'review_photos',
+    'delete_photos',
+    'delete_comments',
+    'manage_tickets',
+    'manage_categories',
+    'manage_tags',
+    'view_statistics',
+    'view_user_details',
+    'manage_roles',
+    'manage_spots'
+));
@@ -0,0 +1,26 @@
+-- 000025_user_trust_permission.up.sql
+-- Separate permission for setting user trust tiers. Tiers were gated by
+-- review_photos, which let any reviewer grant trusted status; no grants are
+-- carried over.
+
+ALTER TABLE admin_permissions DROP CONSTRAINT chk_admin_permissions_permission;
+ALTER TABLE admin_permissions ADD CONSTRAINT chk_admin_permissions_permission CHECK (permission IN (
+    'manage_announcements',
+    'manage_featured',
+    'ban_users',
+    'mute_comment',
+    'mute_message',
+    'mute_upload',
+    'review_photos',
+    'delete_photos',
+    'delete_comments',
+    'manage_tickets',
+    'manage_categories',
+    'manage_tags',
+    'view_statistics',
+    'view_user_details',
+    'manage_roles',
+    'manage_spots',
+    'manage_user_storage',
+    'manage_user_trust'
+));
@@ -74,7 +74,8 @@ INSERT INTO admin_permissions (admin_id, permission, granted_by) VALUES
 (2, 'manage_categories', 1),
 (2, 'manage_tags', 1),
 (2, 'view_statistics', 1),
-(2, 'view_user_details', 1);
+(2, 'view_user_details', 1),
+(2, 'manage_user_storage', 1);
 
 -- ============================================
 -- 标签数据